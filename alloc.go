@@ -0,0 +1,70 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+// Allocator provides the buffers a writer stores its data in, for example from an arena or a pool,
+// so that encoding many short-lived messages doesn't leave all the garbage to the GC. The buffers
+// of the writers that EncodeBlocks uses for the blocks also come from the allocator of the writer.
+type Allocator interface {
+	// Alloc returns a slice of length zero and a capacity of at least n bytes.
+	Alloc(n int) []byte
+
+	// Free takes back a buffer returned by Alloc once the writer doesn't need it anymore.
+	Free(b []byte)
+}
+
+// WithAllocator makes a writer take its buffers from a. A buffer is freed as soon as the writer moves
+// the data to another one, so the data returned by BitData is only valid until the next write. Readers ignore it.
+func WithAllocator(a Allocator) Option {
+	return func(c *config) {
+		c.alloc = a
+	}
+}
+
+// Release gives the buffers of the writer back to its allocator and empties the writer,
+// which keeps its options and can be used again. The data returned by BitData must not be used afterwards.
+func (w *Writer) Release() {
+	w.freeChunks(w.chunks)
+
+	*w = Writer{
+		order:   w.order,
+		strict:  w.strict,
+		padding: w.padding,
+		trace:   w.trace,
+		sink:    w.sink,
+		alloc:   w.alloc,
+		discard: w.discard,
+	}
+}
+
+// newChunk returns an empty buffer with the capacity of n bytes.
+func (w *Writer) newChunk(n int) []byte {
+	if w.alloc == nil {
+		return make([]byte, 0, n)
+	}
+	return w.alloc.Alloc(n)[:0]
+}
+
+// freeChunks gives the chunks back to the allocator.
+func (w *Writer) freeChunks(chunks [][]byte) {
+	if w.alloc == nil {
+		return
+	}
+	for _, c := range chunks {
+		if cap(c) > 0 && !w.isShared(c) {
+			w.alloc.Free(c)
+		}
+	}
+}
+
+// reserve makes room for n more bytes in the chunk c, n at most writerChunkSize minus its length,
+// by moving it to a bigger buffer from the allocator. Without an allocator, append grows the chunk instead.
+func (w *Writer) reserve(c *[]byte, n int) {
+	if w.alloc == nil || len(*c)+n <= cap(*c) {
+		return
+	}
+
+	b := append(w.newChunk(min(max(2*cap(*c), len(*c)+n, 64), writerChunkSize)), *c...)
+	w.freeChunks([][]byte{*c})
+	*c = b
+}
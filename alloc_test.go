@@ -0,0 +1,157 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"bytes"
+	"testing"
+)
+
+// countingAllocator hands out buffers and tracks the ones that haven't been freed.
+type countingAllocator struct {
+	live   map[*byte]int
+	allocs int
+}
+
+func (a *countingAllocator) Alloc(n int) []byte {
+	if a.live == nil {
+		a.live = make(map[*byte]int)
+	}
+	b := make([]byte, n)
+	a.live[&b[0]] = n
+	a.allocs++
+	return b[:0]
+}
+
+func (a *countingAllocator) Free(b []byte) {
+	p := &b[:1][0]
+	if _, ok := a.live[p]; !ok {
+		panic("freeing a buffer not from the allocator")
+	}
+	delete(a.live, p)
+}
+
+func writeAllocTest(w *Writer) {
+	for i := range 50000 {
+		w.Write16(uint16(i), 13)
+		w.Write64(uint64(i)*0x9E3779B97F4A7C15, 64)
+		w.Write([]byte{byte(i), byte(i >> 8), 3})
+	}
+}
+
+func TestAllocator(t *testing.T) {
+	for _, opts := range [][]Option{nil, {WithCapacity(100)}, {WithBitOrder(MSBFirst)}} {
+		ref := NewWriter(opts...)
+		writeAllocTest(ref)
+
+		a := &countingAllocator{}
+		w := NewWriter(append(opts, WithAllocator(a))...)
+		writeAllocTest(w)
+
+		if len(w.chunks) < 2 {
+			t.Fatalf("expected several chunks, got %d", len(w.chunks))
+		}
+		if len(a.live) != len(w.chunks) {
+			t.Errorf("expected %d live buffers, got %d", len(w.chunks), len(a.live))
+		}
+
+		if !bytes.Equal(w.BitData(), ref.BitData()) {
+			t.Errorf("data differs from a writer without an allocator")
+		}
+		if len(a.live) != 1 {
+			t.Errorf("expected one live buffer after BitData, got %d", len(a.live))
+		}
+
+		w.Release()
+		if len(a.live) != 0 {
+			t.Errorf("expected no live buffers after Release, got %d", len(a.live))
+		}
+		if w.BitsWritten() != 0 || len(w.BitData()) != 0 {
+			t.Errorf("expected an empty writer after Release")
+		}
+
+		fresh := NewWriter(opts...)
+		fresh.Write8(5, 3)
+		w.Write8(5, 3)
+		if got := w.BitData(); !bytes.Equal(got, fresh.BitData()) {
+			t.Errorf("expected %v after reuse, got %v", fresh.BitData(), got)
+		}
+	}
+}
+
+func TestAllocatorBlocks(t *testing.T) {
+	a := &countingAllocator{}
+	w := NewWriter(WithAllocator(a))
+
+	err := EncodeBlocks(w, 8, 1, func(i int, bw *Writer) error {
+		for j := range 1000 {
+			bw.Write32(uint32(i*j), 20)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if a.allocs <= len(w.chunks) {
+		t.Errorf("expected the blocks to use the allocator, got %d allocations", a.allocs)
+	}
+	if len(a.live) != len(w.chunks) {
+		t.Errorf("expected only the buffers of the writer to be live, got %d", len(a.live))
+	}
+}
+
+func TestAllocatorScratch(t *testing.T) {
+	reg := &Registry{}
+	_ = Register(reg, 1,
+		func(w *Writer, v uint32) error { w.Write32(v, 32); return nil },
+		func(r *Reader) (uint32, error) { return r.Read32(32) })
+
+	writes := map[string]func(w *Writer){
+		"WriteRepeat": func(w *Writer) { w.WriteRepeat(5, 3, 10000) },
+		"WriteTLVValue": func(w *Writer) {
+			if err := w.WriteTLVValue(TLVFormat{TagBits: 4, LenBits: 8}, reg, uint32(7)); err != nil {
+				t.Fatal(err)
+			}
+		},
+	}
+	for name, write := range writes {
+		a := &countingAllocator{}
+		w := NewWriter(WithAllocator(a))
+		write(w)
+
+		if a.allocs <= len(w.chunks) {
+			t.Errorf("%s: expected the scratch writer to use the allocator, got %d allocations", name, a.allocs)
+		}
+		if len(a.live) != len(w.chunks) {
+			t.Errorf("%s: expected only the buffers of the writer to be live, got %d", name, len(a.live))
+		}
+	}
+}
+
+func TestAllocatorShared(t *testing.T) {
+	a := &countingAllocator{}
+	w := NewWriter(WithAllocator(a))
+	w.BeginMessage(16)
+	for i := range 100 {
+		w.Write8(byte(i), 8)
+	}
+	s := w.Freeze()
+
+	w.Write8(0xFF, 8)
+	if err := w.EndMessage(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, _ := NewReaderBits(w.BitData(), w.BitsWritten()).Read16(16); got != 808 {
+		t.Errorf("message length mismatch: want=%d got=%d", 808, got)
+	}
+	if got, _ := s.Reader().Read16(16); got != 0 {
+		t.Errorf("the shared data was modified: %d", got)
+	}
+
+	// The frozen data stays with s, all the other buffers go back to the allocator.
+	w.Release()
+	if len(a.live) != 1 {
+		t.Errorf("expected only the frozen buffer to be live, got %d", len(a.live))
+	}
+}
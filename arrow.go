@@ -0,0 +1,76 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+// Helpers for Apache Arrow validity bitmaps. Arrow packs bitmaps LSB-first, like the default bit order
+// of this package, so a bitmap can be used as BitData directly and vice versa.
+
+// ArrowAlignment is the buffer alignment and padding recommended by the Arrow columnar format.
+const ArrowAlignment = 64
+
+// ArrowValidity builds a validity bitmap with a bit set for every true element of valid and returns it
+// with the number of nulls. The bitmap is padded with zero bytes to a multiple of ArrowAlignment bytes.
+func ArrowValidity(valid []bool) (bitmap []byte, nullCount int) {
+	bitmap = make([]byte, arrowPadded(uint(len(valid))))
+
+	w := NewWriter()
+	w.WriteBools(valid)
+	copy(bitmap, w.BitData())
+
+	return bitmap, len(valid) - onesCount(bitmap, 0, uint(len(valid)))
+}
+
+// ToArrowBitmap copies length bits of the data starting at the bit offset offsetBits into a validity
+// bitmap padded to a multiple of ArrowAlignment bytes and returns it with the number of nulls,
+// the zero bits. It panics if the range is out of bounds.
+func ToArrowBitmap(d BitData, offsetBits, length uint) (bitmap []byte, nullCount int) {
+	if offsetBits+length > uint(len(d))*8 {
+		panic("bitdata: bit range out of bounds")
+	}
+
+	bitmap = make([]byte, arrowPadded(length))
+	if offsetBits%8 == 0 {
+		copy(bitmap, d[offsetBits/8:(offsetBits+length+7)/8])
+		if rem := length % 8; rem > 0 {
+			bitmap[length/8] &= mask[byte](byte(rem))
+		}
+	} else {
+		copy(bitmap, d.Extract(offsetBits, length))
+	}
+
+	return bitmap, int(length) - onesCount(bitmap, 0, length)
+}
+
+// FromArrowBitmap returns length bits of the validity bitmap of an array with the given offset as BitData.
+// A nil bitmap, which Arrow uses when there are no nulls, gives all one bits.
+// It panics if the bitmap is too short.
+func FromArrowBitmap(bitmap []byte, offset, length uint) BitData {
+	if bitmap == nil {
+		w := NewWriter()
+		w.WriteRepeat(1, 1, int(length))
+		return w.BitData()
+	}
+	if offset+length > uint(len(bitmap))*8 {
+		panic("bitdata: bit range out of bounds")
+	}
+
+	return BitData(bitmap).Extract(offset, length)
+}
+
+// ArrowNullCount returns the number of zero bits in length bits of the validity bitmap starting at the offset.
+// A nil bitmap has no nulls. It panics if the bitmap is too short.
+func ArrowNullCount(bitmap []byte, offset, length uint) int {
+	if bitmap == nil {
+		return 0
+	}
+	if offset+length > uint(len(bitmap))*8 {
+		panic("bitdata: bit range out of bounds")
+	}
+
+	return int(length) - onesCount(bitmap, offset, length)
+}
+
+// arrowPadded returns the size in bytes of a bitmap of n bits padded to ArrowAlignment.
+func arrowPadded(n uint) uint {
+	return (n + ArrowAlignment*8 - 1) / (ArrowAlignment * 8) * ArrowAlignment
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+)
+
+func TestArrowValidity(t *testing.T) {
+	valid := []bool{true, false, true, true, false, false, true, true, true, false}
+
+	bitmap, nulls := ArrowValidity(valid)
+	if len(bitmap) != ArrowAlignment {
+		t.Errorf("bitmap length: want=%d got=%d", ArrowAlignment, len(bitmap))
+	}
+	if nulls != 4 {
+		t.Errorf("null count: want=4 got=%d", nulls)
+	}
+	if bitmap[0] != 0b11001101 || bitmap[1] != 0b01 {
+		t.Errorf("bitmap mismatch: % X", bitmap[:2])
+	}
+
+	if bitmap, nulls = ArrowValidity(make([]bool, 513)); len(bitmap) != 2*ArrowAlignment || nulls != 513 {
+		t.Errorf("513 elements: length=%d nulls=%d", len(bitmap), nulls)
+	}
+}
+
+func TestArrowBitmap(t *testing.T) {
+	rnd := rand.New(rand.NewSource(42))
+	d := make(BitData, 100)
+	rnd.Read(d)
+
+	for i := 0; i < 200; i++ {
+		offset := uint(rnd.Intn(400))
+		length := uint(rnd.Intn(400))
+
+		bitmap, nulls := ToArrowBitmap(d, offset, length)
+		if uint(len(bitmap)) != arrowPadded(length) || len(bitmap)%ArrowAlignment != 0 {
+			t.Errorf("bitmap length %d for %d bits", len(bitmap), length)
+		}
+		if want := int(length) - d.OnesCount(offset, length); nulls != want {
+			t.Errorf("null count for %d,%d: want=%d got=%d", offset, length, want, nulls)
+		}
+		if got := ArrowNullCount(bitmap, 0, length); got != nulls {
+			t.Errorf("ArrowNullCount: want=%d got=%d", nulls, got)
+		}
+		if bytes.Count(bitmap[(length+7)/8:], []byte{0}) != len(bitmap)-int(length+7)/8 {
+			t.Errorf("padding isn't zero for %d,%d", offset, length)
+		}
+
+		back := FromArrowBitmap(bitmap, 0, length)
+		if !bytes.Equal(back, d.Extract(offset, length)) {
+			t.Errorf("round trip mismatch for %d,%d", offset, length)
+		}
+	}
+}
+
+func TestArrowNilBitmap(t *testing.T) {
+	if got := ArrowNullCount(nil, 3, 20); got != 0 {
+		t.Errorf("null count of nil bitmap: %d", got)
+	}
+
+	d := FromArrowBitmap(nil, 0, 12)
+	if !bytes.Equal(d, BitData{0xFF, 0x0F}) {
+		t.Errorf("nil bitmap: % X", d)
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"errors"
+	"math/bits"
+)
+
+var ErrInvalidWidth = errors.New("invalid width header")
+
+// autoWidthBits is the size of the width header written by WriteAuto64; it holds widths from 0 to 64.
+const autoWidthBits = 7
+
+// MinBits returns the minimal number of bits needed to hold v. It returns 0 for 0.
+func MinBits(v uint64) byte {
+	return byte(bits.Len64(v))
+}
+
+// WriteAuto64 writes a 7-bit header with the minimal width of v followed by v in exactly that many bits.
+func (w *Writer) WriteAuto64(v uint64) {
+	n := MinBits(v)
+	w.Write8(n, autoWidthBits)
+	w.Write64(v, n)
+}
+
+func (r *Reader) ReadAuto64() (uint64, error) {
+	n, err := r.Read8(autoWidthBits)
+	if err != nil {
+		return 0, err
+	}
+	if n > 64 {
+		r.bitsRead -= autoWidthBits
+		return 0, ErrInvalidWidth
+	}
+
+	v, err := r.Read64(n)
+	if err != nil {
+		r.bitsRead -= autoWidthBits
+		return 0, err
+	}
+
+	return v, nil
+}
+
+func (r *ReaderError) ReadAuto64() (v uint64) {
+	if r.err == nil {
+		v, r.err = r.reader.ReadAuto64()
+	}
+	return
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestMinBits(t *testing.T) {
+	tests := []struct {
+		v   uint64
+		exp byte
+	}{
+		{v: 0, exp: 0},
+		{v: 1, exp: 1},
+		{v: 2, exp: 2},
+		{v: 255, exp: 8},
+		{v: 256, exp: 9},
+		{v: ^uint64(0), exp: 64},
+	}
+
+	for _, test := range tests {
+		if got := MinBits(test.v); got != test.exp {
+			t.Errorf("MinBits(%d): want=%d got=%d", test.v, test.exp, got)
+		}
+	}
+}
+
+func TestAuto64(t *testing.T) {
+	values := []uint64{0, 1, 5, 1000, 1 << 40, ^uint64(0)}
+
+	w := NewWriter()
+	var size uint
+	for _, v := range values {
+		w.WriteAuto64(v)
+		size += autoWidthBits + uint(MinBits(v))
+	}
+
+	if size != w.BitsWritten() {
+		t.Errorf("bits written mismatch: want=%d got=%d", size, w.BitsWritten())
+	}
+
+	r := NewReaderError(w.BitData())
+	for _, want := range values {
+		if got := r.ReadAuto64(); got != want {
+			t.Errorf("value mismatch: want=%d got=%d", want, got)
+		}
+	}
+	if err := r.Error(); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	rr := NewReader(BitData{65})
+	if _, err := rr.ReadAuto64(); err != ErrInvalidWidth {
+		t.Errorf("expected ErrInvalidWidth, got %v", err)
+	}
+
+	rr = NewReader(BitData{20, 0})
+	if _, err := rr.ReadAuto64(); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("expected EOF, got %v", err)
+	}
+	if rr.BitsRead() != 0 {
+		t.Errorf("reader advanced on error: %d", rr.BitsRead())
+	}
+}
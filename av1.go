@@ -0,0 +1,198 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import "errors"
+
+var ErrInvalidOBU = errors.New("invalid OBU header")
+
+// maxLEB128Bytes is the maximum length of a leb128() value in AV1.
+const maxLEB128Bytes = 8
+
+// OBUHeader is the header of an AV1 OBU (open bitstream unit). The OBU functions expect
+// a writer or reader in the MSBFirst order used by AV1.
+type OBUHeader struct {
+	Type       byte // obu_type, 4 bits
+	Extension  bool // obu_extension_flag
+	HasSize    bool // obu_has_size_field
+	TemporalID byte // temporal_id, 3 bits, only with Extension
+	SpatialID  byte // spatial_id, 2 bits, only with Extension
+}
+
+const (
+	OBUSequenceHeader       = 1
+	OBUTemporalDelimiter    = 2
+	OBUFrameHeader          = 3
+	OBUTileGroup            = 4
+	OBUMetadata             = 5
+	OBUFrame                = 6
+	OBURedundantFrameHeader = 7
+	OBUTileList             = 8
+	OBUPadding              = 15
+)
+
+// WriteOBUHeader writes the one or two bytes of an OBU header, without the size field.
+func (w *Writer) WriteOBUHeader(h OBUHeader) {
+	w.WriteBool(false) // obu_forbidden_bit
+	w.Write8(h.Type, 4)
+	w.WriteBool(h.Extension)
+	w.WriteBool(h.HasSize)
+	w.WriteBool(false) // obu_reserved_1bit
+
+	if h.Extension {
+		w.Write8(h.TemporalID, 3)
+		w.Write8(h.SpatialID, 2)
+		w.Write8(0, 3) // extension_header_reserved_3bits
+	}
+}
+
+// ReadOBUHeader reads an OBU header, without the size field. It returns ErrInvalidOBU if the forbidden bit is set.
+// On error the read position is left unchanged.
+func (r *Reader) ReadOBUHeader() (OBUHeader, error) {
+	start := r.bitsRead
+
+	var h OBUHeader
+	v, err := r.Read8(8)
+	if err == nil && v&0x80 != 0 {
+		err = ErrInvalidOBU
+	}
+	if err == nil && v&0x04 != 0 {
+		if ext, e := r.Read8(8); e == nil {
+			h.TemporalID = ext >> 5
+			h.SpatialID = ext >> 3 & 3
+		} else {
+			err = e
+		}
+	}
+	if err != nil {
+		r.bitsRead = start
+		return OBUHeader{}, err
+	}
+
+	h.Type = v >> 3 & 0xF
+	h.Extension = v&0x04 != 0
+	h.HasSize = v&0x02 != 0
+
+	return h, nil
+}
+
+// WriteLEB128 writes v in the leb128() format of AV1, using byteCount bytes. Encoders that backfill
+// sizes pad the value to a fixed length with continuation bits. A byteCount of zero uses the fewest bytes.
+// It panics if v doesn't fit into byteCount bytes or byteCount is more than 8.
+func (w *Writer) WriteLEB128(v uint32, byteCount int) {
+	if byteCount == 0 {
+		byteCount = 1
+		for x := v >> 7; x != 0; x >>= 7 {
+			byteCount++
+		}
+	}
+	if byteCount > maxLEB128Bytes || byteCount < 5 && uint64(v)>>(7*byteCount) != 0 {
+		panic("bitdata: leb128 value out of range")
+	}
+
+	for i := 0; i < byteCount-1; i++ {
+		w.Write8(byte(v)|0x80, 8)
+		v >>= 7
+	}
+	w.Write8(byte(v), 8)
+}
+
+// ReadLEB128 reads a value in the leb128() format of AV1. It returns ErrVarintOverflow if the value
+// takes more than 8 bytes or doesn't fit into 32 bits. On error the read position is left unchanged.
+func (r *Reader) ReadLEB128() (uint32, error) {
+	start := r.bitsRead
+
+	var v uint64
+	for i := 0; i < maxLEB128Bytes; i++ {
+		b, err := r.Read8(8)
+		if err != nil {
+			r.bitsRead = start
+			return 0, err
+		}
+
+		v |= uint64(b&0x7F) << (7 * i)
+		if b < 0x80 {
+			if v > 1<<32-1 {
+				break
+			}
+			return uint32(v), nil
+		}
+	}
+
+	r.bitsRead = start
+	return 0, ErrVarintOverflow
+}
+
+// WriteOBU writes a complete OBU: the header with the size field and the payload bytes.
+func (w *Writer) WriteOBU(h OBUHeader, payload []byte) {
+	h.HasSize = true
+	w.WriteOBUHeader(h)
+	w.WriteLEB128(uint32(len(payload)), 0)
+	w.WriteBitData(payload, uint(len(payload))*8)
+}
+
+// ReadOBU reads an OBU header and its size, and returns a reader limited to the payload.
+// An OBU without the size field extends to the end of the data. The reader r continues after the OBU.
+func (r *Reader) ReadOBU() (OBUHeader, *Reader, error) {
+	start := r.bitsRead
+
+	h, err := r.ReadOBUHeader()
+	if err != nil {
+		return h, nil, err
+	}
+
+	var size uint
+	if h.HasSize {
+		n, err := r.ReadLEB128()
+		if err != nil {
+			r.bitsRead = start
+			return OBUHeader{}, nil, err
+		}
+		ok, err := r.ensureCount(uint64(n), 8)
+		if err != nil {
+			r.bitsRead = start
+			return OBUHeader{}, nil, err
+		}
+		if ok {
+			size = uint(n)
+		}
+	} else {
+		size = (r.end - r.bitsRead) / 8
+	}
+
+	sub := *r
+	sub.end = r.bitsRead + size*8
+
+	r.bitsRead = sub.end
+
+	return h, &sub, nil
+}
+
+func (r *ReaderError) ReadOBUHeader() (h OBUHeader) {
+	if r.err == nil {
+		h, r.err = r.reader.ReadOBUHeader()
+	}
+	return
+}
+
+func (r *ReaderError) ReadLEB128() (v uint32) {
+	if r.err == nil {
+		v, r.err = r.reader.ReadLEB128()
+	}
+	return
+}
+
+// ReadOBU is like Reader.ReadOBU. If an error occurs, the returned reader holds the error.
+func (r *ReaderError) ReadOBU() (OBUHeader, *ReaderError) {
+	if r.err != nil {
+		return OBUHeader{}, &ReaderError{err: r.err}
+	}
+
+	h, sub, err := r.reader.ReadOBU()
+	if err != nil {
+		r.err = err
+		return h, &ReaderError{err: err}
+	}
+
+	return h, &ReaderError{reader: *sub}
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestOBU(t *testing.T) {
+	w := NewWriterMSB()
+	w.WriteOBU(OBUHeader{Type: OBUTemporalDelimiter}, nil)
+	w.WriteOBU(OBUHeader{Type: OBUFrame, Extension: true, TemporalID: 5, SpatialID: 2}, []byte{1, 2, 3})
+	w.WriteOBUHeader(OBUHeader{Type: OBUPadding})
+	w.Write8(0xAA, 8)
+
+	want := []byte{0x12, 0x00, 0x36, 0xB0, 0x03, 1, 2, 3, 0x78, 0xAA}
+	if !bytes.Equal(want, w.BitData()) {
+		t.Fatalf("encoding mismatch: want=%x got=%x", want, w.BitData())
+	}
+
+	r := NewReaderErrorMSB(w.BitData())
+
+	h, p := r.ReadOBU()
+	if h.Type != OBUTemporalDelimiter || !h.HasSize {
+		t.Errorf("unexpected header: %+v", h)
+	}
+	if _, err := p.reader.Read8(1); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("payload not empty: %v", err)
+	}
+
+	h, p = r.ReadOBU()
+	if want := (OBUHeader{Type: OBUFrame, Extension: true, HasSize: true, TemporalID: 5, SpatialID: 2}); h != want {
+		t.Errorf("header mismatch: want=%+v got=%+v", want, h)
+	}
+	if v := p.Read32(24); v != 0x010203 {
+		t.Errorf("payload mismatch: %x", v)
+	}
+
+	h, p = r.ReadOBU()
+	if h.Type != OBUPadding || h.HasSize {
+		t.Errorf("unexpected header: %+v", h)
+	}
+	if v := p.Read8(8); v != 0xAA {
+		t.Errorf("payload mismatch: %x", v)
+	}
+
+	if err := r.Error(); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	if err := p.Error(); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
+
+func TestOBUInvalid(t *testing.T) {
+	r := NewReaderMSB(BitData{0x80})
+	if _, err := r.ReadOBUHeader(); !errors.Is(err, ErrInvalidOBU) {
+		t.Errorf("want=%v got=%v", ErrInvalidOBU, err)
+	}
+
+	r = NewReaderMSB(BitData{0x12, 0x05, 1, 2})
+	if _, _, err := r.ReadOBU(); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("want=%v got=%v", io.ErrUnexpectedEOF, err)
+	}
+	if r.BitsRead() != 0 {
+		t.Errorf("read position changed: %d", r.BitsRead())
+	}
+}
+
+func TestLEB128(t *testing.T) {
+	tests := []struct {
+		v         uint32
+		byteCount int
+		want      []byte
+	}{
+		{v: 0, want: []byte{0}},
+		{v: 300, want: []byte{0xAC, 0x02}},
+		{v: 5, byteCount: 4, want: []byte{0x85, 0x80, 0x80, 0x00}},
+		{v: 1<<32 - 1, want: []byte{0xFF, 0xFF, 0xFF, 0xFF, 0x0F}},
+		{v: 1, byteCount: 8, want: []byte{0x81, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x00}},
+	}
+
+	for _, test := range tests {
+		w := NewWriterMSB()
+		w.WriteLEB128(test.v, test.byteCount)
+		if !bytes.Equal(test.want, w.BitData()) {
+			t.Errorf("encoding mismatch for %d: want=%x got=%x", test.v, test.want, w.BitData())
+		}
+
+		if v, err := NewReaderMSB(w.BitData()).ReadLEB128(); err != nil || v != test.v {
+			t.Errorf("value mismatch: want=%d got=%d err=%v", test.v, v, err)
+		}
+	}
+
+	overflow := []BitData{
+		{0xFF, 0xFF, 0xFF, 0xFF, 0x1F},
+		{0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x00},
+	}
+	for _, d := range overflow {
+		if _, err := NewReaderMSB(d).ReadLEB128(); !errors.Is(err, ErrVarintOverflow) {
+			t.Errorf("want=%v for %x got=%v", ErrVarintOverflow, d, err)
+		}
+	}
+}
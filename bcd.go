@@ -0,0 +1,110 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"errors"
+	"math/bits"
+)
+
+var ErrInvalidBCD = errors.New("invalid bcd digit")
+
+const maxBCDDigits = 20
+
+// WriteBCD writes the lowest digits decimal digits of v as packed BCD, 4 bits per digit. The digits form
+// one number of 4*digits bits written in the bit order of the writer: in the MSBFirst order this is
+// the standard packed BCD layout, most significant digit first, and in the LSBFirst order the least
+// significant digit comes first, in the low nibble of the first byte. Like the other Write methods
+// it silently drops higher digits.
+func (w *Writer) WriteBCD(v uint64, digits byte) {
+	if digits > maxBCDDigits {
+		panic("bitdata: too many BCD digits")
+	}
+
+	// At most 16 digits fit into a Write64, so longer numbers are split after the 16th digit.
+	loDigits := min(digits, 16)
+	hiDigits := digits - loDigits
+	lo := packBCD(v, loDigits)
+	hi := packBCD(v/1e16, hiDigits)
+
+	if w.order == MSBFirst {
+		w.Write64(hi, 4*hiDigits)
+		w.Write64(lo, 4*loDigits)
+	} else {
+		w.Write64(lo, 4*loDigits)
+		w.Write64(hi, 4*hiDigits)
+	}
+}
+
+// packBCD returns the lowest n decimal digits of v, at most 16, as packed BCD.
+func packBCD(v uint64, n byte) uint64 {
+	var packed uint64
+	for i := byte(0); i < n; i++ {
+		packed |= (v % 10) << (4 * i)
+		v /= 10
+	}
+	return packed
+}
+
+// ReadBCD reads packed BCD written by WriteBCD. It returns ErrInvalidBCD if a digit is greater than 9
+// or if the number doesn't fit into uint64.
+func (r *Reader) ReadBCD(digits byte) (uint64, error) {
+	if digits > maxBCDDigits {
+		return 0, ErrBitCountTooBig
+	}
+
+	loDigits := min(digits, 16)
+	hiDigits := digits - loDigits
+
+	var lo, hi uint64
+	var err error
+	if r.order == MSBFirst {
+		if hi, err = r.Read64(4 * hiDigits); err == nil {
+			lo, err = r.Read64(4 * loDigits)
+		}
+	} else {
+		if lo, err = r.Read64(4 * loDigits); err == nil {
+			hi, err = r.Read64(4 * hiDigits)
+		}
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	loValue, ok := unpackBCD(lo, loDigits)
+	if !ok {
+		return 0, ErrInvalidBCD
+	}
+	hiValue, ok := unpackBCD(hi, hiDigits)
+	if !ok {
+		return 0, ErrInvalidBCD
+	}
+
+	high, v := bits.Mul64(hiValue, 1e16)
+	v, carry := bits.Add64(v, loValue, 0)
+	if high != 0 || carry != 0 {
+		return 0, ErrInvalidBCD
+	}
+
+	return v, nil
+}
+
+// unpackBCD returns the value of n packed BCD digits, at most 16, and reports whether all of them are valid.
+func unpackBCD(packed uint64, n byte) (uint64, bool) {
+	var v uint64
+	for i := int(n) - 1; i >= 0; i-- {
+		d := packed >> (4 * i) & 0xF
+		if d > 9 {
+			return 0, false
+		}
+		v = v*10 + d
+	}
+	return v, true
+}
+
+func (r *ReaderError) ReadBCD(digits byte) (v uint64) {
+	if r.err == nil {
+		v, r.err = r.reader.ReadBCD(digits)
+	}
+	return
+}
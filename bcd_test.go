@@ -0,0 +1,85 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestBCD(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  uint64
+		digits byte
+		exp    uint64
+	}{
+		{name: "zero", value: 0, digits: 1, exp: 0},
+		{name: "date", value: 250629, digits: 6, exp: 250629},
+		{name: "truncated", value: 1234, digits: 2, exp: 34},
+		{name: "padded", value: 7, digits: 5, exp: 7},
+		{name: "sixteen", value: 1234567890123456, digits: 16, exp: 1234567890123456},
+		{name: "max", value: math.MaxUint64, digits: 20, exp: math.MaxUint64},
+	}
+
+	for _, test := range tests {
+		for _, order := range []BitOrder{LSBFirst, MSBFirst} {
+			t.Run(test.name, func(t *testing.T) {
+				w := NewWriter(WithBitOrder(order))
+				w.WriteBool(true)
+				w.WriteBCD(test.value, test.digits)
+
+				if want, got := 1+4*uint(test.digits), w.BitsWritten(); want != got {
+					t.Errorf("size mismatch: want=%d got=%d", want, got)
+				}
+
+				r := NewReader(w.BitData(), WithBitOrder(order))
+				r.Skip(1)
+				v, err := r.ReadBCD(test.digits)
+				if err != nil {
+					t.Errorf("unexpected error: %s", err)
+				}
+				if v != test.exp {
+					t.Errorf("value mismatch: want=%d got=%d", test.exp, v)
+				}
+			})
+		}
+	}
+}
+
+func TestBCDLayout(t *testing.T) {
+	w := NewWriter()
+	w.WriteBCD(1234, 4)
+
+	if want, got := uint16(0x1234), uint16(w.BitData()[0])|uint16(w.BitData()[1])<<8; want != got {
+		t.Errorf("layout mismatch: want=%#x got=%#x", want, got)
+	}
+
+	w = NewWriter(WithBitOrder(MSBFirst))
+	w.WriteBCD(12345678901234567890, 20)
+	want := BitData{0x12, 0x34, 0x56, 0x78, 0x90, 0x12, 0x34, 0x56, 0x78, 0x90}
+	if got := w.BitData(); !bytes.Equal(got, want) {
+		t.Errorf("MSBFirst layout mismatch: want=%x got=%x", want, got)
+	}
+}
+
+func TestBCDInvalid(t *testing.T) {
+	w := NewWriter()
+	w.Write16(0x1A3, 12)
+
+	if _, err := NewReader(w.BitData()).ReadBCD(3); !errors.Is(err, ErrInvalidBCD) {
+		t.Errorf("expected error, got %v", err)
+	}
+
+	w = NewWriter()
+	w.WriteBCD(99999999999999999, 16)
+	w.WriteBCD(9999, 4)
+
+	r := NewReaderError(w.BitData())
+	r.ReadBCD(20)
+	if err := r.Error(); !errors.Is(err, ErrInvalidBCD) {
+		t.Errorf("expected overflow error, got %v", err)
+	}
+}
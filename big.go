@@ -0,0 +1,67 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import "math/big"
+
+// WriteBig writes the lowest bitCount bits of v, least significant bits first.
+// Negative values are written in two's complement.
+func (w *Writer) WriteBig(v *big.Int, bitCount uint) {
+	word := new(big.Int).SetUint64(^uint64(0))
+	t := new(big.Int).And(v, bigMask(bitCount))
+	chunk := new(big.Int)
+
+	for bitCount > 0 {
+		n := byte(64)
+		if bitCount < 64 {
+			n = byte(bitCount)
+		}
+
+		w.Write64(chunk.And(t, word).Uint64(), n)
+		t.Rsh(t, 64)
+		bitCount -= uint(n)
+	}
+}
+
+// ReadBig reads a non-negative bitCount bits wide value written with WriteBig.
+// On error, the read position is left unchanged.
+func (r *Reader) ReadBig(bitCount uint) (*big.Int, error) {
+	if ok, err := r.ensure(bitCount); !ok {
+		if err != nil {
+			return nil, err
+		}
+		return new(big.Int), nil
+	}
+
+	v := new(big.Int)
+	chunk := new(big.Int)
+
+	for shift := uint(0); shift < bitCount; shift += 64 {
+		n := byte(64)
+		if bitCount-shift < 64 {
+			n = byte(bitCount - shift)
+		}
+
+		u, err := r.Read64(n)
+		if err != nil {
+			r.bitsRead -= shift
+			return nil, err
+		}
+
+		v.Or(v, chunk.Lsh(chunk.SetUint64(u), shift))
+	}
+
+	return v, nil
+}
+
+func (r *ReaderError) ReadBig(bitCount uint) (v *big.Int) {
+	if r.err == nil {
+		v, r.err = r.reader.ReadBig(bitCount)
+	}
+	return
+}
+
+func bigMask(bitCount uint) *big.Int {
+	m := new(big.Int).Lsh(big.NewInt(1), bitCount)
+	return m.Sub(m, big.NewInt(1))
+}
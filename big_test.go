@@ -0,0 +1,61 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"io"
+	"math/big"
+	"testing"
+)
+
+func TestBig(t *testing.T) {
+	hash, _ := new(big.Int).SetString("c0ffee0123456789abcdef0123456789abcdef0123456789abcdef0123456789", 16)
+
+	tests := []struct {
+		name     string
+		value    *big.Int
+		bitCount uint
+		exp      *big.Int
+	}{
+		{name: "zero", value: big.NewInt(0), bitCount: 0, exp: big.NewInt(0)},
+		{name: "small", value: big.NewInt(5), bitCount: 3, exp: big.NewInt(5)},
+		{name: "word", value: new(big.Int).SetUint64(^uint64(0)), bitCount: 64, exp: new(big.Int).SetUint64(^uint64(0))},
+		{name: "hash-256", value: hash, bitCount: 256, exp: hash},
+		{name: "truncated", value: hash, bitCount: 100, exp: new(big.Int).And(hash, bigMask(100))},
+		{name: "negative", value: big.NewInt(-1), bitCount: 70, exp: bigMask(70)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w := NewWriter()
+			w.Write8(0b101, 3)
+			w.WriteBig(test.value, test.bitCount)
+			w.WriteBool(true)
+
+			if want, got := 4+test.bitCount, w.BitsWritten(); want != got {
+				t.Errorf("bits written mismatch: want=%d got=%d", want, got)
+			}
+
+			r := NewReaderError(w.BitData())
+			r.Skip(3)
+			if got := r.ReadBig(test.bitCount); got.Cmp(test.exp) != 0 {
+				t.Errorf("value mismatch: want=%x got=%x", test.exp, got)
+			}
+			if !r.ReadBool() {
+				t.Errorf("trailing bit mismatch")
+			}
+			if err := r.Error(); err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		})
+	}
+
+	r := NewReader(make(BitData, 16))
+	r.Skip(1)
+	if _, err := r.ReadBig(128); err != io.ErrUnexpectedEOF {
+		t.Errorf("expected EOF, got %v", err)
+	}
+	if r.BitsRead() != 1 {
+		t.Errorf("reader advanced on error: %d", r.BitsRead())
+	}
+}
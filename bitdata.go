@@ -4,30 +4,96 @@ package bitdata
 
 import (
 	"errors"
+	"fmt"
 	"io"
+	"math/bits"
 )
 
 type BitData []byte
 
 type Writer struct {
-	data        *BitData
+	chunks      [][]byte
+	size        uint
 	bitsWritten uint
+	order       BitOrder
+	strict      bool
+	padding     PaddingPolicy
+
+	recording bool
+	fields    []Field
+
+	trace TraceFunc
+
+	sections     []Section
+	openSections []openSection
+
+	openMessages []openMessage
+
+	budget *budget
+
+	// sink receives the bits on Flush; base is the byte offset of the first chunk,
+	// and flushed the number of bits already passed to the sink.
+	sink    BitSink
+	base    uint
+	flushed uint
+
+	alloc Allocator
+
+	// shared holds the whole bytes of the first chunk while they are shared with a Shared value.
+	shared BitData
+
+	// discard is set for the writer of a SizeCalculator, which drops the written bytes as it goes;
+	// dropped stands in for a byte that is changed after being dropped.
+	discard bool
+	dropped byte
 }
 
-var ErrBitCountTooBig = errors.New("bit count too big")
+var (
+	ErrBitCountTooBig = errors.New("bit count too big")
+	ErrInvalidRange   = errors.New("invalid bit range")
+)
+
+func NewWriter(opts ...Option) *Writer {
+	c := newConfig(opts)
 
-func NewWriter() *Writer {
-	return &Writer{
-		data:        (*BitData)(new([]byte)),
+	w := &Writer{
 		bitsWritten: 0,
+		order:       c.order,
+		strict:      c.strict,
+		padding:     c.padding,
+		alloc:       c.alloc,
+	}
+	if c.capacity > 0 {
+		w.chunks = [][]byte{w.newChunk(min(c.capacity, writerChunkSize))}
 	}
+
+	return w
 }
 
+// BitData returns a view of the written data without copying it. The view shares memory with the writer:
+// if the data doesn't end on a byte boundary, later writes fill the remaining bits of its last byte.
+// Use AppendBitData for an independent copy, or Freeze for a read-only view that isn't changed by later writes.
 func (w *Writer) BitData() BitData {
-	return *w.data
+	return w.assemble()
+}
+
+// AppendBitData appends a copy of the written data to dst and returns the extended slice.
+// Unlike BitData, the result is never changed by later writes.
+func (w *Writer) AppendBitData(dst BitData) BitData {
+	for _, c := range w.chunks {
+		dst = append(dst, c...)
+	}
+	return dst
+}
+
+func (w *Writer) BitsWritten() uint {
+	return w.bitsWritten
 }
 
 func (w *Writer) WriteBool(v bool) {
+	if w.trace != nil {
+		w.traceWrite("WriteBool", boolToUint64(v), 1)
+	}
 	if v {
 		write[byte](w, 1, 1)
 	} else {
@@ -36,41 +102,158 @@ func (w *Writer) WriteBool(v bool) {
 }
 
 func (w *Writer) Write8(v uint8, bitCount byte) {
+	if w.strict {
+		checkFits(v, bitCount)
+	}
+	if w.trace != nil {
+		w.traceWrite("Write8", uint64(v), bitCount)
+	}
 	write[uint8](w, v, bitCount)
 }
 
 func (w *Writer) Write16(v uint16, bitCount byte) {
+	if w.strict {
+		checkFits(v, bitCount)
+	}
+	if w.trace != nil {
+		w.traceWrite("Write16", uint64(v), bitCount)
+	}
 	write[uint16](w, v, bitCount)
 }
 
 func (w *Writer) Write32(v uint32, bitCount byte) {
+	if w.strict {
+		checkFits(v, bitCount)
+	}
+	if w.trace != nil {
+		w.traceWrite("Write32", uint64(v), bitCount)
+	}
 	write[uint32](w, v, bitCount)
 }
 
 func (w *Writer) Write64(v uint64, bitCount byte) {
+	if w.strict {
+		checkFits(v, bitCount)
+	}
+	if w.trace != nil {
+		w.traceWrite("Write64", uint64(v), bitCount)
+	}
 	write[uint64](w, v, bitCount)
 }
 
 type Reader struct {
 	data     BitData
 	bitsRead uint
+	end      uint
+	order    BitOrder
+	padding  PaddingPolicy
+	partial  bool
+	optional bool
+	ended    bool
+
+	limits Limits
+	depth  int
+
+	// allocated counts the bytes charged against limits.MaxAlloc, shared with the readers derived from this one
+	// other than its cursors.
+	// While a decode operation has a bit budget, decodeEnd is where it ends and fullEnd is the end it replaced.
+	allocated   *uint64
+	decodeStart uint
+	decodeEnd   uint
+	fullEnd     uint
+
+	src source
+
+	buf     uint64
+	bufBits byte
+	bufPos  uint
+
+	trace TraceFunc
 }
 
-func NewReader(data BitData) *Reader {
-	return &Reader{
+func NewReader(data BitData, opts ...Option) *Reader {
+	r := &Reader{
 		data:     data,
 		bitsRead: 0,
+		end:      uint(len(data)) * 8,
+	}
+	r.configure(opts)
+
+	return r
+}
+
+// configure applies the options to a new reader. It's kept out of NewReader so that NewReader can be
+// inlined and the readers that don't escape stay on the stack.
+func (r *Reader) configure(opts []Option) {
+	if len(opts) == 0 {
+		// The config escapes to the heap when options are applied to it.
+		return
+	}
+	c := newConfig(opts)
+
+	r.order = c.order
+	r.padding = c.padding
+	r.optional = c.optional
+	r.limits = c.limits
+	if c.limits.MaxAlloc > 0 {
+		r.allocated = new(uint64)
 	}
 }
 
+// NewReaderBits returns a reader of the first bitCount bits of the data. Reads past them fail
+// as at the end of the data. It panics if the data is shorter.
+func NewReaderBits(data BitData, bitCount uint, opts ...Option) *Reader {
+	if bitCount > uint(len(data))*8 {
+		panic("bitdata: bit range out of bounds")
+	}
+
+	r := NewReader(data, opts...)
+	r.end = bitCount
+	return r
+}
+
+func (r *Reader) BitsRead() uint {
+	return r.bitsRead
+}
+
 func (r *Reader) Skip(bitCount uint) {
 	r.bitsRead += bitCount
 }
 
+// SkipChecked is like Skip, but it returns io.ErrUnexpectedEOF and leaves the read position unchanged
+// if fewer than bitCount bits remain.
+func (r *Reader) SkipChecked(bitCount uint) error {
+	if ok, err := r.ensure(bitCount); !ok {
+		return err
+	}
+	r.bitsRead += bitCount
+	return nil
+}
+
+// SkipBack moves the read position back by bitCount bits, for example after reading ahead while parsing
+// speculatively. It returns ErrInvalidRange and leaves the read position unchanged if it would move before
+// the start of the data, or, for a reader created with NewReaderSource, before the data it still keeps.
+func (r *Reader) SkipBack(bitCount uint) error {
+	if bitCount > r.bitsRead {
+		return fmt.Errorf("%w: %d bits back from bit offset %d", ErrInvalidRange, bitCount, r.bitsRead)
+	}
+	if s, ok := r.src.(*bitSource); ok && (r.bitsRead-bitCount)/8 < s.base {
+		return fmt.Errorf("%w: data before byte %d is discarded", ErrInvalidRange, s.base)
+	}
+
+	r.bitsRead -= bitCount
+
+	return nil
+}
+
 func (r *Reader) ReadBool() (bool, error) {
 	v, err := read[byte](r, 1)
 	if err != nil {
-		return false, err
+		return false, r.readError("ReadBool", 1, err)
+	}
+
+	if r.trace != nil {
+		r.traceRead("ReadBool", 1, uint64(v))
 	}
 
 	return v != 0, nil
@@ -78,30 +261,70 @@ func (r *Reader) ReadBool() (bool, error) {
 
 func (r *Reader) Read8(bitCount byte) (uint8, error) {
 	if bitCount > 8 {
-		return 0, ErrBitCountTooBig
+		return 0, r.readError("Read8", bitCount, ErrBitCountTooBig)
 	}
-	return read[uint8](r, bitCount)
+
+	v, err := read[uint8](r, bitCount)
+	if err != nil {
+		return 0, r.readError("Read8", bitCount, err)
+	}
+
+	if r.trace != nil {
+		r.traceRead("Read8", bitCount, uint64(v))
+	}
+
+	return v, nil
 }
 
 func (r *Reader) Read16(bitCount byte) (uint16, error) {
 	if bitCount > 16 {
-		return 0, ErrBitCountTooBig
+		return 0, r.readError("Read16", bitCount, ErrBitCountTooBig)
 	}
-	return read[uint16](r, bitCount)
+
+	v, err := read[uint16](r, bitCount)
+	if err != nil {
+		return 0, r.readError("Read16", bitCount, err)
+	}
+
+	if r.trace != nil {
+		r.traceRead("Read16", bitCount, uint64(v))
+	}
+
+	return v, nil
 }
 
 func (r *Reader) Read32(bitCount byte) (uint32, error) {
 	if bitCount > 32 {
-		return 0, ErrBitCountTooBig
+		return 0, r.readError("Read32", bitCount, ErrBitCountTooBig)
+	}
+
+	v, err := read[uint32](r, bitCount)
+	if err != nil {
+		return 0, r.readError("Read32", bitCount, err)
+	}
+
+	if r.trace != nil {
+		r.traceRead("Read32", bitCount, uint64(v))
 	}
-	return read[uint32](r, bitCount)
+
+	return v, nil
 }
 
 func (r *Reader) Read64(bitCount byte) (uint64, error) {
 	if bitCount > 64 {
-		return 0, ErrBitCountTooBig
+		return 0, r.readError("Read64", bitCount, ErrBitCountTooBig)
+	}
+
+	v, err := read[uint64](r, bitCount)
+	if err != nil {
+		return 0, r.readError("Read64", bitCount, err)
+	}
+
+	if r.trace != nil {
+		r.traceRead("Read64", bitCount, uint64(v))
 	}
-	return read[uint64](r, bitCount)
+
+	return v, nil
 }
 
 type ReaderError struct {
@@ -109,9 +332,9 @@ type ReaderError struct {
 	err    error
 }
 
-func NewReaderError(data BitData) *ReaderError {
+func NewReaderError(data BitData, opts ...Option) *ReaderError {
 	return &ReaderError{
-		reader: *NewReader(data),
+		reader: *NewReader(data, opts...),
 		err:    nil,
 	}
 }
@@ -124,6 +347,18 @@ func (r *ReaderError) Skip(bitCount uint) {
 	r.reader.Skip(bitCount)
 }
 
+func (r *ReaderError) SkipChecked(bitCount uint) {
+	if r.err == nil {
+		r.err = r.reader.SkipChecked(bitCount)
+	}
+}
+
+func (r *ReaderError) SkipBack(bitCount uint) {
+	if r.err == nil {
+		r.err = r.reader.SkipBack(bitCount)
+	}
+}
+
 func (r *ReaderError) ReadBool() (v bool) {
 	if r.err == nil {
 		v, r.err = r.reader.ReadBool()
@@ -167,31 +402,84 @@ func mask[T integer](n byte) T {
 	return T(1)<<n - 1
 }
 
+func signExtend(v uint64, bitCount byte) int64 {
+	if bitCount == 0 {
+		return 0
+	}
+	shift := 64 - bitCount
+	return int64(v<<shift) >> shift
+}
+
+// checkFits panics if the value has bits set above the lowest bitCount bits.
+func checkFits[T integer](v T, bitCount byte) {
+	if bitCount < 64 && uint64(v)>>bitCount != 0 {
+		panic("bitdata: value doesn't fit into the bit count")
+	}
+}
+
+// checkFitsSigned panics if the value is out of the range of bitCount bit two's complement numbers.
+func checkFitsSigned(v int64, bitCount byte) {
+	if bitCount > 0 && bitCount < 64 && (v < -1<<(bitCount-1) || v >= 1<<(bitCount-1)) {
+		panic("bitdata: value doesn't fit into the bit count")
+	}
+}
+
 func write[T integer](w *Writer, value T, bitCount byte) {
 	if bitCount == 0 {
 		return
 	}
 
-	value = value & mask[T](bitCount)
+	v := uint64(value) & mask[uint64](bitCount)
+
+	if w.recording {
+		w.fields = append(w.fields, Field{Offset: w.bitsWritten, Width: bitCount, Value: v})
+	}
 
-	idx := w.bitsWritten / 8
 	ofs := w.bitsWritten % 8
-	bitsRemain := int8(bitCount)
+	end := w.bitsWritten + uint(bitCount)
 
-	if ofs > 0 {
-		(*w.data)[idx] = (*w.data)[idx] | byte(value<<ofs)
-		bits := int8(8 - byte(ofs))
-		bitsRemain -= bits
-		value >>= bits
+	if w.order == MSBFirst {
+		writeMSB(w, v, bitCount)
+		w.checkBudget()
+		return
 	}
 
-	for bitsRemain > 0 {
-		*w.data = append(*w.data, byte(value))
-		value >>= 8
-		bitsRemain -= 8
+	if ofs == 0 && w.appendAligned(v, bitCount) {
+		w.bitsWritten = end
+		w.checkBudget()
+		return
+	}
+
+	if ofs > 0 {
+		*w.byteAt(w.bitsWritten / 8) |= byte(v << ofs)
+		v >>= 8 - ofs
 	}
 
+	w.appendWord(v, (end+7)/8-w.size)
+
 	w.bitsWritten += uint(bitCount)
+	w.checkBudget()
+}
+
+// writeMSB is the MSBFirst part of write, for v already masked to bitCount bits.
+func writeMSB(w *Writer, v uint64, bitCount byte) {
+	n := uint(bitCount)
+
+	if ofs := w.bitsWritten % 8; ofs > 0 {
+		free := 8 - ofs
+		b := w.byteAt(w.bitsWritten / 8)
+		if n <= free {
+			*b |= byte(v << (free - n))
+			w.bitsWritten += n
+			return
+		}
+		*b |= byte(v >> (n - free))
+		w.bitsWritten += free
+		n -= free
+	}
+
+	w.appendWord(bits.ReverseBytes64(v<<(64-n)), (n+7)/8)
+	w.bitsWritten += n
 }
 
 func read[T integer](r *Reader, bitCount byte) (T, error) {
@@ -199,40 +487,232 @@ func read[T integer](r *Reader, bitCount byte) (T, error) {
 		return 0, nil
 	}
 
-	var value T
+	if r.bitsRead+uint(bitCount) > r.end {
+		if err := r.pastEnd(uint(bitCount)); err != nil {
+			return 0, err
+		}
+		if r.partial {
+			return 0, ErrNeedMoreData
+		}
+		if r.optional && r.atEnd() {
+			r.ended = true
+			return 0, nil
+		}
+		return 0, io.ErrUnexpectedEOF
+	}
+
+	if r.bufPos != r.bitsRead || r.bufBits < bitCount {
+		if err := r.fill(); err != nil {
+			return 0, err
+		}
+		if r.bufBits < bitCount && r.optional && r.atEnd() {
+			r.ended = true
+			return 0, nil
+		}
+		if r.bufBits < bitCount {
+			return 0, io.ErrUnexpectedEOF
+		}
+	}
+
+	v := r.buf
+	if r.order == MSBFirst {
+		v >>= 64 - bitCount
+		r.buf <<= bitCount
+	} else {
+		r.buf >>= bitCount
+	}
+	r.bufBits -= bitCount
+	r.bitsRead += uint(bitCount)
+	r.bufPos = r.bitsRead
+
+	return T(v) & mask[T](bitCount), nil
+}
+
+// ensure reports whether n bits remain, for the methods that check the length before reading. If they don't,
+// it returns the error of read: io.ErrUnexpectedEOF, ErrNeedMoreData for a partial reader, or the LimitError
+// past the bit budget of a decode operation. For a reader with an optional tail at its end it returns false
+// with no error and records that the reader ended early; the method then returns zero values.
+func (r *Reader) ensure(n uint) (bool, error) {
+	if err := r.probe(n); err != nil {
+		return false, err
+	}
 
+	if r.bitsRead > r.end || r.end-r.bitsRead < n {
+		if err := r.pastEnd(n); err != nil {
+			return false, err
+		}
+		if r.partial {
+			return false, ErrNeedMoreData
+		}
+		if r.optional && r.atEnd() {
+			r.ended = true
+			return false, nil
+		}
+		return false, io.ErrUnexpectedEOF
+	}
+
+	return true, nil
+}
+
+// ensureCount is ensure for count values of size bits each, with a count read from the stream that may
+// not fit into a bit count.
+func (r *Reader) ensureCount(count, size uint64) (bool, error) {
+	hi, n := bits.Mul64(count, size)
+	if hi != 0 || n > uint64(maxInt) {
+		n = uint64(maxInt)
+	}
+	return r.ensure(uint(n))
+}
+
+// probe reads up to n bits ahead from a source that finds its end only while reading, so that the end
+// of the reader is known for them. It returns the errors of the source other than reaching its end.
+func (r *Reader) probe(n uint) error {
+	if _, ok := r.src.(sourceEnder); !ok || n == 0 || r.bitsRead > r.end || r.end-r.bitsRead < n {
+		return nil
+	}
+
+	_, err := r.bytes(r.bitsRead/8, (r.bitsRead+n+7)/8)
+	if r.clampEnd() && (errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF)) {
+		return nil
+	}
+
+	return err
+}
+
+// clampEnd sets the end of a source that has found it while reading as the end of the reader.
+// It reports whether the source has found its end.
+func (r *Reader) clampEnd() bool {
+	e, ok := r.src.(sourceEnder)
+	if !ok {
+		return false
+	}
+
+	end, ended := e.bitEnd()
+	if ended && end < r.end {
+		r.end = max(end, r.bitsRead)
+	}
+
+	return ended
+}
+
+// fill loads up to 64 bits starting at the read position into the bit buffer.
+// The buffer is tied to the position it was filled at, so any change of the position invalidates it.
+func (r *Reader) fill() error {
+	n := byte(min(64, r.end-r.bitsRead))
 	idx := r.bitsRead / 8
 	ofs := r.bitsRead % 8
-	bitsRemain := int8(bitCount)
-	var bitsRead byte
 
-	if ofs > 0 {
-		if idx >= uint(len(r.data)) {
-			return 0, io.ErrUnexpectedEOF
+	var v uint64
+	if r.src == nil && idx+9 <= uint(len(r.data)) && r.order == MSBFirst {
+		v = load64BE(r.data[idx:]) << ofs
+		if ofs > 0 {
+			v |= uint64(r.data[idx+8]) >> (8 - ofs)
+		}
+	} else if r.src == nil && idx+9 <= uint(len(r.data)) {
+		v = load64(r.data[idx:]) >> ofs
+		if ofs > 0 {
+			v |= uint64(r.data[idx+8]) << (64 - ofs)
+		}
+	} else {
+		end := r.end
+		buf, err := r.bytes(idx, (r.bitsRead+uint(n)+7)/8)
+		if r.clampEnd(); r.end < end {
+			// A source that has just found its end can still provide the bits before it.
+			return r.fill()
+		}
+		if err != nil {
+			return err
+		}
+		if r.order == MSBFirst {
+			v = loadWordMSB(buf, ofs)
+		} else {
+			v = loadWord(buf, ofs)
 		}
+	}
+
+	r.buf = v
+	r.bufBits = n
+	r.bufPos = r.bitsRead
 
-		value = T(r.data[idx]>>ofs) & mask[T](bitCount)
-		bits := int8(8 - byte(ofs))
-		bitsRemain -= bits
-		bitsRead += byte(bits)
-		idx++
+	return nil
+}
+
+// loadWord returns the bits of up to 9 bytes starting at the bit offset ofs of the first byte.
+func loadWord(buf []byte, ofs uint) uint64 {
+	var v uint64
+	for i, b := range buf[:min(len(buf), 8)] {
+		v |= uint64(b) << (8 * i)
+	}
+	v >>= ofs
+	if len(buf) > 8 {
+		v |= uint64(buf[8]) << (64 - ofs)
 	}
+	return v
+}
 
-	for bitsRemain > 0 {
-		if idx >= uint(len(r.data)) {
-			return 0, io.ErrUnexpectedEOF
+func getBit(d BitData, i uint) bool {
+	return d[i/8]>>(i%8)&1 == 1
+}
+
+func setBit(d BitData, i uint, v bool) {
+	if v {
+		d[i/8] |= 1 << (i % 8)
+	} else {
+		d[i/8] &^= 1 << (i % 8)
+	}
+}
+
+// getBits returns bitCount bits, up to 56, of the data at the bit offset offsetBits.
+func getBits(d BitData, offsetBits uint, bitCount byte) uint64 {
+	idx := offsetBits / 8
+	return loadWord(d[idx:min(idx+8, uint(len(d)))], offsetBits%8) & mask[uint64](bitCount)
+}
+
+// putBits overwrites bitCount bits of the data at the bit offset offsetBits with the lowest bits of v.
+func putBits(d BitData, offsetBits uint, v uint64, bitCount byte) {
+	for bitCount > 0 {
+		ofs := offsetBits % 8
+		n := byte(8 - ofs)
+		if n > bitCount {
+			n = bitCount
 		}
 
-		v := T(r.data[idx]) << bitsRead
-		m := mask[T](byte(bitsRemain)) << bitsRead
-		value |= v & m
+		m := mask[byte](n) << ofs
+		d[offsetBits/8] = d[offsetBits/8]&^m | byte(v<<ofs)&m
 
-		idx++
-		bitsRead += 8
-		bitsRemain -= 8
+		v >>= n
+		offsetBits += uint(n)
+		bitCount -= n
 	}
+}
 
-	r.bitsRead += uint(bitCount)
+// writeBits appends bitCount bits of the data starting at the bit offset offsetBits.
+func writeBits(w *Writer, d BitData, offsetBits, bitCount uint) {
+	r := NewReader(d)
+	r.order = w.order
+	r.Skip(offsetBits)
+
+	if err := copyBits(w, r, bitCount); err != nil {
+		panic("bitdata: bit range out of bounds")
+	}
+}
+
+// copyBits appends the next bitCount bits of the reader.
+func copyBits(w *Writer, r *Reader, bitCount uint) error {
+	for bitCount > 0 {
+		n := byte(min(bitCount, 64))
+
+		v, err := read[uint64](r, n)
+		if err != nil {
+			return err
+		}
+		if r.order != w.order {
+			v = bits.Reverse64(v) >> (64 - n)
+		}
+		write[uint64](w, v, n)
+
+		bitCount -= uint(n)
+	}
 
-	return value, nil
+	return nil
 }
@@ -3,8 +3,10 @@
 package bitdata
 
 import (
+	"errors"
 	"io"
 	"math/rand/v2"
+	"strconv"
 	"testing"
 )
 
@@ -181,8 +183,8 @@ func TestBitDataZero(t *testing.T) {
 	if w.bitsWritten != 0 {
 		t.Errorf("expected 0 bits, got %d", w.bitsWritten)
 	}
-	if len(*w.data) != 0 {
-		t.Errorf("expected 0 len, got %d", len(*w.data))
+	if len(w.BitData()) != 0 {
+		t.Errorf("expected 0 len, got %d", len(w.BitData()))
 	}
 
 	r := NewReader(w.BitData())
@@ -206,7 +208,7 @@ func TestBitDataError(t *testing.T) {
 
 	r = NewReader(w.BitData())
 	_, err = r.ReadBool()
-	if err != io.ErrUnexpectedEOF {
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
 		t.Errorf("expected error, got %v", err)
 	}
 
@@ -217,22 +219,22 @@ func TestBitDataError(t *testing.T) {
 	r = NewReader(w.BitData())
 
 	_, err = r.Read8(10)
-	if err != ErrBitCountTooBig {
+	if !errors.Is(err, ErrBitCountTooBig) {
 		t.Errorf("expected error, got %v", err)
 	}
 
 	_, err = r.Read16(20)
-	if err != ErrBitCountTooBig {
+	if !errors.Is(err, ErrBitCountTooBig) {
 		t.Errorf("expected error, got %v", err)
 	}
 
 	_, err = r.Read32(40)
-	if err != ErrBitCountTooBig {
+	if !errors.Is(err, ErrBitCountTooBig) {
 		t.Errorf("expected error, got %v", err)
 	}
 
 	_, err = r.Read64(65)
-	if err != ErrBitCountTooBig {
+	if !errors.Is(err, ErrBitCountTooBig) {
 		t.Errorf("expected error, got %v", err)
 	}
 
@@ -242,7 +244,7 @@ func TestBitDataError(t *testing.T) {
 	}
 
 	_, err = r.Read8(7)
-	if err != io.ErrUnexpectedEOF {
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
 		t.Errorf("expected error, got %v", err)
 	}
 }
@@ -265,7 +267,7 @@ func TestBitDataTooManyBits(t *testing.T) {
 		t.Errorf("value mismatch, want %b, got %b, error %v", 0, v, err)
 	}
 
-	if _, err := r.ReadBool(); err != io.ErrUnexpectedEOF {
+	if _, err := r.ReadBool(); !errors.Is(err, io.ErrUnexpectedEOF) {
 		t.Errorf("err mismatch, want %v, got %v", io.ErrUnexpectedEOF, err)
 	}
 
@@ -324,9 +326,182 @@ func TestReaderError(t *testing.T) {
 			r.Skip(12)
 			test.fn(t, r)
 
-			if want, got := io.ErrUnexpectedEOF, r.Error(); want != got {
+			if want, got := io.ErrUnexpectedEOF, r.Error(); !errors.Is(got, want) {
 				t.Errorf("want %v, got %v", want, got)
 			}
 		})
 	}
 }
+
+func BenchmarkWrite(b *testing.B) {
+	for _, bitCount := range []byte{5, 13, 32, 64} {
+		b.Run(strconv.Itoa(int(bitCount)), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				w := NewWriter()
+				for j := 0; j < 1000; j++ {
+					w.Write64(uint64(j)*0x9E3779B97F4A7C15, bitCount)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkRead(b *testing.B) {
+	for _, bitCount := range []byte{5, 13, 32, 64} {
+		w := NewWriter()
+		for j := 0; j < 1000; j++ {
+			w.Write64(uint64(j)*0x9E3779B97F4A7C15, bitCount)
+		}
+		d := w.BitData()
+
+		b.Run(strconv.Itoa(int(bitCount)), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				r := NewReader(d)
+				for j := 0; j < 1000; j++ {
+					_, _ = r.Read64(bitCount)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkAligned(b *testing.B) {
+	b.Run("write", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			w := NewWriter()
+			for j := 0; j < 250; j++ {
+				w.Write8(uint8(j), 8)
+				w.Write16(uint16(j), 16)
+				w.Write32(uint32(j), 32)
+				w.Write64(uint64(j), 64)
+			}
+		}
+	})
+
+	w := NewWriter()
+	for j := 0; j < 250; j++ {
+		w.Write8(uint8(j), 8)
+		w.Write16(uint16(j), 16)
+		w.Write32(uint32(j), 32)
+		w.Write64(uint64(j), 64)
+	}
+	d := w.BitData()
+
+	b.Run("read", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			r := NewReader(d)
+			for j := 0; j < 250; j++ {
+				_, _ = r.Read8(8)
+				_, _ = r.Read16(16)
+				_, _ = r.Read32(32)
+				_, _ = r.Read64(64)
+			}
+		}
+	})
+}
+
+func BenchmarkReadSmall(b *testing.B) {
+	w := NewWriter()
+	for j := 0; j < 1000; j++ {
+		w.Write8(uint8(j), byte(j%8+1))
+	}
+	d := w.BitData()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r := NewReader(d)
+		for j := 0; j < 1000; j++ {
+			_, _ = r.Read8(byte(j%8 + 1))
+		}
+	}
+}
+
+func TestReaderBuffer(t *testing.T) {
+	w := NewWriter()
+	for i := 0; i < 100; i++ {
+		w.Write8(uint8(i), 7)
+	}
+	d := w.BitData()
+
+	// moving the position in any way must not serve stale buffered bits
+	r := NewReader(d)
+	_, _ = r.Read8(7)
+	r.Skip(7)
+	if v, _ := r.Read8(7); v != 2 {
+		t.Errorf("after skip: want=2 got=%d", v)
+	}
+	_, _ = r.Seek(0, io.SeekStart)
+	if v, _ := r.Read8(7); v != 0 {
+		t.Errorf("after seek: want=0 got=%d", v)
+	}
+	if _, err := r.Read64(63); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	if v, _ := r.Read8(7); v != 10 {
+		t.Errorf("after wide read: want=10 got=%d", v)
+	}
+
+	sub := *r
+	sub.end = sub.bitsRead + 7
+	if v, _ := sub.Read8(7); v != 11 {
+		t.Errorf("bounded copy: want=11 got=%d", v)
+	}
+	if _, err := sub.Read8(1); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("bounded copy: expected EOF, got %v", err)
+	}
+	if v, _ := r.Read8(7); v != 11 {
+		t.Errorf("original after copy: want=11 got=%d", v)
+	}
+}
+
+func TestSkipChecked(t *testing.T) {
+	r := NewReader(BitData{0xFF, 0xFF})
+
+	if err := r.SkipChecked(10); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := r.SkipChecked(7); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("expected io.ErrUnexpectedEOF, got %v", err)
+	}
+	if r.BitsRead() != 10 {
+		t.Errorf("failed skip moved the reader to %d", r.BitsRead())
+	}
+	if err := r.SkipChecked(6); err != nil || r.BitsRead() != 16 {
+		t.Errorf("unexpected result: err=%v pos=%d", err, r.BitsRead())
+	}
+
+	re := NewReaderError(BitData{0xFF})
+	re.SkipChecked(9)
+	re.Skip(1)
+	if !errors.Is(re.Error(), io.ErrUnexpectedEOF) || re.reader.BitsRead() != 1 {
+		t.Errorf("unexpected result: err=%v pos=%d", re.Error(), re.reader.BitsRead())
+	}
+}
+
+func TestSkipBack(t *testing.T) {
+	r := NewReader(BitData{0x34, 0x12, 0xCD})
+
+	if v, err := r.Read16(16); err != nil || v != 0x1234 {
+		t.Fatalf("unexpected %x, %v", v, err)
+	}
+	if err := r.SkipBack(12); err != nil || r.BitsRead() != 4 {
+		t.Fatalf("unexpected result: err=%v pos=%d", err, r.BitsRead())
+	}
+	if v, err := r.Read16(12); err != nil || v != 0x123 {
+		t.Errorf("unexpected %x, %v", v, err)
+	}
+	if err := r.SkipBack(17); !errors.Is(err, ErrInvalidRange) || r.BitsRead() != 16 {
+		t.Errorf("unexpected result: err=%v pos=%d", err, r.BitsRead())
+	}
+	if err := r.SkipBack(16); err != nil || r.BitsRead() != 0 {
+		t.Errorf("unexpected result: err=%v pos=%d", err, r.BitsRead())
+	}
+
+	re := NewReaderError(BitData{0xFF})
+	re.Skip(3)
+	re.SkipBack(4)
+	re.Skip(1)
+	if !errors.Is(re.Error(), ErrInvalidRange) || re.reader.BitsRead() != 4 {
+		t.Errorf("unexpected result: err=%v pos=%d", re.Error(), re.reader.BitsRead())
+	}
+}
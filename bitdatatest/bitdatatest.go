@@ -0,0 +1,101 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+// Package bitdatatest provides helpers for property-based testing of bit layouts built with bitdata:
+// random bit streams, a testing/quick generator and round-trip assertions.
+package bitdatatest
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+
+	"github.com/marko-gacesa/bitdata"
+)
+
+// Bits is a random bit stream for testing/quick: a bitdata.Bits whose unused bits of the last byte are zero.
+type Bits struct {
+	bitdata.Bits
+}
+
+// Generate implements quick.Generator. The generated stream has up to 8*size bits.
+func (Bits) Generate(rnd *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(Bits{RandomBits(rnd, uint(rnd.Intn(8*size+1)))})
+}
+
+// RandomBits returns a stream of n random bits.
+func RandomBits(rnd *rand.Rand, n uint) bitdata.Bits {
+	d := make(bitdata.BitData, (n+7)/8)
+	rnd.Read(d)
+	if rem := n % 8; rem > 0 {
+		d[len(d)-1] &= 1<<rem - 1
+	}
+	return bitdata.Bits{Data: d, Count: n}
+}
+
+// RandomValue returns a random value of the struct type T that conforms to its bit layout, as described
+// in bitdata.Marshal, together with its encoding. Fields narrower than their type only get values that fit,
+// so the value survives an encode and decode round trip unchanged.
+func RandomValue[T any](rnd *rand.Rand, opts ...bitdata.Option) (T, bitdata.Bits, error) {
+	var v T
+
+	rv, ok := quick.Value(reflect.TypeOf(v), rnd)
+	if !ok {
+		return v, bitdata.Bits{}, fmt.Errorf("%w: can't generate %T", bitdata.ErrUnsupportedType, v)
+	}
+
+	w := bitdata.NewWriter(opts...)
+	if err := w.Encode(rv.Interface()); err != nil {
+		return v, bitdata.Bits{}, err
+	}
+
+	b := w.Bits()
+	if err := bitdata.NewReader(b.Data, opts...).Decode(&v); err != nil {
+		return v, bitdata.Bits{}, err
+	}
+
+	return v, b, nil
+}
+
+// RoundTrip encodes v with Encode and decodes it back, in both bit orders, and reports a test error
+// if the decoded value differs or if the decoder doesn't consume the whole stream.
+func RoundTrip[T any](t testing.TB, v T) {
+	t.Helper()
+
+	RoundTripFunc(t, v,
+		func(w *bitdata.Writer, v T) error {
+			return w.Encode(v)
+		},
+		func(r *bitdata.Reader) (v T, err error) {
+			err = r.Decode(&v)
+			return
+		})
+}
+
+// RoundTripFunc is like RoundTrip for values written and read by the given functions.
+func RoundTripFunc[T any](t testing.TB, v T, write func(w *bitdata.Writer, v T) error, read func(r *bitdata.Reader) (T, error)) {
+	t.Helper()
+
+	for _, o := range []bitdata.BitOrder{bitdata.LSBFirst, bitdata.MSBFirst} {
+		w := bitdata.NewWriter(bitdata.WithBitOrder(o))
+		if err := write(w, v); err != nil {
+			t.Errorf("bit order %d: write failed: %v", o, err)
+			continue
+		}
+
+		r := bitdata.NewReader(w.BitData(), bitdata.WithBitOrder(o))
+		got, err := read(r)
+		if err != nil {
+			t.Errorf("bit order %d: read failed: %v", o, err)
+			continue
+		}
+
+		if !reflect.DeepEqual(got, v) {
+			t.Errorf("bit order %d: round trip mismatch: want=%+v got=%+v", o, v, got)
+		}
+		if r.BitsRead() != w.BitsWritten() {
+			t.Errorf("bit order %d: %d bits written, %d bits read", o, w.BitsWritten(), r.BitsRead())
+		}
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+//go:build !bitdata_noreflect
+
+package bitdatatest
+
+import (
+	"math/rand"
+	"testing"
+	"testing/quick"
+
+	"github.com/marko-gacesa/bitdata"
+)
+
+type sample struct {
+	Kind  uint8 `bits:"3"`
+	Delta int16 `bits:"5"`
+	Flag  bool
+	Count uint32 `bits:"20"`
+	Pair  [2]int8
+}
+
+func TestBitsGenerator(t *testing.T) {
+	f := func(b Bits) bool {
+		if uint(len(b.Data)) != (b.Count+7)/8 {
+			return false
+		}
+		if b.Count%8 != 0 && b.Data[len(b.Data)-1]>>(b.Count%8) != 0 {
+			return false
+		}
+
+		w := bitdata.NewWriter()
+		w.WriteBitData(b.Data, b.Count)
+		return w.BitsWritten() == b.Count && string(w.BitData()) == string(b.Data)
+	}
+
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRandomValue(t *testing.T) {
+	rnd := rand.New(rand.NewSource(42))
+
+	for i := 0; i < 100; i++ {
+		v, b, err := RandomValue[sample](rnd)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if b.Count != 3+5+1+20+16 {
+			t.Errorf("unexpected size %d", b.Count)
+		}
+		if v.Kind > 7 || v.Delta < -16 || v.Delta > 15 || v.Count >= 1<<20 {
+			t.Errorf("value doesn't conform: %+v", v)
+		}
+
+		RoundTrip(t, v)
+	}
+}
+
+func TestRoundTripFunc(t *testing.T) {
+	f := func(v uint64) bool {
+		RoundTripFunc(t, v,
+			func(w *bitdata.Writer, v uint64) error {
+				w.WriteUvarint(v)
+				return nil
+			},
+			func(r *bitdata.Reader) (uint64, error) {
+				return r.ReadUvarint()
+			})
+		return !t.Failed()
+	}
+
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdatatest
+
+import (
+	"errors"
+	"io"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/marko-gacesa/bitdata"
+)
+
+// Backend is an implementation of bitdata.BitSink and bitdata.BitSource checked by Conformance.
+type Backend struct {
+	// NewSink returns an empty sink.
+	NewSink func() bitdata.BitSink
+
+	// NewSource returns a source of exactly the bits written to the sink.
+	NewSource func(bitdata.BitSink) bitdata.BitSource
+}
+
+// Conformance checks that the backend behaves as bitdata.Writer and bitdata.Reader do: the order of
+// the bits, the behavior at the end of the data, zero-width operations and the masking of values.
+func Conformance(t *testing.T, b Backend) {
+	t.Helper()
+
+	t.Run("Ordering", func(t *testing.T) {
+		rnd := rand.New(rand.NewPCG(1, 2))
+
+		type field struct {
+			v     uint64
+			width byte
+		}
+		fields := make([]field, 2000)
+		for i := range fields {
+			width := byte(rnd.IntN(65))
+			fields[i] = field{v: rnd.Uint64() & (1<<width - 1), width: width}
+		}
+
+		sink := b.NewSink()
+		var total uint
+		for _, f := range fields {
+			sink.Write64(f.v, f.width)
+			total += uint(f.width)
+			if got := sink.BitsWritten(); got != total {
+				t.Fatalf("BitsWritten: got %d, want %d", got, total)
+			}
+		}
+
+		src := b.NewSource(sink)
+		total = 0
+		for i, f := range fields {
+			v, err := src.Read64(f.width)
+			if err != nil {
+				t.Fatalf("field %d: %v", i, err)
+			}
+			if v != f.v {
+				t.Fatalf("field %d of %d bits: got %#x, want %#x", i, f.width, v, f.v)
+			}
+			total += uint(f.width)
+			if got := src.BitsRead(); got != total {
+				t.Fatalf("BitsRead: got %d, want %d", got, total)
+			}
+		}
+	})
+
+	t.Run("EOF", func(t *testing.T) {
+		sink := b.NewSink()
+		sink.Write64(0x2AB, 10)
+
+		src := b.NewSource(sink)
+		if _, err := src.Read64(11); !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Errorf("reading past the end: expected io.ErrUnexpectedEOF, got %v", err)
+		}
+		if got := src.BitsRead(); got != 0 {
+			t.Errorf("a failed read moved the position to %d", got)
+		}
+		if v, err := src.Read64(10); err != nil || v != 0x2AB {
+			t.Errorf("got %#x, %v after a failed read", v, err)
+		}
+		if _, err := src.Read64(1); !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Errorf("reading at the end: expected io.ErrUnexpectedEOF, got %v", err)
+		}
+		if _, err := src.Read64(65); !errors.Is(err, bitdata.ErrBitCountTooBig) {
+			t.Errorf("reading 65 bits: expected ErrBitCountTooBig, got %v", err)
+		}
+	})
+
+	t.Run("ZeroWidth", func(t *testing.T) {
+		sink := b.NewSink()
+		sink.Write64(^uint64(0), 0)
+		if got := sink.BitsWritten(); got != 0 {
+			t.Errorf("writing zero bits wrote %d", got)
+		}
+		sink.Write64(5, 3)
+		sink.Write64(^uint64(0), 0)
+		sink.Write64(1, 2)
+
+		src := b.NewSource(sink)
+		if v, err := src.Read64(0); v != 0 || err != nil || src.BitsRead() != 0 {
+			t.Errorf("reading zero bits: got %d, %v", v, err)
+		}
+		if v, _ := src.Read64(3); v != 5 {
+			t.Errorf("got %d, want 5", v)
+		}
+		if v, _ := src.Read64(2); v != 1 {
+			t.Errorf("got %d, want 1", v)
+		}
+		if v, err := src.Read64(0); v != 0 || err != nil {
+			t.Errorf("reading zero bits at the end: got %d, %v", v, err)
+		}
+	})
+
+	t.Run("Masking", func(t *testing.T) {
+		sink := b.NewSink()
+		sink.Write64(^uint64(0), 3)
+		sink.Write64(0, 5)
+		sink.Write64(0xFFFF_0000_0000_0001, 33)
+		sink.Write64(0, 1)
+
+		src := b.NewSource(sink)
+		for _, want := range []struct {
+			v     uint64
+			width byte
+		}{{7, 3}, {0, 5}, {1, 33}, {0, 1}} {
+			if v, err := src.Read64(want.width); err != nil || v != want.v {
+				t.Errorf("got %#x, %v, want %#x", v, err, want.v)
+			}
+		}
+	})
+}
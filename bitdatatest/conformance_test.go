@@ -0,0 +1,33 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdatatest
+
+import (
+	"testing"
+
+	"github.com/marko-gacesa/bitdata"
+)
+
+func TestConformance(t *testing.T) {
+	for _, order := range []bitdata.BitOrder{bitdata.LSBFirst, bitdata.MSBFirst} {
+		Conformance(t, Backend{
+			NewSink: func() bitdata.BitSink {
+				return bitdata.NewWriter(bitdata.WithBitOrder(order))
+			},
+			NewSource: func(s bitdata.BitSink) bitdata.BitSource {
+				w := s.(*bitdata.Writer)
+				return bitdata.NewReaderBits(w.BitData(), w.BitsWritten(), bitdata.WithBitOrder(order))
+			},
+		})
+	}
+
+	Conformance(t, Backend{
+		NewSink: func() bitdata.BitSink {
+			return bitdata.NewWriter()
+		},
+		NewSource: func(s bitdata.BitSink) bitdata.BitSource {
+			w := s.(*bitdata.Writer)
+			return bitdata.NewReaderSource(bitdata.NewReaderBits(w.BitData(), w.BitsWritten()))
+		},
+	})
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// BitOrder selects how the bits of a stream are packed into bytes.
+type BitOrder byte
+
+const (
+	// LSBFirst fills every byte starting from its least significant bit, and writes the least significant
+	// bit of a value first. It is the default order.
+	LSBFirst BitOrder = iota
+
+	// MSBFirst fills every byte starting from its most significant bit, and writes the most significant
+	// bit of a value first, as most video, audio and network formats do.
+	// Functions working directly on BitData, such as CRC.Checksum and the bitwise ones, always use LSBFirst.
+	MSBFirst
+)
+
+// ConvertOrder returns a copy of the data, a stream in the bit order from, with the same stream of bits
+// laid out in the bit order to: if the orders differ, the bits are reversed within every byte. If byteSwapWidth
+// is greater than one, the bytes are also reversed within every group of byteSwapWidth bytes, which converts
+// words stored in the other byte order, for example the 32-bit words of a capture from big-endian hardware
+// with a byteSwapWidth of 4. It panics if the length of the data isn't a multiple of byteSwapWidth.
+func ConvertOrder(data BitData, from, to BitOrder, byteSwapWidth int) BitData {
+	width := max(byteSwapWidth, 1)
+	if len(data)%width != 0 {
+		panic("bitdata: data length isn't a multiple of the byte swap width")
+	}
+
+	out := make(BitData, len(data))
+	for i := 0; i < len(data); i += width {
+		for j := range width {
+			b := data[i+width-1-j]
+			if from != to {
+				b = bits.Reverse8(b)
+			}
+			out[i+j] = b
+		}
+	}
+
+	return out
+}
+
+func NewWriterMSB() *Writer {
+	return &Writer{order: MSBFirst}
+}
+
+func NewReaderMSB(data BitData) *Reader {
+	r := NewReader(data)
+	r.order = MSBFirst
+	return r
+}
+
+func NewReaderErrorMSB(data BitData) *ReaderError {
+	return &ReaderError{reader: *NewReaderMSB(data)}
+}
+
+func (w *Writer) BitOrder() BitOrder {
+	return w.order
+}
+
+func (r *Reader) BitOrder() BitOrder {
+	return r.order
+}
+
+// loadWordMSB is like loadWord for MSBFirst, returning the bits aligned to the most significant bit.
+func loadWordMSB(buf []byte, ofs uint) uint64 {
+	var v uint64
+	for i, b := range buf[:min(len(buf), 8)] {
+		v |= uint64(b) << (56 - 8*i)
+	}
+	v <<= ofs
+	if len(buf) > 8 {
+		v |= uint64(buf[8]) >> (8 - ofs)
+	}
+	return v
+}
+
+// byteOrder returns the byte order in which a 64-bit value written with the bit order o
+// ends up in the stream, when written on a byte boundary.
+func byteOrder(o BitOrder) binary.ByteOrder {
+	if o == MSBFirst {
+		return binary.BigEndian
+	}
+	return binary.LittleEndian
+}
+
+// bitAt returns the bit of b at the stream position i of the byte, for the order o.
+func bitAt(b byte, i uint, o BitOrder) bool {
+	if o == MSBFirst {
+		return b>>(7-i)&1 == 1
+	}
+	return b>>i&1 == 1
+}
+
+// lsbFirst returns the data in the order o as the same stream in the LSBFirst order.
+func lsbFirst(d BitData, o BitOrder) BitData {
+	if o == MSBFirst {
+		return d.ReverseByteBits()
+	}
+	return d
+}
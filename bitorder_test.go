@@ -0,0 +1,206 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math/rand"
+	"testing"
+)
+
+func TestMSBFirst(t *testing.T) {
+	w := NewWriterMSB()
+	w.Write8(0b101, 3)
+	w.Write16(0x1234, 16)
+	w.Write8(0b11111, 5)
+	w.Write64(0x0102030405060708, 64)
+
+	want := []byte{0b10100010, 0b01000110, 0b10011111, 1, 2, 3, 4, 5, 6, 7, 8}
+	if !bytes.Equal(want, w.BitData()) {
+		t.Errorf("encoding mismatch: want=%08b got=%08b", want, w.BitData())
+	}
+
+	r := NewReaderErrorMSB(w.BitData())
+	if v := r.Read8(3); v != 0b101 {
+		t.Errorf("want=%b got=%b", 0b101, v)
+	}
+	if v := r.Read16(16); v != 0x1234 {
+		t.Errorf("want=%x got=%x", 0x1234, v)
+	}
+	if v := r.Read8(5); v != 0b11111 {
+		t.Errorf("want=%b got=%b", 0b11111, v)
+	}
+	if v := r.Read64(64); v != 0x0102030405060708 {
+		t.Errorf("want=%x got=%x", uint64(0x0102030405060708), v)
+	}
+	if err := r.Error(); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
+
+func TestMSBFirstRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+
+	type value struct {
+		v uint64
+		n byte
+	}
+
+	values := make([]value, 1000)
+	w := NewWriterMSB()
+	for i := range values {
+		n := byte(rnd.Intn(64) + 1)
+		values[i] = value{v: rnd.Uint64() & mask[uint64](n), n: n}
+		w.Write64(values[i].v, n)
+	}
+
+	// reverse the bits of every byte to get the same stream in the LSBFirst order
+	lsb := w.BitData().ReverseByteBits()
+
+	r := NewReaderMSB(w.BitData())
+	rl := NewReader(lsb)
+	for _, x := range values {
+		v, err := r.Read64(x.n)
+		if err != nil || v != x.v {
+			t.Fatalf("value mismatch: want=%x got=%x err=%v", x.v, v, err)
+		}
+
+		v, _ = rl.Read64(x.n)
+		if got := msbFirst(v, x.n, LSBFirst); got != x.v {
+			t.Fatalf("stream mismatch: want=%x got=%x", x.v, got)
+		}
+	}
+}
+
+func TestMSBFirstCopy(t *testing.T) {
+	src := NewWriterMSB()
+	src.Write8(0b1011, 4)
+	src.Write32(0xCAFE, 19)
+
+	w := NewWriterMSB()
+	w.WriteBool(true)
+	w.WriteBitData(src.BitData(), src.BitsWritten())
+	w.WriteRepeat(0b101, 3, 1000)
+
+	r := NewReaderMSB(w.BitData())
+	r.Skip(1)
+	if v, _ := r.Read8(4); v != 0b1011 {
+		t.Errorf("want=%b got=%b", 0b1011, v)
+	}
+	if v, _ := r.Read32(19); v != 0xCAFE {
+		t.Errorf("want=%x got=%x", 0xCAFE, v)
+	}
+	for i := 0; i < 1000; i++ {
+		if v, _ := r.Read8(3); v != 0b101 {
+			t.Fatalf("repeat %d: want=%b got=%b", i, 0b101, v)
+		}
+	}
+
+	// a stream copied into a writer of the other order keeps its bit sequence
+	lsb := NewWriter()
+	if err := copyBits(lsb, NewReaderMSB(src.BitData()), src.BitsWritten()); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if want := src.BitData().ReverseByteBits(); !bytes.Equal(want, lsb.BitData()) {
+		t.Errorf("order conversion mismatch: want=%08b got=%08b", want, lsb.BitData())
+	}
+}
+
+func TestMSBFirstMessage(t *testing.T) {
+	w := NewWriterMSB()
+	w.WriteBool(true)
+	w.BeginMessage(12)
+	w.Write16(0xABC, 12)
+	w.Write8(1, 3)
+	if err := w.EndMessage(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	r := NewReaderMSB(w.BitData())
+	r.Skip(1)
+	m, err := r.ReadMessage(12)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if v, _ := m.Read16(12); v != 0xABC {
+		t.Errorf("want=%x got=%x", 0xABC, v)
+	}
+
+	pos, ok := NewReaderMSB(w.BitData()).Find(0xABC, 12)
+	if !ok || pos != 13 {
+		t.Errorf("find: want=13 got=%d,%t", pos, ok)
+	}
+}
+
+func TestMSBFirstBools(t *testing.T) {
+	s := []bool{true, false, false, true, true}
+
+	w := NewWriterMSB()
+	w.WriteBools(s)
+	if want := []byte{0b10011000}; !bytes.Equal(want, w.BitData()) {
+		t.Errorf("encoding mismatch: want=%08b got=%08b", want, w.BitData())
+	}
+
+	got, err := NewReaderMSB(w.BitData()).ReadBools(len(s))
+	if err != nil || len(got) != len(s) {
+		t.Fatalf("unexpected result: %v %v", got, err)
+	}
+	for i := range s {
+		if s[i] != got[i] {
+			t.Errorf("bool %d mismatch", i)
+		}
+	}
+}
+
+func TestConvertOrder(t *testing.T) {
+	rnd := rand.New(rand.NewSource(7))
+
+	bools := make([]bool, 203)
+	for i := range bools {
+		bools[i] = rnd.Intn(2) == 1
+	}
+
+	w := NewWriterMSB()
+	w.WriteBools(bools)
+	w.WriteZeros(5)
+
+	r := NewReader(ConvertOrder(w.BitData(), MSBFirst, LSBFirst, 0))
+	for i, want := range bools {
+		if got, _ := r.ReadBool(); got != want {
+			t.Fatalf("bit %d differs", i)
+		}
+	}
+
+	words := []uint32{0x12345678, 0xCAFEBABE, 7}
+	var capture []byte
+	for _, v := range words {
+		capture = binary.BigEndian.AppendUint32(capture, v)
+	}
+	r = NewReader(ConvertOrder(capture, LSBFirst, LSBFirst, 4))
+	for _, want := range words {
+		if got, _ := r.Read32(32); got != want {
+			t.Errorf("expected %x, got %x", want, got)
+		}
+	}
+
+	data := make(BitData, 64)
+	rnd.Read(data)
+	for _, width := range []int{0, 1, 2, 4, 8, 16} {
+		for _, from := range []BitOrder{LSBFirst, MSBFirst} {
+			for _, to := range []BitOrder{LSBFirst, MSBFirst} {
+				back := ConvertOrder(ConvertOrder(data, from, to, width), to, from, width)
+				if !bytes.Equal(back, data) {
+					t.Errorf("width %d %v->%v: not reversible", width, from, to)
+				}
+			}
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected panic for a partial word")
+		}
+	}()
+	ConvertOrder(BitData{1, 2, 3}, LSBFirst, LSBFirst, 2)
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+// SplitPlanes decomposes the samples, one per byte, into bit planes: plane i holds bit i of every sample,
+// len(data) bits in the LSBFirst order, for i from 0 to width-1. Higher bits of the samples are ignored.
+// It panics if width isn't between 1 and 8.
+func SplitPlanes(data []byte, width int) []BitData {
+	if width < 1 || width > 8 {
+		panic("bitdata: invalid bit plane width")
+	}
+
+	planes := make([]BitData, width)
+	for i := range planes {
+		planes[i] = make(BitData, (len(data)+7)/8)
+	}
+
+	whole := len(data) / 8
+	for j := 0; j < whole; j++ {
+		x := load64(data[8*j:])
+		for i, p := range planes {
+			// The multiplication gathers the lowest bits of the 8 bytes into the top byte.
+			p[j] = byte((x >> i & 0x0101010101010101) * 0x0102040810204080 >> 56)
+		}
+	}
+
+	for k := 8 * whole; k < len(data); k++ {
+		for i, p := range planes {
+			p[k/8] |= (data[k] >> i & 1) << (k % 8)
+		}
+	}
+
+	return planes
+}
+
+// MergePlanes is the inverse of SplitPlanes: it returns n samples with bit i of each taken from plane i.
+// It panics if a plane holds fewer than n bits or if there are more than 8 planes.
+func MergePlanes(planes []BitData, n int) []byte {
+	if len(planes) > 8 {
+		panic("bitdata: invalid bit plane width")
+	}
+	for _, p := range planes {
+		if len(p)*8 < n {
+			panic("bitdata: bit range out of bounds")
+		}
+	}
+
+	data := make([]byte, n)
+
+	whole := n / 8
+	for j := 0; j < whole; j++ {
+		var x uint64
+		for i, p := range planes {
+			x |= spreadByte(p[j]) << i
+		}
+		store64(data[8*j:], x)
+	}
+
+	for k := 8 * whole; k < n; k++ {
+		for i, p := range planes {
+			data[k] |= (p[k/8] >> (k % 8) & 1) << i
+		}
+	}
+
+	return data
+}
+
+// spreadByte moves bit i of b to bit 8*i.
+func spreadByte(b byte) uint64 {
+	x := uint64(b)
+	x = (x | x<<28) & 0x0000000F0000000F
+	x = (x | x<<14) & 0x0003000300030003
+	x = (x | x<<7) & 0x0101010101010101
+	return x
+}
@@ -0,0 +1,41 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+)
+
+func TestBitPlanes(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+
+	for _, n := range []int{0, 1, 7, 8, 9, 64, 101} {
+		data := make([]byte, n)
+		rnd.Read(data)
+
+		for _, width := range []int{1, 3, 8} {
+			planes := SplitPlanes(data, width)
+			if len(planes) != width {
+				t.Fatalf("got %d planes", len(planes))
+			}
+
+			for i, p := range planes {
+				for k, s := range data {
+					if getBit(p, uint(k)) != (s>>i&1 == 1) {
+						t.Fatalf("n=%d width=%d: plane %d, bit %d differs", n, width, i, k)
+					}
+				}
+			}
+
+			want := make([]byte, n)
+			for k, s := range data {
+				want[k] = s & mask[byte](byte(width))
+			}
+			if got := MergePlanes(planes, n); !bytes.Equal(got, want) {
+				t.Errorf("n=%d width=%d: merged %x, want %x", n, width, got, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"database/sql/driver"
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+var ErrInvalidBits = errors.New("invalid bit length")
+
+// Bits is bit data together with its length in bits, so the unused bits of the last byte
+// aren't mistaken for data.
+type Bits struct {
+	Data  BitData
+	Count uint
+}
+
+// Bits returns the written data with its length. Like BitData, it shares memory with the writer.
+func (w *Writer) Bits() Bits {
+	return Bits{Data: w.BitData(), Count: w.bitsWritten}
+}
+
+// Reader returns a reader of the bits, limited to the bit length.
+func (b Bits) Reader() *Reader {
+	r := NewReader(b.Data)
+	r.end = min(b.Count, r.end)
+	return r
+}
+
+// GobEncode implements gob.GobEncoder: the bit length as a varint followed by the bytes holding the bits.
+func (b Bits) GobEncode() ([]byte, error) {
+	n := (b.Count + 7) / 8
+	if n > uint(len(b.Data)) {
+		return nil, ErrInvalidBits
+	}
+
+	buf := binary.AppendUvarint(make([]byte, 0, binary.MaxVarintLen64+n), uint64(b.Count))
+	return append(buf, b.Data[:n]...), nil
+}
+
+// GobDecode implements gob.GobDecoder.
+func (b *Bits) GobDecode(data []byte) error {
+	count, k := binary.Uvarint(data)
+	if k <= 0 {
+		return ErrInvalidBits
+	}
+	if size := uint64(len(data)-k) * 8; count > size || size-count >= 8 {
+		return ErrInvalidBits
+	}
+
+	b.Data = append(BitData(nil), data[k:]...)
+	b.Count = uint(count)
+
+	return nil
+}
+
+// Value implements driver.Valuer. The bits are stored as a string of '0' and '1' characters in stream
+// order, the text form of the PostgreSQL bit varying type.
+func (b Bits) Value() (driver.Value, error) {
+	if b.Count > uint(len(b.Data))*8 {
+		return nil, ErrInvalidBits
+	}
+	return b.Data.FormatBinary(b.Count, 0), nil
+}
+
+// Scan implements sql.Scanner. It accepts the text form written by Value as a string or a byte slice,
+// and nil, which gives empty bits.
+func (b *Bits) Scan(src any) error {
+	var s string
+	switch src := src.(type) {
+	case nil:
+		*b = Bits{}
+		return nil
+	case string:
+		s = src
+	case []byte:
+		s = string(src)
+	default:
+		return fmt.Errorf("bitdata: can't scan %T into Bits", src)
+	}
+
+	d, n, err := ParseBits(s)
+	if err != nil {
+		return err
+	}
+
+	*b = Bits{Data: d, Count: n}
+
+	return nil
+}
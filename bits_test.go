@@ -0,0 +1,85 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"testing"
+)
+
+func TestBitsGob(t *testing.T) {
+	type cached struct {
+		Name    string
+		Payload Bits
+	}
+
+	w := NewWriter()
+	w.Write16(0x1234, 13)
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(cached{Name: "a", Payload: w.Bits()}); err != nil {
+		t.Fatal(err)
+	}
+
+	var got cached
+	if err := gob.NewDecoder(&buf).Decode(&got); err != nil {
+		t.Fatal(err)
+	}
+
+	if got.Name != "a" || got.Payload.Count != 13 || !bytes.Equal(got.Payload.Data, w.BitData()) {
+		t.Errorf("mismatch: %+v", got)
+	}
+	if v, err := got.Payload.Reader().Read16(13); err != nil || v != 0x1234 {
+		t.Errorf("value mismatch: %x %v", v, err)
+	}
+	if _, err := got.Payload.Reader().Read16(14); err == nil {
+		t.Errorf("reader isn't limited to the bit length")
+	}
+}
+
+func TestBitsGobInvalid(t *testing.T) {
+	if _, err := (Bits{Data: BitData{1}, Count: 9}).GobEncode(); !errors.Is(err, ErrInvalidBits) {
+		t.Errorf("expected ErrInvalidBits, got %v", err)
+	}
+
+	var b Bits
+	for _, data := range [][]byte{nil, {9, 1}, {1, 1, 1}, {0x80}, {0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0x01}} {
+		if err := b.GobDecode(data); !errors.Is(err, ErrInvalidBits) {
+			t.Errorf("%x: expected ErrInvalidBits, got %v", data, err)
+		}
+	}
+}
+
+func TestBitsSQL(t *testing.T) {
+	w := NewWriter()
+	w.Write8(0b1101, 4)
+	w.WriteBool(true)
+
+	v, err := w.Bits().Value()
+	if err != nil || v != "10111" {
+		t.Errorf("unexpected value: %v %v", v, err)
+	}
+
+	for _, src := range []any{"10111", []byte("10111")} {
+		var b Bits
+		if err := b.Scan(src); err != nil {
+			t.Errorf("%T: scan failed: %v", src, err)
+		}
+		if b.Count != 5 || !bytes.Equal(b.Data, w.BitData()) {
+			t.Errorf("%T: mismatch: %+v", src, b)
+		}
+	}
+
+	b := Bits{Data: BitData{1}, Count: 8}
+	if err := b.Scan(nil); err != nil || b.Count != 0 || b.Data != nil {
+		t.Errorf("nil scan: %+v %v", b, err)
+	}
+	if err := b.Scan("012"); !errors.Is(err, ErrInvalidSyntax) {
+		t.Errorf("expected ErrInvalidSyntax, got %v", err)
+	}
+	if err := b.Scan(42); err == nil {
+		t.Errorf("expected error for int source")
+	}
+}
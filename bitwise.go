@@ -0,0 +1,91 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"math/bits"
+)
+
+// And sets dst to the bitwise AND of the first bitCount bits of a and b and returns it.
+//
+// The result takes (bitCount+7)/8 bytes and the bits after bitCount are cleared. If dst doesn't have
+// enough capacity, a new slice is allocated. The dst may be the same slice as a or b for an in-place
+// operation. It panics if a or b is shorter than bitCount bits.
+func And(dst, a, b BitData, bitCount uint) BitData {
+	return bitwise(dst, a, b, bitCount, func(x, y byte) byte { return x & y })
+}
+
+// Or is like And, but computes the bitwise OR.
+func Or(dst, a, b BitData, bitCount uint) BitData {
+	return bitwise(dst, a, b, bitCount, func(x, y byte) byte { return x | y })
+}
+
+// Xor is like And, but computes the bitwise XOR.
+func Xor(dst, a, b BitData, bitCount uint) BitData {
+	return bitwise(dst, a, b, bitCount, func(x, y byte) byte { return x ^ y })
+}
+
+// AndNot is like And, but computes a AND NOT b, clearing the bits of a that are set in b.
+func AndNot(dst, a, b BitData, bitCount uint) BitData {
+	return bitwise(dst, a, b, bitCount, func(x, y byte) byte { return x &^ y })
+}
+
+// Not sets dst to the inverted first bitCount bits of a and returns it. See And for the handling of dst.
+func Not(dst, a BitData, bitCount uint) BitData {
+	return bitwise(dst, a, a, bitCount, func(x, _ byte) byte { return ^x })
+}
+
+func bitwise(dst, a, b BitData, bitCount uint, op func(x, y byte) byte) BitData {
+	n := int((bitCount + 7) / 8)
+	if len(a) < n || len(b) < n {
+		panic("bitdata: bit range out of bounds")
+	}
+
+	if cap(dst) < n {
+		dst = make(BitData, n)
+	}
+	dst = dst[:n]
+
+	for i := 0; i < n; i++ {
+		dst[i] = op(a[i], b[i])
+	}
+
+	if rem := bitCount % 8; rem > 0 {
+		dst[n-1] &= mask[byte](byte(rem))
+	}
+
+	return dst
+}
+
+// Equal reports whether the first bitCount bits of a and b are the same. Unlike bytes.Equal,
+// it ignores the padding bits after bitCount. It panics if a or b is shorter than bitCount bits.
+func Equal(a, b BitData, bitCount uint) bool {
+	return Compare(a, b, bitCount) == 0
+}
+
+// Compare compares the first bitCount bits of a and b in stream order and returns -1, 0 or +1.
+// At the first bit that differs, the data with the zero bit is the smaller one.
+// The padding bits after bitCount are ignored. It panics if a or b is shorter than bitCount bits.
+func Compare(a, b BitData, bitCount uint) int {
+	n := (bitCount + 7) / 8
+	if uint(len(a)) < n || uint(len(b)) < n {
+		panic("bitdata: bit range out of bounds")
+	}
+
+	for i := uint(0); i < n; i++ {
+		x := a[i] ^ b[i]
+		if i == n-1 && bitCount%8 > 0 {
+			x &= mask[byte](byte(bitCount % 8))
+		}
+		if x == 0 {
+			continue
+		}
+
+		if a[i]>>bits.TrailingZeros8(x)&1 == 0 {
+			return -1
+		}
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestBitwise(t *testing.T) {
+	a, _, _ := ParseBits("1100 1100 1111")
+	b, _, _ := ParseBits("1010 1010 0000 1")
+
+	tests := []struct {
+		name string
+		fn   func(dst BitData) BitData
+		exp  string
+	}{
+		{name: "and", fn: func(dst BitData) BitData { return And(dst, a, b, 12) }, exp: "1000 1000 0000"},
+		{name: "or", fn: func(dst BitData) BitData { return Or(dst, a, b, 12) }, exp: "1110 1110 1111"},
+		{name: "xor", fn: func(dst BitData) BitData { return Xor(dst, a, b, 12) }, exp: "0110 0110 1111"},
+		{name: "and-not", fn: func(dst BitData) BitData { return AndNot(dst, a, b, 12) }, exp: "0100 0100 1111"},
+		{name: "not", fn: func(dst BitData) BitData { return Not(dst, a, 12) }, exp: "0011 0011 0000"},
+		{name: "not-partial", fn: func(dst BitData) BitData { return Not(dst, a, 5) }, exp: "0011 0"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			exp, _, _ := ParseBits(test.exp)
+
+			if got := test.fn(nil); !bytes.Equal(exp, got) {
+				t.Errorf("want=%s got=%s", exp.BinaryString(), got.BinaryString())
+			}
+
+			dst := make(BitData, 0, 4)
+			if got := test.fn(dst); !bytes.Equal(exp, got) || &got[0] != &dst[:1][0] {
+				t.Errorf("dst not reused or wrong: want=%s got=%s", exp.BinaryString(), got.BinaryString())
+			}
+		})
+	}
+
+	c := BitData(bytes.Clone(a))
+	c = Xor(c, c, b, 12)
+	if want, _, _ := ParseBits("0110 0110 1111"); !bytes.Equal(want, c) {
+		t.Errorf("in-place mismatch: want=%s got=%s", want.BinaryString(), c.BinaryString())
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected panic")
+		}
+	}()
+	And(nil, a, b, 17)
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b BitData
+		bits uint
+		exp  int
+	}{
+		{name: "empty", a: nil, b: nil, bits: 0, exp: 0},
+		{name: "padding-ignored", a: BitData{0x12, 0b0101}, b: BitData{0x12, 0b1101}, bits: 11, exp: 0},
+		{name: "last-bit-differs", a: BitData{0x12, 0b0101}, b: BitData{0x12, 0b0001}, bits: 11, exp: 1},
+		{name: "first-bit-decides", a: BitData{0b10}, b: BitData{0b01}, bits: 2, exp: -1},
+		{name: "first-byte-decides", a: BitData{0x01, 0x00}, b: BitData{0x00, 0xFF}, bits: 16, exp: 1},
+		{name: "longer-data", a: BitData{0xFF, 0x00}, b: BitData{0xFF}, bits: 8, exp: 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := Compare(test.a, test.b, test.bits); got != test.exp {
+				t.Errorf("want=%d got=%d", test.exp, got)
+			}
+			if got := Compare(test.b, test.a, test.bits); got != -test.exp {
+				t.Errorf("reversed: want=%d got=%d", -test.exp, got)
+			}
+			if got := Equal(test.a, test.b, test.bits); got != (test.exp == 0) {
+				t.Errorf("equal: want=%t got=%t", test.exp == 0, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,171 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"errors"
+	"io"
+)
+
+var ErrInvalidAD = errors.New("invalid advertising data")
+
+// BLEAdvHeader is the 16-bit header of a Bluetooth LE advertising channel PDU. Bluetooth LE sends
+// the least significant bit first, so the BLE functions expect a writer or reader in the LSBFirst order.
+type BLEAdvHeader struct {
+	PDUType byte // 4 bits
+	ChSel   bool
+	TxAdd   bool // the advertiser address is random
+	RxAdd   bool // the target address is random
+	Length  byte // the payload size in bytes
+}
+
+// Advertising channel PDU types.
+const (
+	BLEAdvInd        = 0
+	BLEAdvDirectInd  = 1
+	BLEAdvNonconnInd = 2
+	BLEScanReq       = 3
+	BLEScanRsp       = 4
+	BLEConnectInd    = 5
+	BLEAdvScanInd    = 6
+)
+
+// Common AD types of the Bluetooth assigned numbers.
+const (
+	ADTypeFlags                = 0x01
+	ADTypeIncomplete16BitUUIDs = 0x02
+	ADTypeComplete16BitUUIDs   = 0x03
+	ADTypeShortName            = 0x08
+	ADTypeCompleteName         = 0x09
+	ADTypeTxPower              = 0x0A
+	ADTypeServiceData16BitUUID = 0x16
+	ADTypeManufacturerData     = 0xFF
+)
+
+// ADStructure is an AD structure of the advertising data: a type and its data.
+type ADStructure struct {
+	Type byte
+	Data []byte
+}
+
+func (w *Writer) WriteBLEAdvHeader(h BLEAdvHeader) {
+	w.Write8(h.PDUType, 4)
+	w.WriteBool(false) // RFU
+	w.WriteBool(h.ChSel)
+	w.WriteBool(h.TxAdd)
+	w.WriteBool(h.RxAdd)
+	w.Write8(h.Length, 8)
+}
+
+func (r *Reader) ReadBLEAdvHeader() (BLEAdvHeader, error) {
+	var h BLEAdvHeader
+
+	rr := ReaderError{reader: *r}
+	h.PDUType = rr.Read8(4)
+	rr.Skip(1)
+	h.ChSel = rr.ReadBool()
+	h.TxAdd = rr.ReadBool()
+	h.RxAdd = rr.ReadBool()
+	h.Length = rr.Read8(8)
+
+	if rr.err != nil {
+		return BLEAdvHeader{}, rr.err
+	}
+
+	*r = rr.reader
+
+	return h, nil
+}
+
+// WriteBLEAddress writes a 48-bit device address, least significant byte first as on air.
+// The address is given in the usual display order, most significant byte first.
+func (w *Writer) WriteBLEAddress(a [6]byte) {
+	for i := 5; i >= 0; i-- {
+		w.Write8(a[i], 8)
+	}
+}
+
+func (r *Reader) ReadBLEAddress() (a [6]byte, err error) {
+	var b [6]byte
+	if err = readAddrBytes(r, b[:]); err != nil {
+		return
+	}
+	for i := range b {
+		a[5-i] = b[i]
+	}
+	return
+}
+
+// WriteADStructures writes AD structures, each as a length byte, the type and the data.
+// It returns ErrInvalidAD if the data of a structure is longer than 254 bytes.
+func (w *Writer) WriteADStructures(ads []ADStructure) error {
+	for _, ad := range ads {
+		if len(ad.Data) > 254 {
+			return ErrInvalidAD
+		}
+	}
+
+	for _, ad := range ads {
+		w.Write8(byte(len(ad.Data)+1), 8)
+		w.Write8(ad.Type, 8)
+		writeAddrBytes(w, ad.Data)
+	}
+
+	return nil
+}
+
+// ReadADStructures reads the AD structures of size bytes of advertising data. A zero length byte
+// ends the data early; the rest of it is skipped as padding. It returns ErrInvalidAD if a structure
+// doesn't fit into the size. On error the read position is left unchanged.
+func (r *Reader) ReadADStructures(size int) ([]ADStructure, error) {
+	start := r.bitsRead
+	if size < 0 {
+		return nil, io.ErrUnexpectedEOF
+	}
+	if ok, err := r.ensureCount(uint64(size), 8); !ok {
+		return nil, err
+	}
+	end := r.bitsRead + uint(size)*8
+
+	var ads []ADStructure
+	for r.bitsRead < end {
+		n, _ := r.Read8(8)
+		if n == 0 {
+			break
+		}
+		if r.bitsRead+uint(n)*8 > end {
+			r.bitsRead = start
+			return nil, ErrInvalidAD
+		}
+
+		ad := ADStructure{Data: make([]byte, n-1)}
+		ad.Type, _ = r.Read8(8)
+		_ = readAddrBytes(r, ad.Data)
+		ads = append(ads, ad)
+	}
+
+	r.bitsRead = end
+
+	return ads, nil
+}
+
+func (r *ReaderError) ReadBLEAdvHeader() (h BLEAdvHeader) {
+	if r.err == nil {
+		h, r.err = r.reader.ReadBLEAdvHeader()
+	}
+	return
+}
+
+func (r *ReaderError) ReadBLEAddress() (a [6]byte) {
+	if r.err == nil {
+		a, r.err = r.reader.ReadBLEAddress()
+	}
+	return
+}
+
+func (r *ReaderError) ReadADStructures(size int) (ads []ADStructure) {
+	if r.err == nil {
+		ads, r.err = r.reader.ReadADStructures(size)
+	}
+	return
+}
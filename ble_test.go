@@ -0,0 +1,65 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestBLEAdvPDU(t *testing.T) {
+	addr := [6]byte{0xC0, 0x11, 0x22, 0x33, 0x44, 0x55}
+	ads := []ADStructure{
+		{Type: ADTypeFlags, Data: []byte{0x06}},
+		{Type: ADTypeCompleteName, Data: []byte("bitdata")},
+		{Type: ADTypeManufacturerData, Data: []byte{0x4C, 0x00, 0x02}},
+	}
+
+	w := NewWriter()
+	w.WriteBLEAdvHeader(BLEAdvHeader{PDUType: BLEAdvInd, TxAdd: true, Length: 6 + 3 + 9 + 5})
+	w.WriteBLEAddress(addr)
+	if err := w.WriteADStructures(ads); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	want := []byte{
+		0x40, 23,
+		0x55, 0x44, 0x33, 0x22, 0x11, 0xC0,
+		2, 0x01, 0x06,
+		8, 0x09, 'b', 'i', 't', 'd', 'a', 't', 'a',
+		4, 0xFF, 0x4C, 0x00, 0x02,
+	}
+	if !bytes.Equal(want, w.BitData()) {
+		t.Errorf("encoding mismatch: want=%x got=%x", want, w.BitData())
+	}
+
+	r := NewReaderError(w.BitData())
+	h := r.ReadBLEAdvHeader()
+	if h.PDUType != BLEAdvInd || !h.TxAdd || h.RxAdd || h.Length != 23 {
+		t.Errorf("unexpected header: %+v", h)
+	}
+	if got := r.ReadBLEAddress(); got != addr {
+		t.Errorf("address mismatch: want=%x got=%x", addr, got)
+	}
+	if got := r.ReadADStructures(int(h.Length) - 6); !reflect.DeepEqual(ads, got) {
+		t.Errorf("AD mismatch: want=%v got=%v", ads, got)
+	}
+	if err := r.Error(); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
+
+func TestADStructuresPadding(t *testing.T) {
+	r := NewReader(BitData{2, 0x01, 0x06, 0, 0, 0, 0xAA})
+	ads, err := r.ReadADStructures(6)
+	if err != nil || len(ads) != 1 || r.BitsRead() != 48 {
+		t.Errorf("unexpected result: %v %v %d", ads, err, r.BitsRead())
+	}
+
+	r = NewReader(BitData{5, 0x09, 'a', 'b'})
+	if _, err := r.ReadADStructures(4); !errors.Is(err, ErrInvalidAD) || r.BitsRead() != 0 {
+		t.Errorf("want=%v got=%v at %d", ErrInvalidAD, err, r.BitsRead())
+	}
+}
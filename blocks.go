@@ -0,0 +1,163 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+)
+
+// EncodeBlocks encodes n independent blocks and writes them preceded by a block index: the number
+// of blocks and the bit length of every block, as LEB128 varints. The blocks are encoded by calling
+// encode for each of them with a fresh Writer, running up to workers calls concurrently.
+// A workers value of zero or less uses runtime.GOMAXPROCS.
+func EncodeBlocks(w *Writer, n, workers int, encode func(i int, w *Writer) error) error {
+	blocks := make([]*Writer, n)
+
+	err := runParallel(n, workers, func(i int) error {
+		bw := &Writer{order: w.order, alloc: w.alloc}
+		if err := encode(i, bw); err != nil {
+			return err
+		}
+		blocks[i] = bw
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	w.WriteUvarint(uint64(n))
+	for _, b := range blocks {
+		w.WriteUvarint(uint64(b.bitsWritten))
+	}
+	for _, b := range blocks {
+		w.WriteBitData(b.BitData(), b.bitsWritten)
+		b.Release()
+	}
+
+	return nil
+}
+
+// DecodeBlocks reads the block index written by EncodeBlocks and decodes the blocks by calling decode
+// with a reader limited to each block, running up to workers calls concurrently. The results are
+// returned in block order. The reader r continues after the last block. For the readers of NewReaderSource
+// and NewRandomReader, whose cursors share the source, the blocks are decoded one at a time.
+func DecodeBlocks[T any](r *Reader, workers int, decode func(i int, r *Reader) (T, error)) ([]T, error) {
+	start := r.bitsRead
+
+	index, err := readBlockIndex(r)
+	if err != nil {
+		r.bitsRead = start
+		return nil, err
+	}
+
+	results := make([]T, len(index))
+
+	switch r.src.(type) {
+	case *bitSource, *streamSource:
+		// The cursors of a sequentially read source share it, so the blocks are decoded one at a time.
+		workers = 1
+	}
+
+	err = runParallel(len(index), workers, func(i int) error {
+		sub := r.Cursor()
+		sub.bitsRead = index[i][0]
+		sub.end = index[i][1]
+
+		v, err := decode(i, sub)
+		if err != nil {
+			return err
+		}
+		results[i] = v
+		return nil
+	})
+	if err != nil {
+		r.bitsRead = start
+		return nil, err
+	}
+
+	if len(index) > 0 {
+		r.bitsRead = index[len(index)-1][1]
+	}
+
+	return results, nil
+}
+
+// readBlockIndex reads the block index and returns the start and the end bit offset of every block.
+func readBlockIndex(r *Reader) ([][2]uint, error) {
+	n, err := r.ReadUvarint()
+	if err != nil {
+		return nil, err
+	}
+	if ok, err := r.ensureCount(n, 8); !ok {
+		return nil, err
+	}
+
+	lengths := make([]uint64, n)
+	for i := range lengths {
+		if lengths[i], err = r.ReadUvarint(); err != nil {
+			return nil, err
+		}
+	}
+
+	var total uint64
+	for _, l := range lengths {
+		total = min(total+min(l, uint64(maxInt)), uint64(maxInt))
+	}
+	if ok, err := r.ensureCount(total, 1); !ok {
+		return nil, err
+	}
+
+	index := make([][2]uint, n)
+	pos := r.bitsRead
+	for i, l := range lengths {
+		index[i] = [2]uint{pos, pos + uint(l)}
+		pos += uint(l)
+	}
+
+	return index, nil
+}
+
+// runParallel calls fn for every index from 0 to n-1 using up to workers goroutines.
+// It returns the error of the lowest failed index, annotated with that index.
+func runParallel(n, workers int, fn func(i int) error) error {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	workers = min(workers, n)
+
+	errs := make([]error, n)
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		next int
+	)
+	for g := 0; g < workers; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				mu.Lock()
+				i := next
+				next++
+				mu.Unlock()
+
+				if i >= n {
+					return
+				}
+				errs[i] = fn(i)
+			}
+		}()
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			return fmt.Errorf("block %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestBlocks(t *testing.T) {
+	const blocks = 37
+
+	w := NewWriter()
+	w.Write8(0b101, 3)
+	err := EncodeBlocks(w, blocks, 4, func(i int, w *Writer) error {
+		for j := 0; j <= i; j++ {
+			w.Write16(uint16(i*100+j), 13)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	w.Write8(0b11, 2)
+
+	for _, workers := range []int{0, 1, 8} {
+		r := NewReader(w.BitData())
+		r.Skip(3)
+
+		sums, err := DecodeBlocks(r, workers, func(i int, r *Reader) (int, error) {
+			sum := 0
+			for v, err := range r.Values64(13) {
+				if err != nil {
+					return 0, err
+				}
+				sum += int(v)
+			}
+			return sum, nil
+		})
+		if err != nil {
+			t.Errorf("unexpected error: %s", err)
+			continue
+		}
+
+		for i, sum := range sums {
+			want := 0
+			for j := 0; j <= i; j++ {
+				want += i*100 + j
+			}
+			if sum != want {
+				t.Errorf("workers %d, block %d: want=%d got=%d", workers, i, want, sum)
+			}
+		}
+
+		if v, err := r.Read8(2); err != nil || v != 0b11 {
+			t.Errorf("workers %d: trailing bits mismatch: %b", workers, v)
+		}
+	}
+}
+
+func TestBlocksErrors(t *testing.T) {
+	errBlock := errors.New("block failed")
+
+	w := NewWriter()
+	err := EncodeBlocks(w, 10, 3, func(i int, w *Writer) error {
+		if i == 4 || i == 7 {
+			return errBlock
+		}
+		return nil
+	})
+	if !errors.Is(err, errBlock) || err.Error() != "block 4: block failed" {
+		t.Errorf("expected error of block 4, got %v", err)
+	}
+	if w.BitsWritten() != 0 {
+		t.Errorf("writer changed on error")
+	}
+
+	_ = EncodeBlocks(w, 3, 0, func(i int, w *Writer) error {
+		w.Write8(uint8(i), 8)
+		return nil
+	})
+
+	r := NewReader(w.BitData())
+	_, err = DecodeBlocks(r, 0, func(i int, r *Reader) (uint8, error) {
+		if i == 1 {
+			return 0, errBlock
+		}
+		return r.Read8(8)
+	})
+	if !errors.Is(err, errBlock) || r.BitsRead() != 0 {
+		t.Errorf("expected error of block 1 and unchanged reader, got %v at %d", err, r.BitsRead())
+	}
+
+	r = NewReader(w.BitData()[:3])
+	if _, err := DecodeBlocks(r, 0, func(int, *Reader) (uint8, error) { return 0, nil }); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("expected EOF, got %v", err)
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"io"
+	"math/bits"
+)
+
+// WriteBools writes each element of s as a single bit. The bits are packed into words before writing.
+func (w *Writer) WriteBools(s []bool) {
+	for len(s) > 0 {
+		n := len(s)
+		if n > 64 {
+			n = 64
+		}
+
+		var v uint64
+		for i, b := range s[:n] {
+			if b {
+				v |= 1 << i
+			}
+		}
+		if w.order == MSBFirst {
+			v = bits.Reverse64(v) >> (64 - n)
+		}
+		w.Write64(v, byte(n))
+
+		s = s[n:]
+	}
+}
+
+// ReadBools reads n bits written with WriteBools.
+func (r *Reader) ReadBools(n int) ([]bool, error) {
+	return r.AppendBools(nil, n)
+}
+
+// AppendBools reads n bits and appends them to dst. On error, dst is returned unchanged
+// and the read position is left unchanged.
+func (r *Reader) AppendBools(dst []bool, n int) ([]bool, error) {
+	if n < 0 {
+		return dst, io.ErrUnexpectedEOF
+	}
+	if ok, err := r.ensure(uint(n)); !ok {
+		if err != nil {
+			return dst, err
+		}
+		return append(dst, make([]bool, n)...), nil
+	}
+
+	start, size := r.bitsRead, len(dst)
+	for n > 0 {
+		c := n
+		if c > 64 {
+			c = 64
+		}
+
+		v, err := r.Read64(byte(c))
+		if err != nil {
+			r.bitsRead = start
+			return dst[:size], err
+		}
+		if r.order == MSBFirst {
+			v = bits.Reverse64(v) >> (64 - c)
+		}
+		for i := 0; i < c; i++ {
+			dst = append(dst, v>>i&1 == 1)
+		}
+
+		n -= c
+	}
+
+	return dst, nil
+}
+
+func (r *ReaderError) ReadBools(n int) (v []bool) {
+	if r.err == nil {
+		v, r.err = r.reader.ReadBools(n)
+	}
+	return
+}
+
+func (r *ReaderError) AppendBools(dst []bool, n int) []bool {
+	if r.err == nil {
+		dst, r.err = r.reader.AppendBools(dst, n)
+	}
+	return dst
+}
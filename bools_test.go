@@ -0,0 +1,52 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"io"
+	"slices"
+	"testing"
+)
+
+func TestBools(t *testing.T) {
+	flags := make([]bool, 200)
+	for i := range flags {
+		flags[i] = i%3 == 0 || i%7 == 0
+	}
+
+	w := NewWriter()
+	w.Write8(0b10, 2)
+	w.WriteBools(flags)
+	w.WriteBools(nil)
+
+	want := NewWriter()
+	want.Write8(0b10, 2)
+	for _, b := range flags {
+		want.WriteBool(b)
+	}
+
+	if !slices.Equal(want.BitData(), w.BitData()) || want.BitsWritten() != w.BitsWritten() {
+		t.Errorf("data mismatch: want=%x got=%x", want.BitData(), w.BitData())
+	}
+
+	r := NewReaderError(w.BitData())
+	r.Skip(2)
+	got := r.ReadBools(150)
+	got = r.AppendBools(got, 50)
+	if err := r.Error(); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	if !slices.Equal(flags, got) {
+		t.Errorf("value mismatch: want=%v got=%v", flags, got)
+	}
+
+	rr := NewReader(w.BitData())
+	dst := []bool{true}
+	dst, err := rr.AppendBools(dst, 500)
+	if err != io.ErrUnexpectedEOF {
+		t.Errorf("expected EOF, got %v", err)
+	}
+	if len(dst) != 1 || rr.BitsRead() != 0 {
+		t.Errorf("state changed on error: len=%d read=%d", len(dst), rr.BitsRead())
+	}
+}
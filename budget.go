@@ -0,0 +1,72 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import "errors"
+
+var ErrBudgetExceeded = errors.New("bit budget exceeded")
+
+// budget is the bit budget of a writer set by SetBudget.
+type budget struct {
+	end      uint
+	exceeded bool
+	onExceed func(over uint)
+}
+
+// SetBudget limits the writer to the given number of bits from the current offset. The writes aren't
+// stopped by the budget: a write crossing it calls the function set by OnBudgetExceeded and makes
+// BudgetErr return ErrBudgetExceeded. Setting a new budget clears the exceeded state.
+func (w *Writer) SetBudget(bits uint) {
+	var onExceed func(uint)
+	if w.budget != nil {
+		onExceed = w.budget.onExceed
+	}
+	w.budget = &budget{end: w.bitsWritten + bits, onExceed: onExceed}
+}
+
+// ClearBudget removes the bit budget of the writer.
+func (w *Writer) ClearBudget() {
+	w.budget = nil
+}
+
+// OnBudgetExceeded sets the function called, once per budget, by the write that exceeds the budget.
+// It receives the number of bits written over the budget. It must be called after SetBudget.
+func (w *Writer) OnBudgetExceeded(f func(over uint)) {
+	if w.budget == nil {
+		panic("bitdata: OnBudgetExceeded without a budget")
+	}
+	w.budget.onExceed = f
+}
+
+// BitsRemaining returns the number of bits that can be written before the budget is exceeded,
+// zero if it already is, and the maximum uint if the writer has no budget.
+func (w *Writer) BitsRemaining() uint {
+	if w.budget == nil {
+		return ^uint(0)
+	}
+	if w.bitsWritten >= w.budget.end {
+		return 0
+	}
+	return w.budget.end - w.bitsWritten
+}
+
+// BudgetErr returns ErrBudgetExceeded if more bits than the budget allows have been written.
+func (w *Writer) BudgetErr() error {
+	if w.budget != nil && w.budget.exceeded {
+		return ErrBudgetExceeded
+	}
+	return nil
+}
+
+// checkBudget is called after every write that advances the offset.
+func (w *Writer) checkBudget() {
+	b := w.budget
+	if b == nil || b.exceeded || w.bitsWritten <= b.end {
+		return
+	}
+
+	b.exceeded = true
+	if b.onExceed != nil {
+		b.onExceed(w.bitsWritten - b.end)
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestWriterBudget(t *testing.T) {
+	w := NewWriter()
+	if got := w.BitsRemaining(); got != ^uint(0) {
+		t.Errorf("remaining without a budget: got %d", got)
+	}
+
+	w.Write8(1, 4)
+	w.SetBudget(20)
+
+	var calls, over uint
+	w.OnBudgetExceeded(func(n uint) {
+		calls++
+		over = n
+	})
+
+	w.Write16(0x123, 12)
+	if got := w.BitsRemaining(); got != 8 {
+		t.Errorf("remaining: got %d, want 8", got)
+	}
+	if err := w.BudgetErr(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	w.WriteBitData(BitData{0xAB, 0xCD}, 16)
+	if calls != 1 || over != 8 {
+		t.Errorf("callback: got %d calls, over %d", calls, over)
+	}
+	if got := w.BitsRemaining(); got != 0 {
+		t.Errorf("remaining: got %d, want 0", got)
+	}
+	if err := w.BudgetErr(); !errors.Is(err, ErrBudgetExceeded) {
+		t.Errorf("expected ErrBudgetExceeded, got %v", err)
+	}
+
+	w.WriteRepeat(1, 1, 40)
+	if calls != 1 {
+		t.Errorf("callback called %d times", calls)
+	}
+
+	w.SetBudget(10)
+	if err := w.BudgetErr(); err != nil {
+		t.Errorf("unexpected error after a new budget: %v", err)
+	}
+	w.WriteRepeat(1, 1, 11)
+	if calls != 2 || over != 1 {
+		t.Errorf("callback: got %d calls, over %d", calls, over)
+	}
+
+	w.ClearBudget()
+	if err := w.BudgetErr(); err != nil {
+		t.Errorf("unexpected error without a budget: %v", err)
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"errors"
+	"math"
+)
+
+var ErrSignalRange = errors.New("signal value out of range")
+
+// CANSignal describes a signal of a CAN frame the way DBC files do. The StartBit uses the DBC bit numbering,
+// bit i being bit i%8 of byte i/8. With the LSBFirst order (Intel, @1) the start bit is the least significant
+// bit of the value, which continues toward higher bits. With the MSBFirst order (Motorola, @0) the start bit is
+// the most significant bit, which continues toward lower bits of the byte and then to the top of the next byte.
+// The physical value is raw*Factor + Offset; a zero Factor is treated as 1.
+type CANSignal struct {
+	StartBit uint
+	Length   byte
+	Order    BitOrder
+	Signed   bool
+	Factor   float64
+	Offset   float64
+}
+
+// streamOffset returns the position of the signal's first bit in the stream of the signal's bit order.
+func (s CANSignal) streamOffset() uint {
+	if s.Order == MSBFirst {
+		return s.StartBit/8*8 + 7 - s.StartBit%8
+	}
+	return s.StartBit
+}
+
+func (s CANSignal) factor() float64 {
+	if s.Factor == 0 {
+		return 1
+	}
+	return s.Factor
+}
+
+// Raw returns the raw value of the signal in the frame, sign extended for a signed signal.
+// It returns ErrInvalidRange if the signal doesn't fit into the frame.
+func (s CANSignal) Raw(frame []byte) (int64, error) {
+	if s.Length == 0 || s.Length > 64 {
+		return 0, ErrInvalidRange
+	}
+
+	r := NewReader(frame)
+	r.order = s.Order
+	r.Skip(s.streamOffset())
+
+	v, err := r.Read64(s.Length)
+	if err != nil {
+		return 0, ErrInvalidRange
+	}
+
+	if s.Signed {
+		return signExtend(v, s.Length), nil
+	}
+	return int64(v), nil
+}
+
+// SetRaw writes the raw value of the signal into the frame. It returns ErrSignalRange if the value
+// doesn't fit into the signal and ErrInvalidRange if the signal doesn't fit into the frame.
+func (s CANSignal) SetRaw(frame []byte, raw int64) error {
+	if s.Length == 0 || s.Length > 64 {
+		return ErrInvalidRange
+	}
+
+	off := s.streamOffset()
+	if off+uint(s.Length) > uint(len(frame))*8 {
+		return ErrInvalidRange
+	}
+
+	if s.Signed {
+		if s.Length < 64 && (raw < -1<<(s.Length-1) || raw >= 1<<(s.Length-1)) {
+			return ErrSignalRange
+		}
+	} else if raw < 0 || uint64(raw) > mask[uint64](s.Length) {
+		return ErrSignalRange
+	}
+
+	v := msbFirst(uint64(raw)&mask[uint64](s.Length), s.Length, s.Order)
+	for i := uint(0); i < uint(s.Length); i++ {
+		p := off + i
+		if s.Order == MSBFirst {
+			p = p/8*8 + 7 - p%8
+		}
+		setBit(frame, p, v>>(uint(s.Length)-1-i)&1 == 1)
+	}
+
+	return nil
+}
+
+// Decode returns the physical value of the signal in the frame.
+func (s CANSignal) Decode(frame []byte) (float64, error) {
+	raw, err := s.Raw(frame)
+	if err != nil {
+		return 0, err
+	}
+	if !s.Signed && raw < 0 {
+		return float64(uint64(raw))*s.factor() + s.Offset, nil
+	}
+	return float64(raw)*s.factor() + s.Offset, nil
+}
+
+// Encode writes the physical value v into the frame, rounded to the nearest raw value.
+// It returns ErrSignalRange if the raw value doesn't fit into the signal.
+func (s CANSignal) Encode(frame []byte, v float64) error {
+	raw := math.Round((v - s.Offset) / s.factor())
+	if math.IsNaN(raw) || raw < math.MinInt64 || raw >= math.MaxInt64 {
+		return ErrSignalRange
+	}
+	return s.SetRaw(frame, int64(raw))
+}
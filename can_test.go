@@ -0,0 +1,75 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestCANSignalRaw(t *testing.T) {
+	frame := []byte{0x12, 0x34, 0x56, 0x78, 0x9A, 0xBC, 0xDE, 0xF0}
+
+	tests := []struct {
+		s    CANSignal
+		want int64
+	}{
+		{s: CANSignal{StartBit: 0, Length: 16, Order: LSBFirst}, want: 0x3412},
+		{s: CANSignal{StartBit: 7, Length: 16, Order: MSBFirst}, want: 0x1234},
+		{s: CANSignal{StartBit: 4, Length: 8, Order: LSBFirst}, want: 0x41},
+		{s: CANSignal{StartBit: 11, Length: 12, Order: MSBFirst}, want: 0x456},
+		{s: CANSignal{StartBit: 56, Length: 8, Order: LSBFirst, Signed: true}, want: -16},
+		{s: CANSignal{StartBit: 7, Length: 64, Order: MSBFirst}, want: 0x123456789ABCDEF0},
+	}
+
+	for _, test := range tests {
+		got, err := test.s.Raw(frame)
+		if err != nil || got != test.want {
+			t.Errorf("raw mismatch for %+v: want=%#x got=%#x err=%v", test.s, test.want, got, err)
+		}
+
+		f := make([]byte, 8)
+		if err := test.s.SetRaw(f, test.want); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if got, _ := test.s.Raw(f); got != test.want {
+			t.Errorf("round trip mismatch for %+v: want=%#x got=%#x", test.s, test.want, got)
+		}
+	}
+}
+
+func TestCANSignalPhysical(t *testing.T) {
+	// engine speed: 16 bits, Motorola, 0.25 rpm per bit; coolant temperature: 8 bits, Intel, offset -40
+	rpm := CANSignal{StartBit: 23, Length: 16, Order: MSBFirst, Factor: 0.25}
+	temp := CANSignal{StartBit: 40, Length: 8, Order: LSBFirst, Factor: 1, Offset: -40}
+
+	frame := make([]byte, 8)
+	if err := rpm.Encode(frame, 3000.3); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := temp.Encode(frame, 90); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if want := []byte{0, 0, 0x2E, 0xE1, 0, 130, 0, 0}; !bytes.Equal(want, frame) {
+		t.Errorf("frame mismatch: want=%x got=%x", want, frame)
+	}
+
+	if v, err := rpm.Decode(frame); err != nil || v != 3000.25 {
+		t.Errorf("rpm mismatch: %v %v", v, err)
+	}
+	if v, err := temp.Decode(frame); err != nil || v != 90 {
+		t.Errorf("temperature mismatch: %v %v", v, err)
+	}
+
+	if err := temp.Encode(frame, 300); !errors.Is(err, ErrSignalRange) {
+		t.Errorf("want=%v got=%v", ErrSignalRange, err)
+	}
+	if err := temp.Encode(frame, -41); !errors.Is(err, ErrSignalRange) {
+		t.Errorf("want=%v got=%v", ErrSignalRange, err)
+	}
+	if _, err := (CANSignal{StartBit: 60, Length: 8}).Raw(frame); !errors.Is(err, ErrInvalidRange) {
+		t.Errorf("want=%v got=%v", ErrInvalidRange, err)
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"errors"
+	"io"
+)
+
+var ErrInvalidCBOR = errors.New("invalid CBOR item")
+
+// maxCBORDepth limits the nesting of arrays, maps and tags of a CBOR item.
+const maxCBORDepth = 64
+
+// WriteCBOR embeds a single encoded CBOR item: it pads the data with zero bits to the next byte boundary
+// and writes the item. A CBOR item carries its own length, so no length prefix is written.
+// It returns ErrInvalidCBOR if the item isn't exactly one well-formed CBOR item.
+func (w *Writer) WriteCBOR(item []byte) error {
+	r := NewReader(item)
+	if _, err := readCBORItem(r, nil, 0); err != nil || r.bitsRead != r.end {
+		return ErrInvalidCBOR
+	}
+
+	w.AlignToByte()
+	w.WriteBitData(item, uint(len(item))*8)
+
+	return nil
+}
+
+// ReadCBOR extracts a CBOR item embedded with WriteCBOR. It skips the padding up to the next byte boundary
+// and returns a copy of the item bytes, finding the end of the item from its content. It returns ErrInvalidCBOR
+// if the item isn't well-formed. On error the read position is left unchanged.
+func (r *Reader) ReadCBOR() ([]byte, error) {
+	start := r.bitsRead
+	r.AlignToByte()
+
+	item, err := readCBORItem(r, nil, 0)
+	if err != nil {
+		r.bitsRead = start
+		return nil, err
+	}
+
+	return item, nil
+}
+
+func (r *ReaderError) ReadCBOR() (item []byte) {
+	if r.err == nil {
+		item, r.err = r.reader.ReadCBOR()
+	}
+	return
+}
+
+// readCBORItem reads a CBOR item byte by byte and appends it to buf.
+func readCBORItem(r *Reader, buf []byte, depth int) ([]byte, error) {
+	if depth > maxCBORDepth {
+		return nil, ErrInvalidCBOR
+	}
+
+	b, err := r.Read8(8)
+	if err != nil {
+		return nil, err
+	}
+	buf = append(buf, b)
+
+	major, info := b>>5, b&0x1F
+
+	if info == 31 {
+		return readCBORIndefinite(r, buf, major, depth)
+	}
+
+	buf, arg, err := readCBORArgument(r, buf, info)
+	if err != nil {
+		return nil, err
+	}
+
+	switch major {
+	case 2, 3: // byte and text string
+		if ok, err := r.ensureCount(arg, 8); !ok {
+			if err == nil {
+				// The head of the string is already read, so an item cut by an optional end is truncated.
+				err = io.ErrUnexpectedEOF
+			}
+			return nil, err
+		}
+		n := len(buf)
+		buf = append(buf, make([]byte, arg)...)
+		if _, err = io.ReadFull(r, buf[n:]); err != nil {
+			return nil, err
+		}
+	case 4, 5: // array and map
+		if major == 5 {
+			arg *= 2
+		}
+		for i := uint64(0); i < arg; i++ {
+			if buf, err = readCBORItem(r, buf, depth+1); err != nil {
+				return nil, err
+			}
+		}
+	case 6: // tag
+		buf, err = readCBORItem(r, buf, depth+1)
+	}
+
+	return buf, err
+}
+
+// readCBORIndefinite reads the content of an indefinite-length item up to and including the break code.
+func readCBORIndefinite(r *Reader, buf []byte, major byte, depth int) ([]byte, error) {
+	if major < 2 || major > 5 {
+		return nil, ErrInvalidCBOR
+	}
+
+	for count := 0; ; count++ {
+		b, err := r.Read8(8)
+		if err != nil {
+			return nil, err
+		}
+		if b == 0xFF {
+			if major == 5 && count%2 == 1 {
+				return nil, ErrInvalidCBOR
+			}
+			return append(buf, b), nil
+		}
+		r.bitsRead -= 8
+
+		// chunks of an indefinite string must be definite strings of the same type
+		if (major == 2 || major == 3) && (b>>5 != major || b&0x1F == 31) {
+			return nil, ErrInvalidCBOR
+		}
+
+		if buf, err = readCBORItem(r, buf, depth+1); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// readCBORArgument reads the argument of an item whose additional information is info.
+func readCBORArgument(r *Reader, buf []byte, info byte) ([]byte, uint64, error) {
+	if info < 24 {
+		return buf, uint64(info), nil
+	}
+	if info > 27 {
+		return nil, 0, ErrInvalidCBOR
+	}
+
+	var arg uint64
+	for i := 0; i < 1<<(info-24); i++ {
+		b, err := r.Read8(8)
+		if err != nil {
+			return nil, 0, err
+		}
+		buf = append(buf, b)
+		arg = arg<<8 | uint64(b)
+	}
+
+	return buf, arg, nil
+}
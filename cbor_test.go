@@ -0,0 +1,78 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestCBOR(t *testing.T) {
+	items := [][]byte{
+		{0x00},                               // 0
+		{0x38, 0x63},                         // -100
+		{0x83, 0x01, 0x02, 0x03},             // [1, 2, 3]
+		{0xA1, 0x61, 0x61, 0x01},             // {"a": 1}
+		{0x9F, 0x01, 0x82, 0x02, 0x03, 0xFF}, // [_ 1, [2, 3]]
+		{0x5F, 0x42, 0x01, 0x02, 0x41, 0x03, 0xFF},                   // (_ h'0102', h'03')
+		{0xC1, 0x1A, 0x51, 0x4B, 0x67, 0xB0},                         // 1(1363896240)
+		{0xFB, 0x3F, 0xF1, 0x99, 0x99, 0x99, 0x99, 0x99, 0x9A},       // 1.1
+		{0xBF, 0x61, 0x61, 0xF5, 0x61, 0x62, 0x9F, 0xF6, 0xFF, 0xFF}, // {_ "a": true, "b": [_ null]}
+	}
+
+	w := NewWriter()
+	for _, item := range items {
+		w.Write8(1, 1)
+		if err := w.WriteCBOR(item); err != nil {
+			t.Fatalf("unexpected error for %x: %s", item, err)
+		}
+	}
+	w.Write8(0b11, 2)
+
+	r := NewReaderError(w.BitData())
+	for _, item := range items {
+		r.Skip(1)
+		if got := r.ReadCBOR(); !bytes.Equal(item, got) {
+			t.Errorf("item mismatch: want=%x got=%x", item, got)
+		}
+	}
+	if v := r.Read8(2); v != 0b11 {
+		t.Errorf("want=%b got=%b", 0b11, v)
+	}
+	if err := r.Error(); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
+
+func TestCBORInvalid(t *testing.T) {
+	tests := []struct {
+		item []byte
+		err  error
+	}{
+		{item: []byte{0x01, 0x02}, err: nil},                        // two items, only the first one is read
+		{item: []byte{0x1C}, err: ErrInvalidCBOR},                   // reserved additional information
+		{item: []byte{0xFF}, err: ErrInvalidCBOR},                   // break outside of an item
+		{item: []byte{0x1F}, err: ErrInvalidCBOR},                   // indefinite integer
+		{item: []byte{0x5F, 0x61, 0x61, 0xFF}, err: ErrInvalidCBOR}, // text chunk in a byte string
+		{item: []byte{0xBF, 0x01, 0xFF}, err: ErrInvalidCBOR},       // map without a value
+		{item: []byte{0x83, 0x01, 0x02}, err: io.ErrUnexpectedEOF},
+		{item: []byte{0x45, 0x01}, err: io.ErrUnexpectedEOF},
+	}
+
+	for _, test := range tests {
+		if err := NewWriter().WriteCBOR(test.item); !errors.Is(err, ErrInvalidCBOR) {
+			t.Errorf("want=%v for %x got=%v", ErrInvalidCBOR, test.item, err)
+		}
+
+		if _, err := NewReader(test.item).ReadCBOR(); !errors.Is(err, test.err) {
+			t.Errorf("want=%v for %x got=%v", test.err, test.item, err)
+		}
+	}
+
+	nested := append(bytes.Repeat([]byte{0x81}, maxCBORDepth+1), 0x00)
+	if _, err := NewReader(nested).ReadCBOR(); !errors.Is(err, ErrInvalidCBOR) {
+		t.Errorf("want=%v got=%v", ErrInvalidCBOR, err)
+	}
+}
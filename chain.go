@@ -0,0 +1,84 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+// Chain wraps a writer so that writes can be chained, and keeps the first error of the chained
+// operations that can fail. The chain stops at the first error: the operations after it do nothing.
+//
+//	err := w.Chain().Write8(kind, 3).Write16(length, 11).WriteBool(last).Do(writePayload).Err()
+type Chain struct {
+	w   *Writer
+	err error
+}
+
+// Chain returns a chain writing to the writer.
+func (w *Writer) Chain() *Chain {
+	return &Chain{w: w}
+}
+
+func (c *Chain) WriteBool(v bool) *Chain {
+	if c.err == nil {
+		c.w.WriteBool(v)
+	}
+	return c
+}
+
+func (c *Chain) Write8(v uint8, bitCount byte) *Chain {
+	if c.err == nil {
+		c.w.Write8(v, bitCount)
+	}
+	return c
+}
+
+func (c *Chain) Write16(v uint16, bitCount byte) *Chain {
+	if c.err == nil {
+		c.w.Write16(v, bitCount)
+	}
+	return c
+}
+
+func (c *Chain) Write32(v uint32, bitCount byte) *Chain {
+	if c.err == nil {
+		c.w.Write32(v, bitCount)
+	}
+	return c
+}
+
+func (c *Chain) Write64(v uint64, bitCount byte) *Chain {
+	if c.err == nil {
+		c.w.Write64(v, bitCount)
+	}
+	return c
+}
+
+func (c *Chain) WriteUvarint(v uint64) *Chain {
+	if c.err == nil {
+		c.w.WriteUvarint(v)
+	}
+	return c
+}
+
+func (c *Chain) WriteBitData(d BitData, bitCount uint) *Chain {
+	if c.err == nil {
+		c.w.WriteBitData(d, bitCount)
+	}
+	return c
+}
+
+// Do calls fn with the writer, unless an earlier operation of the chain failed, and keeps its error.
+func (c *Chain) Do(fn func(w *Writer) error) *Chain {
+	if c.err == nil {
+		c.err = fn(c.w)
+	}
+	return c
+}
+
+// Writer returns the writer of the chain.
+func (c *Chain) Writer() *Writer {
+	return c.w
+}
+
+// Err returns the first error of the chained operations.
+func (c *Chain) Err() error {
+	return c.err
+}
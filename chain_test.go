@@ -0,0 +1,58 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestChain(t *testing.T) {
+	want := NewWriter()
+	want.Write8(5, 3)
+	want.Write16(1000, 11)
+	want.WriteBool(true)
+	want.Write32(7, 5)
+	want.Write64(1<<40, 41)
+	want.WriteUvarint(300)
+	want.WriteBitData(BitData{0xAB}, 6)
+
+	w := NewWriter()
+	err := w.Chain().
+		Write8(5, 3).
+		Write16(1000, 11).
+		WriteBool(true).
+		Write32(7, 5).
+		Write64(1<<40, 41).
+		WriteUvarint(300).
+		WriteBitData(BitData{0xAB}, 6).
+		Err()
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if w.BitsWritten() != want.BitsWritten() || !bytes.Equal(w.BitData(), want.BitData()) {
+		t.Errorf("data mismatch: want=%x got=%x", want.BitData(), w.BitData())
+	}
+}
+
+func TestChainError(t *testing.T) {
+	errFirst := errors.New("first")
+	calls := 0
+
+	c := NewWriter().Chain().
+		Do(func(w *Writer) error { calls++; return nil }).
+		Do(func(w *Writer) error { calls++; return errFirst }).
+		Do(func(w *Writer) error { calls++; return errors.New("second") }).
+		Write8(1, 1)
+
+	if !errors.Is(c.Err(), errFirst) {
+		t.Errorf("expected the first error, got %v", c.Err())
+	}
+	if calls != 2 {
+		t.Errorf("functions after the error must not be called: %d calls", calls)
+	}
+	if c.Writer().BitsWritten() != 0 {
+		t.Errorf("writes after the error must not be done: %d bits written", c.Writer().BitsWritten())
+	}
+}
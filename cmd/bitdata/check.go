@@ -0,0 +1,44 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+)
+
+// runCheck checks that a schema only appends fields to an older one, so that the data written
+// with the older schema can still be decoded with the newer one.
+func runCheck(args []string, _ io.Reader, stdout io.Writer) error {
+	fs := flag.NewFlagSet("check", flag.ContinueOnError)
+	oldFlag := fs.String("old", "", "the older schema, as comma separated name:width, or @file")
+	schemaFlag := fs.String("schema", "", "the newer schema, as comma separated name:width, or @file")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return errors.New("too many arguments")
+	}
+	if *oldFlag == "" || *schemaFlag == "" {
+		return errors.New("missing schema")
+	}
+
+	older, err := loadSchema(*oldFlag)
+	if err != nil {
+		return fmt.Errorf("old schema: %w", err)
+	}
+	newer, err := loadSchema(*schemaFlag)
+	if err != nil {
+		return err
+	}
+
+	if err := checkCompatible(older, newer); err != nil {
+		return fmt.Errorf("incompatible schema: %w", err)
+	}
+
+	_, err = fmt.Fprintln(stdout, "compatible")
+
+	return err
+}
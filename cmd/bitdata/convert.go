@@ -0,0 +1,172 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/marko-gacesa/bitdata"
+)
+
+// runEncode reads a JSON object with a number or a bool for every field of the schema
+// and writes the packed data, padded with zero bits to a whole byte. The fields of versions after
+// the one selected are left out, and a field added in a version can be omitted for its default value.
+func runEncode(args []string, stdin io.Reader, stdout io.Writer) error {
+	schema, o, version, rest, err := parseConvertFlags("encode", args)
+	if err != nil {
+		return err
+	}
+
+	input, err := readInput(rest, stdin)
+	if err != nil {
+		return err
+	}
+
+	d := json.NewDecoder(bytes.NewReader(input))
+	d.UseNumber()
+
+	var obj map[string]any
+	if err := d.Decode(&obj); err != nil {
+		return err
+	}
+
+	w := bitdata.NewWriter(bitdata.WithBitOrder(o))
+	for _, f := range schema {
+		v, ok := obj[f.name]
+		delete(obj, f.name)
+
+		if f.since > version {
+			continue
+		}
+		if !ok && f.since == 0 {
+			return fmt.Errorf("missing field %s", f.name)
+		}
+
+		u := f.def
+		if ok {
+			if u, err = fieldValue(f, v); err != nil {
+				return fmt.Errorf("field %s: %w", f.name, err)
+			}
+		}
+		w.Write64(u, f.width)
+	}
+	for name := range obj {
+		return fmt.Errorf("unknown field %s", name)
+	}
+
+	_ = w.Finish(bitdata.PaddingZeros)
+	_, err = w.WriteTo(stdout)
+
+	return err
+}
+
+// runDecode reads packed data and writes a JSON object with the fields of the schema in schema order.
+// The fields of versions after the one selected aren't read and get their default values.
+func runDecode(args []string, stdin io.Reader, stdout io.Writer) error {
+	schema, o, version, rest, err := parseConvertFlags("decode", args)
+	if err != nil {
+		return err
+	}
+
+	data, err := readInput(rest, stdin)
+	if err != nil {
+		return err
+	}
+
+	r := bitdata.NewReader(data, bitdata.WithBitOrder(o))
+
+	var out bytes.Buffer
+	out.WriteByte('{')
+	for i, f := range schema {
+		v := f.def
+		if f.since <= version {
+			if v, err = r.Read64(f.width); err != nil {
+				return fmt.Errorf("field %s: %w", f.name, err)
+			}
+		}
+
+		if i > 0 {
+			out.WriteByte(',')
+		}
+		name, _ := json.Marshal(f.name)
+		out.Write(name)
+		out.WriteByte(':')
+
+		if f.signed && f.width < 64 && v>>(f.width-1) != 0 {
+			out.WriteString(strconv.FormatInt(int64(v|^uint64(0)<<f.width), 10))
+		} else if f.signed {
+			out.WriteString(strconv.FormatInt(int64(v), 10))
+		} else {
+			out.WriteString(strconv.FormatUint(v, 10))
+		}
+	}
+	out.WriteString("}\n")
+
+	_, err = out.WriteTo(stdout)
+
+	return err
+}
+
+// parseConvertFlags returns the schema, the bit order and the schema version selected by the flags,
+// and the remaining arguments. Without the version flag, the latest version is used.
+func parseConvertFlags(name string, args []string) ([]schemaField, bitdata.BitOrder, uint, []string, error) {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	order := fs.String("order", "lsb", "bit order of the data: lsb or msb")
+	schemaFlag := fs.String("schema", "", "schema of the data, as comma separated name:width, or @file")
+	versionFlag := fs.Int("version", -1, "version of the schema the data is in, -1 for the latest")
+	if err := fs.Parse(args); err != nil {
+		return nil, 0, 0, nil, err
+	}
+
+	o, err := parseOrder(*order)
+	if err != nil {
+		return nil, 0, 0, nil, err
+	}
+	if *schemaFlag == "" {
+		return nil, 0, 0, nil, errors.New("missing schema")
+	}
+
+	schema, err := loadSchema(*schemaFlag)
+	if err != nil {
+		return nil, 0, 0, nil, err
+	}
+
+	version := ^uint(0)
+	if *versionFlag >= 0 {
+		version = uint(*versionFlag)
+	}
+
+	return schema, o, version, fs.Args(), nil
+}
+
+// fieldValue returns the bits of the JSON value v for the field, checking that it fits.
+func fieldValue(f schemaField, v any) (uint64, error) {
+	var s string
+	switch v := v.(type) {
+	case bool:
+		if v {
+			return 1, nil
+		}
+		return 0, nil
+	case json.Number:
+		s = v.String()
+	default:
+		return 0, fmt.Errorf("expected a number, got %v", v)
+	}
+
+	if f.signed {
+		x, err := strconv.ParseInt(s, 10, int(f.width))
+		if err != nil {
+			return 0, err
+		}
+		return uint64(x) & (^uint64(0) >> (64 - f.width)), nil
+	}
+
+	return strconv.ParseUint(s, 10, int(f.width))
+}
@@ -0,0 +1,134 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	schema := "kind:3, delta:i5, flag:1\nlength:11"
+	in := `{"kind": 5, "delta": -3, "flag": true, "length": 1000}`
+
+	for _, order := range []string{"lsb", "msb"} {
+		var packed bytes.Buffer
+		if err := runEncode([]string{"-schema", schema, "-order", order}, strings.NewReader(in), &packed); err != nil {
+			t.Fatalf("%s: encode failed: %v", order, err)
+		}
+		if packed.Len() != 3 {
+			t.Errorf("%s: unexpected size %d", order, packed.Len())
+		}
+
+		var out bytes.Buffer
+		if err := runDecode([]string{"-schema", schema, "-order", order}, &packed, &out); err != nil {
+			t.Fatalf("%s: decode failed: %v", order, err)
+		}
+		if want := `{"kind":5,"delta":-3,"flag":1,"length":1000}` + "\n"; out.String() != want {
+			t.Errorf("%s: want=%q got=%q", order, want, out.String())
+		}
+	}
+
+	var packed bytes.Buffer
+	if err := runEncode([]string{"-schema", "a:4,b:4", "-order", "msb"}, strings.NewReader(`{"a":1,"b":15}`), &packed); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(packed.Bytes(), []byte{0x1F}) {
+		t.Errorf("unexpected data: %x", packed.Bytes())
+	}
+}
+
+func TestEncodeErrors(t *testing.T) {
+	tests := []struct {
+		schema, in string
+	}{
+		{schema: "a:3", in: `{"a": 8}`},
+		{schema: "a:i3", in: `{"a": -5}`},
+		{schema: "a:3", in: `{"a": -1}`},
+		{schema: "a:3", in: `{"a": "x"}`},
+		{schema: "a:3", in: `{}`},
+		{schema: "a:3", in: `{"a": 1, "b": 2}`},
+		{schema: "a:3", in: `[1]`},
+	}
+
+	for _, test := range tests {
+		if err := runEncode([]string{"-schema", test.schema}, strings.NewReader(test.in), &bytes.Buffer{}); err == nil {
+			t.Errorf("%s %s: expected error", test.schema, test.in)
+		}
+	}
+
+	if err := runEncode(nil, strings.NewReader(`{}`), &bytes.Buffer{}); err == nil {
+		t.Errorf("expected error for a missing schema")
+	}
+}
+
+func TestEncodeDecodeVersions(t *testing.T) {
+	schema := "kind:3, delta:i5\n@2\nscale:4=2, flag:1=true"
+
+	var old bytes.Buffer
+	if err := runEncode([]string{"-schema", schema, "-version", "1"}, strings.NewReader(`{"kind": 5, "delta": -3, "scale": 9}`), &old); err != nil {
+		t.Fatal(err)
+	}
+	if old.Len() != 1 {
+		t.Errorf("unexpected size %d", old.Len())
+	}
+
+	var out bytes.Buffer
+	if err := runDecode([]string{"-schema", schema, "-version", "1"}, &old, &out); err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"kind":5,"delta":-3,"scale":2,"flag":1}` + "\n"; out.String() != want {
+		t.Errorf("want=%q got=%q", want, out.String())
+	}
+
+	var cur bytes.Buffer
+	if err := runEncode([]string{"-schema", schema}, strings.NewReader(`{"kind": 5, "delta": -3, "flag": false}`), &cur); err != nil {
+		t.Fatal(err)
+	}
+	out.Reset()
+	if err := runDecode([]string{"-schema", schema}, &cur, &out); err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"kind":5,"delta":-3,"scale":2,"flag":0}` + "\n"; out.String() != want {
+		t.Errorf("want=%q got=%q", want, out.String())
+	}
+}
+
+func TestCheck(t *testing.T) {
+	var out bytes.Buffer
+	if err := runCheck([]string{"-old", "a:4", "-schema", "a:4,@1,b:2=1"}, nil, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != "compatible\n" {
+		t.Errorf("unexpected output %q", out.String())
+	}
+
+	for _, args := range [][]string{
+		{"-old", "a:4", "-schema", "a:5"},
+		{"-old", "a:4", "-schema", "a:4,b:1"},
+		{"-old", "a:4"},
+		{"-old", "a:4", "-schema", "a:4", "x"},
+	} {
+		if err := runCheck(args, nil, &bytes.Buffer{}); err == nil {
+			t.Errorf("%v: expected error", args)
+		}
+	}
+}
+
+func TestSchemaFile(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "schema.txt")
+	if err := os.WriteFile(name, []byte("# header\nversion:4\nsize:i12\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if err := runDecode([]string{"-schema", "@" + name}, bytes.NewReader([]byte{0xF3, 0xFF}), &out); err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"version":3,"size":-1}` + "\n"; out.String() != want {
+		t.Errorf("want=%q got=%q", want, out.String())
+	}
+}
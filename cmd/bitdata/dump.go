@@ -0,0 +1,94 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/marko-gacesa/bitdata"
+)
+
+func runDump(args []string, stdin io.Reader, stdout io.Writer) error {
+	fs := flag.NewFlagSet("dump", flag.ContinueOnError)
+	order := fs.String("order", "lsb", "bit order of the data: lsb or msb")
+	perLine := fs.Int("bytes", 8, "bytes per line")
+	fields := fs.String("fields", "", "schema of the fields to decode, as comma separated name:width, or @file")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	o, err := parseOrder(*order)
+	if err != nil {
+		return err
+	}
+	if *perLine <= 0 {
+		return fmt.Errorf("invalid number of bytes per line: %d", *perLine)
+	}
+
+	data, err := readInput(fs.Args(), stdin)
+	if err != nil {
+		return err
+	}
+
+	if *fields != "" {
+		schema, err := loadSchema(*fields)
+		if err != nil {
+			return err
+		}
+
+		f, err := decodeFields(data, o, schema)
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(stdout, bitdata.DumpFields(f))
+		return err
+	}
+
+	dumpLines(stdout, data, o, *perLine)
+
+	return nil
+}
+
+func parseOrder(s string) (bitdata.BitOrder, error) {
+	switch s {
+	case "lsb":
+		return bitdata.LSBFirst, nil
+	case "msb":
+		return bitdata.MSBFirst, nil
+	}
+	return 0, fmt.Errorf("invalid bit order %q", s)
+}
+
+// dumpLines prints the data with the byte offset, the bytes in hex and the bits in stream order on every line.
+func dumpLines(w io.Writer, data []byte, o bitdata.BitOrder, perLine int) {
+	for ofs := 0; ofs < len(data); ofs += perLine {
+		line := bitdata.BitData(data[ofs:min(ofs+perLine, len(data))])
+
+		bits := line
+		if o == bitdata.MSBFirst {
+			bits = line.ReverseByteBits()
+		}
+
+		_, _ = fmt.Fprintf(w, "%08x  %-*s  %s\n", ofs, perLine*3-1, line.FormatHex(1), bits.BinaryString())
+	}
+}
+
+// decodeFields reads the fields of the schema.
+func decodeFields(data []byte, o bitdata.BitOrder, schema []schemaField) ([]bitdata.Field, error) {
+	r := bitdata.NewReader(data, bitdata.WithBitOrder(o))
+
+	fields := make([]bitdata.Field, 0, len(schema))
+	for _, f := range schema {
+		offset := r.BitsRead()
+		v, err := r.Read64(f.width)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", f.name, err)
+		}
+
+		fields = append(fields, bitdata.Field{Offset: offset, Width: f.width, Value: v, Label: f.name})
+	}
+
+	return fields, nil
+}
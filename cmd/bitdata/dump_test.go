@@ -0,0 +1,65 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestDump(t *testing.T) {
+	in := []byte{0x01, 0x80, 0xFF}
+
+	var out bytes.Buffer
+	if err := runDump([]string{"-bytes", "2"}, bytes.NewReader(in), &out); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "" +
+		"00000000  01 80  10000000 00000001\n" +
+		"00000002  ff     11111111\n"
+	if out.String() != want {
+		t.Errorf("want:\n%s\ngot:\n%s", want, out.String())
+	}
+
+	out.Reset()
+	if err := runDump([]string{"-order", "msb", "-"}, bytes.NewReader(in), &out); err != nil {
+		t.Fatal(err)
+	}
+	if want := "00000000  01 80 ff" + strings.Repeat(" ", 15+2) + "00000001 10000000 11111111\n"; out.String() != want {
+		t.Errorf("want:\n%q\ngot:\n%q", want, out.String())
+	}
+}
+
+func TestDumpFields(t *testing.T) {
+	in := []byte{0b1010_1101, 0xFF}
+
+	var out bytes.Buffer
+	if err := runDump([]string{"-order", "msb", "-fields", "kind:3,len:5,rest:8"}, bytes.NewReader(in), &out); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("unexpected output:\n%s", out.String())
+	}
+	for i, want := range []string{"0 3 0x5 101 kind", "3 5 0xd 01101 len", "8 8 0xff 11111111 rest"} {
+		if got := strings.Join(strings.Fields(lines[i+1]), " "); got != want {
+			t.Errorf("line %d: want=%q got=%q", i+1, want, got)
+		}
+	}
+
+	err := runDump([]string{"-fields", "a:16,b:1"}, bytes.NewReader(in), &out)
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("expected io.ErrUnexpectedEOF, got %v", err)
+	}
+
+	for _, spec := range []string{"a", "a:0", "a:65", "a:x"} {
+		if err := runDump([]string{"-fields", spec}, bytes.NewReader(in), &out); err == nil {
+			t.Errorf("%q: expected error", spec)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+// Command bitdata inspects and converts bit-packed data.
+//
+// Usage:
+//
+//	bitdata dump [flags] [file]
+//	bitdata encode -schema <schema> [flags] [file]
+//	bitdata decode -schema <schema> [flags] [file]
+//	bitdata check -old <schema> -schema <schema>
+//
+// A schema lists the fields of the data as name:width, separated by commas or new lines. A width
+// prefixed with "i" marks a signed field, as in "delta:i5". A schema starting with "@" is read from a file.
+// The encode command converts a JSON object to packed data and decode converts packed data to JSON.
+//
+// A schema grows by appending fields after a version marker, as in "@2". Such a field can have a default
+// value, as in "scale:4=3", which decode uses for data written in an older version, selected with
+// the -version flag. The check command verifies that a schema only appends fields to an older one.
+//
+// Without a file, or with "-", the data is read from the standard input.
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+var commands = map[string]func(args []string, stdin io.Reader, stdout io.Writer) error{
+	"dump":   runDump,
+	"encode": runEncode,
+	"decode": runDecode,
+	"check":  runCheck,
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	cmd, ok := commands[os.Args[1]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "bitdata: unknown command %q\n", os.Args[1])
+		usage()
+		os.Exit(2)
+	}
+
+	if err := cmd(os.Args[2:], os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "bitdata: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: bitdata <command> [flags] [file]")
+	fmt.Fprintln(os.Stderr, "commands:")
+	fmt.Fprintln(os.Stderr, "  dump    print data as hex and binary, optionally split into fields")
+	fmt.Fprintln(os.Stderr, "  encode  convert a JSON object to packed data described by a schema")
+	fmt.Fprintln(os.Stderr, "  decode  convert packed data described by a schema to a JSON object")
+	fmt.Fprintln(os.Stderr, "  check   verify that a schema only appends fields to an older one")
+}
+
+// readInput returns the content of the file named by the only positional argument, or of stdin.
+func readInput(args []string, stdin io.Reader) ([]byte, error) {
+	switch {
+	case len(args) > 1:
+		return nil, fmt.Errorf("too many arguments")
+	case len(args) == 0 || args[0] == "-":
+		return io.ReadAll(stdin)
+	default:
+		return os.ReadFile(args[0])
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// schemaField is a field of a schema: a name and a width, optionally prefixed with "i" for a signed
+// two's complement value, as in "delta:i5", and optionally followed by a default value, as in "scale:4=3".
+// The fields after a version marker, as in "@2", were added in that version.
+type schemaField struct {
+	name   string
+	width  byte
+	signed bool
+	since  uint
+	def    uint64 // the bits of the value in older versions
+}
+
+// loadSchema parses the schema given as a flag value. A value starting with "@" names a file with the schema.
+func loadSchema(s string) ([]schemaField, error) {
+	if name, ok := strings.CutPrefix(s, "@"); ok {
+		b, err := os.ReadFile(name)
+		if err != nil {
+			return nil, err
+		}
+		s = string(b)
+	}
+	return parseSchema(s)
+}
+
+// parseSchema parses a list of name:width fields and version markers separated by commas or new lines.
+// Lines starting with "#" are comments.
+func parseSchema(s string) ([]schemaField, error) {
+	var (
+		fields []schemaField
+		since  uint
+	)
+	for _, line := range strings.Split(s, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "#") {
+			continue
+		}
+
+		for _, item := range strings.Split(line, ",") {
+			item = strings.TrimSpace(item)
+			if item == "" {
+				continue
+			}
+
+			if v, ok := strings.CutPrefix(item, "@"); ok {
+				n, err := strconv.ParseUint(v, 10, 0)
+				if err != nil || uint(n) <= since {
+					return nil, fmt.Errorf("invalid version %q, versions must increase", item)
+				}
+				since = uint(n)
+				continue
+			}
+
+			name, width, ok := strings.Cut(item, ":")
+			if !ok || name == "" {
+				return nil, fmt.Errorf("invalid field %q, expected name:width", item)
+			}
+
+			f := schemaField{name: name, since: since}
+			width, def, hasDef := strings.Cut(width, "=")
+			width, f.signed = strings.CutPrefix(width, "i")
+
+			n, err := strconv.ParseUint(width, 10, 8)
+			if err != nil || n == 0 || n > 64 {
+				return nil, fmt.Errorf("invalid width of field %q", name)
+			}
+			f.width = byte(n)
+
+			if hasDef {
+				if f.def, err = fieldValue(f, defaultValue(def)); err != nil {
+					return nil, fmt.Errorf("invalid default of field %q: %w", name, err)
+				}
+			}
+
+			fields = append(fields, f)
+		}
+	}
+
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("empty schema")
+	}
+
+	return fields, nil
+}
+
+// defaultValue returns the default value of a field as fieldValue expects it.
+func defaultValue(s string) any {
+	if b, err := strconv.ParseBool(s); err == nil {
+		return b
+	}
+	return json.Number(s)
+}
+
+// checkCompatible checks that newer only appends fields to older: the fields of older must have the same
+// width, signedness and version in newer, and the added fields must be in a version above all of them.
+func checkCompatible(older, newer []schemaField) error {
+	if len(newer) < len(older) {
+		return fmt.Errorf("field %s removed", older[len(newer)].name)
+	}
+
+	var latest uint
+	for i, of := range older {
+		nf := newer[i]
+		if nf.width != of.width || nf.signed != of.signed {
+			return fmt.Errorf("field %s changed from %s to %s", of.name, of.typeName(), nf.typeName())
+		}
+		if nf.since != of.since {
+			return fmt.Errorf("field %s moved from version %d to %d", of.name, of.since, nf.since)
+		}
+		latest = of.since
+	}
+
+	for _, nf := range newer[len(older):] {
+		if nf.since <= latest {
+			return fmt.Errorf("added field %s needs a version above %d", nf.name, latest)
+		}
+	}
+
+	return nil
+}
+
+func (f schemaField) typeName() string {
+	if f.signed {
+		return "i" + strconv.Itoa(int(f.width))
+	}
+	return strconv.Itoa(int(f.width))
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseSchema(t *testing.T) {
+	got, err := parseSchema("a:1, b:i7\n# comment, x:1\n\nc:64,")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []schemaField{{name: "a", width: 1}, {name: "b", width: 7, signed: true}, {name: "c", width: 64}}
+	if !slices.Equal(got, want) {
+		t.Errorf("want=%v got=%v", want, got)
+	}
+
+	for _, s := range []string{"", "a", ":3", "a:0", "a:65", "a:x", "a:i", "# only a comment"} {
+		if _, err := parseSchema(s); err == nil {
+			t.Errorf("%q: expected error", s)
+		}
+	}
+}
+
+func TestParseSchemaVersions(t *testing.T) {
+	got, err := parseSchema("a:4\n@2\nb:i3=-1, c:1=true\n@5, d:8")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []schemaField{
+		{name: "a", width: 4},
+		{name: "b", width: 3, signed: true, since: 2, def: 7},
+		{name: "c", width: 1, since: 2, def: 1},
+		{name: "d", width: 8, since: 5},
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("want=%v got=%v", want, got)
+	}
+
+	for _, s := range []string{"a:1,@0,b:1", "a:1,@2,b:1,@2,c:1", "a:1,@x", "a:3=8", "a:i3=-5", "a:3=x"} {
+		if _, err := parseSchema(s); err == nil {
+			t.Errorf("%q: expected error", s)
+		}
+	}
+}
+
+func TestCheckCompatible(t *testing.T) {
+	older := "a:4, b:i3\n@2\nc:1=1"
+
+	for _, newer := range []string{older, older + "\n@3\nd:5=2, e:1"} {
+		if err := checkCompatible(mustParseSchema(t, older), mustParseSchema(t, newer)); err != nil {
+			t.Errorf("%q: unexpected error: %v", newer, err)
+		}
+	}
+
+	for _, newer := range []string{"a:4, b:i3", "a:4, b:3, @2, c:1", "a:4, b:i3, c:1", "a:4, b:i3, @2, c:2", "a:4, @2, b:i3, c:1", "a:4, b:i3, d:1, @2, c:1", older + ",d:5"} {
+		if err := checkCompatible(mustParseSchema(t, older), mustParseSchema(t, newer)); err == nil {
+			t.Errorf("%q: expected error", newer)
+		}
+	}
+}
+
+func mustParseSchema(t *testing.T, s string) []schemaField {
+	t.Helper()
+	f, err := parseSchema(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
@@ -0,0 +1,258 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"errors"
+	"io"
+)
+
+var ErrInvalidCoAP = errors.New("invalid CoAP message")
+
+const (
+	coapVersion       = 1
+	coapMaxToken      = 8
+	coapPayloadMarker = 0xFF
+)
+
+// CoAP message types.
+const (
+	CoAPConfirmable    = 0
+	CoAPNonConfirmable = 1
+	CoAPAcknowledgment = 2
+	CoAPReset          = 3
+)
+
+// CoAPHeader is the fixed header of a CoAP message followed by the token. The Code holds the 3-bit class
+// and the 5-bit detail, 0x45 being 2.05 Content. The CoAP and MQTT-SN functions expect a writer or reader
+// in the MSBFirst order.
+type CoAPHeader struct {
+	Type      byte // 2 bits
+	Code      byte
+	MessageID uint16
+	Token     []byte // up to 8 bytes
+}
+
+// CoAPOption is a CoAP option. Options are written in the order of their numbers as deltas.
+type CoAPOption struct {
+	Number uint16
+	Value  []byte
+}
+
+// WriteCoAPHeader writes the header of version 1 and the token. It returns ErrInvalidCoAP
+// for a token longer than 8 bytes.
+func (w *Writer) WriteCoAPHeader(h CoAPHeader) error {
+	if len(h.Token) > coapMaxToken {
+		return ErrInvalidCoAP
+	}
+
+	w.Write8(coapVersion, 2)
+	w.Write8(h.Type, 2)
+	w.Write8(byte(len(h.Token)), 4)
+	w.Write8(h.Code, 8)
+	w.Write16(h.MessageID, 16)
+	writeAddrBytes(w, h.Token)
+
+	return nil
+}
+
+// ReadCoAPHeader reads the header and the token. It returns ErrInvalidCoAP if the version isn't 1
+// or the token length is reserved. On error the read position is left unchanged.
+func (r *Reader) ReadCoAPHeader() (CoAPHeader, error) {
+	var h CoAPHeader
+
+	rr := ReaderError{reader: *r}
+	version := rr.Read8(2)
+	h.Type = rr.Read8(2)
+	tkl := rr.Read8(4)
+	if rr.err == nil && (version != coapVersion || tkl > coapMaxToken) {
+		return h, ErrInvalidCoAP
+	}
+	h.Code = rr.Read8(8)
+	h.MessageID = rr.Read16(16)
+	if tkl > 0 {
+		h.Token = make([]byte, tkl)
+		rr.readBytes(h.Token)
+	}
+
+	if rr.err != nil {
+		return CoAPHeader{}, rr.err
+	}
+
+	*r = rr.reader
+
+	return h, nil
+}
+
+// WriteCoAPOptions writes the options, which must be sorted by their numbers, and the payload marker
+// if the payload isn't empty, followed by the payload. It returns ErrInvalidCoAP if the options aren't sorted.
+func (w *Writer) WriteCoAPOptions(opts []CoAPOption, payload []byte) error {
+	for i := 1; i < len(opts); i++ {
+		if opts[i].Number < opts[i-1].Number {
+			return ErrInvalidCoAP
+		}
+	}
+	for _, o := range opts {
+		if len(o.Value) > 0xFFFF+269 {
+			return ErrInvalidCoAP
+		}
+	}
+
+	var prev uint16
+	for _, o := range opts {
+		delta, dext, dn := coapNibble(uint(o.Number - prev))
+		length, lext, ln := coapNibble(uint(len(o.Value)))
+
+		w.Write8(delta, 4)
+		w.Write8(length, 4)
+		w.Write16(dext, dn)
+		w.Write16(lext, ln)
+		writeAddrBytes(w, o.Value)
+
+		prev = o.Number
+	}
+
+	if len(payload) > 0 {
+		w.Write8(coapPayloadMarker, 8)
+		writeAddrBytes(w, payload)
+	}
+
+	return nil
+}
+
+// ReadCoAPOptions reads the options and the payload that follow the header up to the end of the data.
+// It returns ErrInvalidCoAP for a reserved nibble value or a payload marker without a payload.
+// On error the read position is left unchanged.
+func (r *Reader) ReadCoAPOptions() (opts []CoAPOption, payload []byte, err error) {
+	start := r.bitsRead
+	defer func() {
+		if err != nil {
+			r.bitsRead = start
+			opts, payload = nil, nil
+		}
+	}()
+
+	var number uint
+	for r.bitsRead+8 <= r.end {
+		b, _ := r.Read8(8)
+		if b == coapPayloadMarker {
+			n := (r.end - r.bitsRead) / 8
+			if n == 0 {
+				err = ErrInvalidCoAP
+				return
+			}
+			payload = make([]byte, n)
+			_, err = io.ReadFull(r, payload)
+			return
+		}
+
+		var delta, length uint
+		if delta, err = readCoAPNibble(r, b>>4); err != nil {
+			return
+		}
+		if length, err = readCoAPNibble(r, b&0xF); err != nil {
+			return
+		}
+
+		number += delta
+		if number > 0xFFFF {
+			err = ErrInvalidCoAP
+			return
+		}
+
+		o := CoAPOption{Number: uint16(number), Value: make([]byte, length)}
+		if err = readAddrBytes(r, o.Value); err != nil {
+			return
+		}
+		opts = append(opts, o)
+	}
+
+	return
+}
+
+// coapNibble returns the 4-bit value of an option delta or length with its extension and the extension width.
+func coapNibble(v uint) (byte, uint16, byte) {
+	switch {
+	case v < 13:
+		return byte(v), 0, 0
+	case v < 269:
+		return 13, uint16(v - 13), 8
+	default:
+		return 14, uint16(v - 269), 16
+	}
+}
+
+func readCoAPNibble(r *Reader, nibble byte) (uint, error) {
+	switch nibble {
+	case 13:
+		v, err := r.Read8(8)
+		return uint(v) + 13, err
+	case 14:
+		v, err := r.Read16(16)
+		return uint(v) + 269, err
+	case 15:
+		return 0, ErrInvalidCoAP
+	default:
+		return uint(nibble), nil
+	}
+}
+
+// MQTTSNFlags is the flags byte of MQTT-SN messages.
+type MQTTSNFlags struct {
+	DUP          bool
+	QoS          byte // 2 bits, 3 is QoS -1
+	Retain       bool
+	Will         bool
+	CleanSession bool
+	TopicIDType  byte // 2 bits
+}
+
+func (w *Writer) WriteMQTTSNFlags(f MQTTSNFlags) {
+	w.WriteBool(f.DUP)
+	w.Write8(f.QoS, 2)
+	w.WriteBool(f.Retain)
+	w.WriteBool(f.Will)
+	w.WriteBool(f.CleanSession)
+	w.Write8(f.TopicIDType, 2)
+}
+
+func (r *Reader) ReadMQTTSNFlags() (MQTTSNFlags, error) {
+	var f MQTTSNFlags
+
+	rr := ReaderError{reader: *r}
+	f.DUP = rr.ReadBool()
+	f.QoS = rr.Read8(2)
+	f.Retain = rr.ReadBool()
+	f.Will = rr.ReadBool()
+	f.CleanSession = rr.ReadBool()
+	f.TopicIDType = rr.Read8(2)
+
+	if rr.err != nil {
+		return MQTTSNFlags{}, rr.err
+	}
+
+	*r = rr.reader
+
+	return f, nil
+}
+
+func (r *ReaderError) ReadCoAPHeader() (h CoAPHeader) {
+	if r.err == nil {
+		h, r.err = r.reader.ReadCoAPHeader()
+	}
+	return
+}
+
+func (r *ReaderError) ReadCoAPOptions() (opts []CoAPOption, payload []byte) {
+	if r.err == nil {
+		opts, payload, r.err = r.reader.ReadCoAPOptions()
+	}
+	return
+}
+
+func (r *ReaderError) ReadMQTTSNFlags() (f MQTTSNFlags) {
+	if r.err == nil {
+		f, r.err = r.reader.ReadMQTTSNFlags()
+	}
+	return
+}
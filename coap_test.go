@@ -0,0 +1,90 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestCoAP(t *testing.T) {
+	h := CoAPHeader{Type: CoAPConfirmable, Code: 0x01, MessageID: 0x1234, Token: []byte{0xAB}}
+	opts := []CoAPOption{
+		{Number: 11, Value: []byte("temperature")},
+		{Number: 60, Value: []byte{0x04}},
+		{Number: 2048, Value: bytes.Repeat([]byte{1}, 300)},
+	}
+	payload := []byte("hi")
+
+	w := NewWriterMSB()
+	if err := w.WriteCoAPHeader(h); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := w.WriteCoAPOptions(opts, payload); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	d := w.BitData()
+	if want := []byte{0x41, 0x01, 0x12, 0x34, 0xAB, 0xBB}; !bytes.Equal(want, d[:6]) {
+		t.Errorf("encoding mismatch: want=%x got=%x", want, d[:6])
+	}
+	if want := []byte{0xD1, 49 - 13, 0x04}; !bytes.Equal(want, d[17:20]) {
+		t.Errorf("extended delta mismatch: want=%x got=%x", want, d[17:20])
+	}
+	if want := []byte{0xEE, 0x06, 0xB7, 0x00, 0x1F}; !bytes.Equal(want, d[20:25]) {
+		t.Errorf("extended delta and length mismatch: want=%x got=%x", want, d[20:25])
+	}
+
+	r := NewReaderErrorMSB(d)
+	if got := r.ReadCoAPHeader(); !reflect.DeepEqual(h, got) {
+		t.Errorf("header mismatch: want=%+v got=%+v", h, got)
+	}
+	gotOpts, gotPayload := r.ReadCoAPOptions()
+	if !reflect.DeepEqual(opts, gotOpts) || !bytes.Equal(payload, gotPayload) {
+		t.Errorf("options mismatch: want=%v %q got=%v %q", opts, payload, gotOpts, gotPayload)
+	}
+	if err := r.Error(); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
+
+func TestCoAPInvalid(t *testing.T) {
+	tests := []BitData{
+		{0x81, 0x01, 0x12, 0x34, 0xAB}, // version 2
+		{0x49, 0x01, 0x12, 0x34},       // token length 9
+	}
+	for _, d := range tests {
+		if _, err := NewReaderMSB(d).ReadCoAPHeader(); !errors.Is(err, ErrInvalidCoAP) {
+			t.Errorf("want=%v for %x got=%v", ErrInvalidCoAP, d, err)
+		}
+	}
+
+	for _, d := range []BitData{{0xF1, 0x00}, {0x11, 0x00, 0xFF}} {
+		r := NewReaderMSB(d)
+		if _, _, err := r.ReadCoAPOptions(); !errors.Is(err, ErrInvalidCoAP) || r.BitsRead() != 0 {
+			t.Errorf("want=%v for %x got=%v at %d", ErrInvalidCoAP, d, err, r.BitsRead())
+		}
+	}
+
+	err := NewWriterMSB().WriteCoAPOptions([]CoAPOption{{Number: 5}, {Number: 4}}, nil)
+	if !errors.Is(err, ErrInvalidCoAP) {
+		t.Errorf("want=%v got=%v", ErrInvalidCoAP, err)
+	}
+}
+
+func TestMQTTSNFlags(t *testing.T) {
+	f := MQTTSNFlags{DUP: true, QoS: 1, Retain: true, CleanSession: true, TopicIDType: 2}
+
+	w := NewWriterMSB()
+	w.WriteMQTTSNFlags(f)
+	if want := []byte{0b1_01_1_0_1_10}; !bytes.Equal(want, w.BitData()) {
+		t.Errorf("encoding mismatch: want=%08b got=%08b", want, w.BitData())
+	}
+
+	got, err := NewReaderMSB(w.BitData()).ReadMQTTSNFlags()
+	if err != nil || got != f {
+		t.Errorf("flags mismatch: want=%+v got=%+v err=%v", f, got, err)
+	}
+}
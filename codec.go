@@ -0,0 +1,558 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+//go:build !bitdata_noreflect
+
+package bitdata
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/bits"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var (
+	bitMarshalerType   = reflect.TypeFor[BitMarshaler]()
+	bitUnmarshalerType = reflect.TypeFor[BitUnmarshaler]()
+)
+
+// Encode writes a struct, or a pointer to a struct, in the format described in Marshal.
+func (w *Writer) Encode(v any) error {
+	if m, ok := v.(BitMarshaler); ok {
+		return m.EncodeBits(w)
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return fmt.Errorf("%w: nil pointer", ErrUnsupportedType)
+		}
+		rv = rv.Elem()
+	}
+
+	c, err := structCodecOf(rv.Type())
+	if err != nil {
+		return err
+	}
+
+	return c.encode(w, rv)
+}
+
+// Decode reads a struct in the format described in Marshal into the struct pointed to by v.
+func (r *Reader) Decode(v any) error {
+	if err := r.enter(); err != nil {
+		return err
+	}
+	defer r.leave()
+
+	if u, ok := v.(BitUnmarshaler); ok {
+		return u.DecodeBits(r)
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return fmt.Errorf("%w: decoding requires a non-nil pointer", ErrUnsupportedType)
+	}
+	rv = rv.Elem()
+
+	c, err := structCodecOf(rv.Type())
+	if err != nil {
+		return err
+	}
+
+	return c.decode(r, rv)
+}
+
+// BitsOf returns the number of bits Encode writes for every value of the struct type T, or the number of bits
+// a struct field of the type T without a tag takes. It returns ErrUnsupportedType if the size varies, which is
+// the case for interfaces and types that implement BitMarshaler or BitUnmarshaler.
+func BitsOf[T any]() (uint, error) {
+	t := reflect.TypeFor[T]()
+
+	vc, err := compileValue(t, fieldTag{})
+	if err != nil {
+		return 0, err
+	}
+	if vc.bits < 0 {
+		return 0, fmt.Errorf("%w: %s has no fixed size", ErrUnsupportedType, t)
+	}
+
+	return uint(vc.bits), nil
+}
+
+type valueCodec struct {
+	encode func(w *Writer, v reflect.Value) error
+	decode func(r *Reader, v reflect.Value) error
+	bits   int // the size of every encoded value, or -1 if it varies
+}
+
+type fieldCodec struct {
+	index  int
+	name   string
+	since  uint
+	def    reflect.Value // the value of the field in older versions, zero if invalid
+	layout string
+	valueCodec
+}
+
+type structCodec struct {
+	fields []fieldCodec
+	bits   int
+}
+
+func (c *structCodec) encode(w *Writer, v reflect.Value) error {
+	for i := range c.fields {
+		f := &c.fields[i]
+		if err := f.encode(w, v.Field(f.index)); err != nil {
+			return fmt.Errorf("field %s: %w", f.name, err)
+		}
+	}
+	return nil
+}
+
+func (c *structCodec) decode(r *Reader, v reflect.Value) error {
+	for i := range c.fields {
+		f := &c.fields[i]
+		if err := f.decode(r, v.Field(f.index)); err != nil {
+			return fmt.Errorf("field %s: %w", f.name, err)
+		}
+	}
+	return nil
+}
+
+var structCodecs sync.Map // map[reflect.Type]*structCodec
+
+func structCodecOf(t reflect.Type) (*structCodec, error) {
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w: %s is not a struct", ErrUnsupportedType, t)
+	}
+
+	if c, ok := structCodecs.Load(t); ok {
+		return c.(*structCodec), nil
+	}
+
+	c, err := compileStruct(t)
+	if err != nil {
+		return nil, err
+	}
+
+	actual, _ := structCodecs.LoadOrStore(t, c)
+
+	return actual.(*structCodec), nil
+}
+
+func compileStruct(t reflect.Type) (*structCodec, error) {
+	c := &structCodec{}
+
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		tag, hasTag := sf.Tag.Lookup("bits")
+		if tag == "-" {
+			continue
+		}
+
+		var ft fieldTag
+		if hasTag {
+			var err error
+			if ft, err = parseFieldTag(tag); err != nil {
+				return nil, fmt.Errorf("%w: field %s: %q", ErrInvalidTag, sf.Name, tag)
+			}
+		}
+
+		vc, err := compileValue(sf.Type, ft)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", sf.Name, err)
+		}
+		if ft.setOrder {
+			vc = withBitOrder(vc, ft.order)
+		}
+
+		f := fieldCodec{index: i, name: sf.Name, since: ft.since, layout: layoutOf(sf.Type, ft), valueCodec: vc}
+		if n := len(c.fields); n > 0 && f.since < c.fields[n-1].since {
+			return nil, fmt.Errorf("%w: field %s: since %d after a field since %d", ErrInvalidTag, sf.Name, f.since, c.fields[n-1].since)
+		}
+		if ft.hasDef {
+			if f.def, err = parseDefault(sf.Type, ft.def); err != nil {
+				return nil, fmt.Errorf("%w: field %s: default %q", ErrInvalidTag, sf.Name, ft.def)
+			}
+		}
+
+		c.fields = append(c.fields, f)
+		if c.bits >= 0 && vc.bits >= 0 {
+			c.bits += vc.bits
+		} else {
+			c.bits = -1
+		}
+	}
+
+	return c, nil
+}
+
+// fieldTag is the parsed content of the "bits" struct tag.
+type fieldTag struct {
+	bits      byte
+	byteOrder binary.ByteOrder // nil for the natural byte order of the bit order
+	order     BitOrder
+	setOrder  bool
+	since     uint
+	def       string
+	hasDef    bool
+}
+
+func parseFieldTag(tag string) (fieldTag, error) {
+	var ft fieldTag
+
+	width, mods, hasMods := strings.Cut(tag, ",")
+	if width = strings.TrimSpace(width); width != "" || !hasMods {
+		n, err := strconv.ParseUint(width, 10, 8)
+		if err != nil || n == 0 {
+			return ft, ErrInvalidTag
+		}
+		ft.bits = byte(n)
+	}
+
+	if !hasMods {
+		return ft, nil
+	}
+
+	for _, m := range strings.Split(mods, ",") {
+		m = strings.TrimSpace(m)
+		key, val, _ := strings.Cut(m, "=")
+
+		switch {
+		case (m == "be" || m == "le") && ft.byteOrder == nil:
+			ft.byteOrder = binary.ByteOrder(binary.LittleEndian)
+			if m == "be" {
+				ft.byteOrder = binary.BigEndian
+			}
+		case (m == "msb" || m == "lsb") && !ft.setOrder:
+			ft.order, ft.setOrder = LSBFirst, true
+			if m == "msb" {
+				ft.order = MSBFirst
+			}
+		case key == "since" && ft.since == 0:
+			n, err := strconv.ParseUint(val, 10, 0)
+			if err != nil || n == 0 {
+				return ft, ErrInvalidTag
+			}
+			ft.since = uint(n)
+		case key == "default" && !ft.hasDef:
+			ft.def, ft.hasDef = val, true
+		default:
+			return ft, ErrInvalidTag
+		}
+	}
+
+	return ft, nil
+}
+
+// withBitOrder returns a codec that switches the stream to the bit order o for the value.
+func withBitOrder(vc valueCodec, o BitOrder) valueCodec {
+	return valueCodec{
+		bits: vc.bits,
+		encode: func(w *Writer, v reflect.Value) error {
+			if w.order == o {
+				return vc.encode(w, v)
+			}
+			if w.bitsWritten%8 != 0 {
+				return fmt.Errorf("%w: bit order change at bit offset %d", ErrUnaligned, w.bitsWritten)
+			}
+
+			prev := w.order
+			w.order = o
+			err := vc.encode(w, v)
+			w.order = prev
+
+			if err == nil && w.bitsWritten%8 != 0 {
+				err = fmt.Errorf("%w: bit order change at bit offset %d", ErrUnaligned, w.bitsWritten)
+			}
+			return err
+		},
+		decode: func(r *Reader, v reflect.Value) error {
+			if r.order == o {
+				return vc.decode(r, v)
+			}
+			if r.bitsRead%8 != 0 {
+				return fmt.Errorf("%w: bit order change at bit offset %d", ErrUnaligned, r.bitsRead)
+			}
+
+			prev := r.order
+			r.order, r.bufBits = o, 0
+			err := vc.decode(r, v)
+			r.order, r.bufBits = prev, 0
+
+			if err == nil && r.bitsRead%8 != 0 {
+				err = fmt.Errorf("%w: bit order change at bit offset %d", ErrUnaligned, r.bitsRead)
+			}
+			return err
+		},
+	}
+}
+
+// swapBytes returns the n low bits of v with their bytes reversed if the byte order bo isn't
+// the one the bit order o produces on its own.
+func swapBytes(v uint64, n byte, bo binary.ByteOrder, o BitOrder) uint64 {
+	if bo == nil || bo == byteOrder(o) {
+		return v
+	}
+	return bits.ReverseBytes64(v << (64 - n))
+}
+
+// compileValue returns the codec for a value of the type t. If the tag has no width, the full size of the type is used.
+// The methods of BitMarshaler and BitUnmarshaler take precedence over the encoding of the kind of the type.
+func compileValue(t reflect.Type, ft fieldTag) (valueCodec, error) {
+	enc := t.Implements(bitMarshalerType)
+	dec := reflect.PointerTo(t).Implements(bitUnmarshalerType)
+	if !enc && !dec {
+		return compileKind(t, ft)
+	}
+
+	if ft.bits != 0 || ft.byteOrder != nil {
+		return valueCodec{}, fmt.Errorf("%w: %s encodes itself", ErrInvalidTag, t)
+	}
+
+	vc := valueCodec{bits: -1}
+	if !enc || !dec {
+		var err error
+		if vc, err = compileKind(t, ft); err != nil {
+			return valueCodec{}, err
+		}
+		vc.bits = -1
+	}
+
+	if enc {
+		vc.encode = func(w *Writer, v reflect.Value) error {
+			return v.Interface().(BitMarshaler).EncodeBits(w)
+		}
+	}
+	if dec {
+		vc.decode = func(r *Reader, v reflect.Value) error {
+			return v.Addr().Interface().(BitUnmarshaler).DecodeBits(r)
+		}
+	}
+
+	return vc, nil
+}
+
+func compileKind(t reflect.Type, ft fieldTag) (valueCodec, error) {
+	bits, bo := ft.bits, ft.byteOrder
+
+	switch t.Kind() {
+	case reflect.Bool:
+		if bo != nil {
+			return valueCodec{}, fmt.Errorf("%w: bool has no byte order", ErrInvalidTag)
+		}
+		if bits > 1 {
+			return valueCodec{}, fmt.Errorf("%w: bool takes 1 bit", ErrInvalidTag)
+		}
+		return valueCodec{
+			bits: 1,
+			encode: func(w *Writer, v reflect.Value) error {
+				w.WriteBool(v.Bool())
+				return nil
+			},
+			decode: func(r *Reader, v reflect.Value) error {
+				b, err := r.ReadBool()
+				if err == nil {
+					v.SetBool(b)
+				}
+				return err
+			},
+		}, nil
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := fieldBits(t, bits, bo)
+		if err != nil {
+			return valueCodec{}, err
+		}
+		return valueCodec{
+			bits: int(n),
+			encode: func(w *Writer, v reflect.Value) error {
+				u := v.Uint()
+				if bo != nil {
+					if w.strict {
+						checkFits(u, n)
+					}
+					u = swapBytes(u, n, bo, w.order)
+				}
+				w.Write64(u, n)
+				return nil
+			},
+			decode: func(r *Reader, v reflect.Value) error {
+				u, err := r.Read64(n)
+				if err == nil {
+					v.SetUint(swapBytes(u, n, bo, r.order))
+				}
+				return err
+			},
+		}, nil
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := fieldBits(t, bits, bo)
+		if err != nil {
+			return valueCodec{}, err
+		}
+		return valueCodec{
+			bits: int(n),
+			encode: func(w *Writer, v reflect.Value) error {
+				x := v.Int()
+				if w.strict {
+					checkFitsSigned(x, n)
+				}
+				w.Write64(swapBytes(uint64(x)&mask[uint64](n), n, bo, w.order), n)
+				return nil
+			},
+			decode: func(r *Reader, v reflect.Value) error {
+				u, err := r.Read64(n)
+				if err == nil {
+					v.SetInt(signExtend(swapBytes(u, n, bo, r.order), n))
+				}
+				return err
+			},
+		}, nil
+
+	case reflect.Float32:
+		if bits != 0 && bits != 32 {
+			return valueCodec{}, fmt.Errorf("%w: float32 takes 32 bits", ErrInvalidTag)
+		}
+		return valueCodec{
+			bits: 32,
+			encode: func(w *Writer, v reflect.Value) error {
+				w.Write64(swapBytes(uint64(math.Float32bits(float32(v.Float()))), 32, bo, w.order), 32)
+				return nil
+			},
+			decode: func(r *Reader, v reflect.Value) error {
+				u, err := r.Read64(32)
+				if err == nil {
+					v.SetFloat(float64(math.Float32frombits(uint32(swapBytes(u, 32, bo, r.order)))))
+				}
+				return err
+			},
+		}, nil
+
+	case reflect.Float64:
+		if bits != 0 && bits != 64 {
+			return valueCodec{}, fmt.Errorf("%w: float64 takes 64 bits", ErrInvalidTag)
+		}
+		return valueCodec{
+			bits: 64,
+			encode: func(w *Writer, v reflect.Value) error {
+				w.Write64(swapBytes(math.Float64bits(v.Float()), 64, bo, w.order), 64)
+				return nil
+			},
+			decode: func(r *Reader, v reflect.Value) error {
+				u, err := r.Read64(64)
+				if err == nil {
+					v.SetFloat(math.Float64frombits(swapBytes(u, 64, bo, r.order)))
+				}
+				return err
+			},
+		}, nil
+
+	case reflect.Array:
+		elem, err := compileValue(t.Elem(), ft)
+		if err != nil {
+			return valueCodec{}, err
+		}
+		size := -1
+		if elem.bits >= 0 {
+			size = elem.bits * t.Len()
+		}
+		return valueCodec{
+			bits: size,
+			encode: func(w *Writer, v reflect.Value) error {
+				for i := 0; i < v.Len(); i++ {
+					if err := elem.encode(w, v.Index(i)); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+			decode: func(r *Reader, v reflect.Value) error {
+				for i := 0; i < v.Len(); i++ {
+					if err := elem.decode(r, v.Index(i)); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+		}, nil
+
+	case reflect.Interface:
+		if bits != 0 || bo != nil {
+			return valueCodec{}, fmt.Errorf("%w: interface fields can't have a bit width or a byte order", ErrInvalidTag)
+		}
+		return valueCodec{
+			bits: -1,
+			encode: func(w *Writer, v reflect.Value) error {
+				return DefaultRegistry.Encode(w, v.Interface())
+			},
+			decode: func(r *Reader, v reflect.Value) error {
+				x, err := DefaultRegistry.Decode(r)
+				if err != nil {
+					return err
+				}
+				if !reflect.TypeOf(x).AssignableTo(v.Type()) {
+					return fmt.Errorf("%w: %T isn't %s", ErrUnsupportedType, x, v.Type())
+				}
+				v.Set(reflect.ValueOf(x))
+				return nil
+			},
+		}, nil
+
+	case reflect.Struct:
+		if bits != 0 || bo != nil {
+			return valueCodec{}, fmt.Errorf("%w: struct fields can't have a bit width or a byte order", ErrInvalidTag)
+		}
+		c, err := structCodecOf(t)
+		if err != nil {
+			return valueCodec{}, err
+		}
+		return valueCodec{
+			bits:   c.bits,
+			encode: c.encode,
+			decode: func(r *Reader, v reflect.Value) error {
+				if err := r.enter(); err != nil {
+					return err
+				}
+				defer r.leave()
+				return c.decode(r, v)
+			},
+		}, nil
+	}
+
+	return valueCodec{}, fmt.Errorf("%w: %s", ErrUnsupportedType, t)
+}
+
+func fieldBits(t reflect.Type, bits byte, bo binary.ByteOrder) (byte, error) {
+	size := byte(t.Bits())
+	if bits == 0 {
+		bits = size
+	}
+	if bits > size {
+		return 0, fmt.Errorf("%w: %d bits don't fit into %s", ErrInvalidTag, bits, t)
+	}
+	if bo != nil && bits%8 != 0 {
+		return 0, fmt.Errorf("%w: byte order of %d bits", ErrInvalidTag, bits)
+	}
+	return bits, nil
+}
+
+// typeKey identifies the type of a value in a Registry.
+type typeKey = reflect.Type
+
+func typeKeyFor[T any]() typeKey {
+	return reflect.TypeFor[T]()
+}
+
+func typeKeyOf(v any) typeKey {
+	return reflect.TypeOf(v)
+}
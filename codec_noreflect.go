@@ -0,0 +1,73 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+//go:build bitdata_noreflect
+
+package bitdata
+
+import (
+	"fmt"
+)
+
+// Encode writes v with its EncodeBits method. Without reflection other values aren't supported.
+func (w *Writer) Encode(v any) error {
+	if m, ok := v.(BitMarshaler); ok {
+		return m.EncodeBits(w)
+	}
+	return fmt.Errorf("%w: %T doesn't implement BitMarshaler", ErrUnsupportedType, v)
+}
+
+// Decode reads into v with its DecodeBits method. Without reflection other values aren't supported.
+func (r *Reader) Decode(v any) error {
+	if err := r.enter(); err != nil {
+		return err
+	}
+	defer r.leave()
+
+	if u, ok := v.(BitUnmarshaler); ok {
+		return u.DecodeBits(r)
+	}
+	return fmt.Errorf("%w: %T doesn't implement BitUnmarshaler", ErrUnsupportedType, v)
+}
+
+// EncodeDelta needs reflection to compare the fields, so it always returns ErrUnsupportedType.
+func (w *Writer) EncodeDelta(prev, cur any) error {
+	return fmt.Errorf("%w: delta encoding requires reflection", ErrUnsupportedType)
+}
+
+// DecodeDelta needs reflection to update the fields, so it always returns ErrUnsupportedType.
+func (r *Reader) DecodeDelta(v any) error {
+	return fmt.Errorf("%w: delta encoding requires reflection", ErrUnsupportedType)
+}
+
+// EncodeVersion writes v with its EncodeBits method, like Encode.
+func (w *Writer) EncodeVersion(v any, version uint) error {
+	return w.Encode(v)
+}
+
+// DecodeVersion reads into v with its DecodeBits method, like Decode.
+func (r *Reader) DecodeVersion(v any, version uint) error {
+	return r.Decode(v)
+}
+
+// CheckCompatible needs reflection to compare the layouts, so it always returns ErrUnsupportedType.
+func CheckCompatible(older, newer any) error {
+	return fmt.Errorf("%w: layout checks require reflection", ErrUnsupportedType)
+}
+
+// BitsOf needs reflection to find the layout of T, so it always returns ErrUnsupportedType.
+func BitsOf[T any]() (uint, error) {
+	return 0, fmt.Errorf("%w: size calculation requires reflection", ErrUnsupportedType)
+}
+
+// typeKey identifies the type of a value in a Registry by its name as formatted by the %T verb.
+// The name doesn't include the import path, so the types of the same name in different packages
+// share a key; Registry tells them apart by their registrations.
+type typeKey = string
+
+func typeKeyFor[T any]() typeKey {
+	return fmt.Sprintf("%T", (*T)(nil))[1:]
+}
+
+func typeKeyOf(v any) typeKey {
+	return fmt.Sprintf("%T", v)
+}
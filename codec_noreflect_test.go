@@ -0,0 +1,128 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+//go:build bitdata_noreflect
+
+package bitdata
+
+import (
+	"errors"
+	"testing"
+)
+
+type noReflectPoint struct {
+	X, Y int16
+}
+
+func (p noReflectPoint) EncodeBits(w *Writer) error {
+	w.Write16(uint16(p.X), 12)
+	w.Write16(uint16(p.Y), 12)
+	return nil
+}
+
+func (p *noReflectPoint) DecodeBits(r *Reader) error {
+	rr := ReaderError{reader: *r}
+	p.X = int16(signExtend(uint64(rr.Read16(12)), 12))
+	p.Y = int16(signExtend(uint64(rr.Read16(12)), 12))
+	if rr.err != nil {
+		return rr.err
+	}
+	*r = rr.reader
+	return nil
+}
+
+func TestNoReflectCodec(t *testing.T) {
+	p := noReflectPoint{X: -5, Y: 1000}
+
+	data, err := Marshal(p)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var q noReflectPoint
+	if err := Unmarshal(data, &q); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if q != p {
+		t.Errorf("expected %+v, got %+v", p, q)
+	}
+
+	plain := struct{ A uint8 }{A: 1}
+	if _, err := Marshal(plain); !errors.Is(err, ErrUnsupportedType) {
+		t.Errorf("Marshal: expected ErrUnsupportedType, got %v", err)
+	}
+	if err := Unmarshal(data, &plain); !errors.Is(err, ErrUnsupportedType) {
+		t.Errorf("Unmarshal: expected ErrUnsupportedType, got %v", err)
+	}
+	if err := NewWriter().EncodeDelta(p, p); !errors.Is(err, ErrUnsupportedType) {
+		t.Errorf("EncodeDelta: expected ErrUnsupportedType, got %v", err)
+	}
+}
+
+func TestNoReflectRegistry(t *testing.T) {
+	reg := &Registry{}
+	if err := Register[noReflectPoint](reg, 3, nil, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := Register[noReflectPoint](reg, 4, nil, nil); !errors.Is(err, ErrDuplicateRegistration) {
+		t.Errorf("expected ErrDuplicateRegistration, got %v", err)
+	}
+
+	w := NewWriter()
+	if err := reg.Encode(w, noReflectPoint{X: 7, Y: -7}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := reg.Encode(w, 1); !errors.Is(err, ErrUnknownType) {
+		t.Errorf("expected ErrUnknownType, got %v", err)
+	}
+
+	v, err := reg.Decode(NewReader(w.BitData()))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p, ok := v.(noReflectPoint); !ok || p != (noReflectPoint{X: 7, Y: -7}) {
+		t.Errorf("unexpected value: %#v", v)
+	}
+}
+
+// registerSameName registers a type named sameName local to the function, different from the one
+// of the other call, and returns a value of it.
+func registerSameName(t *testing.T, reg *Registry, tag uint64, first bool) any {
+	if first {
+		type sameName uint8
+		if err := Register(reg, tag,
+			func(w *Writer, v sameName) error { w.Write8(uint8(v), 8); return nil },
+			func(r *Reader) (sameName, error) { v, err := r.Read8(8); return sameName(v), err }); err != nil {
+			t.Fatal(err)
+		}
+		return sameName(1)
+	}
+
+	type sameName uint16
+	if err := Register(reg, tag,
+		func(w *Writer, v sameName) error { w.Write16(uint16(v), 16); return nil },
+		func(r *Reader) (sameName, error) { v, err := r.Read16(16); return sameName(v), err }); err != nil {
+		t.Fatal(err)
+	}
+	return sameName(2)
+}
+
+func TestNoReflectRegistrySameName(t *testing.T) {
+	reg := &Registry{}
+	a := registerSameName(t, reg, 1, true)
+	b := registerSameName(t, &Registry{}, 2, false)
+
+	if err := reg.Encode(NewWriter(), b); !errors.Is(err, ErrUnknownType) {
+		t.Errorf("expected ErrUnknownType, got %v", err)
+	}
+
+	registerSameName(t, reg, 2, false)
+	for _, v := range []any{a, b} {
+		w := NewWriter()
+		if err := reg.Encode(w, v); err != nil {
+			t.Fatal(err)
+		}
+		if got, err := reg.Decode(NewReader(w.BitData())); err != nil || got != v {
+			t.Errorf("got %v, %v, want %v", got, err, v)
+		}
+	}
+}
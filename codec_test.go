@@ -0,0 +1,219 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+//go:build !bitdata_noreflect
+
+package bitdata
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+type codecPoint struct {
+	X int16 `bits:"10"`
+	Y int16 `bits:"10"`
+}
+
+type codecRecord struct {
+	Valid    bool
+	Kind     uint8  `bits:"3"`
+	Count    uint16 `bits:"12"`
+	Delta    int32  `bits:"7"`
+	Ratio    float32
+	Scale    float64
+	Pos      codecPoint
+	Levels   [3]uint8 `bits:"4"`
+	Ignored  string   `bits:"-"`
+	internal int
+}
+
+func TestMarshal(t *testing.T) {
+	in := codecRecord{
+		Valid:    true,
+		Kind:     5,
+		Count:    4000,
+		Delta:    -60,
+		Ratio:    0.25,
+		Scale:    -1e100,
+		Pos:      codecPoint{X: -512, Y: 511},
+		Levels:   [3]uint8{1, 15, 7},
+		Ignored:  "ignored",
+		internal: 42,
+	}
+
+	d, err := Marshal(&in)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+		return
+	}
+
+	if want, got := (1+3+12+7+32+64+20+12+7)/8, len(d); want != got {
+		t.Errorf("size mismatch: want=%d got=%d", want, got)
+	}
+
+	var out codecRecord
+	if err := Unmarshal(d, &out); err != nil {
+		t.Errorf("unexpected error: %s", err)
+		return
+	}
+
+	in.Ignored, in.internal = "", 0
+	if in != out {
+		t.Errorf("mismatch:\nwant=%+v\n got=%+v", in, out)
+	}
+}
+
+func TestMarshalTruncates(t *testing.T) {
+	type s struct {
+		A uint8 `bits:"3"`
+		B int8  `bits:"3"`
+	}
+
+	d, _ := Marshal(s{A: 0xFF, B: 5})
+
+	var out s
+	_ = Unmarshal(d, &out)
+
+	if want := (s{A: 7, B: -3}); want != out {
+		t.Errorf("want=%+v got=%+v", want, out)
+	}
+}
+
+func TestMarshalErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		v    any
+		err  error
+	}{
+		{name: "not-struct", v: 5, err: ErrUnsupportedType},
+		{name: "slice", v: struct{ A []int }{}, err: ErrUnsupportedType},
+		{name: "too-wide", v: struct {
+			A uint8 `bits:"9"`
+		}{}, err: ErrInvalidTag},
+		{name: "bad-tag", v: struct {
+			A uint8 `bits:"x"`
+		}{}, err: ErrInvalidTag},
+		{name: "float-width", v: struct {
+			A float32 `bits:"16"`
+		}{}, err: ErrInvalidTag},
+		{name: "bad-modifier", v: struct {
+			A uint16 `bits:"16,xe"`
+		}{}, err: ErrInvalidTag},
+		{name: "byte-order-width", v: struct {
+			A uint16 `bits:"12,be"`
+		}{}, err: ErrInvalidTag},
+		{name: "unaligned-bit-order", v: struct {
+			A bool
+			B uint8 `bits:",msb"`
+		}{}, err: ErrUnaligned},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := Marshal(test.v); !errors.Is(err, test.err) {
+				t.Errorf("want %v, got %v", test.err, err)
+			}
+		})
+	}
+
+	var out codecRecord
+	if err := Unmarshal(BitData{1, 2}, &out); err == nil {
+		t.Error("expected error")
+	}
+	if err := Unmarshal(BitData{}, out); !errors.Is(err, ErrUnsupportedType) {
+		t.Errorf("expected error, got %v", err)
+	}
+}
+
+func TestMarshalFieldOrder(t *testing.T) {
+	type packed struct {
+		Intel    uint16  `bits:"16,le"`
+		Motorola uint16  `bits:"16,be"`
+		Signed   int32   `bits:"24,be"`
+		Ratio    float32 `bits:",be"`
+		Nibbles  struct {
+			Hi uint8 `bits:"4"`
+			Lo uint8 `bits:"4"`
+		} `bits:",msb"`
+	}
+
+	in := packed{Intel: 0x1234, Motorola: 0x1234, Signed: -2, Ratio: 1}
+	in.Nibbles.Hi, in.Nibbles.Lo = 0xA, 0x5
+
+	want := BitData{0x34, 0x12, 0x12, 0x34, 0xFF, 0xFF, 0xFE, 0x3F, 0x80, 0x00, 0x00, 0xA5}
+
+	for _, o := range []BitOrder{LSBFirst, MSBFirst} {
+		w := NewWriter(WithBitOrder(o))
+		if err := w.Encode(&in); err != nil {
+			t.Fatalf("order %d: unexpected error: %v", o, err)
+		}
+		if got := w.BitData(); !bytes.Equal(got, want) {
+			t.Errorf("order %d: want=%x got=%x", o, want, got)
+		}
+
+		var out packed
+		if err := NewReader(w.BitData(), WithBitOrder(o)).Decode(&out); err != nil {
+			t.Fatalf("order %d: unexpected error: %v", o, err)
+		}
+		if in != out {
+			t.Errorf("order %d: mismatch:\nwant=%+v\n got=%+v", o, in, out)
+		}
+	}
+}
+
+func TestDecodeLimitsDepth(t *testing.T) {
+	type inner struct {
+		A uint8 `bits:"4"`
+	}
+	type middle struct {
+		In inner
+	}
+	type outer struct {
+		Mid middle
+	}
+
+	d := BitData{0x05}
+
+	var v outer
+	if err := NewReader(d, WithLimits(Limits{MaxDepth: 3})).Decode(&v); err != nil || v.Mid.In.A != 5 {
+		t.Errorf("unexpected %+v, %v", v, err)
+	}
+	if err := NewReader(d, WithLimits(Limits{MaxDepth: 2})).Decode(&v); !errors.Is(err, ErrLimitExceeded) {
+		t.Errorf("expected ErrLimitExceeded, got %v", err)
+	}
+}
+
+func TestBitsOf(t *testing.T) {
+	type fixed struct {
+		D struct {
+			E float32 `bits:",be"`
+		} `bits:",msb"`
+		A uint8 `bits:"3"`
+		B [2]int16
+		C bool
+		F uint32 `bits:"20,since=2"`
+	}
+
+	want := uint(3 + 32 + 1 + 32 + 20)
+	if got, err := BitsOf[fixed](); err != nil || got != want {
+		t.Errorf("want=%d got=%d, %v", want, got, err)
+	}
+
+	w := NewSizeCalculator()
+	if err := w.Encode(fixed{}); err != nil || w.BitsWritten() != want {
+		t.Errorf("encoded %d bits, %v", w.BitsWritten(), err)
+	}
+
+	if got, err := BitsOf[uint16](); err != nil || got != 16 {
+		t.Errorf("got %d, %v", got, err)
+	}
+
+	type variable struct {
+		A uint8
+		V any
+	}
+	if _, err := BitsOf[variable](); !errors.Is(err, ErrUnsupportedType) {
+		t.Errorf("expected ErrUnsupportedType, got %v", err)
+	}
+}
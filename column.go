@@ -0,0 +1,171 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"io"
+	"math/bits"
+)
+
+// WriteColumnSegment writes a column segment: a header with the number of values as a varint, the value
+// width in 7 bits and a flag telling if there is a validity bitmap, then the bitmap with a bit set
+// for every valid value, and then the values packed with the width. The width is the minimal one for
+// the largest valid value, and null values are written as zero. A nil valid means there are no nulls,
+// otherwise it must have the same length as values.
+func (w *Writer) WriteColumnSegment(values []uint64, valid []bool) {
+	if valid != nil && len(valid) != len(values) {
+		panic("bitdata: validity length mismatch")
+	}
+
+	var width byte
+	for i, v := range values {
+		if valid == nil || valid[i] {
+			width = max(width, byte(bits.Len64(v)))
+		}
+	}
+
+	w.WriteUvarint(uint64(len(values)))
+	w.Write8(width, 7)
+	w.WriteBool(valid != nil)
+
+	if valid != nil {
+		w.WriteBools(valid)
+	}
+
+	for i, v := range values {
+		if valid != nil && !valid[i] {
+			v = 0
+		}
+		w.Write64(v, width)
+	}
+}
+
+// ColumnSegment gives random access to the values of a column segment written with WriteColumnSegment.
+type ColumnSegment struct {
+	data      BitData
+	order     BitOrder
+	count     int
+	width     byte
+	nullable  bool
+	bitmapPos uint
+	valuesPos uint
+}
+
+// ReadColumnSegment reads the header of a column segment and returns the segment, leaving the reader after it.
+// The values aren't decoded; for a reader that isn't backed by memory, the segment content is copied.
+// On error the read position is left unchanged.
+func (r *Reader) ReadColumnSegment() (*ColumnSegment, error) {
+	start := r.bitsRead
+
+	rr := ReaderError{reader: *r}
+	n := rr.ReadUvarint()
+	width := rr.Read8(7)
+	nullable := rr.ReadBool()
+	if rr.err != nil {
+		r.bitsRead = start
+		return nil, rr.err
+	}
+	if width > 64 {
+		r.bitsRead = start
+		return nil, ErrBitCountTooBig
+	}
+
+	size := uint64(width)
+	if nullable {
+		size++
+	}
+	if n > uint64(maxInt) {
+		r.bitsRead = start
+		return nil, io.ErrUnexpectedEOF
+	}
+	if ok, err := rr.reader.ensureCount(n, size); !ok {
+		if err != nil {
+			r.bitsRead = start
+			return nil, err
+		}
+		*r = rr.reader
+		return &ColumnSegment{order: r.order}, nil
+	}
+
+	s := &ColumnSegment{
+		data:      rr.reader.data,
+		order:     r.order,
+		count:     int(n),
+		width:     width,
+		nullable:  nullable,
+		bitmapPos: rr.reader.bitsRead,
+	}
+	s.valuesPos = s.bitmapPos
+	if nullable {
+		s.valuesPos += uint(n)
+	}
+	end := s.valuesPos + uint(n)*uint(width)
+
+	if r.src != nil {
+		if err := r.charge(uint64(end-s.bitmapPos+7)/8, 1); err != nil {
+			r.bitsRead = start
+			return nil, err
+		}
+		w := &Writer{order: r.order}
+		if err := copyBits(w, &rr.reader, end-s.bitmapPos); err != nil {
+			r.bitsRead = start
+			return nil, err
+		}
+		s.data = w.BitData()
+		s.valuesPos -= s.bitmapPos
+		s.bitmapPos = 0
+	}
+
+	r.bitsRead = end
+
+	return s, nil
+}
+
+func (r *ReaderError) ReadColumnSegment() (s *ColumnSegment) {
+	if r.err == nil {
+		s, r.err = r.reader.ReadColumnSegment()
+	}
+	return
+}
+
+// Len returns the number of values in the segment.
+func (s *ColumnSegment) Len() int {
+	return s.count
+}
+
+// Width returns the width of the packed values in bits.
+func (s *ColumnSegment) Width() byte {
+	return s.width
+}
+
+// IsNull reports whether the i-th value is null. It panics if i is out of range.
+func (s *ColumnSegment) IsNull(i int) bool {
+	s.check(i)
+	if !s.nullable {
+		return false
+	}
+
+	v, _ := s.at(s.bitmapPos+uint(i), 1)
+	return v == 0
+}
+
+// Get returns the i-th value and false if it is null. It panics if i is out of range.
+func (s *ColumnSegment) Get(i int) (uint64, bool) {
+	if s.IsNull(i) {
+		return 0, false
+	}
+
+	v, _ := s.at(s.valuesPos+uint(i)*uint(s.width), s.width)
+	return v, true
+}
+
+func (s *ColumnSegment) check(i int) {
+	if i < 0 || i >= s.count {
+		panic("bitdata: column index out of range")
+	}
+}
+
+func (s *ColumnSegment) at(pos uint, bitCount byte) (uint64, error) {
+	r := Reader{data: s.data, bitsRead: pos, end: uint(len(s.data)) * 8, order: s.order}
+	return read[uint64](&r, bitCount)
+}
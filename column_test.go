@@ -0,0 +1,77 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+func TestColumnSegment(t *testing.T) {
+	values := []uint64{5, 0, 1000, 7, 999, 3}
+	valid := []bool{true, false, true, true, false, true}
+
+	for _, o := range []BitOrder{LSBFirst, MSBFirst} {
+		w := &Writer{order: o}
+		w.Write8(3, 2)
+		w.WriteColumnSegment(values, valid)
+		w.WriteColumnSegment(values, nil)
+		w.Write8(0x5A, 8)
+
+		if want := uint(2 + 2*16 + 6 + 2*6*10 + 8); w.BitsWritten() != want {
+			t.Errorf("order %d: size mismatch: want=%d got=%d", o, want, w.BitsWritten())
+		}
+
+		readers := map[string]*Reader{
+			"memory": {data: w.BitData(), end: w.BitsWritten(), order: o},
+			"source": NewReaderAt(bytes.NewReader(w.BitData()), int64(len(w.BitData()))),
+		}
+		readers["source"].order = o
+
+		for name, r := range readers {
+			r.Skip(2)
+
+			s, err := r.ReadColumnSegment()
+			if err != nil {
+				t.Fatalf("order %d %s: read failed: %v", o, name, err)
+			}
+			if s.Len() != len(values) || s.Width() != 10 {
+				t.Errorf("order %d %s: len=%d width=%d", o, name, s.Len(), s.Width())
+			}
+			for i := len(values) - 1; i >= 0; i-- {
+				v, ok := s.Get(i)
+				if ok != valid[i] || ok && v != values[i] {
+					t.Errorf("order %d %s: value %d: got %d,%t", o, name, i, v, ok)
+				}
+			}
+
+			s, err = r.ReadColumnSegment()
+			if err != nil {
+				t.Fatalf("order %d %s: read failed: %v", o, name, err)
+			}
+			for i := range values {
+				if v, ok := s.Get(i); !ok || v != values[i] {
+					t.Errorf("order %d %s: value %d: got %d,%t", o, name, i, v, ok)
+				}
+			}
+
+			if v, _ := r.Read8(8); v != 0x5A {
+				t.Errorf("order %d %s: reader isn't after the segment", o, name)
+			}
+		}
+	}
+}
+
+func TestColumnSegmentTruncated(t *testing.T) {
+	w := NewWriter()
+	w.WriteColumnSegment([]uint64{1, 2, 3, 4, 5, 6, 7, 8}, nil)
+
+	r := NewReader(w.BitData()[:2])
+	if _, err := r.ReadColumnSegment(); err != io.ErrUnexpectedEOF {
+		t.Errorf("expected io.ErrUnexpectedEOF, got %v", err)
+	}
+	if r.BitsRead() != 0 {
+		t.Errorf("position changed on error")
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+var ErrInvalidCompressed = errors.New("invalid compressed stream")
+
+// Compressor is a compression algorithm for FinishCompressed and NewCompressedReader.
+// Other algorithms, such as zstd, are plugged in by implementing it.
+type Compressor interface {
+	NewWriter(w io.Writer) (io.WriteCloser, error)
+	NewReader(r io.Reader) (io.ReadCloser, error)
+}
+
+var (
+	// Gzip compresses with compress/gzip at the default level.
+	Gzip Compressor = gzipCompressor{}
+
+	// Flate compresses with compress/flate at the default level.
+	Flate Compressor = flateCompressor{}
+)
+
+type gzipCompressor struct{}
+
+func (gzipCompressor) NewWriter(w io.Writer) (io.WriteCloser, error) { return gzip.NewWriter(w), nil }
+func (gzipCompressor) NewReader(r io.Reader) (io.ReadCloser, error)  { return gzip.NewReader(r) }
+
+type flateCompressor struct{}
+
+func (flateCompressor) NewWriter(w io.Writer) (io.WriteCloser, error) {
+	return flate.NewWriter(w, flate.DefaultCompression)
+}
+func (flateCompressor) NewReader(r io.Reader) (io.ReadCloser, error) { return flate.NewReader(r), nil }
+
+// FinishCompressed pads the stream with zero bits to a byte boundary and returns it compressed with c,
+// preceded by the original length in bits as an LEB128 varint, which NewCompressedReader restores.
+func (w *Writer) FinishCompressed(c Compressor) ([]byte, error) {
+	bitCount := w.bitsWritten
+	w.AlignToByte()
+
+	var buf bytes.Buffer
+	buf.Write(binary.AppendUvarint(nil, uint64(bitCount)))
+
+	zw, err := c.NewWriter(&buf)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := zw.Write(w.BitData()); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// NewCompressedReader decompresses data returned by FinishCompressed with c and returns a reader of
+// the original bits, without the padding. It returns ErrInvalidCompressed if the length doesn't match
+// the decompressed data.
+func NewCompressedReader(data []byte, c Compressor, opts ...Option) (*Reader, error) {
+	bitCount, n := binary.Uvarint(data)
+	if n <= 0 {
+		return nil, fmt.Errorf("%w: invalid length", ErrInvalidCompressed)
+	}
+
+	zr, err := c.NewReader(bytes.NewReader(data[n:]))
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+
+	// Reading at most one byte more than expected stops a decompression bomb early.
+	size := bitCount/8 + min(bitCount%8, 1)
+	d, err := io.ReadAll(io.LimitReader(zr, int64(min(size, math.MaxInt64-1))+1))
+	if err != nil {
+		return nil, err
+	}
+	if uint64(len(d)) != size {
+		return nil, fmt.Errorf("%w: %d bits in %d bytes", ErrInvalidCompressed, bitCount, len(d))
+	}
+
+	r := NewReader(d, opts...)
+	r.end = uint(bitCount)
+
+	return r, nil
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestFinishCompressed(t *testing.T) {
+	for _, c := range []Compressor{Gzip, Flate} {
+		w := NewWriter(WithBitOrder(MSBFirst))
+		for i := range 1000 {
+			w.Write16(uint16(i%10), 11)
+		}
+		w.Write8(5, 3)
+
+		data, err := w.FinishCompressed(c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(data) > 1000 {
+			t.Errorf("compressed to %d bytes", len(data))
+		}
+
+		r, err := NewCompressedReader(data, c, WithBitOrder(MSBFirst))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := range 1000 {
+			if v, err := r.Read16(11); err != nil || v != uint16(i%10) {
+				t.Fatalf("value %d: got %d, %v", i, v, err)
+			}
+		}
+		if v, err := r.Read8(3); err != nil || v != 5 {
+			t.Fatalf("got %d, %v", v, err)
+		}
+		if err := r.Finish(PaddingNone); err != nil {
+			t.Errorf("padding wasn't removed: %v", err)
+		}
+
+		data[1]++
+		if _, err := NewCompressedReader(data, c); !errors.Is(err, ErrInvalidCompressed) {
+			t.Errorf("expected ErrInvalidCompressed, got %v", err)
+		}
+	}
+}
+
+// endlessCompressor decompresses any data to an endless stream of zero bytes.
+type endlessCompressor struct{}
+
+func (endlessCompressor) NewWriter(w io.Writer) (io.WriteCloser, error) {
+	return nil, errors.ErrUnsupported
+}
+func (endlessCompressor) NewReader(io.Reader) (io.ReadCloser, error) {
+	return io.NopCloser(zeroReader{}), nil
+}
+
+type zeroReader struct{}
+
+func (zeroReader) Read(p []byte) (int, error) {
+	clear(p)
+	return len(p), nil
+}
+
+func TestCompressedReaderTooLong(t *testing.T) {
+	if _, err := NewCompressedReader([]byte{80}, endlessCompressor{}); !errors.Is(err, ErrInvalidCompressed) {
+		t.Errorf("expected ErrInvalidCompressed, got %v", err)
+	}
+}
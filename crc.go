@@ -0,0 +1,145 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"errors"
+)
+
+var ErrCRCMismatch = errors.New("crc mismatch")
+
+// CRC describes a cyclic redundancy check of up to 64 bits. Checksums are computed over bits
+// in stream order, so a reflected CRC over whole bytes matches the standard byte-oriented value.
+type CRC struct {
+	width     byte
+	poly      uint64
+	init      uint64
+	xorOut    uint64
+	reflected bool
+}
+
+var (
+	// CRC8 is CRC-8/MAXIM (Dallas 1-Wire).
+	CRC8 = NewCRC(8, 0x31, 0, 0, true)
+
+	// CRC16CCITT is the reflected CRC-16-CCITT, also known as CRC-16/KERMIT.
+	CRC16CCITT = NewCRC(16, 0x1021, 0, 0, true)
+
+	// CRC16MPEGAudio protects MPEG audio frames and ADTS headers. It matches CRC-16/CMS only for the bits
+	// fed in the MSBFirst stream order, as WriteCRC and VerifyCRC of MSBFirst streams do; Checksum over
+	// plain bytes takes their bits from the least significant one, so it needs d.ReverseByteBits() for that.
+	CRC16MPEGAudio = NewCRC(16, 0x8005, 0xFFFF, 0, false)
+
+	// CRC32 is the IEEE CRC-32, the same one computed by hash/crc32.ChecksumIEEE.
+	CRC32 = NewCRC(32, 0x04C11DB7, 0xFFFFFFFF, 0xFFFFFFFF, true)
+)
+
+// NewCRC returns a CRC with the given width, polynomial (in the normal, non-reversed notation),
+// initial register value and final XOR value. A reflected CRC shifts the register toward
+// the least significant bit, a non-reflected one toward the most significant bit.
+func NewCRC(width byte, poly, init, xorOut uint64, reflected bool) CRC {
+	if width == 0 || width > 64 {
+		panic("bitdata: invalid CRC width")
+	}
+
+	m := mask[uint64](width)
+	c := CRC{
+		width:     width,
+		poly:      poly & m,
+		init:      init & m,
+		xorOut:    xorOut & m,
+		reflected: reflected,
+	}
+
+	if reflected {
+		c.poly = reverseBits(c.poly, width)
+		c.init = reverseBits(c.init, width)
+	}
+
+	return c
+}
+
+func (c CRC) Width() byte {
+	return c.width
+}
+
+// Checksum computes the CRC over bitCount bits of the data starting at bit offset offsetBits.
+func (c CRC) Checksum(d BitData, offsetBits, bitCount uint) uint64 {
+	if offsetBits+bitCount > uint(len(d))*8 {
+		panic("bitdata: CRC range out of bounds")
+	}
+
+	reg := c.init
+	top := c.width - 1
+	m := mask[uint64](c.width)
+
+	for i := offsetBits; i < offsetBits+bitCount; i++ {
+		bit := uint64(d[i/8]>>(i%8)) & 1
+		if c.reflected {
+			if (reg^bit)&1 != 0 {
+				reg = reg>>1 ^ c.poly
+			} else {
+				reg >>= 1
+			}
+		} else {
+			if (reg>>top^bit)&1 != 0 {
+				reg = (reg<<1 ^ c.poly) & m
+			} else {
+				reg = (reg << 1) & m
+			}
+		}
+	}
+
+	return reg ^ c.xorOut
+}
+
+// WriteCRC writes the CRC of all bits written from the bit offset fromBit up to now.
+// The bits are taken in the stream order, also for writers in the MSBFirst order.
+func (w *Writer) WriteCRC(c CRC, fromBit uint) {
+	if fromBit > w.bitsWritten {
+		panic("bitdata: CRC range out of bounds")
+	}
+
+	sum := c.Checksum(lsbFirst(w.bytes(fromBit/8, w.size), w.order), fromBit%8, w.bitsWritten-fromBit)
+	w.Write64(sum, c.width)
+}
+
+// VerifyCRC reads a CRC and compares it with the CRC of all bits read from the bit offset fromBit
+// up to now. It returns ErrCRCMismatch if they differ.
+func (r *Reader) VerifyCRC(c CRC, fromBit uint) error {
+	if fromBit > r.bitsRead || r.bitsRead > r.end {
+		return ErrInvalidRange
+	}
+
+	d, err := r.bytes(fromBit/8, (r.bitsRead+7)/8)
+	if err != nil {
+		return err
+	}
+	sum := c.Checksum(lsbFirst(d, r.order), fromBit%8, r.bitsRead-fromBit)
+
+	v, err := r.Read64(c.width)
+	if err != nil {
+		return err
+	}
+
+	if v != sum {
+		return ErrCRCMismatch
+	}
+
+	return nil
+}
+
+func (r *ReaderError) VerifyCRC(c CRC, fromBit uint) {
+	if r.err == nil {
+		r.err = r.reader.VerifyCRC(c, fromBit)
+	}
+}
+
+func reverseBits(v uint64, n byte) uint64 {
+	var r uint64
+	for i := byte(0); i < n; i++ {
+		r = r<<1 | v&1
+		v >>= 1
+	}
+	return r
+}
@@ -0,0 +1,124 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"bytes"
+	"errors"
+	"hash/crc32"
+	"math/rand/v2"
+	"testing"
+)
+
+func TestCRCCheckValues(t *testing.T) {
+	check := BitData("123456789")
+
+	tests := []struct {
+		name string
+		crc  CRC
+		exp  uint64
+	}{
+		{name: "crc8", crc: CRC8, exp: 0xA1},
+		{name: "crc16-ccitt", crc: CRC16CCITT, exp: 0x2189},
+		{name: "crc32", crc: CRC32, exp: 0xCBF43926},
+		{name: "crc16-xmodem", crc: NewCRC(16, 0x1021, 0, 0, false), exp: 0x31C3},
+		{name: "crc16-x25", crc: NewCRC(16, 0x1021, 0xFFFF, 0xFFFF, true), exp: 0x906E},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			d := check
+			if !test.crc.reflected {
+				// non-reflected CRCs expect bytes MSB-first
+				d = make(BitData, len(check))
+				for i, b := range check {
+					d[i] = byte(reverseBits(uint64(b), 8))
+				}
+			}
+			if got := test.crc.Checksum(d, 0, uint(len(d))*8); got != test.exp {
+				t.Errorf("want=%#x got=%#x", test.exp, got)
+			}
+		})
+	}
+}
+
+func TestCRC32MatchesHash(t *testing.T) {
+	d := make(BitData, 100)
+	for i := range d {
+		d[i] = byte(rand.Uint32())
+	}
+
+	for _, n := range []int{0, 1, 7, 64, 100} {
+		if want, got := uint64(crc32.ChecksumIEEE(d[:n])), CRC32.Checksum(d, 0, uint(n)*8); want != got {
+			t.Errorf("len=%d: want=%#x got=%#x", n, want, got)
+		}
+	}
+}
+
+func TestCRCUnaligned(t *testing.T) {
+	payload, bitCount, _ := ParseBits("1011 0011 1110 0001 0101 1")
+
+	for ofs := byte(0); ofs < 16; ofs++ {
+		w := NewWriter()
+		w.Write16(0xFFFF, ofs)
+		for i := uint(0); i < bitCount; i++ {
+			w.WriteBool(payload[i/8]>>(i%8)&1 == 1)
+		}
+		w.Write8(0xFF, 3)
+
+		for _, c := range []CRC{CRC8, CRC16CCITT, CRC32} {
+			if want, got := c.Checksum(payload, 0, bitCount), c.Checksum(w.BitData(), uint(ofs), bitCount); want != got {
+				t.Errorf("offset=%d width=%d: want=%#x got=%#x", ofs, c.Width(), want, got)
+			}
+		}
+	}
+}
+
+func TestWriteVerifyCRC(t *testing.T) {
+	w := NewWriter()
+	w.Write8(0b101, 3)
+	w.Write32(0xDEADBEEF, 32)
+	w.Write8(0b11, 2)
+	w.WriteCRC(CRC16CCITT, 3)
+	w.WriteBool(true)
+
+	r := NewReader(w.BitData())
+	r.Skip(3 + 32 + 2)
+	if err := r.VerifyCRC(CRC16CCITT, 3); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	d := w.BitData()
+	d[1] ^= 0x10 // flip one payload bit
+
+	r = NewReader(d)
+	r.Skip(3 + 32 + 2)
+	if err := r.VerifyCRC(CRC16CCITT, 3); !errors.Is(err, ErrCRCMismatch) {
+		t.Errorf("expected mismatch, got %v", err)
+	}
+
+	re := NewReaderError(d)
+	re.Skip(3 + 32 + 2)
+	re.VerifyCRC(CRC16CCITT, 3)
+	if !errors.Is(re.Error(), ErrCRCMismatch) {
+		t.Errorf("expected mismatch, got %v", re.Error())
+	}
+}
+
+func TestCRCMSBFirst(t *testing.T) {
+	w := NewWriterMSB()
+	for _, b := range []byte("123456789") {
+		w.Write8(b, 8)
+	}
+	w.WriteCRC(CRC16MPEGAudio, 0)
+
+	if want := []byte{0xAE, 0xE7}; !bytes.Equal(want, w.BitData()[9:]) {
+		t.Errorf("CRC mismatch: want=%x got=%x", want, w.BitData()[9:])
+	}
+
+	r := NewReaderMSB(w.BitData())
+	r.Skip(72)
+	if err := r.VerifyCRC(CRC16MPEGAudio, 0); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
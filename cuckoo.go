@@ -0,0 +1,270 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"errors"
+	"math/bits"
+)
+
+var ErrInvalidCuckoo = errors.New("invalid cuckoo filter")
+
+const (
+	cuckooBucketSize = 4
+	cuckooMaxKicks   = 500
+)
+
+// CuckooFilter is a probabilistic set of 64-bit hashes that, unlike a Bloom filter, supports deletion.
+// It stores a fingerprint of FingerprintBits bits of every hash in one of two buckets of four slots,
+// packed without padding. The false positive rate is about 8/2^FingerprintBits.
+type CuckooFilter struct {
+	data    BitData
+	buckets uint
+	fpBits  byte
+	count   int
+
+	// victim holds the fingerprint that didn't fit after the last failed insertion.
+	victim      uint64
+	victimIndex uint
+	hasVictim   bool
+
+	rnd uint64
+}
+
+// NewCuckooFilter returns an empty cuckoo filter for about capacity hashes with fingerprints
+// of the given width. It panics if fingerprintBits isn't between 1 and 32.
+func NewCuckooFilter(capacity int, fingerprintBits byte) *CuckooFilter {
+	if fingerprintBits < 1 || fingerprintBits > 32 {
+		panic("bitdata: invalid cuckoo fingerprint width")
+	}
+
+	n := uint(max(capacity, 1)+cuckooBucketSize-1) / cuckooBucketSize
+	buckets := uint(1) << bits.Len(n*20/19)
+
+	return newCuckooFilter(buckets, fingerprintBits)
+}
+
+func newCuckooFilter(buckets uint, fpBits byte) *CuckooFilter {
+	return &CuckooFilter{
+		data:    make(BitData, (buckets*cuckooBucketSize*uint(fpBits)+7)/8),
+		buckets: buckets,
+		fpBits:  fpBits,
+		rnd:     0x9E3779B97F4A7C15,
+	}
+}
+
+// Len returns the number of hashes in the filter.
+func (f *CuckooFilter) Len() int {
+	return f.count
+}
+
+// Insert adds the hash to the filter. The insertion that fills the filter still succeeds by keeping
+// the last displaced fingerprint aside. Once the filter is full, Insert returns false and the hash
+// is not stored, so Contains may not find it; insertions fail until a hash is deleted.
+func (f *CuckooFilter) Insert(hash uint64) bool {
+	if f.hasVictim {
+		return false
+	}
+
+	fp, i1, i2 := f.locate(hash)
+	if f.insertAt(i1, fp) || f.insertAt(i2, fp) {
+		f.count++
+		return true
+	}
+
+	i := i1
+	if f.next()&1 == 1 {
+		i = i2
+	}
+	for range cuckooMaxKicks {
+		s := uint(f.next() % cuckooBucketSize)
+		fp = f.swap(i, s, fp)
+		i = f.alt(i, fp)
+		if f.insertAt(i, fp) {
+			f.count++
+			return true
+		}
+	}
+
+	f.victim, f.victimIndex, f.hasVictim = fp, i, true
+	f.count++
+
+	return true
+}
+
+// Contains reports whether the hash may be in the filter.
+func (f *CuckooFilter) Contains(hash uint64) bool {
+	fp, i1, i2 := f.locate(hash)
+	if f.hasVictim && f.victim == fp && (f.victimIndex == i1 || f.victimIndex == i2) {
+		return true
+	}
+	return f.find(i1, fp) >= 0 || f.find(i2, fp) >= 0
+}
+
+// Delete removes the hash from the filter and reports whether it was found. Only hashes that were
+// inserted may be deleted; deleting others can remove a hash with the same fingerprint.
+func (f *CuckooFilter) Delete(hash uint64) bool {
+	fp, i1, i2 := f.locate(hash)
+
+	switch {
+	case f.hasVictim && f.victim == fp && (f.victimIndex == i1 || f.victimIndex == i2):
+		f.hasVictim = false
+	case f.find(i1, fp) >= 0:
+		f.set(i1, uint(f.find(i1, fp)), 0)
+	case f.find(i2, fp) >= 0:
+		f.set(i2, uint(f.find(i2, fp)), 0)
+	default:
+		return false
+	}
+	f.count--
+
+	if f.hasVictim && f.insertAt(f.victimIndex, f.victim) {
+		f.hasVictim = false
+	}
+
+	return true
+}
+
+// locate returns the fingerprint of the hash, which is never zero, and its two bucket indexes.
+func (f *CuckooFilter) locate(hash uint64) (fp uint64, i1, i2 uint) {
+	fp = hash >> 32 & mask[uint64](f.fpBits)
+	if fp == 0 {
+		fp = 1
+	}
+	i1 = uint(hash) & (f.buckets - 1)
+	return fp, i1, f.alt(i1, fp)
+}
+
+// alt returns the other bucket of the fingerprint stored in the bucket i.
+func (f *CuckooFilter) alt(i uint, fp uint64) uint {
+	return (i ^ uint(fp*0x5BD1E995)) & (f.buckets - 1)
+}
+
+func (f *CuckooFilter) get(i, s uint) uint64 {
+	return getBits(f.data, (i*cuckooBucketSize+s)*uint(f.fpBits), f.fpBits)
+}
+
+func (f *CuckooFilter) set(i, s uint, fp uint64) {
+	putBits(f.data, (i*cuckooBucketSize+s)*uint(f.fpBits), fp, f.fpBits)
+}
+
+// swap stores the fingerprint in the slot s of the bucket i and returns the fingerprint it replaced.
+func (f *CuckooFilter) swap(i, s uint, fp uint64) uint64 {
+	old := f.get(i, s)
+	f.set(i, s, fp)
+	return old
+}
+
+func (f *CuckooFilter) find(i uint, fp uint64) int {
+	for s := range uint(cuckooBucketSize) {
+		if f.get(i, s) == fp {
+			return int(s)
+		}
+	}
+	return -1
+}
+
+func (f *CuckooFilter) insertAt(i uint, fp uint64) bool {
+	s := f.find(i, 0)
+	if s < 0 {
+		return false
+	}
+	f.set(i, uint(s), fp)
+	return true
+}
+
+// next returns the next value of the xorshift generator choosing the fingerprints to evict.
+func (f *CuckooFilter) next() uint64 {
+	f.rnd ^= f.rnd << 13
+	f.rnd ^= f.rnd >> 7
+	f.rnd ^= f.rnd << 17
+	return f.rnd
+}
+
+// WriteCuckooFilter writes the filter: the fingerprint width in 6 bits, the base-2 logarithm of the number
+// of buckets in 6 bits, the number of hashes as an LEB128 varint, a bit telling whether a victim follows,
+// the victim's bucket index and fingerprint, and the packed buckets.
+func (w *Writer) WriteCuckooFilter(f *CuckooFilter) {
+	w.Write8(f.fpBits-1, 6)
+	w.Write8(byte(bits.TrailingZeros(f.buckets)), 6)
+	w.WriteUvarint(uint64(f.count))
+	w.WriteBool(f.hasVictim)
+	if f.hasVictim {
+		w.WriteUvarint(uint64(f.victimIndex))
+		w.Write64(f.victim, f.fpBits)
+	}
+	w.WriteBitData(f.data, f.buckets*cuckooBucketSize*uint(f.fpBits))
+}
+
+// ReadCuckooFilter reads a filter written with WriteCuckooFilter. It returns ErrInvalidCuckoo if the header
+// is inconsistent. On error the read position is left unchanged.
+func (r *Reader) ReadCuckooFilter() (*CuckooFilter, error) {
+	start := r.bitsRead
+
+	rr := ReaderError{reader: *r}
+	f, err := readCuckooFilter(&rr)
+	if rr.err != nil {
+		err = rr.err
+	}
+	if err != nil {
+		r.bitsRead = start
+		return nil, err
+	}
+
+	*r = rr.reader
+
+	return f, nil
+}
+
+func readCuckooFilter(r *ReaderError) (*CuckooFilter, error) {
+	fpBits := r.Read8(6) + 1
+	logBuckets := r.Read8(6)
+	count := r.ReadUvarint()
+	hasVictim := r.ReadBool()
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	if fpBits > 32 {
+		return nil, ErrInvalidCuckoo
+	}
+
+	buckets := uint(1) << logBuckets
+	size := buckets * cuckooBucketSize * uint(fpBits)
+	if logBuckets > 40 || size > r.reader.end-min(r.reader.bitsRead, r.reader.end) {
+		return nil, ErrInvalidCuckoo
+	}
+
+	f := newCuckooFilter(buckets, fpBits)
+	if count > uint64(buckets)*cuckooBucketSize+1 {
+		return nil, ErrInvalidCuckoo
+	}
+	f.count = int(count)
+
+	if hasVictim {
+		f.hasVictim = true
+		f.victimIndex = uint(r.ReadUvarint())
+		f.victim = r.Read64(fpBits)
+		if f.victimIndex >= buckets || f.victim == 0 {
+			return nil, ErrInvalidCuckoo
+		}
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	w := &Writer{order: r.reader.order}
+	if err := copyBits(w, &r.reader, size); err != nil {
+		return nil, err
+	}
+	copy(f.data, w.BitData())
+
+	return f, nil
+}
+
+func (r *ReaderError) ReadCuckooFilter() (f *CuckooFilter) {
+	if r.err == nil {
+		f, r.err = r.reader.ReadCuckooFilter()
+	}
+	return
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+func TestCuckooFilter(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	hashes := make([]uint64, 1000)
+	for i := range hashes {
+		hashes[i] = rnd.Uint64()
+	}
+
+	f := NewCuckooFilter(len(hashes), 12)
+	for _, h := range hashes {
+		if !f.Insert(h) {
+			t.Fatal("filter full")
+		}
+	}
+	if f.Len() != len(hashes) {
+		t.Errorf("got length %d", f.Len())
+	}
+	for _, h := range hashes {
+		if !f.Contains(h) {
+			t.Fatalf("hash %x not found", h)
+		}
+	}
+
+	falsePositives := 0
+	for range 10000 {
+		if f.Contains(rnd.Uint64()) {
+			falsePositives++
+		}
+	}
+	if falsePositives > 50 {
+		t.Errorf("%d false positives", falsePositives)
+	}
+
+	for _, order := range []BitOrder{LSBFirst, MSBFirst} {
+		w := NewWriter(WithBitOrder(order))
+		w.Write8(3, 3)
+		w.WriteCuckooFilter(f)
+
+		r := NewReader(w.BitData(), WithBitOrder(order))
+		r.Skip(3)
+		g, err := r.ReadCuckooFilter()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if r.bitsRead != w.bitsWritten || g.Len() != f.Len() {
+			t.Fatalf("%v: read %d of %d bits, length %d", order, r.bitsRead, w.bitsWritten, g.Len())
+		}
+		for _, h := range hashes {
+			if !g.Contains(h) {
+				t.Fatalf("%v: hash %x not found after reading", order, h)
+			}
+		}
+	}
+
+	for _, h := range hashes[:500] {
+		if !f.Delete(h) {
+			t.Fatalf("hash %x not deleted", h)
+		}
+	}
+	for _, h := range hashes[500:] {
+		if !f.Contains(h) {
+			t.Fatalf("hash %x lost by deletions", h)
+		}
+	}
+	if f.Len() != 500 {
+		t.Errorf("got length %d", f.Len())
+	}
+}
+
+func TestCuckooFilterFull(t *testing.T) {
+	f := NewCuckooFilter(8, 8)
+	n := 0
+	for h := uint64(1); f.Insert(h * 0x9E3779B97F4A7C15); h++ {
+		n++
+	}
+	if n < 8 || n != f.Len() {
+		t.Errorf("inserted %d, length %d", n, f.Len())
+	}
+	for h := uint64(1); h <= uint64(n); h++ {
+		if !f.Contains(h * 0x9E3779B97F4A7C15) {
+			t.Fatalf("hash %d not found in a full filter", h)
+		}
+	}
+
+	w := NewWriter()
+	w.WriteCuckooFilter(f)
+	d := w.BitData()
+	if _, err := NewReader(d[:len(d)-1]).ReadCuckooFilter(); err == nil {
+		t.Error("expected an error for truncated data")
+	}
+	d[0] |= 0x3F
+	if _, err := NewReader(d).ReadCuckooFilter(); !errors.Is(err, ErrInvalidCuckoo) {
+		t.Errorf("expected ErrInvalidCuckoo, got %v", err)
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"errors"
+	"math/bits"
+)
+
+// Helpers for the bit packing conventions of DEFLATE (RFC 1951). DEFLATE packs data elements
+// LSB-first, like this package, but Huffman codes are packed starting with their most significant bit.
+
+var ErrStoredLength = errors.New("stored block length mismatch")
+
+const maxStoredBlock = 1<<16 - 1
+
+// ReverseCode returns the lowest length bits of code in reverse order.
+func ReverseCode(code uint32, length byte) uint32 {
+	if length == 0 {
+		return 0
+	}
+	return bits.Reverse32(code) >> (32 - length)
+}
+
+// WriteCode writes a Huffman code of the given length, starting with its most significant bit.
+func (w *Writer) WriteCode(code uint32, length byte) {
+	w.Write32(ReverseCode(code, length), length)
+}
+
+// ReadCode reads a Huffman code of the given length written with WriteCode.
+func (r *Reader) ReadCode(length byte) (uint32, error) {
+	v, err := r.Read32(length)
+	if err != nil {
+		return 0, err
+	}
+	return ReverseCode(v, length), nil
+}
+
+func (r *ReaderError) ReadCode(length byte) (v uint32) {
+	if r.err == nil {
+		v, r.err = r.reader.ReadCode(length)
+	}
+	return
+}
+
+// AlignToByte writes zero bits up to the next byte boundary.
+func (w *Writer) AlignToByte() {
+	if ofs := w.bitsWritten % 8; ofs > 0 {
+		w.Write8(0, byte(8-ofs))
+	}
+}
+
+// AlignToByte skips the bits up to the next byte boundary.
+func (r *Reader) AlignToByte() {
+	if ofs := r.bitsRead % 8; ofs > 0 {
+		r.Skip(8 - ofs)
+	}
+}
+
+func (r *ReaderError) AlignToByte() {
+	r.reader.AlignToByte()
+}
+
+// WriteStoredBlock writes a DEFLATE stored block: the BFINAL bit, the block type 00, padding to the byte
+// boundary, the length and its complement, and the bytes of p. The p can have up to 65535 bytes.
+func (w *Writer) WriteStoredBlock(final bool, p []byte) error {
+	if len(p) > maxStoredBlock {
+		return ErrLengthOverflow
+	}
+
+	w.WriteBool(final)
+	w.Write8(0, 2)
+	w.AlignToByte()
+	w.Write16(uint16(len(p)), 16)
+	w.Write16(^uint16(len(p)), 16)
+	w.WriteBitData(p, uint(len(p))*8)
+
+	return nil
+}
+
+// ReadStoredBlock reads the content of a DEFLATE stored block whose BFINAL bit and block type were
+// already read: it skips the padding, checks the length against its complement and returns the bytes.
+func (r *Reader) ReadStoredBlock() ([]byte, error) {
+	start := r.bitsRead
+	r.AlignToByte()
+
+	n, err := r.Read16(16)
+	if err != nil {
+		r.bitsRead = start
+		return nil, err
+	}
+
+	nn, err := r.Read16(16)
+	if err != nil {
+		r.bitsRead = start
+		return nil, err
+	}
+	if n != ^nn {
+		r.bitsRead = start
+		return nil, ErrStoredLength
+	}
+
+	p := make([]byte, n)
+	if ok, err := r.ensure(uint(n) * 8); !ok {
+		if err != nil {
+			r.bitsRead = start
+			return nil, err
+		}
+		return p, nil
+	}
+
+	if _, err := r.Read(p); err != nil && n > 0 {
+		r.bitsRead = start
+		return nil, err
+	}
+
+	return p, nil
+}
+
+func (r *ReaderError) ReadStoredBlock() (p []byte) {
+	if r.err == nil {
+		p, r.err = r.reader.ReadStoredBlock()
+	}
+	return
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"bytes"
+	"compress/flate"
+	"io"
+	"testing"
+)
+
+func TestReverseCode(t *testing.T) {
+	tests := []struct {
+		code   uint32
+		length byte
+		exp    uint32
+	}{
+		{code: 0, length: 0, exp: 0},
+		{code: 0b1, length: 1, exp: 0b1},
+		{code: 0b110, length: 3, exp: 0b011},
+		{code: 0b0011_0000, length: 8, exp: 0b0000_1100},
+		{code: 0b1_0000_0000_0000_01, length: 15, exp: 0b1_0000_0000_0000_01},
+	}
+
+	for _, test := range tests {
+		if got := ReverseCode(test.code, test.length); got != test.exp {
+			t.Errorf("ReverseCode(%b, %d): want=%b got=%b", test.code, test.length, test.exp, got)
+		}
+	}
+
+	w := NewWriter()
+	w.WriteCode(0b110, 3) // stream order: 1, 1, 0
+	w.WriteCode(0b0111, 4)
+	if want, got := "1100 111", w.BitData().FormatBinary(w.BitsWritten(), 4); want != got {
+		t.Errorf("want %q, got %q", want, got)
+	}
+
+	r := NewReaderError(w.BitData())
+	if a, b := r.ReadCode(3), r.ReadCode(4); a != 0b110 || b != 0b0111 {
+		t.Errorf("read mismatch: %b %b", a, b)
+	}
+}
+
+func TestStoredBlock(t *testing.T) {
+	payload := []byte("stored, not compressed")
+
+	w := NewWriter()
+	if err := w.WriteStoredBlock(true, payload); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	got, err := io.ReadAll(flate.NewReader(bytes.NewReader(w.BitData())))
+	if err != nil || !bytes.Equal(payload, got) {
+		t.Errorf("flate decoding: got=%q err=%v", got, err)
+	}
+
+	if err := w.WriteStoredBlock(false, make([]byte, 1<<16)); err != ErrLengthOverflow {
+		t.Errorf("expected ErrLengthOverflow, got %v", err)
+	}
+
+	var buf bytes.Buffer
+	fw, _ := flate.NewWriter(&buf, flate.NoCompression)
+	_, _ = fw.Write(payload)
+	_ = fw.Close()
+
+	r := NewReaderError(buf.Bytes())
+	var decoded []byte
+	for {
+		final := r.ReadBool()
+		if typ := r.Read8(2); typ != 0 {
+			break // the closing empty block uses fixed Huffman codes
+		}
+		decoded = append(decoded, r.ReadStoredBlock()...)
+		if final || r.Error() != nil {
+			break
+		}
+	}
+	if err := r.Error(); err != nil || !bytes.Equal(payload, decoded) {
+		t.Errorf("stored block decoding: got=%q err=%v", decoded, err)
+	}
+
+	bad := NewWriter()
+	bad.Write8(0, 3)
+	bad.AlignToByte()
+	bad.Write16(5, 16)
+	bad.Write16(5, 16)
+	rr := NewReader(bad.BitData())
+	rr.Skip(3)
+	if _, err := rr.ReadStoredBlock(); err != ErrStoredLength || rr.BitsRead() != 3 {
+		t.Errorf("expected ErrStoredLength at 3, got %v at %d", err, rr.BitsRead())
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+//go:build !bitdata_noreflect
+
+package bitdata
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// EncodeDelta writes the difference between two values of the same struct type, as described in Marshal.
+// It writes a change mask, one bit per encoded top-level field, followed by the fields of cur
+// that differ from the fields of prev.
+func (w *Writer) EncodeDelta(prev, cur any) error {
+	pv, cv := reflect.Indirect(reflect.ValueOf(prev)), reflect.Indirect(reflect.ValueOf(cur))
+	if !pv.IsValid() || !cv.IsValid() || pv.Type() != cv.Type() {
+		return fmt.Errorf("%w: delta requires two values of the same struct type", ErrUnsupportedType)
+	}
+
+	c, err := structCodecOf(cv.Type())
+	if err != nil {
+		return err
+	}
+
+	changed := make([]bool, len(c.fields))
+	for i := range c.fields {
+		idx := c.fields[i].index
+		changed[i] = !pv.Field(idx).Equal(cv.Field(idx))
+		w.WriteBool(changed[i])
+	}
+
+	for i := range c.fields {
+		if !changed[i] {
+			continue
+		}
+
+		f := &c.fields[i]
+		if err := f.encode(w, cv.Field(f.index)); err != nil {
+			return fmt.Errorf("field %s: %w", f.name, err)
+		}
+	}
+
+	return nil
+}
+
+// DecodeDelta reads a delta written by EncodeDelta and applies it to the struct pointed to by v,
+// which must hold the same value that was used as prev when encoding.
+func (r *Reader) DecodeDelta(v any) error {
+	if err := r.enter(); err != nil {
+		return err
+	}
+	defer r.leave()
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return fmt.Errorf("%w: decoding requires a non-nil pointer", ErrUnsupportedType)
+	}
+	rv = rv.Elem()
+
+	c, err := structCodecOf(rv.Type())
+	if err != nil {
+		return err
+	}
+
+	changed := make([]bool, len(c.fields))
+	for i := range changed {
+		if changed[i], err = r.ReadBool(); err != nil {
+			return err
+		}
+	}
+
+	// Decode into a copy so that a failed decode leaves the baseline intact.
+	tmp := reflect.New(rv.Type()).Elem()
+	tmp.Set(rv)
+
+	for i := range c.fields {
+		if !changed[i] {
+			continue
+		}
+
+		f := &c.fields[i]
+		if err := f.decode(r, tmp.Field(f.index)); err != nil {
+			return fmt.Errorf("field %s: %w", f.name, err)
+		}
+	}
+
+	rv.Set(tmp)
+
+	return nil
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+//go:build !bitdata_noreflect
+
+package bitdata
+
+import (
+	"errors"
+	"testing"
+)
+
+type deltaEntity struct {
+	ID     uint16 `bits:"10"`
+	Pos    codecPoint
+	Health uint8 `bits:"7"`
+	Alive  bool
+}
+
+func TestDelta(t *testing.T) {
+	prev := deltaEntity{ID: 17, Pos: codecPoint{X: 10, Y: 20}, Health: 100, Alive: true}
+	cur := prev
+	cur.Pos.X = 12
+	cur.Health = 95
+
+	w := NewWriter()
+	if err := w.EncodeDelta(prev, &cur); err != nil {
+		t.Errorf("unexpected error: %s", err)
+		return
+	}
+
+	// change mask plus Pos and Health
+	if want, got := uint(4+20+7), w.BitsWritten(); want != got {
+		t.Errorf("size mismatch: want=%d got=%d", want, got)
+	}
+
+	state := prev
+	if err := NewReader(w.BitData()).DecodeDelta(&state); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	if state != cur {
+		t.Errorf("mismatch:\nwant=%+v\n got=%+v", cur, state)
+	}
+}
+
+func TestDeltaUnchanged(t *testing.T) {
+	v := deltaEntity{ID: 1}
+
+	w := NewWriter()
+	_ = w.EncodeDelta(v, v)
+	if want, got := uint(4), w.BitsWritten(); want != got {
+		t.Errorf("size mismatch: want=%d got=%d", want, got)
+	}
+}
+
+func TestDeltaErrors(t *testing.T) {
+	w := NewWriter()
+	if err := w.EncodeDelta(deltaEntity{}, codecPoint{}); !errors.Is(err, ErrUnsupportedType) {
+		t.Errorf("expected error, got %v", err)
+	}
+
+	// the mask says everything changed, but the data ends; the baseline must stay intact
+	w = NewWriter()
+	w.Write8(0xF, 4)
+	w.Write16(5, 10)
+
+	state := deltaEntity{ID: 1, Health: 2}
+	if err := NewReader(w.BitData()).DecodeDelta(&state); err == nil {
+		t.Error("expected error")
+	}
+	if want := (deltaEntity{ID: 1, Health: 2}); state != want {
+		t.Errorf("baseline modified: %+v", state)
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"errors"
+	"math/bits"
+)
+
+var ErrDictionaryIndex = errors.New("dictionary index out of range")
+
+// WriteDictionary writes s with dictionary encoding: the number of distinct values as a varint, the
+// distinct values in the order of their first appearance, each written by the function write, the length
+// of s as a varint, and for every element the index of its value in the dictionary. The indices have
+// the minimal width for the dictionary size, so a slice with a single distinct value takes no index bits.
+func WriteDictionary[T comparable](w *Writer, s []T, write func(w *Writer, v T) error) error {
+	index := make(map[T]uint64)
+	var dict []T
+	for _, v := range s {
+		if _, ok := index[v]; !ok {
+			index[v] = uint64(len(dict))
+			dict = append(dict, v)
+		}
+	}
+
+	if err := WriteSlice(w, dict, 0, write); err != nil {
+		return err
+	}
+
+	w.WriteUvarint(uint64(len(s)))
+	width := dictionaryIndexBits(len(dict))
+	for _, v := range s {
+		w.Write64(index[v], width)
+	}
+
+	return nil
+}
+
+// ReadDictionary reads a slice written by WriteDictionary, the dictionary values are read by the
+// function read. It returns ErrDictionaryIndex for an index outside the dictionary.
+// On error the read position is left unchanged.
+func ReadDictionary[T any](r *Reader, read func(r *Reader) (T, error)) ([]T, error) {
+	start := r.bitsRead
+
+	s, err := readDictionary(r, read)
+	if err != nil {
+		r.bitsRead = start
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func readDictionary[T any](r *Reader, read func(r *Reader) (T, error)) ([]T, error) {
+	dict, err := ReadSlice(r, 0, read)
+	if err != nil {
+		return nil, err
+	}
+
+	n, err := readLength(r, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	width := dictionaryIndexBits(len(dict))
+	if n > 0 && len(dict) == 0 {
+		return nil, ErrDictionaryIndex
+	}
+	if ok, err := r.ensureCount(uint64(n), uint64(width)); !ok {
+		return nil, err
+	}
+	if err := r.charge(uint64(n), byteSize[T]()); err != nil {
+		return nil, err
+	}
+
+	s := make([]T, 0, preallocSize(n))
+	for i := 0; i < n; i++ {
+		idx, err := r.Read64(width)
+		if err != nil {
+			return nil, err
+		}
+		if idx >= uint64(len(dict)) {
+			return nil, ErrDictionaryIndex
+		}
+		s = append(s, dict[idx])
+	}
+
+	return s, nil
+}
+
+// dictionaryIndexBits returns the width of indices into a dictionary of n values.
+func dictionaryIndexBits(n int) byte {
+	if n <= 1 {
+		return 0
+	}
+	return byte(bits.Len64(uint64(n - 1)))
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func writeCountry(w *Writer, s string) error {
+	w.Write8(s[0], 8)
+	w.Write8(s[1], 8)
+	return nil
+}
+
+func readCountry(r *Reader) (string, error) {
+	var b [2]byte
+	if _, err := r.Read(b[:]); err != nil {
+		return "", err
+	}
+	return string(b[:]), nil
+}
+
+func TestDictionary(t *testing.T) {
+	tests := []struct {
+		values []string
+		bits   uint
+	}{
+		{values: nil, bits: 8 + 8},
+		{values: []string{"RS", "RS", "RS"}, bits: 8 + 16 + 8},
+		{values: []string{"RS", "DE", "RS", "FR", "DE", "RS"}, bits: 8 + 3*16 + 8 + 6*2},
+	}
+
+	for _, test := range tests {
+		w := NewWriter()
+		if err := WriteDictionary(w, test.values, writeCountry); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			continue
+		}
+		if w.BitsWritten() != test.bits {
+			t.Errorf("size mismatch for %v: want=%d got=%d", test.values, test.bits, w.BitsWritten())
+		}
+
+		got, err := ReadDictionary(NewReader(w.BitData()), readCountry)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if !slices.Equal(got, test.values) {
+			t.Errorf("mismatch: want=%v got=%v", test.values, got)
+		}
+	}
+}
+
+func TestDictionaryInvalidIndex(t *testing.T) {
+	w := NewWriter()
+	_ = WriteSlice(w, []string{"RS", "DE", "FR"}, 0, writeCountry)
+	w.WriteUvarint(2)
+	w.Write8(1, 2)
+	w.Write8(3, 2)
+
+	r := NewReader(w.BitData())
+	if _, err := ReadDictionary(r, readCountry); !errors.Is(err, ErrDictionaryIndex) {
+		t.Errorf("expected ErrDictionaryIndex, got %v", err)
+	}
+	if r.BitsRead() != 0 {
+		t.Errorf("position changed on error")
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"fmt"
+	"strings"
+)
+
+// BitDiff is a range of bits that differ between two BitData values. A and B hold the bits of the range
+// re-aligned to bit 0. If one of the values is shorter, the range past its end counts as differing, and
+// its side holds only the bits of the range before its end, or nil if there are none.
+type BitDiff struct {
+	Offset uint
+	Length uint
+	A, B   BitData
+}
+
+// Diff returns the ranges of bits that differ between a and b. Touching ranges are merged into one.
+func Diff(a, b BitData) []BitDiff {
+	var (
+		diffs []BitDiff
+		start uint
+		open  bool
+	)
+
+	n := len(a)
+	if len(b) < n {
+		n = len(b)
+	}
+
+	for i := 0; i < n; i++ {
+		x := a[i] ^ b[i]
+		pos := uint(i) * 8
+
+		switch {
+		case x == 0 && !open, x == 0xFF && open:
+			continue
+		case x == 0:
+			diffs = append(diffs, newBitDiff(a, b, start, pos-start))
+			open = false
+			continue
+		}
+
+		for j := uint(0); j < 8; j++ {
+			differs := x>>j&1 == 1
+			if differs && !open {
+				start, open = pos+j, true
+			} else if !differs && open {
+				diffs = append(diffs, newBitDiff(a, b, start, pos+j-start))
+				open = false
+			}
+		}
+	}
+
+	if open {
+		diffs = append(diffs, newBitDiff(a, b, start, uint(n)*8-start))
+	}
+
+	if len(a) != len(b) {
+		start = uint(n) * 8
+		if k := len(diffs) - 1; k >= 0 && diffs[k].Offset+diffs[k].Length == start {
+			// The last range reaches the end of the shorter value, so it continues past it.
+			start = diffs[k].Offset
+			diffs = diffs[:k]
+		}
+
+		end := uint(max(len(a), len(b))) * 8
+		diffs = append(diffs, BitDiff{
+			Offset: start,
+			Length: end - start,
+			A:      diffSide(a, start, end),
+			B:      diffSide(b, start, end),
+		})
+	}
+
+	return diffs
+}
+
+// diffSide returns the bits of d from offset up to end or the end of d, or nil if d ends before offset.
+func diffSide(d BitData, offset, end uint) BitData {
+	n := min(end, uint(len(d))*8)
+	if n <= offset {
+		return nil
+	}
+	return d.Extract(offset, n-offset)
+}
+
+func newBitDiff(a, b BitData, offset, length uint) BitDiff {
+	return BitDiff{
+		Offset: offset,
+		Length: length,
+		A:      a.Extract(offset, length),
+		B:      b.Extract(offset, length),
+	}
+}
+
+// DiffReport returns a human-readable description of the differences between a and b.
+func DiffReport(a, b BitData) string {
+	diffs := Diff(a, b)
+	if len(diffs) == 0 {
+		return "equal\n"
+	}
+
+	var sb strings.Builder
+	_, _ = fmt.Fprintf(&sb, "%d differing ranges, first at bit %d (byte %d, bit %d)\n",
+		len(diffs), diffs[0].Offset, diffs[0].Offset/8, diffs[0].Offset%8)
+
+	for _, d := range diffs {
+		_, _ = fmt.Fprintf(&sb, "bits %d..%d (%d): a=%s b=%s\n", d.Offset, d.Offset+d.Length-1, d.Length,
+			diffValue(d.A, d.Length), diffValue(d.B, d.Length))
+	}
+
+	return sb.String()
+}
+
+func diffValue(d BitData, length uint) string {
+	if d == nil {
+		return "<none>"
+	}
+
+	const maxShown = 64
+	if length > maxShown {
+		return d.FormatBinary(maxShown, 8) + "..."
+	}
+
+	return d.FormatBinary(length, 8)
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	a, _, _ := ParseBits("1011 0000 1111 0000 0000 0000")
+	b, _, _ := ParseBits("1001 0000 1100 0011 1100 0000")
+
+	diffs := Diff(a, b)
+
+	want := []struct {
+		offset, length uint
+		a, b           string
+	}{
+		{offset: 2, length: 1, a: "1", b: "0"},
+		{offset: 10, length: 2, a: "11", b: "00"},
+		{offset: 14, length: 4, a: "0000", b: "1111"},
+	}
+
+	if len(diffs) != len(want) {
+		t.Errorf("diff count mismatch: want=%d got=%d\n%s", len(want), len(diffs), DiffReport(a, b))
+		return
+	}
+
+	for i, w := range want {
+		d := diffs[i]
+		if d.Offset != w.offset || d.Length != w.length {
+			t.Errorf("diff %d: want=%d+%d got=%d+%d", i, w.offset, w.length, d.Offset, d.Length)
+		}
+		if got := d.A.FormatBinary(d.Length, 0); got != w.a {
+			t.Errorf("diff %d: a: want=%s got=%s", i, w.a, got)
+		}
+		if got := d.B.FormatBinary(d.Length, 0); got != w.b {
+			t.Errorf("diff %d: b: want=%s got=%s", i, w.b, got)
+		}
+	}
+}
+
+func TestDiffRunAcrossBytes(t *testing.T) {
+	a := BitData{0x00, 0x00, 0x00, 0x00}
+	b := BitData{0x80, 0xFF, 0xFF, 0x01}
+
+	diffs := Diff(a, b)
+	if len(diffs) != 1 || diffs[0].Offset != 7 || diffs[0].Length != 18 {
+		t.Errorf("unexpected diff: %+v", diffs)
+	}
+}
+
+func TestDiffLength(t *testing.T) {
+	a := BitData{1, 2, 3}
+	b := BitData{1, 2}
+
+	diffs := Diff(a, b)
+	if len(diffs) != 1 {
+		t.Errorf("diff count mismatch: want=%d got=%d", 1, len(diffs))
+		return
+	}
+
+	d := diffs[0]
+	if d.Offset != 16 || d.Length != 8 || d.B != nil || !bytes.Equal(d.A, BitData{3}) {
+		t.Errorf("unexpected diff: %+v", d)
+	}
+
+	if len(Diff(a, a)) != 0 {
+		t.Error("expected no differences")
+	}
+}
+
+func TestDiffReport(t *testing.T) {
+	if want, got := "equal\n", DiffReport(BitData{5}, BitData{5}); want != got {
+		t.Errorf("want %q, got %q", want, got)
+	}
+
+	report := DiffReport(BitData{0b0001}, BitData{0b1001})
+	if !strings.Contains(report, "first at bit 3") || !strings.Contains(report, "bits 3..3 (1): a=0 b=1") {
+		t.Errorf("unexpected report:\n%s", report)
+	}
+}
+
+func TestDiffTouchingLength(t *testing.T) {
+	a := BitData{1, 0x80}
+	b := BitData{1, 0x00, 0xFF}
+
+	diffs := Diff(a, b)
+	if len(diffs) != 1 {
+		t.Fatalf("diff count mismatch: want=%d got=%d\n%s", 1, len(diffs), DiffReport(a, b))
+	}
+
+	d := diffs[0]
+	if d.Offset != 15 || d.Length != 9 || !bytes.Equal(d.A, BitData{1}) || !bytes.Equal(d.B, BitData{0xFE, 1}) {
+		t.Errorf("unexpected diff: %+v", d)
+	}
+}
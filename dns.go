@@ -0,0 +1,185 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"errors"
+	"io"
+	"strings"
+)
+
+var ErrInvalidDNSName = errors.New("invalid DNS name")
+
+const (
+	maxDNSLabel = 63
+	maxDNSName  = 255
+)
+
+// DNSHeader is the 12-byte header of a DNS message. The DNS functions expect a writer or reader
+// in the MSBFirst order, which gives the network byte order of the 16-bit fields.
+type DNSHeader struct {
+	ID      uint16
+	QR      bool // response
+	Opcode  byte // 4 bits
+	AA      bool // authoritative answer
+	TC      bool // truncated
+	RD      bool // recursion desired
+	RA      bool // recursion available
+	Z       byte // 3 bits, including the AD and CD bits of DNSSEC
+	RCode   byte // 4 bits
+	QDCount uint16
+	ANCount uint16
+	NSCount uint16
+	ARCount uint16
+}
+
+func (w *Writer) WriteDNSHeader(h DNSHeader) {
+	w.Write16(h.ID, 16)
+	w.WriteBool(h.QR)
+	w.Write8(h.Opcode, 4)
+	w.WriteBool(h.AA)
+	w.WriteBool(h.TC)
+	w.WriteBool(h.RD)
+	w.WriteBool(h.RA)
+	w.Write8(h.Z, 3)
+	w.Write8(h.RCode, 4)
+	w.Write16(h.QDCount, 16)
+	w.Write16(h.ANCount, 16)
+	w.Write16(h.NSCount, 16)
+	w.Write16(h.ARCount, 16)
+}
+
+func (r *Reader) ReadDNSHeader() (DNSHeader, error) {
+	var h DNSHeader
+
+	rr := ReaderError{reader: *r}
+	h.ID = rr.Read16(16)
+	h.QR = rr.ReadBool()
+	h.Opcode = rr.Read8(4)
+	h.AA = rr.ReadBool()
+	h.TC = rr.ReadBool()
+	h.RD = rr.ReadBool()
+	h.RA = rr.ReadBool()
+	h.Z = rr.Read8(3)
+	h.RCode = rr.Read8(4)
+	h.QDCount = rr.Read16(16)
+	h.ANCount = rr.Read16(16)
+	h.NSCount = rr.Read16(16)
+	h.ARCount = rr.Read16(16)
+
+	if rr.err != nil {
+		return DNSHeader{}, rr.err
+	}
+
+	*r = rr.reader
+
+	return h, nil
+}
+
+// WriteDNSName writes a domain name as a sequence of labels, each preceded by its length,
+// ending with the empty root label. A trailing dot is optional. It returns ErrInvalidDNSName
+// for an empty label, a label longer than 63 bytes or a name longer than 255 bytes.
+func (w *Writer) WriteDNSName(name string) error {
+	name = strings.TrimSuffix(name, ".")
+
+	var labels []string
+	if name != "" {
+		labels = strings.Split(name, ".")
+	}
+
+	size := 1
+	for _, l := range labels {
+		if len(l) == 0 || len(l) > maxDNSLabel {
+			return ErrInvalidDNSName
+		}
+		size += 1 + len(l)
+	}
+	if size > maxDNSName {
+		return ErrInvalidDNSName
+	}
+
+	for _, l := range labels {
+		w.Write8(byte(len(l)), 8)
+		w.WriteBitData(BitData(l), uint(len(l))*8)
+	}
+	w.Write8(0, 8)
+
+	return nil
+}
+
+// ReadDNSName reads a domain name and returns it with a trailing dot. It follows compression pointers,
+// which are byte offsets from the start of the reader's data, so the data should start with the DNS message.
+// It returns ErrInvalidDNSName for a malformed name or a pointer loop. On error the read position is left unchanged.
+func (r *Reader) ReadDNSName() (string, error) {
+	start := r.bitsRead
+
+	var (
+		sb    strings.Builder
+		cur   = r
+		jumps int
+	)
+
+	for {
+		n, err := cur.Read8(8)
+		if err != nil {
+			r.bitsRead = start
+			return "", err
+		}
+
+		switch {
+		case n == 0:
+			if sb.Len() == 0 {
+				sb.WriteByte('.')
+			}
+			return sb.String(), nil
+
+		case n&0xC0 == 0xC0:
+			lo, err := cur.Read8(8)
+			if err != nil {
+				r.bitsRead = start
+				return "", err
+			}
+
+			// the name continues at the pointer, the reader r after the pointer
+			jumps++
+			if jumps > maxDNSName/2 {
+				r.bitsRead = start
+				return "", ErrInvalidDNSName
+			}
+			jump := *r
+			jump.bitsRead = (uint(n&0x3F)<<8 | uint(lo)) * 8
+			cur = &jump
+
+		case n <= maxDNSLabel:
+			label := make([]byte, n)
+			if _, err := io.ReadFull(cur, label); err != nil {
+				r.bitsRead = start
+				return "", err
+			}
+			if sb.Len()+int(n)+1 > maxDNSName {
+				r.bitsRead = start
+				return "", ErrInvalidDNSName
+			}
+			sb.Write(label)
+			sb.WriteByte('.')
+
+		default:
+			r.bitsRead = start
+			return "", ErrInvalidDNSName
+		}
+	}
+}
+
+func (r *ReaderError) ReadDNSHeader() (h DNSHeader) {
+	if r.err == nil {
+		h, r.err = r.reader.ReadDNSHeader()
+	}
+	return
+}
+
+func (r *ReaderError) ReadDNSName() (name string) {
+	if r.err == nil {
+		name, r.err = r.reader.ReadDNSName()
+	}
+	return
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDNSHeader(t *testing.T) {
+	h := DNSHeader{
+		ID:      0xBEEF,
+		QR:      true,
+		Opcode:  2,
+		AA:      true,
+		RD:      true,
+		RA:      true,
+		RCode:   3,
+		QDCount: 1,
+		ANCount: 2,
+	}
+
+	w := NewWriterMSB()
+	w.WriteDNSHeader(h)
+
+	want := []byte{0xBE, 0xEF, 0x95, 0x83, 0, 1, 0, 2, 0, 0, 0, 0}
+	if !bytes.Equal(want, w.BitData()) {
+		t.Errorf("encoding mismatch: want=%x got=%x", want, w.BitData())
+	}
+
+	r := NewReaderErrorMSB(w.BitData())
+	if got := r.ReadDNSHeader(); got != h {
+		t.Errorf("header mismatch: want=%+v got=%+v", h, got)
+	}
+	if err := r.Error(); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
+
+func TestDNSName(t *testing.T) {
+	w := NewWriterMSB()
+	for _, name := range []string{"www.example.com", ".", "a.b."} {
+		if err := w.WriteDNSName(name); err != nil {
+			t.Fatalf("unexpected error for %q: %s", name, err)
+		}
+	}
+
+	want := []byte("\x03www\x07example\x03com\x00\x00\x01a\x01b\x00")
+	if !bytes.Equal(want, w.BitData()) {
+		t.Errorf("encoding mismatch: want=%q got=%q", want, w.BitData())
+	}
+
+	// mail.example.com, compressed with a pointer to example.com
+	d := append(w.AppendBitData(nil), 4, 'm', 'a', 'i', 'l', 0xC0, 4, 0xFF)
+
+	r := NewReaderErrorMSB(d)
+	for _, name := range []string{"www.example.com.", ".", "a.b.", "mail.example.com."} {
+		if got := r.ReadDNSName(); got != name {
+			t.Errorf("name mismatch: want=%q got=%q", name, got)
+		}
+	}
+	if v := r.Read8(8); v != 0xFF {
+		t.Errorf("position after the pointer mismatch: %x", v)
+	}
+	if err := r.Error(); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
+
+func TestDNSNameInvalid(t *testing.T) {
+	for _, name := range []string{"a..b", strings.Repeat("x", 64), strings.Repeat("abcdefg.", 32)} {
+		if err := NewWriterMSB().WriteDNSName(name); !errors.Is(err, ErrInvalidDNSName) {
+			t.Errorf("want=%v for %q got=%v", ErrInvalidDNSName, name, err)
+		}
+	}
+
+	for _, d := range []BitData{{0xC0, 0x00}, {0x40, 0x00}} {
+		r := NewReaderMSB(d)
+		if _, err := r.ReadDNSName(); !errors.Is(err, ErrInvalidDNSName) || r.BitsRead() != 0 {
+			t.Errorf("want=%v for %x got=%v at %d", ErrInvalidDNSName, d, err, r.BitsRead())
+		}
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"math/bits"
+)
+
+// Densely Packed Decimal encodes three decimal digits in a 10-bit declet, as used by the IEEE 754
+// decimal formats. The bits of a declet are named pqr stu v wxy, from the most significant one.
+
+const maxDPDDeclets = 7
+
+// EncodeDPD returns the declet of a number from 0 to 999. It panics for larger numbers.
+func EncodeDPD(v uint16) uint16 {
+	if v > 999 {
+		panic("bitdata: DPD value out of range")
+	}
+
+	d2, d1, d0 := v/100, v/10%10, v%10
+	a, e, i := d2>>3, d1>>3, d0>>3
+
+	// The low three bits of the digits: bcd, fgh and jkm; h and m are the lowest ones.
+	bcd, fgh, jkm := d2&7, d1&7, d0&7
+	jk := jkm >> 1
+	d, h, m := d2&1, d1&1, d0&1
+
+	switch a<<2 | e<<1 | i {
+	case 0b000:
+		return bcd<<7 | fgh<<4 | jkm
+	case 0b001:
+		return bcd<<7 | fgh<<4 | 0b1000 | m
+	case 0b010:
+		return bcd<<7 | jk<<5 | h<<4 | 0b1010 | m
+	case 0b100:
+		return jk<<8 | d<<7 | fgh<<4 | 0b1100 | m
+	case 0b110:
+		return jk<<8 | d<<7 | h<<4 | 0b1110 | m
+	case 0b101:
+		return (fgh>>1)<<8 | d<<7 | 0b01<<5 | h<<4 | 0b1110 | m
+	case 0b011:
+		return bcd<<7 | 0b10<<5 | h<<4 | 0b1110 | m
+	default:
+		return d<<7 | 0b11<<5 | h<<4 | 0b1110 | m
+	}
+}
+
+// DecodeDPD returns the number from 0 to 999 encoded in the lowest 10 bits of the declet.
+// All 1024 declets are decoded, including the 24 non-canonical ones.
+func DecodeDPD(declet uint16) uint16 {
+	pqr, stu, wxy := declet>>7&7, declet>>4&7, declet&7
+	pq, st, wx := pqr>>1, stu>>1, wxy>>1
+	r, u, y := pqr&1, stu&1, wxy&1
+
+	var d2, d1, d0 uint16
+	switch {
+	case declet>>3&1 == 0:
+		d2, d1, d0 = pqr, stu, wxy
+	case wx == 0b00:
+		d2, d1, d0 = pqr, stu, 8|y
+	case wx == 0b01:
+		d2, d1, d0 = pqr, 8|u, st<<1|y
+	case wx == 0b10:
+		d2, d1, d0 = 8|r, stu, pq<<1|y
+	case st == 0b00:
+		d2, d1, d0 = 8|r, 8|u, pq<<1|y
+	case st == 0b01:
+		d2, d1, d0 = 8|r, pq<<1|u, 8|y
+	case st == 0b10:
+		d2, d1, d0 = pqr, 8|u, 8|y
+	default:
+		d2, d1, d0 = 8|r, 8|u, 8|y
+	}
+
+	return d2*100 + d1*10 + d0
+}
+
+// WriteDPD writes the lowest 3*declets decimal digits of v as Densely Packed Decimal, 10 bits for every
+// three digits, least significant declet first. Like WriteBCD it silently drops higher digits.
+func (w *Writer) WriteDPD(v uint64, declets byte) {
+	if declets > maxDPDDeclets {
+		panic("bitdata: too many DPD declets")
+	}
+
+	for i := byte(0); i < declets; i++ {
+		w.Write16(EncodeDPD(uint16(v%1000)), 10)
+		v /= 1000
+	}
+}
+
+// ReadDPD reads Densely Packed Decimal written by WriteDPD. It returns ErrInvalidBCD if the number
+// doesn't fit into uint64.
+func (r *Reader) ReadDPD(declets byte) (uint64, error) {
+	if declets > maxDPDDeclets {
+		return 0, ErrBitCountTooBig
+	}
+
+	start := r.bitsRead
+
+	var (
+		v     uint64
+		scale uint64 = 1
+	)
+	for i := byte(0); i < declets; i++ {
+		declet, err := r.Read16(10)
+		if err != nil {
+			r.bitsRead = start
+			return 0, err
+		}
+
+		hi, lo := bits.Mul64(uint64(DecodeDPD(declet)), scale)
+		sum, carry := bits.Add64(v, lo, 0)
+		if hi != 0 || carry != 0 {
+			r.bitsRead = start
+			return 0, ErrInvalidBCD
+		}
+		v = sum
+
+		scale *= 1000 // overflows only after the 7th declet, when it's no longer used
+	}
+
+	return v, nil
+}
+
+func (r *ReaderError) ReadDPD(declets byte) (v uint64) {
+	if r.err == nil {
+		v, r.err = r.reader.ReadDPD(declets)
+	}
+	return
+}
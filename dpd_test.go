@@ -0,0 +1,80 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestDPD(t *testing.T) {
+	known := map[uint16]uint16{0: 0x000, 9: 0x009, 55: 0x055, 79: 0x079, 80: 0x00A, 99: 0x05F, 999: 0x0FF}
+	for v, declet := range known {
+		if got := EncodeDPD(v); got != declet {
+			t.Errorf("encode %d: want=%03X got=%03X", v, declet, got)
+		}
+	}
+
+	seen := map[uint16]bool{}
+	for v := uint16(0); v < 1000; v++ {
+		declet := EncodeDPD(v)
+		if declet >= 1<<10 || seen[declet] {
+			t.Errorf("encode %d: invalid or duplicate declet %03X", v, declet)
+		}
+		seen[declet] = true
+
+		if got := DecodeDPD(declet); got != v {
+			t.Errorf("decode %03X: want=%d got=%d", declet, v, got)
+		}
+	}
+
+	for declet := uint16(0); declet < 1<<10; declet++ {
+		if v := DecodeDPD(declet); v > 999 || !seen[declet] && EncodeDPD(v) == declet {
+			t.Errorf("decode %03X: unexpected value %d", declet, v)
+		}
+	}
+}
+
+func TestWriteDPD(t *testing.T) {
+	tests := []struct {
+		value   uint64
+		declets byte
+		exp     uint64
+	}{
+		{value: 0, declets: 1, exp: 0},
+		{value: 123456, declets: 2, exp: 123456},
+		{value: 1234567, declets: 2, exp: 234567},
+		{value: math.MaxUint64, declets: 7, exp: math.MaxUint64},
+	}
+
+	for _, test := range tests {
+		w := NewWriter()
+		w.WriteBool(true)
+		w.WriteDPD(test.value, test.declets)
+		if w.BitsWritten() != 1+10*uint(test.declets) {
+			t.Errorf("%d: size mismatch: %d", test.value, w.BitsWritten())
+		}
+
+		r := NewReaderError(w.BitData())
+		r.ReadBool()
+		if got := r.ReadDPD(test.declets); r.Error() != nil || got != test.exp {
+			t.Errorf("%d: want=%d got=%d err=%v", test.value, test.exp, got, r.Error())
+		}
+	}
+}
+
+func TestReadDPDOverflow(t *testing.T) {
+	w := NewWriter()
+	for i := 0; i < 7; i++ {
+		w.Write16(EncodeDPD(999), 10)
+	}
+
+	r := NewReader(w.BitData())
+	if _, err := r.ReadDPD(7); !errors.Is(err, ErrInvalidBCD) {
+		t.Errorf("expected ErrInvalidBCD, got %v", err)
+	}
+	if r.BitsRead() != 0 {
+		t.Errorf("position changed on error")
+	}
+}
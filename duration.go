@@ -0,0 +1,49 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+var ErrDurationOverflow = errors.New("duration overflows time.Duration")
+
+// WriteDuration writes d as a zigzag encoded varint in units of precision. The duration is truncated
+// toward zero to a multiple of precision, so a coarser precision gives a shorter encoding.
+// A precision below one nanosecond is treated as one nanosecond.
+func (w *Writer) WriteDuration(d, precision time.Duration) {
+	if precision < 1 {
+		precision = 1
+	}
+	w.WriteVarint(int64(d / precision))
+}
+
+// ReadDuration reads a duration written with WriteDuration. The precision must match the one used for writing.
+// It returns ErrDurationOverflow and leaves the read position unchanged if the value in units of precision
+// doesn't fit into a time.Duration.
+func (r *Reader) ReadDuration(precision time.Duration) (time.Duration, error) {
+	if precision < 1 {
+		precision = 1
+	}
+
+	start := r.bitsRead
+	v, err := r.ReadVarint()
+	if err != nil {
+		return 0, err
+	}
+	if v > math.MaxInt64/int64(precision) || v < math.MinInt64/int64(precision) {
+		r.bitsRead = start
+		return 0, ErrDurationOverflow
+	}
+
+	return time.Duration(v) * precision, nil
+}
+
+func (r *ReaderError) ReadDuration(precision time.Duration) (v time.Duration) {
+	if r.err == nil {
+		v, r.err = r.reader.ReadDuration(precision)
+	}
+	return
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestDuration(t *testing.T) {
+	tests := []struct {
+		name      string
+		d         time.Duration
+		precision time.Duration
+		exp       time.Duration
+		expBits   uint
+	}{
+		{name: "zero", d: 0, precision: 0, exp: 0, expBits: 8},
+		{name: "nanoseconds", d: 1500 * time.Nanosecond, precision: 0, exp: 1500 * time.Nanosecond, expBits: 16},
+		{name: "negative", d: -time.Second, precision: 1, exp: -time.Second, expBits: 40},
+		{name: "truncated", d: 1999 * time.Millisecond, precision: time.Second, exp: time.Second, expBits: 8},
+		{name: "truncated-negative", d: -1999 * time.Millisecond, precision: time.Second, exp: -time.Second, expBits: 8},
+		{name: "milliseconds", d: 90 * time.Minute, precision: time.Millisecond, exp: 90 * time.Minute, expBits: 32},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w := NewWriter()
+			w.WriteBool(true)
+			w.WriteDuration(test.d, test.precision)
+
+			if want, got := 1+test.expBits, w.BitsWritten(); want != got {
+				t.Errorf("bits written mismatch: want=%d got=%d", want, got)
+			}
+
+			r := NewReaderError(w.BitData())
+			r.Skip(1)
+			if got := r.ReadDuration(test.precision); got != test.exp {
+				t.Errorf("value mismatch: want=%s got=%s", test.exp, got)
+			}
+			if err := r.Error(); err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func TestDurationOverflow(t *testing.T) {
+	w := NewWriter()
+	w.WriteDuration(time.Duration(math.MaxInt64), time.Nanosecond)
+	w.WriteDuration(-(1<<30)*time.Second, time.Second)
+
+	r := NewReader(w.BitData())
+	if _, err := r.ReadDuration(time.Second); !errors.Is(err, ErrDurationOverflow) || r.BitsRead() != 0 {
+		t.Errorf("expected ErrDurationOverflow, got %v at %d", err, r.BitsRead())
+	}
+	if d, err := r.ReadDuration(time.Nanosecond); err != nil || d != math.MaxInt64 {
+		t.Errorf("got %s, %v", d, err)
+	}
+	if _, err := r.ReadDuration(time.Hour); !errors.Is(err, ErrDurationOverflow) {
+		t.Errorf("expected ErrDurationOverflow, got %v", err)
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"math/bits"
+)
+
+// Write16BE writes all 16 bits of the value with its bytes in big-endian (network) order: the most
+// significant byte comes first in the stream. The bits within each byte follow the bit order of the writer,
+// so for MSBFirst it is the same as Write16(v, 16).
+func (w *Writer) Write16BE(v uint16) {
+	if w.order == LSBFirst {
+		v = bits.ReverseBytes16(v)
+	}
+	w.Write16(v, 16)
+}
+
+// Write32BE is like Write16BE for a 32-bit value.
+func (w *Writer) Write32BE(v uint32) {
+	if w.order == LSBFirst {
+		v = bits.ReverseBytes32(v)
+	}
+	w.Write32(v, 32)
+}
+
+// Write64BE is like Write16BE for a 64-bit value.
+func (w *Writer) Write64BE(v uint64) {
+	if w.order == LSBFirst {
+		v = bits.ReverseBytes64(v)
+	}
+	w.Write64(v, 64)
+}
+
+// Read16BE reads a 16-bit value written with Write16BE.
+func (r *Reader) Read16BE() (uint16, error) {
+	v, err := r.Read16(16)
+	if err != nil || r.order == MSBFirst {
+		return v, err
+	}
+	return bits.ReverseBytes16(v), nil
+}
+
+// Read32BE reads a 32-bit value written with Write32BE.
+func (r *Reader) Read32BE() (uint32, error) {
+	v, err := r.Read32(32)
+	if err != nil || r.order == MSBFirst {
+		return v, err
+	}
+	return bits.ReverseBytes32(v), nil
+}
+
+// Read64BE reads a 64-bit value written with Write64BE.
+func (r *Reader) Read64BE() (uint64, error) {
+	v, err := r.Read64(64)
+	if err != nil || r.order == MSBFirst {
+		return v, err
+	}
+	return bits.ReverseBytes64(v), nil
+}
+
+func (r *ReaderError) Read16BE() (v uint16) {
+	if r.err == nil {
+		v, r.err = r.reader.Read16BE()
+	}
+	return
+}
+
+func (r *ReaderError) Read32BE() (v uint32) {
+	if r.err == nil {
+		v, r.err = r.reader.Read32BE()
+	}
+	return
+}
+
+func (r *ReaderError) Read64BE() (v uint64) {
+	if r.err == nil {
+		v, r.err = r.reader.Read64BE()
+	}
+	return
+}
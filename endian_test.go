@@ -0,0 +1,35 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+func TestBigEndian(t *testing.T) {
+	want := binary.BigEndian.AppendUint16(nil, 0x1234)
+	want = binary.BigEndian.AppendUint32(want, 0x56789ABC)
+	want = binary.BigEndian.AppendUint64(want, 0xDEF0123456789ABC)
+
+	for _, o := range []BitOrder{LSBFirst, MSBFirst} {
+		w := NewWriter(WithBitOrder(o))
+		w.Write16BE(0x1234)
+		w.Write32BE(0x56789ABC)
+		w.Write64BE(0xDEF0123456789ABC)
+
+		if got := w.BitData(); !bytes.Equal(got, want) {
+			t.Errorf("order %d: want=%x got=%x", o, want, got)
+		}
+
+		r := NewReaderError(w.BitData(), WithBitOrder(o))
+		v16, v32, v64 := r.Read16BE(), r.Read32BE(), r.Read64BE()
+		if err := r.Error(); err != nil {
+			t.Fatalf("order %d: unexpected error: %v", o, err)
+		}
+		if v16 != 0x1234 || v32 != 0x56789ABC || v64 != 0xDEF0123456789ABC {
+			t.Errorf("order %d: got %#x %#x %#x", o, v16, v32, v64)
+		}
+	}
+}
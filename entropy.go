@@ -0,0 +1,145 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"math"
+	"math/bits"
+)
+
+// Entropy returns the zero-order entropy of the symbols in bits per symbol, which is the lower bound
+// for the average code length of any code that codes the symbols independently.
+func Entropy[T comparable](symbols []T) float64 {
+	counts := make(map[T]int)
+	for _, s := range symbols {
+		counts[s]++
+	}
+
+	h := 0.0
+	for _, c := range counts {
+		p := float64(c) / float64(len(symbols))
+		h -= p * math.Log2(p)
+	}
+	return h
+}
+
+// BitRunStats describes the bits of a bit range and the runs of equal bits in it.
+type BitRunStats struct {
+	Ones, Zeros               uint
+	Runs                      uint
+	LongestOnes, LongestZeros uint
+}
+
+// Entropy returns the binary entropy of the bits in bits per bit.
+func (s BitRunStats) Entropy() float64 {
+	n := float64(s.Ones + s.Zeros)
+	h := 0.0
+	for _, c := range []uint{s.Ones, s.Zeros} {
+		if c > 0 {
+			p := float64(c) / n
+			h -= p * math.Log2(p)
+		}
+	}
+	return h
+}
+
+// MeanRun returns the average length of a run.
+func (s BitRunStats) MeanRun() float64 {
+	if s.Runs == 0 {
+		return 0
+	}
+	return float64(s.Ones+s.Zeros) / float64(s.Runs)
+}
+
+// RunStats returns the statistics of the bitCount bits starting at the bit offset fromBit.
+// The range is clamped to the length of the data.
+func (d BitData) RunStats(fromBit, bitCount uint) BitRunStats {
+	fromBit, bitCount = d.clampRange(fromBit, bitCount)
+	if bitCount == 0 {
+		return BitRunStats{}
+	}
+
+	var s BitRunStats
+	s.Ones = uint(onesCount(d, fromBit, bitCount))
+	s.Zeros = bitCount - s.Ones
+	_, s.LongestOnes = d.LongestRun(fromBit, bitCount, true)
+	_, s.LongestZeros = d.LongestRun(fromBit, bitCount, false)
+
+	r := NewReader(d)
+	r.Skip(fromBit)
+
+	s.Runs = 1
+	var last uint64
+	for pos, end := fromBit, fromBit+bitCount; pos < end; {
+		n := byte(min(64, end-pos))
+		x, _ := read[uint64](r, n)
+
+		// Count the positions where a bit differs from the one before it.
+		changes := (x ^ (x<<1 | last)) & mask[uint64](n)
+		if pos == fromBit {
+			changes &^= 1
+		}
+		s.Runs += uint(bits.OnesCount64(changes))
+
+		last = x >> (n - 1) & 1
+		pos += uint(n)
+	}
+
+	return s
+}
+
+// CodedSizes holds the estimated sizes in bits of a sequence of values coded in several ways.
+type CodedSizes struct {
+	Packed  uint64  // every value in the minimal width that holds the largest one
+	RLE     uint64  // every run of equal values as a packed value and the run length as an LEB128 varint
+	Rice    uint64  // every value as a Golomb-Rice code with the parameter RiceK
+	RiceK   byte    // the Rice parameter that gives the smallest size
+	Entropy float64 // the zero-order entropy of the values times their count
+}
+
+// EstimateCodedSizes returns the sizes the values would take with the packed, the run-length
+// and the Rice coding, to choose between them without encoding.
+func EstimateCodedSizes(values []uint64) CodedSizes {
+	var maxValue uint64
+	for _, v := range values {
+		maxValue = max(maxValue, v)
+	}
+	width := uint64(MinBits(maxValue))
+
+	s := CodedSizes{
+		Packed:  width * uint64(len(values)),
+		Rice:    math.MaxUint64,
+		Entropy: Entropy(values) * float64(len(values)),
+	}
+
+	for i := 0; i < len(values); {
+		j := i + 1
+		for j < len(values) && values[j] == values[i] {
+			j++
+		}
+		s.RLE += width + 8*uint64(max(1, (bits.Len64(uint64(j-i))+6)/7))
+		i = j
+	}
+
+	for k := byte(0); k < 64; k++ {
+		size := uint64(0)
+		for _, v := range values {
+			size = saturatingAdd(size, v>>k+1+uint64(k))
+		}
+		if size < s.Rice {
+			s.Rice, s.RiceK = size, k
+		}
+	}
+	if len(values) == 0 {
+		s.Rice = 0
+	}
+
+	return s
+}
+
+func saturatingAdd(a, b uint64) uint64 {
+	if a > math.MaxUint64-b {
+		return math.MaxUint64
+	}
+	return a + b
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEntropy(t *testing.T) {
+	tests := []struct {
+		symbols []byte
+		want    float64
+	}{
+		{symbols: nil, want: 0},
+		{symbols: []byte("aaaa"), want: 0},
+		{symbols: []byte("abab"), want: 1},
+		{symbols: []byte("abcd"), want: 2},
+		{symbols: []byte("aabc"), want: 1.5},
+	}
+
+	for _, test := range tests {
+		if got := Entropy(test.symbols); math.Abs(got-test.want) > 1e-9 {
+			t.Errorf("%q: want=%v got=%v", test.symbols, test.want, got)
+		}
+	}
+}
+
+func TestRunStats(t *testing.T) {
+	d, n, _ := ParseBits("0011 1000 0000 0000 0000 0000 0000 0000 0000 0000 0000 0000 0000 0000 0000 0000 1101")
+
+	s := d.RunStats(0, n)
+	want := BitRunStats{Ones: 6, Zeros: 62, Runs: 6, LongestOnes: 3, LongestZeros: 59}
+	if s != want {
+		t.Errorf("want=%+v got=%+v", want, s)
+	}
+	if got := s.MeanRun(); math.Abs(got-68.0/6) > 1e-9 {
+		t.Errorf("mean run: %v", got)
+	}
+
+	s = d.RunStats(3, 3)
+	if want := (BitRunStats{Ones: 2, Zeros: 1, Runs: 2, LongestOnes: 2, LongestZeros: 1}); s != want {
+		t.Errorf("want=%+v got=%+v", want, s)
+	}
+	if got, want := s.Entropy(), -(2.0/3*math.Log2(2.0/3) + 1.0/3*math.Log2(1.0/3)); math.Abs(got-want) > 1e-9 {
+		t.Errorf("entropy: want=%v got=%v", want, got)
+	}
+}
+
+func TestEstimateCodedSizes(t *testing.T) {
+	values := []uint64{5, 5, 5, 5, 1, 0, 2, 2}
+
+	s := EstimateCodedSizes(values)
+	if s.Packed != 3*8 {
+		t.Errorf("packed: %d", s.Packed)
+	}
+	if s.RLE != 4*(3+8) {
+		t.Errorf("rle: %d", s.RLE)
+	}
+	if s.RiceK != 1 || s.Rice != 4*4+2+2+3*2 {
+		t.Errorf("rice: k=%d size=%d", s.RiceK, s.Rice)
+	}
+
+	w := NewWriter()
+	for _, v := range values {
+		w.WriteRice(v, s.RiceK)
+	}
+	if uint64(w.BitsWritten()) != s.Rice {
+		t.Errorf("rice estimate %d, written %d", s.Rice, w.BitsWritten())
+	}
+
+	if s := EstimateCodedSizes(nil); s != (CodedSizes{}) {
+		t.Errorf("empty: %+v", s)
+	}
+}
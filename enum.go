@@ -0,0 +1,45 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrEnumRange = errors.New("enum value out of range")
+
+// WriteEnum writes v, which must be in the range [0, max], using the minimal number of bits
+// that can hold max. Unlike Write8..Write64, an out-of-range value is reported instead of truncated.
+func WriteEnum[T integer](w *Writer, v, max T) error {
+	if v < 0 || v > max {
+		return fmt.Errorf("%w: %d not in [0, %d]", ErrEnumRange, v, max)
+	}
+
+	w.Write64(uint64(v), enumBits(max))
+
+	return nil
+}
+
+// ReadEnum reads a value written with WriteEnum. The max must match the one used for writing.
+func ReadEnum[T integer](r *Reader, max T) (T, error) {
+	if max < 0 {
+		return 0, fmt.Errorf("%w: negative max %d", ErrEnumRange, max)
+	}
+
+	u, err := r.Read64(enumBits(max))
+	if err != nil {
+		return 0, err
+	}
+
+	if u > uint64(max) {
+		r.bitsRead -= uint(enumBits(max))
+		return 0, fmt.Errorf("%w: %d not in [0, %d]", ErrEnumRange, u, max)
+	}
+
+	return T(u), nil
+}
+
+func enumBits[T integer](max T) byte {
+	return MinBits(uint64(max))
+}
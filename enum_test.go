@@ -0,0 +1,65 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"errors"
+	"testing"
+)
+
+type testColor uint8
+
+const (
+	testRed testColor = iota
+	testGreen
+	testBlue
+	testColorMax = testBlue
+)
+
+func TestEnum(t *testing.T) {
+	w := NewWriter()
+	for _, c := range []testColor{testRed, testBlue, testGreen} {
+		if err := WriteEnum(w, c, testColorMax); err != nil {
+			t.Errorf("unexpected error: %s", err)
+		}
+	}
+	if err := WriteEnum(w, 5, 5); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	if want, got := uint(3*2+3), w.BitsWritten(); want != got {
+		t.Errorf("bits written mismatch: want=%d got=%d", want, got)
+	}
+
+	r := NewReader(w.BitData())
+	for _, want := range []testColor{testRed, testBlue, testGreen} {
+		got, err := ReadEnum(r, testColorMax)
+		if err != nil {
+			t.Errorf("unexpected error: %s", err)
+		}
+		if want != got {
+			t.Errorf("value mismatch: want=%d got=%d", want, got)
+		}
+	}
+	if v, err := ReadEnum(r, 5); err != nil || v != 5 {
+		t.Errorf("value mismatch: want=5 got=%d err=%v", v, err)
+	}
+
+	if err := WriteEnum(w, testColor(3), testColorMax); !errors.Is(err, ErrEnumRange) {
+		t.Errorf("expected ErrEnumRange, got %v", err)
+	}
+	if err := WriteEnum(w, -1, 10); !errors.Is(err, ErrEnumRange) {
+		t.Errorf("expected ErrEnumRange, got %v", err)
+	}
+	if want, got := uint(9), w.BitsWritten(); want != got {
+		t.Errorf("rejected value was written: want=%d got=%d", want, got)
+	}
+
+	r = NewReader(BitData{0b11})
+	if _, err := ReadEnum(r, testColorMax); !errors.Is(err, ErrEnumRange) {
+		t.Errorf("expected ErrEnumRange, got %v", err)
+	}
+	if r.BitsRead() != 0 {
+		t.Errorf("reader advanced on error: %d", r.BitsRead())
+	}
+}
@@ -0,0 +1,29 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"fmt"
+)
+
+// ReadError is returned by the basic read methods. It records the operation, the bit offset it was
+// attempted at and the requested number of bits, and wraps the cause, such as io.ErrUnexpectedEOF
+// or ErrBitCountTooBig, so it can be tested with errors.Is.
+type ReadError struct {
+	Op       string
+	Offset   uint
+	BitCount byte
+	Err      error
+}
+
+func (e *ReadError) Error() string {
+	return fmt.Sprintf("%s of %d bits at bit offset %d: %v", e.Op, e.BitCount, e.Offset, e.Err)
+}
+
+func (e *ReadError) Unwrap() error {
+	return e.Err
+}
+
+func (r *Reader) readError(op string, bitCount byte, err error) error {
+	return &ReadError{Op: op, Offset: r.bitsRead, BitCount: bitCount, Err: err}
+}
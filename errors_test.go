@@ -0,0 +1,37 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestReadError(t *testing.T) {
+	r := NewReader(BitData{0xFF, 0xFF})
+	r.Skip(10)
+
+	_, err := r.Read16(12)
+
+	var re *ReadError
+	if !errors.As(err, &re) {
+		t.Fatalf("expected *ReadError, got %T", err)
+	}
+	if re.Op != "Read16" || re.Offset != 10 || re.BitCount != 12 {
+		t.Errorf("unexpected context: %+v", re)
+	}
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("error doesn't wrap io.ErrUnexpectedEOF")
+	}
+	if want := "Read16 of 12 bits at bit offset 10: unexpected EOF"; err.Error() != want {
+		t.Errorf("message mismatch: want=%q got=%q", want, err.Error())
+	}
+
+	rr := NewReaderError(BitData{0xFF})
+	rr.Read8(8)
+	rr.Read32(33)
+	if !errors.As(rr.Error(), &re) || re.Op != "Read32" || re.Offset != 8 || !errors.Is(re, ErrBitCountTooBig) {
+		t.Errorf("unexpected error: %v", rr.Error())
+	}
+}
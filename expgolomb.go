@@ -0,0 +1,119 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"math"
+	"math/bits"
+)
+
+// WriteExpGolomb writes v as an unsigned Exp-Golomb code, the ue(v) of H.264 and H.265:
+// the bit length of v+1 minus one as zero bits, followed by v+1 with its most significant bit first.
+// The code reads the same in both bit orders.
+func (w *Writer) WriteExpGolomb(v uint64) {
+	if v == math.MaxUint64 {
+		writeZeros(w, 64)
+		w.WriteBool(true)
+		writeZeros(w, 64)
+		return
+	}
+
+	v++
+	n := byte(bits.Len64(v))
+	writeZeros(w, uint(n-1))
+	w.Write64(msbFirst(v, n, w.order), n)
+}
+
+// WriteSignedExpGolomb writes v as a signed Exp-Golomb code, the se(v) of H.264 and H.265,
+// which maps positive values to odd and the others to even codes. It panics for math.MinInt64,
+// which has no code that fits into 64 bits.
+func (w *Writer) WriteSignedExpGolomb(v int64) {
+	if v == math.MinInt64 {
+		panic("bitdata: Exp-Golomb value out of range")
+	}
+	w.WriteExpGolomb(signedExpGolomb(v))
+}
+
+// ReadExpGolomb reads an unsigned Exp-Golomb code. It returns ErrVarintOverflow if the value
+// doesn't fit into 64 bits. On error the read position is left unchanged.
+func (r *Reader) ReadExpGolomb() (uint64, error) {
+	start := r.bitsRead
+
+	q, err := readUnary(r)
+	if err == nil && q > 64 {
+		err = ErrVarintOverflow
+	}
+	if err != nil {
+		r.bitsRead = start
+		return 0, err
+	}
+	zeros := byte(q)
+
+	if zeros == 64 {
+		suffix, err := read[uint64](r, 64)
+		if err == nil && suffix != 0 {
+			err = ErrVarintOverflow
+		}
+		if err != nil {
+			r.bitsRead = start
+			return 0, err
+		}
+		return math.MaxUint64, nil
+	}
+
+	suffix, err := read[uint64](r, zeros)
+	if err != nil {
+		r.bitsRead = start
+		return 0, err
+	}
+
+	return (1<<zeros | msbFirst(suffix, zeros, r.order)) - 1, nil
+}
+
+// ReadSignedExpGolomb reads a signed Exp-Golomb code.
+func (r *Reader) ReadSignedExpGolomb() (int64, error) {
+	start := r.bitsRead
+
+	v, err := r.ReadExpGolomb()
+	if err != nil {
+		return 0, err
+	}
+	if v == math.MaxUint64 {
+		r.bitsRead = start
+		return 0, ErrVarintOverflow
+	}
+	if v&1 == 1 {
+		return int64(v>>1) + 1, nil
+	}
+	return -int64(v >> 1), nil
+}
+
+func (r *ReaderError) ReadExpGolomb() (v uint64) {
+	if r.err == nil {
+		v, r.err = r.reader.ReadExpGolomb()
+	}
+	return
+}
+
+func (r *ReaderError) ReadSignedExpGolomb() (v int64) {
+	if r.err == nil {
+		v, r.err = r.reader.ReadSignedExpGolomb()
+	}
+	return
+}
+
+func signedExpGolomb(v int64) uint64 {
+	if v > 0 {
+		return uint64(v)<<1 - 1
+	}
+	return -uint64(v) << 1
+}
+
+// msbFirst converts between the lowest n bits of v and the value whose stream representation
+// in the order o starts with the most significant of those bits.
+func msbFirst(v uint64, n byte, o BitOrder) uint64 {
+	if o == MSBFirst || n == 0 {
+		return v
+	}
+	return bits.Reverse64(v) >> (64 - n)
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"errors"
+	"io"
+	"math"
+	"testing"
+)
+
+func TestExpGolomb(t *testing.T) {
+	tests := []struct {
+		v    uint64
+		code string
+	}{
+		{v: 0, code: "1"},
+		{v: 1, code: "010"},
+		{v: 2, code: "011"},
+		{v: 3, code: "00100"},
+		{v: 8, code: "0001001"},
+	}
+
+	for _, test := range tests {
+		for _, o := range []BitOrder{LSBFirst, MSBFirst} {
+			w := &Writer{order: o}
+			w.WriteExpGolomb(test.v)
+
+			r := &Reader{data: w.BitData(), end: w.BitsWritten(), order: o}
+			var code string
+			for r.bitsRead < r.end {
+				if b, _ := r.ReadBool(); b {
+					code += "1"
+				} else {
+					code += "0"
+				}
+			}
+			if code != test.code {
+				t.Errorf("code mismatch for %d: want=%s got=%s", test.v, test.code, code)
+			}
+
+			r = &Reader{data: w.BitData(), end: w.BitsWritten(), order: o}
+			if v, err := r.ReadExpGolomb(); err != nil || v != test.v {
+				t.Errorf("value mismatch: want=%d got=%d err=%v", test.v, v, err)
+			}
+		}
+	}
+}
+
+func TestExpGolombRange(t *testing.T) {
+	unsigned := []uint64{0, 1, 1<<32 - 1, math.MaxUint64 - 1, math.MaxUint64}
+	signed := []int64{0, 1, -1, 2, -2, math.MaxInt64, math.MinInt64 + 1}
+
+	w := NewWriterMSB()
+	w.WriteBool(true)
+	for _, v := range unsigned {
+		w.WriteExpGolomb(v)
+	}
+	for _, v := range signed {
+		w.WriteSignedExpGolomb(v)
+	}
+
+	r := NewReaderErrorMSB(w.BitData())
+	r.Skip(1)
+	for _, v := range unsigned {
+		if got := r.ReadExpGolomb(); got != v {
+			t.Errorf("value mismatch: want=%d got=%d", v, got)
+		}
+	}
+	for _, v := range signed {
+		if got := r.ReadSignedExpGolomb(); got != v {
+			t.Errorf("value mismatch: want=%d got=%d", v, got)
+		}
+	}
+	if err := r.Error(); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
+
+func TestExpGolombErrors(t *testing.T) {
+	r := NewReaderMSB(BitData{0, 0})
+	if _, err := r.ReadExpGolomb(); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("want=%v got=%v", io.ErrUnexpectedEOF, err)
+	}
+	if r.BitsRead() != 0 {
+		t.Errorf("read position changed: %d", r.BitsRead())
+	}
+
+	// 72 leading zeros
+	r = NewReaderMSB(append(make(BitData, 9), 0xFF))
+	if _, err := r.ReadExpGolomb(); !errors.Is(err, ErrVarintOverflow) {
+		t.Errorf("want=%v got=%v", ErrVarintOverflow, err)
+	}
+
+	w := NewWriterMSB()
+	w.WriteExpGolomb(math.MaxUint64)
+	if _, err := NewReaderMSB(w.BitData()).ReadSignedExpGolomb(); !errors.Is(err, ErrVarintOverflow) {
+		t.Errorf("want=%v got=%v", ErrVarintOverflow, err)
+	}
+}
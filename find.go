@@ -0,0 +1,111 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"io"
+)
+
+// Find returns the bit offset of the first occurrence of the patternBits bits wide pattern at or after
+// the bit offset fromBit. The pattern is matched the way Write64 would write it, so the lowest bit of
+// the pattern is the first bit in the stream. The second return value is false if there's no match.
+func (d BitData) Find(pattern uint64, patternBits byte, fromBit uint) (uint, bool) {
+	r := NewReader(d)
+	r.bitsRead = fromBit
+	return findBits(r, pattern, patternBits)
+}
+
+// Find returns the bit offset of the next occurrence of the pattern, searching from the current position
+// to the end of the reader. The read position is not changed; use Skip to move to the match.
+func (r *Reader) Find(pattern uint64, patternBits byte) (uint, bool) {
+	rr := *r
+	rr.trace = nil
+	return findBits(&rr, pattern, patternBits)
+}
+
+// findBits searches from the current position of the reader, which it advances while reading ahead.
+func findBits(r *Reader, pattern uint64, patternBits byte) (uint, bool) {
+	from, end := r.bitsRead, r.end
+	if patternBits > 64 || from > end || end-from < uint(patternBits) {
+		return 0, false
+	}
+	if patternBits == 0 {
+		return from, true
+	}
+
+	pattern &= mask[uint64](patternBits)
+	top := uint64(1) << (patternBits - 1)
+
+	window, err := read[uint64](r, patternBits)
+	if err != nil {
+		return 0, false
+	}
+
+	var (
+		ahead     uint64
+		aheadBits byte
+	)
+
+	for pos := from; ; pos++ {
+		if window == pattern {
+			return pos, true
+		}
+
+		if pos+uint(patternBits) >= end {
+			return 0, false
+		}
+
+		if aheadBits == 0 {
+			aheadBits = byte(min(64, end-r.bitsRead))
+			if ahead, err = read[uint64](r, aheadBits); err != nil {
+				return 0, false
+			}
+		}
+
+		if r.order == MSBFirst {
+			aheadBits--
+			window = window<<1&mask[uint64](patternBits) | ahead>>aheadBits&1
+		} else {
+			window >>= 1
+			window |= ahead & 1 * top
+			ahead >>= 1
+			aheadBits--
+		}
+	}
+}
+
+// ReadUntil reads the bits up to the next occurrence of the pattern and returns them with their count.
+// The delimiter itself is consumed but not returned. If the pattern isn't found, io.ErrUnexpectedEOF
+// is returned and the read position is left unchanged.
+func (r *Reader) ReadUntil(pattern uint64, patternBits byte) (BitData, uint, error) {
+	w := &Writer{order: r.order}
+	n, err := r.CopyUntil(w, pattern, patternBits)
+	if err != nil {
+		return nil, 0, err
+	}
+	return w.BitData(), n, nil
+}
+
+// CopyUntil is like ReadUntil, but it writes the bits before the delimiter to w.
+func (r *Reader) CopyUntil(w *Writer, pattern uint64, patternBits byte) (uint, error) {
+	pos, ok := r.Find(pattern, patternBits)
+	if !ok {
+		return 0, io.ErrUnexpectedEOF
+	}
+
+	n := pos - r.bitsRead
+	rr := *r
+	if err := copyBits(w, &rr, n); err != nil {
+		return 0, err
+	}
+	r.bitsRead = pos + uint(patternBits)
+
+	return n, nil
+}
+
+func (r *ReaderError) ReadUntil(pattern uint64, patternBits byte) (d BitData, n uint) {
+	if r.err == nil {
+		d, n, r.err = r.reader.ReadUntil(pattern, patternBits)
+	}
+	return
+}
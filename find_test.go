@@ -0,0 +1,108 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"io"
+	"testing"
+)
+
+func TestFind(t *testing.T) {
+	w := NewWriter()
+	w.Write8(0b101, 3)
+	w.Write32(0x000001, 24) // start code at bit 3
+	w.Write16(0xABCD, 16)
+	w.Write32(0x000001, 24) // start code at bit 43
+	w.Write8(0b1, 1)
+	d := w.BitData()
+
+	tests := []struct {
+		name    string
+		pattern uint64
+		bits    byte
+		from    uint
+		exp     uint
+		expOk   bool
+	}{
+		{name: "first", pattern: 0x000001, bits: 24, from: 0, exp: 3, expOk: true},
+		{name: "at-from", pattern: 0x000001, bits: 24, from: 3, exp: 3, expOk: true},
+		{name: "second", pattern: 0x000001, bits: 24, from: 4, exp: 43, expOk: true},
+		{name: "none", pattern: 0x000001, bits: 24, from: 44, expOk: false},
+		{name: "word", pattern: 0xABCD, bits: 16, from: 0, exp: 27, expOk: true},
+		{name: "single-bit", pattern: 1, bits: 1, from: 1, exp: 2, expOk: true},
+		{name: "last-bit", pattern: 0b1_1, bits: 2, from: 60, expOk: false},
+		{name: "empty", pattern: 0, bits: 0, from: 7, exp: 7, expOk: true},
+		{name: "too-wide", pattern: 0, bits: 65, from: 0, expOk: false},
+		{name: "past-end", pattern: 0, bits: 1, from: 100, expOk: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pos, ok := d.Find(test.pattern, test.bits, test.from)
+			if ok != test.expOk || pos != test.exp {
+				t.Errorf("want=%d,%t got=%d,%t", test.exp, test.expOk, pos, ok)
+			}
+		})
+	}
+
+	r := NewReader(d)
+	r.Skip(4)
+	if pos, ok := r.Find(0x000001, 24); !ok || pos != 43 {
+		t.Errorf("want=43,true got=%d,%t", pos, ok)
+	}
+	if r.BitsRead() != 4 {
+		t.Errorf("reader moved: %d", r.BitsRead())
+	}
+}
+
+func TestReadUntil(t *testing.T) {
+	const sync, syncBits = 0x7E, 8
+
+	w := NewWriter()
+	w.Write16(0x1234, 13)
+	w.Write8(sync, syncBits)
+	w.Write8(0b101, 3)
+	w.Write8(sync, syncBits)
+	w.Write8(sync, syncBits)
+	w.Write8(0b11, 2)
+
+	r := NewReaderError(w.BitData())
+
+	d, n := r.ReadUntil(sync, syncBits)
+	if n != 13 || NewReaderError(d).Read16(13) != 0x1234 {
+		t.Errorf("first record mismatch: n=%d data=%x", n, d)
+	}
+
+	d, n = r.ReadUntil(sync, syncBits)
+	if n != 3 || d[0] != 0b101 {
+		t.Errorf("second record mismatch: n=%d data=%x", n, d)
+	}
+
+	d, n = r.ReadUntil(sync, syncBits)
+	if n != 0 || len(d) != 0 {
+		t.Errorf("empty record mismatch: n=%d data=%x", n, d)
+	}
+
+	if err := r.Error(); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	rr := NewReader(w.BitData())
+	rr.Skip(r.reader.BitsRead())
+	if _, _, err := rr.ReadUntil(sync, syncBits); err != io.ErrUnexpectedEOF {
+		t.Errorf("expected EOF, got %v", err)
+	}
+	if rr.BitsRead() != r.reader.BitsRead() {
+		t.Errorf("reader advanced on error")
+	}
+
+	out := NewWriter()
+	out.WriteBool(true)
+	rr = NewReader(w.BitData())
+	if n, err := rr.CopyUntil(out, sync, syncBits); err != nil || n != 13 {
+		t.Errorf("copy mismatch: n=%d err=%v", n, err)
+	}
+	if out.BitsWritten() != 14 || NewReaderError(out.BitData()).Read16(14) != 0x1234<<1|1 {
+		t.Errorf("copied data mismatch: %x", out.BitData())
+	}
+}
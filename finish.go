@@ -0,0 +1,110 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"errors"
+)
+
+var (
+	ErrUnreadBits = errors.New("unread bits remain")
+	ErrUnaligned  = errors.New("data doesn't end on a byte boundary")
+)
+
+// PaddingPolicy tells what is allowed after the last field of a stream.
+type PaddingPolicy byte
+
+const (
+	// PaddingNone requires the stream to end exactly after the last field.
+	PaddingNone PaddingPolicy = iota
+	// PaddingZeros allows zero bits up to the next byte boundary after the last field.
+	PaddingZeros
+)
+
+// Finish checks that the whole stream was read. It returns ErrUnreadBits if bits remain that the padding
+// policy doesn't allow. The read position is moved past the allowed padding.
+func (r *Reader) Finish(p PaddingPolicy) error {
+	// A source that finds its end only while reading is read just past the padding that may remain.
+	if err := r.probe((8-r.bitsRead%8)%8 + 1); err != nil {
+		return err
+	}
+
+	remaining := r.end - r.bitsRead
+	if remaining == 0 {
+		return nil
+	}
+
+	if p != PaddingZeros || remaining > (8-r.bitsRead%8)%8 {
+		return ErrUnreadBits
+	}
+
+	v, err := read[uint8](r, byte(remaining))
+	if err != nil {
+		return err
+	}
+	if v != 0 {
+		r.bitsRead -= remaining
+		return ErrUnreadBits
+	}
+
+	return nil
+}
+
+func (r *ReaderError) Finish(p PaddingPolicy) {
+	if r.err == nil {
+		r.err = r.reader.Finish(p)
+	}
+}
+
+// EndedEarly reports whether a reader created with WithOptionalTail has returned zero for a read past the end.
+func (r *Reader) EndedEarly() bool {
+	return r.ended
+}
+
+func (r *ReaderError) EndedEarly() bool {
+	return r.reader.ended
+}
+
+// atEnd reports whether only the padding allowed by the padding policy of the reader remains.
+func (r *Reader) atEnd() bool {
+	if r.bitsRead >= r.end {
+		return r.bitsRead == r.end
+	}
+
+	remaining := r.end - r.bitsRead
+	if r.padding != PaddingZeros || remaining > (8-r.bitsRead%8)%8 {
+		return false
+	}
+
+	rr := *r
+	rr.optional = false
+	v, err := read[uint8](&rr, byte(remaining))
+
+	return err == nil && v == 0
+}
+
+// Finish completes the stream according to the padding policy: with PaddingZeros it writes zero bits
+// up to the next byte boundary, with PaddingNone it returns ErrUnaligned if the stream doesn't end
+// on a byte boundary.
+func (w *Writer) Finish(p PaddingPolicy) error {
+	if p == PaddingZeros {
+		w.AlignToByte()
+		return nil
+	}
+
+	if w.bitsWritten%8 != 0 {
+		return ErrUnaligned
+	}
+
+	return nil
+}
+
+// Close checks the end of the stream with the padding policy set by WithPadding, like Finish.
+func (r *Reader) Close() error {
+	return r.Finish(r.padding)
+}
+
+// Close completes the stream with the padding policy set by WithPadding, like Finish.
+func (w *Writer) Close() error {
+	return w.Finish(w.padding)
+}
@@ -0,0 +1,185 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"testing"
+)
+
+func TestReaderFinish(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   BitData
+		read   byte
+		policy PaddingPolicy
+		err    error
+	}{
+		{name: "exact", data: BitData{0xFF}, read: 8, policy: PaddingNone, err: nil},
+		{name: "padding not allowed", data: BitData{0x0F}, read: 4, policy: PaddingNone, err: ErrUnreadBits},
+		{name: "zero padding", data: BitData{0x0F}, read: 4, policy: PaddingZeros, err: nil},
+		{name: "nonzero padding", data: BitData{0x8F}, read: 4, policy: PaddingZeros, err: ErrUnreadBits},
+		{name: "whole byte left", data: BitData{0xFF, 0x00}, read: 8, policy: PaddingZeros, err: ErrUnreadBits},
+	}
+
+	for _, test := range tests {
+		r := NewReader(test.data)
+		r.Skip(uint(test.read))
+
+		if err := r.Finish(test.policy); !errors.Is(err, test.err) {
+			t.Errorf("%s: want=%v got=%v", test.name, test.err, err)
+		}
+	}
+
+	for _, o := range []BitOrder{LSBFirst, MSBFirst} {
+		w := &Writer{order: o}
+		w.Write8(5, 3)
+		if err := w.Finish(PaddingNone); !errors.Is(err, ErrUnaligned) {
+			t.Errorf("order %d: expected ErrUnaligned, got %v", o, err)
+		}
+		if err := w.Finish(PaddingZeros); err != nil || w.BitsWritten() != 8 {
+			t.Errorf("order %d: padding failed: %v", o, err)
+		}
+
+		r := NewReaderError(w.BitData())
+		r.reader.order = o
+		r.Read8(3)
+		r.Finish(PaddingZeros)
+		if r.Error() != nil {
+			t.Errorf("order %d: unexpected error: %v", o, r.Error())
+		}
+	}
+}
+
+func TestReaderOptionalTail(t *testing.T) {
+	old := NewWriter()
+	old.Write8(0xA5, 8)
+	old.Write8(9, 4)
+	_ = old.Finish(PaddingZeros)
+
+	r := NewReader(old.BitData(), WithOptionalTail(), WithPadding(PaddingZeros))
+	a, _ := r.Read8(8)
+	b, _ := r.Read8(4)
+	if r.EndedEarly() {
+		t.Errorf("ended before the added fields")
+	}
+
+	c, err := r.Read16(16)
+	if err != nil || c != 0 {
+		t.Errorf("expected zero for a missing field, got %d, %v", c, err)
+	}
+	d, err := r.ReadBool()
+	if err != nil || d {
+		t.Errorf("expected false for a missing field, got %v, %v", d, err)
+	}
+	if a != 0xA5 || b != 9 || !r.EndedEarly() {
+		t.Errorf("unexpected values %x %d, ended=%v", a, b, r.EndedEarly())
+	}
+	if err := r.Close(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// Without a padding policy the padding bits are a truncated field.
+	r = NewReader(old.BitData(), WithOptionalTail())
+	_, _ = r.Read16(12)
+	if _, err := r.Read8(8); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("expected io.ErrUnexpectedEOF, got %v", err)
+	}
+
+	// A field cut in the middle is still an error.
+	r = NewReader(BitData{0xFF, 0x0F}, WithOptionalTail(), WithPadding(PaddingZeros))
+	_, _ = r.Read8(8)
+	if _, err := r.Read16(12); !errors.Is(err, io.ErrUnexpectedEOF) || r.EndedEarly() {
+		t.Errorf("expected io.ErrUnexpectedEOF, got %v", err)
+	}
+
+	rr := NewReaderError(BitData{0x01}, WithOptionalTail())
+	_ = rr.Read8(8)
+	if v := rr.Read32(32); v != 0 || rr.Error() != nil || !rr.EndedEarly() {
+		t.Errorf("unexpected %d, %v", v, rr.Error())
+	}
+}
+
+func TestReaderOptionalTailBulk(t *testing.T) {
+	reads := []struct {
+		name string
+		read func(r *Reader) error
+	}{
+		{"ReadBytesInto", func(r *Reader) error {
+			b := []byte{1, 2, 3}
+			if err := r.ReadBytesInto(b); err != nil || b[0]|b[1]|b[2] != 0 {
+				return fmt.Errorf("got %v, %w", b, err)
+			}
+			return nil
+		}},
+		{"ReadBools", func(r *Reader) error {
+			v, err := r.ReadBools(20)
+			if err != nil || len(v) != 20 || slices.Contains(v, true) {
+				return fmt.Errorf("got %v, %w", v, err)
+			}
+			return nil
+		}},
+		{"ReadNibbles", func(r *Reader) error {
+			b := []byte{1, 2}
+			if err := r.ReadNibbles(b); err != nil || b[0]|b[1] != 0 {
+				return fmt.Errorf("got %v, %w", b, err)
+			}
+			return nil
+		}},
+		{"ReadIP", func(r *Reader) error {
+			ip, err := r.ReadIP()
+			if err != nil || !ip.IsUnspecified() {
+				return fmt.Errorf("got %v, %w", ip, err)
+			}
+			return nil
+		}},
+		{"SkipChecked", func(r *Reader) error { return r.SkipChecked(9) }},
+		{"ExpectZeros", func(r *Reader) error { return r.ExpectZeros(9) }},
+	}
+
+	for _, c := range reads {
+		r := NewReader(BitData{0xA5}, WithOptionalTail())
+		_, _ = r.Read8(8)
+		if err := c.read(r); err != nil {
+			t.Errorf("%s: %v", c.name, err)
+		}
+		if !r.EndedEarly() {
+			t.Errorf("%s: not ended early", c.name)
+		}
+
+		r = NewReader(BitData{0xA5}, WithOptionalTail())
+		_, _ = r.Read8(4)
+		if err := c.read(r); !errors.Is(err, io.ErrUnexpectedEOF) || r.EndedEarly() {
+			t.Errorf("%s: expected io.ErrUnexpectedEOF in the middle of the data, got %v", c.name, err)
+		}
+	}
+}
+
+func TestReaderOptionalTailExpGolomb(t *testing.T) {
+	reads := []struct {
+		name string
+		read func(r *Reader) (uint64, error)
+	}{
+		{"ReadExpGolomb", func(r *Reader) (uint64, error) { return r.ReadExpGolomb() }},
+		{"ReadSignedExpGolomb", func(r *Reader) (uint64, error) { v, err := r.ReadSignedExpGolomb(); return uint64(v), err }},
+		{"ReadRice", func(r *Reader) (uint64, error) { return r.ReadRice(3) }},
+	}
+
+	for _, c := range reads {
+		r := NewReader(BitData{0xA5}, WithOptionalTail())
+		_, _ = r.Read8(8)
+		if v, err := c.read(r); err != nil || v != 0 || !r.EndedEarly() {
+			t.Errorf("%s: expected zero at the end, got %d, %v, ended=%v", c.name, v, err, r.EndedEarly())
+		}
+
+		// The unary prefix is cut by the end.
+		r = NewReader(BitData{0x0F}, WithOptionalTail())
+		_, _ = r.Read8(4)
+		if _, err := c.read(r); !errors.Is(err, io.ErrUnexpectedEOF) || r.EndedEarly() || r.BitsRead() != 4 {
+			t.Errorf("%s: expected io.ErrUnexpectedEOF in the middle of a code, got %v", c.name, err)
+		}
+	}
+}
@@ -0,0 +1,203 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"errors"
+	"math/bits"
+)
+
+var (
+	ErrInvalidResidual   = errors.New("invalid FLAC residual")
+	ErrInvalidFLACNumber = errors.New("invalid FLAC coded number")
+)
+
+// maxFLACNumber is the limit of the UTF-8 like coding of FLAC frame and sample numbers, 36 bits.
+const maxFLACNumber = 1<<36 - 1
+
+// WriteFLACResidual writes the residual of a FLAC subframe using partitioned Rice coding with 2^partitionOrder
+// partitions. The block has len(residual)+predictorOrder samples, the first predictorOrder being the warm-up
+// samples that have no residual. A Rice parameter is chosen for every partition from its mean value.
+// The FLAC functions expect a writer or reader in the MSBFirst order.
+func (w *Writer) WriteFLACResidual(residual []int32, predictorOrder int, partitionOrder byte) {
+	blockSize := len(residual) + predictorOrder
+	if partitionOrder > 15 || blockSize%(1<<partitionOrder) != 0 || blockSize>>partitionOrder < predictorOrder {
+		panic("bitdata: invalid FLAC partition order")
+	}
+
+	partitions := make([][]int32, 1<<partitionOrder)
+	params := make([]byte, len(partitions))
+	wide := false
+	for i := range partitions {
+		n := blockSize >> partitionOrder
+		if i == 0 {
+			n -= predictorOrder
+		}
+		partitions[i], residual = residual[:n], residual[n:]
+		params[i] = riceParam(partitions[i])
+		wide = wide || params[i] > 14
+	}
+
+	paramBits := byte(4)
+	if wide {
+		paramBits = 5
+		w.Write8(1, 2) // RESIDUAL_CODING_METHOD_PARTITIONED_EXP_GOLOMB2
+	} else {
+		w.Write8(0, 2) // RESIDUAL_CODING_METHOD_PARTITIONED_RICE
+	}
+	w.Write8(partitionOrder, 4)
+
+	for i, p := range partitions {
+		w.Write8(params[i], paramBits)
+		for _, v := range p {
+			w.WriteSignedRice(int64(v), params[i])
+		}
+	}
+}
+
+// ReadFLACResidual reads the residual of a FLAC subframe of blockSize samples with predictorOrder warm-up samples,
+// including partitions stored with the escape code as raw signed values. It returns ErrInvalidResidual
+// if the coding method or the partition order doesn't fit the block. On error the read position is left unchanged.
+func (r *Reader) ReadFLACResidual(blockSize, predictorOrder int) (res []int32, err error) {
+	start := r.bitsRead
+	defer func() {
+		if err != nil {
+			r.bitsRead = start
+			res = nil
+		}
+	}()
+
+	rr := ReaderError{reader: *r}
+	method := rr.Read8(2)
+	order := rr.Read8(4)
+	if err = rr.err; err != nil {
+		return
+	}
+	if method > 1 || blockSize%(1<<order) != 0 || blockSize>>order < predictorOrder {
+		err = ErrInvalidResidual
+		return
+	}
+
+	paramBits := 4 + method
+	escape := mask[byte](paramBits)
+
+	res = make([]int32, 0, blockSize-predictorOrder)
+	for i := 0; i < 1<<order && rr.err == nil; i++ {
+		n := blockSize >> order
+		if i == 0 {
+			n -= predictorOrder
+		}
+
+		k := rr.Read8(paramBits)
+		if k != escape {
+			for j := 0; j < n && rr.err == nil; j++ {
+				v := rr.ReadSignedRice(k)
+				if v < -1<<31 || v > 1<<31-1 {
+					rr.err = ErrInvalidResidual
+				}
+				res = append(res, int32(v))
+			}
+			continue
+		}
+
+		width := rr.Read8(5)
+		for j := 0; j < n && rr.err == nil; j++ {
+			res = append(res, int32(signExtend(rr.Read64(width), width)))
+		}
+	}
+
+	if err = rr.err; err != nil {
+		return
+	}
+
+	*r = rr.reader
+
+	return
+}
+
+// WriteFLACNumber writes v, at most 36 bits, in the UTF-8 like coding FLAC frame headers use
+// for frame and sample numbers. It panics if v is too big.
+func (w *Writer) WriteFLACNumber(v uint64) {
+	if v > maxFLACNumber {
+		panic("bitdata: FLAC number out of range")
+	}
+
+	if v < 0x80 {
+		w.Write8(byte(v), 8)
+		return
+	}
+
+	// Every continuation byte holds 6 bits, the lead byte 6-n bits for n continuation bytes.
+	n := 1
+	for v>>(6*n) >= 1<<(6-n) {
+		n++
+	}
+
+	w.Write8(^byte(0xFF>>(n+1))|byte(v>>(6*n)), 8)
+	for i := n - 1; i >= 0; i-- {
+		w.Write8(0x80|byte(v>>(6*i))&0x3F, 8)
+	}
+}
+
+// ReadFLACNumber reads a number written with WriteFLACNumber. It returns ErrInvalidFLACNumber
+// if the bytes aren't a valid coding. On error the read position is left unchanged.
+func (r *Reader) ReadFLACNumber() (uint64, error) {
+	start := r.bitsRead
+
+	lead, err := r.Read8(8)
+	if err != nil {
+		return 0, err
+	}
+
+	n := bits.LeadingZeros8(^lead) - 1
+	if n < 0 {
+		return uint64(lead), nil
+	}
+	if n == 0 || n > 6 {
+		r.bitsRead = start
+		return 0, ErrInvalidFLACNumber
+	}
+
+	v := uint64(lead & (0x7F >> (n + 1)))
+	for i := 0; i < n; i++ {
+		b, err := r.Read8(8)
+		if err == nil && b&0xC0 != 0x80 {
+			err = ErrInvalidFLACNumber
+		}
+		if err != nil {
+			r.bitsRead = start
+			return 0, err
+		}
+		v = v<<6 | uint64(b&0x3F)
+	}
+
+	return v, nil
+}
+
+func (r *ReaderError) ReadFLACResidual(blockSize, predictorOrder int) (res []int32) {
+	if r.err == nil {
+		res, r.err = r.reader.ReadFLACResidual(blockSize, predictorOrder)
+	}
+	return
+}
+
+func (r *ReaderError) ReadFLACNumber() (v uint64) {
+	if r.err == nil {
+		v, r.err = r.reader.ReadFLACNumber()
+	}
+	return
+}
+
+// riceParam returns a Rice parameter close to the optimal one for the zigzag encoded values of p.
+func riceParam(p []int32) byte {
+	if len(p) == 0 {
+		return 0
+	}
+
+	var sum uint64
+	for _, v := range p {
+		sum += zigzag(int64(v))
+	}
+
+	return byte(min(bits.Len64(sum/uint64(len(p))), 30))
+}
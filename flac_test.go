@@ -0,0 +1,112 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"bytes"
+	"errors"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestFLACResidual(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+
+	tests := []struct {
+		blockSize      int
+		predictorOrder int
+		partitionOrder byte
+		scale          int32
+	}{
+		{blockSize: 16, predictorOrder: 0, partitionOrder: 0, scale: 1},
+		{blockSize: 4096, predictorOrder: 2, partitionOrder: 4, scale: 100},
+		{blockSize: 1152, predictorOrder: 8, partitionOrder: 3, scale: 1 << 20},
+	}
+
+	for _, test := range tests {
+		residual := make([]int32, test.blockSize-test.predictorOrder)
+		for i := range residual {
+			residual[i] = rnd.Int31n(2*test.scale+1) - test.scale
+		}
+
+		w := NewWriterMSB()
+		w.WriteFLACResidual(residual, test.predictorOrder, test.partitionOrder)
+		w.Write8(0xA5, 8)
+
+		r := NewReaderErrorMSB(w.BitData())
+		got := r.ReadFLACResidual(test.blockSize, test.predictorOrder)
+		if err := r.Error(); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if !slices.Equal(residual, got) {
+			t.Errorf("residual mismatch for block size %d", test.blockSize)
+		}
+		if v := r.Read8(8); v != 0xA5 {
+			t.Errorf("end mismatch: %x", v)
+		}
+	}
+}
+
+func TestFLACResidualEscape(t *testing.T) {
+	w := NewWriterMSB()
+	w.Write8(0, 2)  // method
+	w.Write8(1, 4)  // partition order
+	w.Write8(15, 4) // escape
+	w.Write8(3, 5)  // raw width
+	w.Write8(0b111, 3)
+	w.Write8(0b011, 3)
+	w.Write8(1, 4) // Rice parameter
+	w.WriteSignedRice(-2, 1)
+	w.WriteSignedRice(5, 1)
+
+	r := NewReaderMSB(w.BitData())
+	got, err := r.ReadFLACResidual(4, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if want := []int32{-1, 3, -2, 5}; !slices.Equal(want, got) {
+		t.Errorf("want=%v got=%v", want, got)
+	}
+
+	// partition order too big for the block
+	r = NewReaderMSB(BitData{0b00_0011_00})
+	if _, err = r.ReadFLACResidual(4, 0); !errors.Is(err, ErrInvalidResidual) {
+		t.Errorf("want=%v got=%v", ErrInvalidResidual, err)
+	}
+	if r.BitsRead() != 0 {
+		t.Errorf("read position changed: %d", r.BitsRead())
+	}
+}
+
+func TestFLACNumber(t *testing.T) {
+	tests := []struct {
+		v    uint64
+		want []byte
+	}{
+		{v: 0, want: []byte{0}},
+		{v: 0x7F, want: []byte{0x7F}},
+		{v: 0x80, want: []byte{0xC2, 0x80}},
+		{v: 0x20AC, want: []byte{0xE2, 0x82, 0xAC}},
+		{v: 0x10FFFF, want: []byte{0xF4, 0x8F, 0xBF, 0xBF}},
+		{v: maxFLACNumber, want: []byte{0xFE, 0xBF, 0xBF, 0xBF, 0xBF, 0xBF, 0xBF}},
+	}
+
+	for _, test := range tests {
+		w := NewWriterMSB()
+		w.WriteFLACNumber(test.v)
+		if !bytes.Equal(test.want, w.BitData()) {
+			t.Errorf("encoding mismatch for %#x: want=%x got=%x", test.v, test.want, w.BitData())
+		}
+
+		if v, err := NewReaderMSB(w.BitData()).ReadFLACNumber(); err != nil || v != test.v {
+			t.Errorf("value mismatch: want=%#x got=%#x err=%v", test.v, v, err)
+		}
+	}
+
+	for _, d := range []BitData{{0x80}, {0xFF}, {0xC2, 0x40}} {
+		if _, err := NewReaderMSB(d).ReadFLACNumber(); !errors.Is(err, ErrInvalidFLACNumber) {
+			t.Errorf("want=%v for %x got=%v", ErrInvalidFLACNumber, d, err)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"errors"
+	"io"
+)
+
+// FrameFormat sets the width of the bit length of frames and the CRC that protects them.
+// The CRC covers the length and the payload.
+type FrameFormat struct {
+	LenBits byte
+	CRC     CRC
+}
+
+// WriteFrame writes a frame: the payload length in bits, the first bitCount bits of the payload
+// and the CRC. It returns ErrLengthOverflow if the length doesn't fit into LenBits bits.
+func (w *Writer) WriteFrame(f FrameFormat, payload BitData, bitCount uint) error {
+	if f.LenBits == 0 || f.LenBits > 64 || uint64(bitCount) > mask[uint64](f.LenBits) {
+		return ErrLengthOverflow
+	}
+	if bitCount > uint(len(payload))*8 {
+		return ErrInvalidRange
+	}
+
+	start := w.bitsWritten
+	w.Write64(uint64(bitCount), f.LenBits)
+	writeBits(w, payload, 0, bitCount)
+	w.WriteCRC(f.CRC, start)
+
+	return nil
+}
+
+// FrameIterator iterates over consecutive frames, skipping the frames with a wrong CRC.
+//
+//	it := r.Frames(format)
+//	for it.Next() {
+//		process(it.Payload())
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type FrameIterator struct {
+	r       *Reader
+	f       FrameFormat
+	payload *Reader
+	skipped int
+	err     error
+}
+
+// Frames returns an iterator over frames starting at the current position. Iteration ends when fewer bits
+// than an empty frame remain, which allows for padding at the end of data. A frame whose length
+// points past the end of data stops the iteration with io.ErrUnexpectedEOF.
+func (r *Reader) Frames(f FrameFormat) *FrameIterator {
+	return &FrameIterator{r: r, f: f}
+}
+
+func (it *FrameIterator) Next() bool {
+	overhead := uint(it.f.LenBits) + uint(it.f.CRC.Width())
+
+	for it.err == nil && it.r.bitsRead <= it.r.end && it.r.end-it.r.bitsRead >= overhead {
+		start := it.r.bitsRead
+
+		n, err := it.r.Read64(it.f.LenBits)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		if n > uint64(it.r.end-it.r.bitsRead-uint(it.f.CRC.Width())) {
+			it.r.bitsRead = start
+			it.err = io.ErrUnexpectedEOF
+			return false
+		}
+
+		payload := it.r.Cursor()
+		payload.end = it.r.bitsRead + uint(n)
+		it.r.bitsRead = payload.end
+
+		err = it.r.VerifyCRC(it.f.CRC, start)
+		if errors.Is(err, ErrCRCMismatch) {
+			it.skipped++
+			continue
+		}
+		if err != nil {
+			it.err = err
+			return false
+		}
+
+		it.payload = payload
+		return true
+	}
+
+	return false
+}
+
+// Payload returns a reader limited to the payload of the current frame.
+func (it *FrameIterator) Payload() *Reader {
+	return it.payload
+}
+
+// Skipped returns the number of frames skipped so far because of a wrong CRC.
+func (it *FrameIterator) Skipped() int {
+	return it.skipped
+}
+
+func (it *FrameIterator) Err() error {
+	return it.err
+}
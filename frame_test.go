@@ -0,0 +1,66 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestFrames(t *testing.T) {
+	f := FrameFormat{LenBits: 12, CRC: CRC16CCITT}
+
+	payloads := []string{"1", "0110 1", "", "1111 0000 1111 0000 1"}
+
+	w := NewWriter()
+	for _, p := range payloads {
+		d, n, _ := ParseBits(p)
+		if err := w.WriteFrame(f, d, n); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	read := func(d BitData) ([]string, int, error) {
+		var got []string
+		it := NewReader(d).Frames(f)
+		for it.Next() {
+			p := it.Payload()
+			n := p.end - p.BitsRead()
+			v, _ := p.Read32(byte(n))
+			b := NewWriter()
+			b.Write32(v, byte(n))
+			got = append(got, b.BitData().FormatBinary(n, 4))
+		}
+		return got, it.Skipped(), it.Err()
+	}
+
+	got, skipped, err := read(w.BitData())
+	if err != nil || skipped != 0 || len(got) != len(payloads) {
+		t.Fatalf("got=%q skipped=%d err=%v", got, skipped, err)
+	}
+	for i := range payloads {
+		if got[i] != payloads[i] {
+			t.Errorf("frame %d: want=%q got=%q", i, payloads[i], got[i])
+		}
+	}
+
+	// Corrupt the payload of the second frame.
+	d := append(BitData(nil), w.BitData()...)
+	d[(12+1+16+13)/8] ^= 1 << ((12 + 1 + 16 + 13) % 8)
+
+	got, skipped, err = read(d)
+	if err != nil || skipped != 1 || len(got) != 3 || got[1] != "" {
+		t.Errorf("got=%q skipped=%d err=%v", got, skipped, err)
+	}
+
+	// Truncate the last frame.
+	_, _, err = read(w.BitData()[:len(w.BitData())-1])
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("expected io.ErrUnexpectedEOF, got %v", err)
+	}
+
+	if err := w.WriteFrame(f, make(BitData, 600), 4096); !errors.Is(err, ErrLengthOverflow) {
+		t.Errorf("expected ErrLengthOverflow, got %v", err)
+	}
+}
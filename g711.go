@@ -0,0 +1,239 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import "math/bits"
+
+// WriteNibbles writes the lowest 4 bits of each sample, as used by 4-bit ADPCM codecs.
+// In the LSBFirst order the first sample of a byte is in its low nibble, in the MSBFirst order in its high nibble.
+// The samples don't need to be byte aligned; 16 of them are written at a time.
+func (w *Writer) WriteNibbles(samples []byte) {
+	if w.recording || w.trace != nil {
+		for _, s := range samples {
+			w.Write8(s, 4)
+		}
+		return
+	}
+
+	for len(samples) >= 16 {
+		var v uint64
+		if w.order == MSBFirst {
+			for _, s := range samples[:16] {
+				v = v<<4 | uint64(s&0xF)
+			}
+		} else {
+			for i, s := range samples[:16] {
+				v |= uint64(s&0xF) << (4 * i)
+			}
+		}
+		write[uint64](w, v, 64)
+		samples = samples[16:]
+	}
+
+	for _, s := range samples {
+		write[byte](w, s, 4)
+	}
+}
+
+// ReadNibbles fills dst with 4-bit samples written by WriteNibbles. It returns io.ErrUnexpectedEOF and
+// leaves the read position unchanged if fewer than 4*len(dst) bits remain.
+func (r *Reader) ReadNibbles(dst []byte) error {
+	if ok, err := r.ensure(4 * uint(len(dst))); !ok {
+		if err == nil {
+			clear(dst)
+		}
+		return err
+	}
+
+	start := r.bitsRead
+	if r.trace != nil {
+		for i := range dst {
+			var err error
+			if dst[i], err = r.Read8(4); err != nil {
+				r.bitsRead = start
+				return err
+			}
+		}
+		return nil
+	}
+
+	for len(dst) >= 16 {
+		v, err := read[uint64](r, 64)
+		if err != nil {
+			r.bitsRead = start
+			return err
+		}
+		if r.order == MSBFirst {
+			v = bits.RotateLeft64(v, 4)
+		}
+		for i := range dst[:16] {
+			dst[i] = byte(v & 0xF)
+			if r.order == MSBFirst {
+				v = bits.RotateLeft64(v, 4)
+			} else {
+				v >>= 4
+			}
+		}
+		dst = dst[16:]
+	}
+
+	for i := range dst {
+		v, err := read[byte](r, 4)
+		if err != nil {
+			r.bitsRead = start
+			return err
+		}
+		dst[i] = v
+	}
+
+	return nil
+}
+
+func (r *ReaderError) ReadNibbles(dst []byte) {
+	if r.err == nil {
+		r.err = r.reader.ReadNibbles(dst)
+	}
+}
+
+// WriteALaw writes the 16-bit linear PCM samples as G.711 A-law bytes, 8 bits each.
+func (w *Writer) WriteALaw(pcm []int16) {
+	writeCompanded(w, pcm, ALawEncode)
+}
+
+// ReadALaw fills dst with the 16-bit linear PCM samples of G.711 A-law bytes. It returns io.ErrUnexpectedEOF
+// and leaves the read position unchanged if fewer than 8*len(dst) bits remain.
+func (r *Reader) ReadALaw(dst []int16) error {
+	return readCompanded(r, dst, ALawDecode)
+}
+
+func (r *ReaderError) ReadALaw(dst []int16) {
+	if r.err == nil {
+		r.err = r.reader.ReadALaw(dst)
+	}
+}
+
+// WriteMuLaw writes the 16-bit linear PCM samples as G.711 µ-law bytes, 8 bits each.
+func (w *Writer) WriteMuLaw(pcm []int16) {
+	writeCompanded(w, pcm, MuLawEncode)
+}
+
+// ReadMuLaw fills dst with the 16-bit linear PCM samples of G.711 µ-law bytes. It returns io.ErrUnexpectedEOF
+// and leaves the read position unchanged if fewer than 8*len(dst) bits remain.
+func (r *Reader) ReadMuLaw(dst []int16) error {
+	return readCompanded(r, dst, MuLawDecode)
+}
+
+func (r *ReaderError) ReadMuLaw(dst []int16) {
+	if r.err == nil {
+		r.err = r.reader.ReadMuLaw(dst)
+	}
+}
+
+func writeCompanded(w *Writer, pcm []int16, encode func(int16) byte) {
+	var buf [64]byte
+	for len(pcm) > 0 {
+		n := min(len(pcm), len(buf))
+		for i, s := range pcm[:n] {
+			buf[i] = encode(s)
+		}
+		w.WriteBitData(buf[:n], uint(n)*8)
+		pcm = pcm[n:]
+	}
+}
+
+func readCompanded(r *Reader, dst []int16, decode func(byte) int16) error {
+	if ok, err := r.ensure(8 * uint(len(dst))); !ok {
+		if err == nil {
+			clear(dst)
+		}
+		return err
+	}
+
+	start := r.bitsRead
+	var buf [64]byte
+	for len(dst) > 0 {
+		n := min(len(dst), len(buf))
+		if err := r.ReadBytesInto(buf[:n]); err != nil {
+			r.bitsRead = start
+			return err
+		}
+		for i, b := range buf[:n] {
+			dst[i] = decode(b)
+		}
+		dst = dst[n:]
+	}
+
+	return nil
+}
+
+// ALawEncode returns the G.711 A-law code of a 16-bit linear PCM sample.
+func ALawEncode(pcm int16) byte {
+	v := int(pcm) >> 3
+	m := byte(0xD5)
+	if v < 0 {
+		m = 0x55
+		v = -v - 1
+	}
+
+	seg := max(bits.Len(uint(v))-5, 0)
+	if seg >= 8 {
+		return 0x7F ^ m
+	}
+
+	a := byte(seg << 4)
+	if seg < 2 {
+		a |= byte(v>>1) & 0xF
+	} else {
+		a |= byte(v>>seg) & 0xF
+	}
+
+	return a ^ m
+}
+
+// ALawDecode returns the 16-bit linear PCM sample of a G.711 A-law code.
+func ALawDecode(a byte) int16 {
+	a ^= 0x55
+
+	t := int(a&0xF)<<4 + 8
+	if seg := (a >> 4) & 7; seg > 0 {
+		t = (t + 0x100) << (seg - 1)
+	}
+
+	if a&0x80 == 0 {
+		return int16(-t)
+	}
+	return int16(t)
+}
+
+const (
+	muLawBias = 0x84
+	muLawClip = 32635
+)
+
+// MuLawEncode returns the G.711 µ-law code of a 16-bit linear PCM sample.
+func MuLawEncode(pcm int16) byte {
+	v := int(pcm)
+	var sign byte
+	if v < 0 {
+		sign = 0x80
+		v = -v
+	}
+
+	v = min(v, muLawClip) + muLawBias
+	exp := bits.Len(uint(v)) - 8
+	mant := byte(v>>(exp+3)) & 0xF
+
+	return ^(sign | byte(exp)<<4 | mant)
+}
+
+// MuLawDecode returns the 16-bit linear PCM sample of a G.711 µ-law code.
+func MuLawDecode(u byte) int16 {
+	u = ^u
+
+	t := (int(u&0xF)<<3 + muLawBias) << ((u >> 4) & 7)
+
+	if u&0x80 != 0 {
+		return int16(muLawBias - t)
+	}
+	return int16(t - muLawBias)
+}
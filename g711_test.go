@@ -0,0 +1,105 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestNibbles(t *testing.T) {
+	samples := make([]byte, 37)
+	for i := range samples {
+		samples[i] = byte(i*7) & 0xF
+	}
+
+	for _, order := range []BitOrder{LSBFirst, MSBFirst} {
+		w := NewWriter(WithBitOrder(order))
+		w.WriteBool(true)
+		w.WriteNibbles(samples)
+
+		want := NewWriter(WithBitOrder(order))
+		want.WriteBool(true)
+		for _, s := range samples {
+			want.Write8(s, 4)
+		}
+		if !slices.Equal(w.BitData(), want.BitData()) {
+			t.Fatalf("%v: got %x, want %x", order, w.BitData(), want.BitData())
+		}
+
+		r := NewReader(w.BitData(), WithBitOrder(order))
+		r.Skip(1)
+		got := make([]byte, len(samples))
+		if err := r.ReadNibbles(got); err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(got, samples) {
+			t.Errorf("%v: got %v", order, got)
+		}
+
+		if err := r.ReadNibbles(got[:1]); err == nil {
+			t.Error("expected an error past the end")
+		}
+	}
+
+	w := NewWriter()
+	w.WriteNibbles([]byte{1, 2, 3, 4})
+	if d := w.BitData(); d[0] != 0x21 || d[1] != 0x43 {
+		t.Errorf("LSBFirst nibble layout: got %x", d)
+	}
+}
+
+func TestG711(t *testing.T) {
+	for _, tt := range []struct {
+		pcm   int16
+		alaw  byte
+		mulaw byte
+	}{
+		{pcm: 0, alaw: 0xD5, mulaw: 0xFF},
+		{pcm: -1, alaw: 0x55, mulaw: 0x7F},
+		{pcm: 32767, alaw: 0xAA, mulaw: 0x80},
+		{pcm: -32768, alaw: 0x2A, mulaw: 0x00},
+		{pcm: 1000, alaw: 0xFA, mulaw: 0xCE},
+	} {
+		if got := ALawEncode(tt.pcm); got != tt.alaw {
+			t.Errorf("A-law of %d: got %#x, want %#x", tt.pcm, got, tt.alaw)
+		}
+		if got := MuLawEncode(tt.pcm); got != tt.mulaw {
+			t.Errorf("µ-law of %d: got %#x, want %#x", tt.pcm, got, tt.mulaw)
+		}
+	}
+
+	for c := range 256 {
+		if got := ALawEncode(ALawDecode(byte(c))); got != byte(c) {
+			t.Errorf("A-law %#x: decoded and encoded to %#x", c, got)
+		}
+		if c == 0x7F {
+			continue // negative zero
+		}
+		if got := MuLawEncode(MuLawDecode(byte(c))); got != byte(c) {
+			t.Errorf("µ-law %#x: decoded and encoded to %#x", c, got)
+		}
+	}
+
+	pcm := []int16{0, 100, -100, 5000, -20000, 32767}
+	w := NewWriter()
+	w.Write8(5, 3)
+	w.WriteALaw(pcm)
+	w.WriteMuLaw(pcm)
+
+	r := NewReader(w.BitData())
+	r.Skip(3)
+	alaw := make([]int16, len(pcm))
+	mulaw := make([]int16, len(pcm))
+	if err := r.ReadALaw(alaw); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.ReadMuLaw(mulaw); err != nil {
+		t.Fatal(err)
+	}
+	for i, s := range pcm {
+		if alaw[i] != ALawDecode(ALawEncode(s)) || mulaw[i] != MuLawDecode(MuLawEncode(s)) {
+			t.Errorf("sample %d: got %d and %d", s, alaw[i], mulaw[i])
+		}
+	}
+}
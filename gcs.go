@@ -0,0 +1,133 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"errors"
+	"iter"
+	"math/bits"
+	"slices"
+)
+
+var ErrInvalidGCS = errors.New("invalid Golomb-compressed set")
+
+// BuildGCS returns a Golomb-compressed set of the hashes with the false positive rate 1/2^p: the number
+// of hashes N as a CompactSize, followed by the differences of the sorted values, the hashes mapped to
+// the range [0, N<<p), as Rice codes with the parameter p. The Rice codes are the ones of WriteRice,
+// with the unary quotient written as zero bits ended by a one bit, in the LSBFirst order. The layout
+// follows BIP-158 but isn't compatible with its filters, which are written MSB first, end the unary
+// quotient with a zero bit and map the hashes to the range [0, N*M) with M=784931.
+// It panics if p is greater than 32 or if there are 1<<32 or more hashes.
+func BuildGCS(hashes []uint64, p uint) BitData {
+	if p > 32 || uint64(len(hashes)) >= 1<<32 {
+		panic("bitdata: invalid Golomb-compressed set parameters")
+	}
+
+	n := uint64(len(hashes))
+	values := make([]uint64, len(hashes))
+	for i, h := range hashes {
+		values[i] = gcsMap(h, n<<p)
+	}
+	slices.Sort(values)
+
+	w := NewWriter()
+	w.WriteCompactSize(n)
+
+	var last uint64
+	for _, v := range values {
+		w.WriteRice(v-last, byte(p))
+		last = v
+	}
+
+	return w.BitData()
+}
+
+// gcsMap maps the hash uniformly to the range [0, f).
+func gcsMap(hash, f uint64) uint64 {
+	hi, _ := bits.Mul64(hash, f)
+	return hi
+}
+
+// GCS is a Golomb-compressed set built by BuildGCS.
+type GCS struct {
+	data  BitData
+	n     uint64
+	p     byte
+	start uint
+}
+
+// NewGCS returns the set encoded in the data with the parameter p that it was built with.
+// It returns ErrInvalidGCS if the header is invalid.
+func NewGCS(d BitData, p uint) (*GCS, error) {
+	if p > 32 {
+		return nil, ErrInvalidGCS
+	}
+
+	r := NewReader(d)
+	n, err := r.ReadCompactSize()
+	if err != nil {
+		return nil, err
+	}
+	if n >= 1<<32 || n > uint64(len(d))*8 {
+		return nil, ErrInvalidGCS
+	}
+
+	return &GCS{data: d, n: n, p: byte(p), start: r.bitsRead}, nil
+}
+
+// Len returns the number of hashes in the set.
+func (s *GCS) Len() int {
+	return int(s.n)
+}
+
+// Values returns an iterator over the mapped values of the set in ascending order.
+func (s *GCS) Values() iter.Seq2[uint64, error] {
+	return func(yield func(uint64, error) bool) {
+		r := NewReader(s.data)
+		r.bitsRead = s.start
+
+		var v uint64
+		for range s.n {
+			delta, err := r.ReadRice(s.p)
+			if err != nil {
+				yield(0, err)
+				return
+			}
+			v += delta
+			if !yield(v, nil) {
+				return
+			}
+		}
+	}
+}
+
+// Contains reports whether the hash may be in the set. It returns false if the data is corrupt.
+func (s *GCS) Contains(hash uint64) bool {
+	return s.ContainsAny([]uint64{hash})
+}
+
+// ContainsAny reports whether any of the hashes may be in the set, decoding the set only once.
+func (s *GCS) ContainsAny(hashes []uint64) bool {
+	targets := make([]uint64, len(hashes))
+	for i, h := range hashes {
+		targets[i] = gcsMap(h, s.n<<s.p)
+	}
+	slices.Sort(targets)
+
+	for v, err := range s.Values() {
+		if err != nil {
+			return false
+		}
+		for len(targets) > 0 && targets[0] < v {
+			targets = targets[1:]
+		}
+		if len(targets) == 0 {
+			return false
+		}
+		if targets[0] == v {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestGCS(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	hashes := make([]uint64, 2000)
+	for i := range hashes {
+		hashes[i] = rnd.Uint64()
+	}
+
+	d := BuildGCS(hashes, 19)
+	if bitsPerItem := float64(len(d)*8) / float64(len(hashes)); bitsPerItem > 21.5 {
+		t.Errorf("%.1f bits per hash", bitsPerItem)
+	}
+
+	s, err := NewGCS(d, 19)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Len() != len(hashes) {
+		t.Errorf("got length %d", s.Len())
+	}
+
+	var last uint64
+	count := 0
+	for v, err := range s.Values() {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v < last || v >= uint64(len(hashes))<<19 {
+			t.Fatalf("value %d out of order or range", v)
+		}
+		last = v
+		count++
+	}
+	if count != len(hashes) {
+		t.Errorf("iterated %d values", count)
+	}
+
+	for _, h := range hashes[:100] {
+		if !s.Contains(h) {
+			t.Fatalf("hash %x not found", h)
+		}
+	}
+	for range 1000 {
+		if s.Contains(rnd.Uint64()) {
+			t.Error("unexpected false positive")
+		}
+	}
+	if !s.ContainsAny([]uint64{rnd.Uint64(), hashes[1500], rnd.Uint64()}) {
+		t.Error("ContainsAny missed a hash")
+	}
+
+	empty, err := NewGCS(BuildGCS(nil, 19), 19)
+	if err != nil || empty.Len() != 0 || empty.Contains(1) {
+		t.Errorf("empty set: %v", err)
+	}
+
+	if _, err := NewGCS(BitData{0xFE, 0xFF, 0xFF, 0xFF, 0xFF}, 19); err == nil {
+		t.Error("expected an error for an invalid header")
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"unsafe"
+)
+
+// Write writes the lowest bitCount bits of v, like Write8..Write64 do, but for any integer type,
+// including the user defined ones such as `type PortID uint16`. Signed values are written in two's complement.
+// It panics if bitCount exceeds the size of T.
+func Write[T integer](w *Writer, v T, bitCount byte) {
+	if bitCount > sizeOf[T]() {
+		panic("bitdata: bit count exceeds the size of the type")
+	}
+	if w.strict && isSigned[T]() {
+		checkFitsSigned(int64(v), bitCount)
+	} else if w.strict {
+		checkFits(v, bitCount)
+	}
+	if w.trace != nil {
+		w.traceWrite("Write", uint64(v)&mask[uint64](bitCount), bitCount)
+	}
+	write(w, v, bitCount)
+}
+
+// Read reads a bitCount bits wide value into any integer type. For signed types the value is sign-extended,
+// so Read[int16](r, 11) of the bits written for -5 returns -5. It returns ErrBitCountTooBig
+// if bitCount exceeds the size of T.
+func Read[T integer](r *Reader, bitCount byte) (T, error) {
+	if bitCount > sizeOf[T]() {
+		return 0, r.readError("Read", bitCount, ErrBitCountTooBig)
+	}
+
+	v, err := read[T](r, bitCount)
+	if err != nil {
+		return 0, r.readError("Read", bitCount, err)
+	}
+
+	if r.trace != nil {
+		r.traceRead("Read", bitCount, uint64(v))
+	}
+
+	if isSigned[T]() {
+		v = T(signExtend(uint64(v), bitCount))
+	}
+
+	return v, nil
+}
+
+// sizeOf returns the size of the integer type in bits.
+func sizeOf[T integer]() byte {
+	var v T
+	return byte(unsafe.Sizeof(v) * 8)
+}
+
+func isSigned[T integer]() bool {
+	var v T
+	return v-1 < 0
+}
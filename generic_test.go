@@ -0,0 +1,73 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestGeneric(t *testing.T) {
+	type portID uint16
+	type flags uint8
+
+	w := NewWriter()
+	Write(w, portID(8080), 14)
+	Write(w, flags(0b101), 3)
+	Write(w, uint64(1)<<63, 64)
+
+	r := NewReader(w.BitData())
+
+	if v, err := Read[portID](r, 14); err != nil || v != 8080 {
+		t.Errorf("portID: v=%d err=%v", v, err)
+	}
+	if v, err := Read[flags](r, 3); err != nil || v != 0b101 {
+		t.Errorf("flags: v=%d err=%v", v, err)
+	}
+	if v, err := Read[uint64](r, 64); err != nil || v != 1<<63 {
+		t.Errorf("uint64: v=%d err=%v", v, err)
+	}
+
+	if _, err := Read[flags](NewReader(BitData{0, 0}), 9); !errors.Is(err, ErrBitCountTooBig) {
+		t.Errorf("expected ErrBitCountTooBig, got %v", err)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic")
+		}
+	}()
+	Write(w, flags(0), 9)
+}
+
+func TestGenericSigned(t *testing.T) {
+	type delta int16
+
+	w := NewWriter(WithStrict())
+	Write(w, int16(-5), 11)
+	Write(w, delta(1023), 11)
+	Write(w, int8(-128), 8)
+	Write(w, int64(-1)<<63, 64)
+
+	r := NewReader(w.BitData())
+
+	if v, err := Read[int16](r, 11); err != nil || v != -5 {
+		t.Errorf("int16: v=%d err=%v", v, err)
+	}
+	if v, err := Read[delta](r, 11); err != nil || v != 1023 {
+		t.Errorf("delta: v=%d err=%v", v, err)
+	}
+	if v, err := Read[int8](r, 8); err != nil || v != -128 {
+		t.Errorf("int8: v=%d err=%v", v, err)
+	}
+	if v, err := Read[int64](r, 64); err != nil || v != -1<<63 {
+		t.Errorf("int64: v=%d err=%v", v, err)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic")
+		}
+	}()
+	Write(w, int16(-1025), 11)
+}
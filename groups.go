@@ -0,0 +1,81 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"errors"
+	"strings"
+)
+
+var ErrInvalidSymbol = errors.New("invalid symbol")
+
+const (
+	// CrockfordBase32 is the alphabet of Douglas Crockford's base32, for 5-bit groups.
+	CrockfordBase32 = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+	// Base64Alphabet is the standard base64 alphabet of RFC 4648, for 6-bit groups.
+	Base64Alphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789+/"
+	// Base64URLAlphabet is the URL and file name safe base64 alphabet of RFC 4648, for 6-bit groups.
+	Base64URLAlphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789-_"
+)
+
+// EncodeGroups splits the data into groups of bitsPerSymbol bits, taking the bits of every byte starting
+// with the most significant one, and returns a string with a symbol of the alphabet for every group.
+// The last group is padded with zero bits. No padding symbols are added, so with Base64Alphabet the result
+// matches unpadded base64. The alphabet must have exactly 2^bitsPerSymbol single byte symbols and
+// bitsPerSymbol must be from 1 to 8, otherwise it panics.
+func EncodeGroups(data BitData, bitsPerSymbol byte, alphabet string) string {
+	checkAlphabet(bitsPerSymbol, alphabet)
+
+	total := uint(len(data)) * 8
+	n := (total + uint(bitsPerSymbol) - 1) / uint(bitsPerSymbol)
+
+	r := NewReaderMSB(data)
+	var sb strings.Builder
+	sb.Grow(int(n))
+	for i := uint(0); i < n; i++ {
+		k := byte(min(uint(bitsPerSymbol), total-r.bitsRead))
+		v, _ := r.Read8(k)
+		sb.WriteByte(alphabet[v<<(bitsPerSymbol-k)])
+	}
+
+	return sb.String()
+}
+
+// DecodeGroups reverses EncodeGroups. The bits left over after the last whole byte are the padding
+// and must be zero. It returns ErrInvalidSymbol for a symbol that isn't in the alphabet or nonzero padding.
+func DecodeGroups(s string, bitsPerSymbol byte, alphabet string) (BitData, error) {
+	checkAlphabet(bitsPerSymbol, alphabet)
+
+	var lookup [256]int16
+	for i := range lookup {
+		lookup[i] = -1
+	}
+	for i := 0; i < len(alphabet); i++ {
+		lookup[alphabet[i]] = int16(i)
+	}
+
+	w := NewWriterMSB()
+	for i := 0; i < len(s); i++ {
+		v := lookup[s[i]]
+		if v < 0 {
+			return nil, ErrInvalidSymbol
+		}
+		w.Write8(byte(v), bitsPerSymbol)
+	}
+
+	d := w.BitData()
+	if pad := w.bitsWritten % 8; pad > 0 {
+		if pad >= uint(bitsPerSymbol) || d[len(d)-1]&(0xFF<<(8-pad)) != 0 {
+			return nil, ErrInvalidSymbol
+		}
+		d = d[:len(d)-1]
+	}
+
+	return d, nil
+}
+
+func checkAlphabet(bitsPerSymbol byte, alphabet string) {
+	if bitsPerSymbol == 0 || bitsPerSymbol > 8 || len(alphabet) != 1<<bitsPerSymbol {
+		panic("bitdata: invalid alphabet")
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"bytes"
+	"encoding/base32"
+	"encoding/base64"
+	"errors"
+	"testing"
+)
+
+func TestEncodeGroups(t *testing.T) {
+	crockford := base32.NewEncoding(CrockfordBase32).WithPadding(base32.NoPadding)
+
+	for _, data := range [][]byte{
+		{},
+		{0xFF},
+		{0x01, 0x02},
+		{0xDE, 0xAD, 0xBE},
+		{0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06},
+		[]byte("hello, world"),
+	} {
+		s := EncodeGroups(data, 6, Base64Alphabet)
+		if want := base64.RawStdEncoding.EncodeToString(data); s != want {
+			t.Errorf("base64 mismatch for %x: want=%s got=%s", data, want, s)
+		}
+		if d, err := DecodeGroups(s, 6, Base64Alphabet); err != nil || !bytes.Equal(d, data) {
+			t.Errorf("base64 decode mismatch for %x: got=%x err=%v", data, d, err)
+		}
+
+		s = EncodeGroups(data, 5, CrockfordBase32)
+		if want := crockford.EncodeToString(data); s != want {
+			t.Errorf("base32 mismatch for %x: want=%s got=%s", data, want, s)
+		}
+		if d, err := DecodeGroups(s, 5, CrockfordBase32); err != nil || !bytes.Equal(d, data) {
+			t.Errorf("base32 decode mismatch for %x: got=%x err=%v", data, d, err)
+		}
+
+		s = EncodeGroups(data, 3, "abcdefgh")
+		if d, err := DecodeGroups(s, 3, "abcdefgh"); err != nil || !bytes.Equal(d, data) {
+			t.Errorf("octal decode mismatch for %x: got=%x err=%v", data, d, err)
+		}
+	}
+}
+
+func TestDecodeGroupsInvalid(t *testing.T) {
+	for _, s := range []string{"A!", "AB", "A", "AAAAA"} {
+		if _, err := DecodeGroups(s, 6, Base64Alphabet); !errors.Is(err, ErrInvalidSymbol) {
+			t.Errorf("%q: expected ErrInvalidSymbol, got %v", s, err)
+		}
+	}
+}
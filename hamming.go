@@ -0,0 +1,210 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"errors"
+	"math/bits"
+)
+
+var ErrUncorrectable = errors.New("uncorrectable error")
+
+// codeword holds up to 128 bits, bit i of the codeword is bit i%64 of word i/64.
+type codeword [2]uint64
+
+func (c *codeword) get(i uint) uint64 {
+	return c[i/64] >> (i % 64) & 1
+}
+
+func (c *codeword) flip(i uint) {
+	c[i/64] ^= 1 << (i % 64)
+}
+
+func (c *codeword) set(i uint, v uint64) {
+	c[i/64] |= (v & 1) << (i % 64)
+}
+
+func hammingParityBits(dataBits byte) uint {
+	p := uint(2)
+	for 1<<p < uint(dataBits)+p+1 {
+		p++
+	}
+	return p
+}
+
+// hammingEncode places data bits at codeword positions 1..n that are not powers of two
+// and sets the parity bits at power of two positions. Position 0 is left for the overall parity.
+func hammingEncode(v uint64, dataBits byte) (c codeword, n uint) {
+	n = uint(dataBits) + hammingParityBits(dataBits)
+
+	var syndrome uint
+	for pos, di := uint(1), byte(0); pos <= n; pos++ {
+		if pos&(pos-1) == 0 {
+			continue
+		}
+		if v>>di&1 == 1 {
+			c.set(pos, 1)
+			syndrome ^= pos
+		}
+		di++
+	}
+
+	for j := uint(0); 1<<j <= n; j++ {
+		c.set(1<<j, uint64(syndrome>>j))
+	}
+
+	return
+}
+
+func hammingSyndrome(c *codeword, n uint) uint {
+	var syndrome uint
+	for pos := uint(1); pos <= n; pos++ {
+		if c.get(pos) == 1 {
+			syndrome ^= pos
+		}
+	}
+	return syndrome
+}
+
+func hammingData(c *codeword, n uint) (v uint64) {
+	for pos, di := uint(1), 0; pos <= n; pos++ {
+		if pos&(pos-1) == 0 {
+			continue
+		}
+		v |= c.get(pos) << di
+		di++
+	}
+	return
+}
+
+func writeCodeword(w *Writer, c codeword, from, to uint) {
+	for from < to {
+		n := to - from
+		if n > 64 {
+			n = 64
+		}
+		if rem := 64 - from%64; n > rem {
+			n = rem
+		}
+		w.Write64(c[from/64]>>(from%64), byte(n))
+		from += n
+	}
+}
+
+func readCodeword(r *Reader, from, to uint) (c codeword, err error) {
+	for i := from; i < to; {
+		n := to - i
+		if rem := 64 - i%64; n > rem {
+			n = rem
+		}
+		var v uint64
+		if v, err = r.Read64(byte(n)); err != nil {
+			return
+		}
+		c[i/64] |= v << (i % 64)
+		i += n
+	}
+	return
+}
+
+// WriteHamming writes the lowest bitCount bits of v (1 to 64) protected with a Hamming code,
+// which adds the minimal number of parity bits allowing correction of a single bit error.
+func (w *Writer) WriteHamming(v uint64, bitCount byte) {
+	if bitCount == 0 || bitCount > 64 {
+		panic("bitdata: invalid Hamming data bit count")
+	}
+	c, n := hammingEncode(v&mask[uint64](bitCount), bitCount)
+	writeCodeword(w, c, 1, n+1)
+}
+
+// WriteHamming74 writes 4 bits of v as a 7-bit Hamming(7,4) codeword.
+func (w *Writer) WriteHamming74(v byte) {
+	w.WriteHamming(uint64(v), 4)
+}
+
+// WriteSECDED writes the lowest bitCount bits of v (1 to 64) protected with an extended Hamming code
+// allowing correction of a single bit error and detection of a double bit error.
+// The overall parity bit is written first.
+func (w *Writer) WriteSECDED(v uint64, bitCount byte) {
+	if bitCount == 0 || bitCount > 64 {
+		panic("bitdata: invalid SECDED data bit count")
+	}
+	c, n := hammingEncode(v&mask[uint64](bitCount), bitCount)
+	c.set(0, uint64(bits.OnesCount64(c[0])+bits.OnesCount64(c[1])))
+	writeCodeword(w, c, 0, n+1)
+}
+
+// ReadHamming reads a value written with WriteHamming, correcting a single bit error.
+func (r *Reader) ReadHamming(bitCount byte) (uint64, error) {
+	if bitCount == 0 || bitCount > 64 {
+		return 0, ErrBitCountTooBig
+	}
+
+	n := uint(bitCount) + hammingParityBits(bitCount)
+	c, err := readCodeword(r, 1, n+1)
+	if err != nil {
+		return 0, err
+	}
+
+	if s := hammingSyndrome(&c, n); s > n {
+		return 0, ErrUncorrectable
+	} else if s != 0 {
+		c.flip(s)
+	}
+
+	return hammingData(&c, n), nil
+}
+
+func (r *Reader) ReadHamming74() (byte, error) {
+	v, err := r.ReadHamming(4)
+	return byte(v), err
+}
+
+// ReadSECDED reads a value written with WriteSECDED, correcting a single bit error.
+// It returns ErrUncorrectable if it detects a double bit error.
+func (r *Reader) ReadSECDED(bitCount byte) (uint64, error) {
+	if bitCount == 0 || bitCount > 64 {
+		return 0, ErrBitCountTooBig
+	}
+
+	n := uint(bitCount) + hammingParityBits(bitCount)
+	c, err := readCodeword(r, 0, n+1)
+	if err != nil {
+		return 0, err
+	}
+
+	s := hammingSyndrome(&c, n)
+	overall := (bits.OnesCount64(c[0]) + bits.OnesCount64(c[1])) % 2
+
+	switch {
+	case overall == 0 && s != 0:
+		return 0, ErrUncorrectable
+	case overall == 1 && s > n:
+		return 0, ErrUncorrectable
+	case overall == 1 && s != 0:
+		c.flip(s)
+	}
+
+	return hammingData(&c, n), nil
+}
+
+func (r *ReaderError) ReadHamming(bitCount byte) (v uint64) {
+	if r.err == nil {
+		v, r.err = r.reader.ReadHamming(bitCount)
+	}
+	return
+}
+
+func (r *ReaderError) ReadHamming74() (v byte) {
+	if r.err == nil {
+		v, r.err = r.reader.ReadHamming74()
+	}
+	return
+}
+
+func (r *ReaderError) ReadSECDED(bitCount byte) (v uint64) {
+	if r.err == nil {
+		v, r.err = r.reader.ReadSECDED(bitCount)
+	}
+	return
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"errors"
+	"math/rand/v2"
+	"testing"
+)
+
+func flipBit(d BitData, i uint) {
+	d[i/8] ^= 1 << (i % 8)
+}
+
+func TestHamming74(t *testing.T) {
+	for v := byte(0); v < 16; v++ {
+		w := NewWriter()
+		w.WriteHamming74(v)
+		if w.BitsWritten() != 7 {
+			t.Errorf("codeword size mismatch: want=%d got=%d", 7, w.BitsWritten())
+		}
+
+		for i := uint(0); i <= 7; i++ {
+			d := append(BitData{}, w.BitData()...)
+			if i < 7 {
+				flipBit(d, i)
+			}
+
+			got, err := NewReader(d).ReadHamming74()
+			if err != nil {
+				t.Errorf("value %d, flipped bit %d: unexpected error: %s", v, i, err)
+			}
+			if got != v {
+				t.Errorf("value %d, flipped bit %d: got=%d", v, i, got)
+			}
+		}
+	}
+}
+
+func TestHammingSECDED(t *testing.T) {
+	for _, bitCount := range []byte{1, 4, 11, 26, 57, 64} {
+		v := rand.Uint64() & mask[uint64](bitCount)
+
+		w := NewWriter()
+		w.Write8(0b11, 2)
+		w.WriteSECDED(v, bitCount)
+		size := w.BitsWritten() - 2
+
+		for i := uint(0); i < size; i++ {
+			d := append(BitData{}, w.BitData()...)
+			flipBit(d, 2+i)
+
+			r := NewReader(d)
+			r.Skip(2)
+			got, err := r.ReadSECDED(bitCount)
+			if err != nil {
+				t.Errorf("bits=%d, flipped bit %d: unexpected error: %s", bitCount, i, err)
+			}
+			if got != v {
+				t.Errorf("bits=%d, flipped bit %d: want=%x got=%x", bitCount, i, v, got)
+			}
+		}
+
+		d := append(BitData{}, w.BitData()...)
+		flipBit(d, 2)
+		flipBit(d, 2+size-1)
+
+		r := NewReader(d)
+		r.Skip(2)
+		if _, err := r.ReadSECDED(bitCount); !errors.Is(err, ErrUncorrectable) {
+			t.Errorf("bits=%d: expected uncorrectable error, got %v", bitCount, err)
+		}
+	}
+}
+
+func TestHammingReaderError(t *testing.T) {
+	w := NewWriter()
+	w.WriteHamming(0x3FF, 10)
+	w.WriteSECDED(0xABCDEF, 24)
+
+	r := NewReaderError(w.BitData())
+	if v := r.ReadHamming(10); v != 0x3FF {
+		t.Errorf("want=%x got=%x", 0x3FF, v)
+	}
+	if v := r.ReadSECDED(24); v != 0xABCDEF {
+		t.Errorf("want=%x got=%x", 0xABCDEF, v)
+	}
+	if err := r.Error(); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	r.ReadHamming74()
+	if err := r.Error(); err == nil {
+		t.Error("expected error")
+	}
+}
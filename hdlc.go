@@ -0,0 +1,144 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"errors"
+	"io"
+)
+
+var ErrInvalidStuffing = errors.New("invalid bit stuffing")
+
+// HDLCFlag is the flag sequence delimiting HDLC frames. Its bits read the same in both bit orders.
+const HDLCFlag = 0x7E
+
+// hdlcRun is the number of consecutive one bits after which a zero bit is stuffed.
+const hdlcRun = 5
+
+// StuffBits returns the first bitCount bits of the data with a zero bit inserted after every five
+// consecutive one bits, so the result never contains the flag sequence, and its length in bits.
+func StuffBits(d BitData, bitCount uint) (BitData, uint) {
+	if bitCount > uint(len(d))*8 {
+		panic("bitdata: bit range out of bounds")
+	}
+
+	w := NewWriter()
+	stuffBits(w, NewReader(d), bitCount, hdlcRun)
+	return w.BitData(), w.bitsWritten
+}
+
+// UnstuffBits reverses StuffBits: it removes the zero bit following every five consecutive one bits.
+// It returns ErrInvalidStuffing if the data contains six consecutive one bits.
+func UnstuffBits(d BitData, bitCount uint) (BitData, uint, error) {
+	if bitCount > uint(len(d))*8 {
+		panic("bitdata: bit range out of bounds")
+	}
+
+	w := NewWriter()
+	if err := unstuffBits(w, NewReader(d), bitCount, hdlcRun); err != nil {
+		return nil, 0, err
+	}
+	return w.BitData(), w.bitsWritten, nil
+}
+
+// WriteHDLCFrame writes a frame: the flag, the first bitCount bits of the data with bit stuffing, and the flag.
+// The data bits are taken in the bit order of the writer.
+func (w *Writer) WriteHDLCFrame(d BitData, bitCount uint) {
+	if bitCount > uint(len(d))*8 {
+		panic("bitdata: bit range out of bounds")
+	}
+
+	r := NewReader(d)
+	r.order = w.order
+
+	w.Write8(HDLCFlag, 8)
+	stuffBits(w, r, bitCount, hdlcRun)
+	w.Write8(HDLCFlag, 8)
+}
+
+// ReadHDLCFrame finds the next frame, skipping anything before its opening flag and any repeated flags,
+// and returns its content without bit stuffing and its length in bits. The closing flag is left unread,
+// because it can also open the next frame. It returns io.ErrUnexpectedEOF if there is no complete frame
+// and ErrInvalidStuffing for an aborted frame. On error the read position is left unchanged.
+func (r *Reader) ReadHDLCFrame() (BitData, uint, error) {
+	start := r.bitsRead
+
+	pos, ok := r.Find(HDLCFlag, 8)
+	if !ok {
+		return nil, 0, io.ErrUnexpectedEOF
+	}
+	r.bitsRead = pos + 8
+
+	for {
+		pos, ok = r.Find(HDLCFlag, 8)
+		if !ok {
+			r.bitsRead = start
+			return nil, 0, io.ErrUnexpectedEOF
+		}
+		if pos != r.bitsRead {
+			break
+		}
+		r.bitsRead += 8
+	}
+
+	w := &Writer{order: r.order}
+	if err := unstuffBits(w, r, pos-r.bitsRead, hdlcRun); err != nil {
+		r.bitsRead = start
+		return nil, 0, err
+	}
+
+	return w.BitData(), w.bitsWritten, nil
+}
+
+// stuffBits copies the bits and inserts a zero bit after every run consecutive one bits.
+func stuffBits(w *Writer, r *Reader, bitCount uint, run int) {
+	ones := 0
+	for i := uint(0); i < bitCount; i++ {
+		b, _ := r.ReadBool()
+		w.WriteBool(b)
+
+		if !b {
+			ones = 0
+			continue
+		}
+		if ones++; ones == run {
+			w.WriteBool(false)
+			ones = 0
+		}
+	}
+}
+
+// unstuffBits copies the bits and removes the zero bit after every run consecutive one bits.
+func unstuffBits(w *Writer, r *Reader, bitCount uint, run int) error {
+	ones := 0
+	for i := uint(0); i < bitCount; i++ {
+		b, err := r.ReadBool()
+		if err != nil {
+			return err
+		}
+
+		if ones == run {
+			if b {
+				return ErrInvalidStuffing
+			}
+			ones = 0
+			continue
+		}
+
+		w.WriteBool(b)
+		if b {
+			ones++
+		} else {
+			ones = 0
+		}
+	}
+
+	return nil
+}
+
+func (r *ReaderError) ReadHDLCFrame() (d BitData, n uint) {
+	if r.err == nil {
+		d, n, r.err = r.reader.ReadHDLCFrame()
+	}
+	return
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+// bitString returns the first n bits of the data in stream order, as written by the writer of the given order.
+func bitString(d BitData, n uint, o BitOrder) string {
+	r := &Reader{data: d, end: n, order: o}
+	var sb strings.Builder
+	for r.bitsRead < r.end {
+		if b, _ := r.ReadBool(); b {
+			sb.WriteByte('1')
+		} else {
+			sb.WriteByte('0')
+		}
+	}
+	return sb.String()
+}
+
+// fromBitString returns the bits of s written with the writer of the given order.
+func fromBitString(s string, o BitOrder) (BitData, uint) {
+	w := &Writer{order: o}
+	for _, c := range s {
+		w.WriteBool(c == '1')
+	}
+	return w.BitData(), w.bitsWritten
+}
+
+func TestStuffBits(t *testing.T) {
+	tests := []struct {
+		in, out string
+	}{
+		{in: "", out: ""},
+		{in: "1111", out: "1111"},
+		{in: "11111", out: "111110"},
+		{in: "111111", out: "1111101"},
+		{in: "01111110", out: "011111010"},
+		{in: "1111111111", out: "111110111110"},
+		{in: "110111110111", out: "1101111100111"},
+	}
+
+	for _, test := range tests {
+		d, n := fromBitString(test.in, LSBFirst)
+
+		s, sn := StuffBits(d, n)
+		if got := bitString(s, sn, LSBFirst); got != test.out {
+			t.Errorf("stuff mismatch for %s: want=%s got=%s", test.in, test.out, got)
+		}
+
+		u, un, err := UnstuffBits(s, sn)
+		if err != nil {
+			t.Errorf("unstuff failed for %s: %v", test.in, err)
+			continue
+		}
+		if got := bitString(u, un, LSBFirst); got != test.in {
+			t.Errorf("unstuff mismatch: want=%s got=%s", test.in, got)
+		}
+	}
+}
+
+func TestUnstuffBitsInvalid(t *testing.T) {
+	d, n := fromBitString("0111111", LSBFirst)
+	if _, _, err := UnstuffBits(d, n); !errors.Is(err, ErrInvalidStuffing) {
+		t.Errorf("expected ErrInvalidStuffing, got %v", err)
+	}
+}
+
+func TestHDLCFrame(t *testing.T) {
+	frames := []string{"0111111011111100", "1", "11111111111111111111"}
+
+	for _, o := range []BitOrder{LSBFirst, MSBFirst} {
+		w := &Writer{order: o}
+		w.Write8(0b1011, 4) // noise before the first flag
+		for _, f := range frames {
+			d, n := fromBitString(f, o)
+			w.WriteHDLCFrame(d, n)
+		}
+
+		r := &Reader{data: w.BitData(), end: w.BitsWritten(), order: o}
+		for _, f := range frames {
+			d, n, err := r.ReadHDLCFrame()
+			if err != nil {
+				t.Errorf("order %d: read failed: %v", o, err)
+				break
+			}
+			if got := bitString(d, n, o); got != f {
+				t.Errorf("order %d: frame mismatch: want=%s got=%s", o, f, got)
+			}
+		}
+
+		pos := r.BitsRead()
+		if _, _, err := r.ReadHDLCFrame(); err != io.ErrUnexpectedEOF {
+			t.Errorf("order %d: expected io.ErrUnexpectedEOF, got %v", o, err)
+		}
+		if r.BitsRead() != pos {
+			t.Errorf("order %d: position changed on error", o)
+		}
+	}
+}
+
+func TestHDLCFrameAbort(t *testing.T) {
+	w := NewWriter()
+	w.Write8(HDLCFlag, 8)
+	w.Write8(0xFF, 8)
+	w.Write8(HDLCFlag, 8)
+
+	r := NewReaderError(w.BitData())
+	r.ReadHDLCFrame()
+	if !errors.Is(r.Error(), ErrInvalidStuffing) {
+		t.Errorf("expected ErrInvalidStuffing, got %v", r.Error())
+	}
+}
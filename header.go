@@ -0,0 +1,95 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrBadMagic           = errors.New("bad magic number")
+	ErrUnsupportedVersion = errors.New("unsupported version")
+	ErrUnexpectedValue    = errors.New("unexpected value")
+)
+
+// Header is a container header: a 32-bit magic number identifying the format,
+// a 16-bit format version and 16 bits of format specific flags.
+type Header struct {
+	Magic   uint32
+	Version uint16
+	Flags   uint16
+}
+
+// HeaderBits is the size of a header in bits.
+const HeaderBits = 32 + 16 + 16
+
+func (w *Writer) WriteHeader(magic uint32, version, flags uint16) {
+	w.Write32(magic, 32)
+	w.Write16(version, 16)
+	w.Write16(flags, 16)
+}
+
+// ReadHeader reads a header written with WriteHeader and checks that it holds the expected magic number.
+func (r *Reader) ReadHeader(magic uint32) (h Header, err error) {
+	if h.Magic, err = r.Read32(32); err != nil {
+		return
+	}
+	if h.Magic != magic {
+		err = fmt.Errorf("%w: want=%#x got=%#x", ErrBadMagic, magic, h.Magic)
+		return
+	}
+	if h.Version, err = r.Read16(16); err != nil {
+		return
+	}
+	h.Flags, err = r.Read16(16)
+	return
+}
+
+// ReadVersioned reads a header and passes the reader to the handler registered for the header's version.
+// It returns ErrUnsupportedVersion if there is no handler for the version.
+func (r *Reader) ReadVersioned(magic uint32, handlers map[uint16]func(r *Reader, h Header) error) (Header, error) {
+	h, err := r.ReadHeader(magic)
+	if err != nil {
+		return h, err
+	}
+
+	handler, ok := handlers[h.Version]
+	if !ok {
+		return h, fmt.Errorf("%w: %d", ErrUnsupportedVersion, h.Version)
+	}
+
+	return h, handler(r, h)
+}
+
+func (r *ReaderError) ReadHeader(magic uint32) (h Header) {
+	if r.err == nil {
+		h, r.err = r.reader.ReadHeader(magic)
+	}
+	return
+}
+
+// Expect64 reads bitCount bits and checks that they hold the expected value, such as a magic number
+// or a sync word. On a mismatch it returns ErrUnexpectedValue with the bit offset and both values,
+// and the read position is left unchanged.
+func (r *Reader) Expect64(value uint64, bitCount byte) error {
+	start := r.bitsRead
+
+	v, err := r.Read64(bitCount)
+	if err != nil {
+		return err
+	}
+
+	if want := value & mask[uint64](bitCount); v != want {
+		r.bitsRead = start
+		return fmt.Errorf("%w at bit offset %d: want=%#x got=%#x", ErrUnexpectedValue, start, want, v)
+	}
+
+	return nil
+}
+
+func (r *ReaderError) Expect64(value uint64, bitCount byte) {
+	if r.err == nil {
+		r.err = r.reader.Expect64(value, bitCount)
+	}
+}
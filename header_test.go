@@ -0,0 +1,109 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"errors"
+	"io"
+	"testing"
+)
+
+const testMagic = 0xB17DA7A0
+
+func TestHeader(t *testing.T) {
+	w := NewWriter()
+	w.WriteHeader(testMagic, 3, 0x8001)
+	w.Write8(42, 7)
+
+	if want, got := uint(HeaderBits+7), w.BitsWritten(); want != got {
+		t.Errorf("size mismatch: want=%d got=%d", want, got)
+	}
+
+	r := NewReaderError(w.BitData())
+	h := r.ReadHeader(testMagic)
+	if want := (Header{Magic: testMagic, Version: 3, Flags: 0x8001}); h != want {
+		t.Errorf("header mismatch: want=%+v got=%+v", want, h)
+	}
+	if v := r.Read8(7); v != 42 {
+		t.Errorf("value mismatch: %d", v)
+	}
+	if err := r.Error(); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	if _, err := NewReader(w.BitData()).ReadHeader(0x12345678); !errors.Is(err, ErrBadMagic) {
+		t.Errorf("expected error, got %v", err)
+	}
+}
+
+func TestReadVersioned(t *testing.T) {
+	handlers := map[uint16]func(r *Reader, h Header) error{
+		1: func(r *Reader, h Header) error {
+			v, err := r.Read8(4)
+			if err == nil && v != 9 {
+				t.Errorf("v1 value mismatch: %d", v)
+			}
+			return err
+		},
+		2: func(r *Reader, h Header) error {
+			v, err := r.Read16(12)
+			if err == nil && v != 999 {
+				t.Errorf("v2 value mismatch: %d", v)
+			}
+			return err
+		},
+	}
+
+	w := NewWriter()
+	w.WriteHeader(testMagic, 1, 0)
+	w.Write8(9, 4)
+	if _, err := NewReader(w.BitData()).ReadVersioned(testMagic, handlers); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	w = NewWriter()
+	w.WriteHeader(testMagic, 2, 0)
+	w.Write16(999, 12)
+	if _, err := NewReader(w.BitData()).ReadVersioned(testMagic, handlers); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	w = NewWriter()
+	w.WriteHeader(testMagic, 3, 0)
+	h, err := NewReader(w.BitData()).ReadVersioned(testMagic, handlers)
+	if !errors.Is(err, ErrUnsupportedVersion) {
+		t.Errorf("expected error, got %v", err)
+	}
+	if h.Version != 3 {
+		t.Errorf("version mismatch: %d", h.Version)
+	}
+}
+
+func TestExpect64(t *testing.T) {
+	w := NewWriter()
+	w.Write16(0x47A, 12)
+	w.Write8(3, 2)
+
+	r := NewReader(w.BitData())
+	err := r.Expect64(0x47B, 12)
+	if !errors.Is(err, ErrUnexpectedValue) {
+		t.Errorf("expected ErrUnexpectedValue, got %v", err)
+	}
+	if want := "unexpected value at bit offset 0: want=0x47b got=0x47a"; err == nil || err.Error() != want {
+		t.Errorf("message mismatch: want=%q got=%v", want, err)
+	}
+	if r.BitsRead() != 0 {
+		t.Errorf("position changed on mismatch")
+	}
+
+	rr := NewReaderError(w.BitData())
+	rr.Expect64(0x47A, 12)
+	rr.Expect64(3, 2)
+	if rr.Error() != nil {
+		t.Errorf("unexpected error: %v", rr.Error())
+	}
+	rr.Expect64(0, 3)
+	if !errors.Is(rr.Error(), io.ErrUnexpectedEOF) {
+		t.Errorf("expected io.ErrUnexpectedEOF, got %v", rr.Error())
+	}
+}
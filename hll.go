@@ -0,0 +1,82 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+// PackRegisters packs the registers of a sketch such as HyperLogLog, width bits each, without padding
+// in the LSBFirst order. It panics if width isn't between 1 and 8.
+func PackRegisters(regs []byte, width byte) BitData {
+	checkRegisterWidth(width)
+
+	d := make(BitData, (uint(len(regs))*uint(width)+7)/8)
+	for i, v := range regs {
+		putBits(d, uint(i)*uint(width), uint64(v), width)
+	}
+	return d
+}
+
+// UnpackRegisters returns n registers of width bits packed by PackRegisters.
+// It panics if width isn't between 1 and 8 or if the data is too short.
+func UnpackRegisters(d BitData, n int, width byte) []byte {
+	checkRegisterWidth(width)
+	checkRegisterLen(d, n, width)
+
+	regs := make([]byte, n)
+	for i := range regs {
+		regs[i] = byte(getBits(d, uint(i)*uint(width), width))
+	}
+	return regs
+}
+
+// MergeRegisters sets each of the n packed registers of dst to the maximum of its value and the register
+// of src, which merges two HyperLogLog sketches. Eight registers are compared at a time without unpacking.
+// It panics if width isn't between 1 and 8 or if dst or src is too short.
+func MergeRegisters(dst, src BitData, n int, width byte) {
+	checkRegisterWidth(width)
+	checkRegisterLen(dst, n, width)
+	checkRegisterLen(src, n, width)
+
+	// Eight registers take width bytes. hi has the top bit of each register set.
+	w := uint(width)
+	var hi uint64
+	for i := range uint(8) {
+		hi |= 1 << (w*i + w - 1)
+	}
+	m := mask[uint64](width)
+
+	var buf [8]byte
+	groups := uint(n / 8)
+	for g := range groups {
+		chunk := dst[g*w : g*w+w]
+		copy(buf[:], chunk)
+		a := load64(buf[:])
+		copy(buf[:], src[g*w:g*w+w])
+		b := load64(buf[:])
+
+		// The top bit of each register of t tells whether the rest of the register of a isn't less than that of b.
+		t := (a | hi) - (b &^ hi)
+		ge := (a&^b | ^(a^b)&t) & hi
+		sel := (ge >> (w - 1)) * m
+
+		store64(buf[:], a&sel|b&^sel)
+		copy(chunk, buf[:w])
+	}
+
+	for i := groups * 8; i < uint(n); i++ {
+		ofs := i * w
+		if v := getBits(src, ofs, width); v > getBits(dst, ofs, width) {
+			putBits(dst, ofs, v, width)
+		}
+	}
+}
+
+func checkRegisterWidth(width byte) {
+	if width < 1 || width > 8 {
+		panic("bitdata: invalid register width")
+	}
+}
+
+func checkRegisterLen(d BitData, n int, width byte) {
+	if uint(len(d))*8 < uint(n)*uint(width) {
+		panic("bitdata: bit range out of bounds")
+	}
+}
@@ -0,0 +1,41 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+)
+
+func TestRegisters(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+
+	for _, width := range []byte{1, 5, 6, 8} {
+		for _, n := range []int{0, 3, 8, 29, 1 << 10} {
+			a := make([]byte, n)
+			b := make([]byte, n)
+			for i := range a {
+				a[i] = byte(rnd.Intn(1 << width))
+				b[i] = byte(rnd.Intn(1 << width))
+			}
+
+			pa := PackRegisters(a, width)
+			if len(pa) != (n*int(width)+7)/8 {
+				t.Fatalf("width %d: packed %d registers into %d bytes", width, n, len(pa))
+			}
+			if got := UnpackRegisters(pa, n, width); !bytes.Equal(got, a) {
+				t.Fatalf("width %d, n %d: unpacked registers differ", width, n)
+			}
+
+			want := make([]byte, n)
+			for i := range want {
+				want[i] = max(a[i], b[i])
+			}
+			MergeRegisters(pa, PackRegisters(b, width), n, width)
+			if got := UnpackRegisters(pa, n, width); !bytes.Equal(got, want) {
+				t.Fatalf("width %d, n %d: merged %v, want %v", width, n, got, want)
+			}
+		}
+	}
+}
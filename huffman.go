@@ -0,0 +1,143 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+// AdaptiveHuffman is an adaptive Huffman code of bytes, using the FGK algorithm. The code tree starts empty
+// and is updated after every symbol, so no code table has to be transmitted. A symbol that has not been seen
+// before is written as the code of the not-yet-transmitted node followed by its 8 bits.
+// The encoder and the decoder must each use their own AdaptiveHuffman, and process the same symbols.
+type AdaptiveHuffman struct {
+	nodes [huffmanNodes]huffmanNode // ordered by the node number, the root is the last
+	leaf  [256]int16
+	nyt   int16
+	path  []bool
+}
+
+type huffmanNode struct {
+	weight      uint64
+	parent      int16
+	left, right int16
+	symbol      int16
+}
+
+const (
+	huffmanNodes    = 2*257 - 1
+	huffmanNYT      = 256
+	huffmanInternal = -1
+)
+
+// NewAdaptiveHuffman returns an adaptive Huffman code with an empty code tree.
+func NewAdaptiveHuffman() *AdaptiveHuffman {
+	h := &AdaptiveHuffman{nyt: huffmanNodes - 1}
+	for i := range h.leaf {
+		h.leaf[i] = -1
+	}
+	h.nodes[h.nyt] = huffmanNode{parent: -1, symbol: huffmanNYT}
+	return h
+}
+
+// Encode writes the code of the symbol and updates the code tree.
+func (h *AdaptiveHuffman) Encode(w *Writer, sym byte) {
+	node := h.leaf[sym]
+	if node < 0 {
+		node = h.nyt
+	}
+
+	h.path = h.path[:0]
+	for i := node; h.nodes[i].parent >= 0; i = h.nodes[i].parent {
+		h.path = append(h.path, h.nodes[h.nodes[i].parent].right == i)
+	}
+	for i := len(h.path) - 1; i >= 0; i-- {
+		w.WriteBool(h.path[i])
+	}
+
+	if node == h.nyt {
+		w.Write8(sym, 8)
+	}
+
+	h.update(sym)
+}
+
+// Decode reads a symbol written with Encode and updates the code tree.
+// On error the read position and the code tree are left unchanged.
+func (h *AdaptiveHuffman) Decode(r *Reader) (byte, error) {
+	start := r.bitsRead
+
+	i := int16(huffmanNodes - 1)
+	for h.nodes[i].symbol == huffmanInternal {
+		right, err := r.ReadBool()
+		if err != nil {
+			r.bitsRead = start
+			return 0, err
+		}
+		if right {
+			i = h.nodes[i].right
+		} else {
+			i = h.nodes[i].left
+		}
+	}
+
+	sym := byte(h.nodes[i].symbol)
+	if i == h.nyt {
+		v, err := r.Read8(8)
+		if err != nil {
+			r.bitsRead = start
+			return 0, err
+		}
+		sym = v
+	}
+
+	h.update(sym)
+
+	return sym, nil
+}
+
+func (h *AdaptiveHuffman) update(sym byte) {
+	q := h.leaf[sym]
+	if q < 0 {
+		// Split the NYT node into a new NYT node and a leaf for the symbol.
+		p := h.nyt
+		h.nodes[p] = huffmanNode{parent: h.nodes[p].parent, left: p - 2, right: p - 1, symbol: huffmanInternal}
+		h.nodes[p-1] = huffmanNode{parent: p, symbol: int16(sym)}
+		h.nodes[p-2] = huffmanNode{parent: p, symbol: huffmanNYT}
+		h.nyt = p - 2
+		h.leaf[sym] = p - 1
+		q = p - 1
+	}
+
+	for q >= 0 {
+		// Move the node to the highest number of its weight block before incrementing it.
+		b := q
+		for b+1 < huffmanNodes && h.nodes[b+1].weight == h.nodes[q].weight {
+			b++
+		}
+		if b != q && b != h.nodes[q].parent {
+			h.swap(q, b)
+			q = b
+		}
+
+		h.nodes[q].weight++
+		q = h.nodes[q].parent
+	}
+}
+
+// swap exchanges the subtrees at the node numbers a and b.
+func (h *AdaptiveHuffman) swap(a, b int16) {
+	na, nb := h.nodes[a], h.nodes[b]
+	na.parent, nb.parent = nb.parent, na.parent
+	h.nodes[a], h.nodes[b] = nb, na
+	h.relink(a)
+	h.relink(b)
+}
+
+func (h *AdaptiveHuffman) relink(i int16) {
+	switch n := &h.nodes[i]; n.symbol {
+	case huffmanInternal:
+		h.nodes[n.left].parent = i
+		h.nodes[n.right].parent = i
+	case huffmanNYT:
+		h.nyt = i
+	default:
+		h.leaf[n.symbol] = i
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"testing"
+)
+
+func TestAdaptiveHuffman(t *testing.T) {
+	rnd := rand.New(rand.NewPCG(1, 2))
+
+	skewed := make([]byte, 5000)
+	for i := range skewed {
+		skewed[i] = byte(rnd.ExpFloat64() * 4)
+	}
+	uniform := make([]byte, 5000)
+	for i := range uniform {
+		uniform[i] = byte(rnd.Uint32())
+	}
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{name: "empty", data: nil},
+		{name: "single", data: []byte{'x'}},
+		{name: "repeated", data: bytes.Repeat([]byte{7}, 100)},
+		{name: "text", data: []byte("abracadabra, the quick brown fox jumps over the lazy dog")},
+		{name: "skewed", data: skewed},
+		{name: "uniform", data: uniform},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w := NewWriter()
+			enc := NewAdaptiveHuffman()
+			for _, b := range test.data {
+				enc.Encode(w, b)
+			}
+
+			r := NewReader(w.BitData())
+			dec := NewAdaptiveHuffman()
+			got := make([]byte, 0, len(test.data))
+			for range test.data {
+				b, err := dec.Decode(r)
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				got = append(got, b)
+			}
+
+			if !bytes.Equal(test.data, got) {
+				t.Errorf("decoded data mismatch")
+			}
+			if r.BitsRead() != w.BitsWritten() {
+				t.Errorf("read %d of %d bits", r.BitsRead(), w.BitsWritten())
+			}
+		})
+	}
+
+	w := NewWriter()
+	enc := NewAdaptiveHuffman()
+	for _, b := range skewed {
+		enc.Encode(w, b)
+	}
+	if w.BitsWritten() > uint(len(skewed))*4 {
+		t.Errorf("skewed data not compressed: %d bits", w.BitsWritten())
+	}
+}
+
+func TestAdaptiveHuffmanEOF(t *testing.T) {
+	w := NewWriter()
+	enc := NewAdaptiveHuffman()
+	enc.Encode(w, 'a')
+	enc.Encode(w, 'b')
+
+	r := NewReader(w.BitData()[:1])
+	dec := NewAdaptiveHuffman()
+	if _, err := dec.Decode(r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := dec.Decode(r); !errors.Is(err, io.ErrUnexpectedEOF) || r.BitsRead() != 8 {
+		t.Errorf("expected io.ErrUnexpectedEOF at 8, got %v at %d", err, r.BitsRead())
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+// Write128 writes the lowest bitCount bits of the 128-bit value made of the hi and lo words.
+// The bits of lo are written first. A bitCount above 128 is treated as 128.
+func (w *Writer) Write128(hi, lo uint64, bitCount byte) {
+	if bitCount > 128 {
+		bitCount = 128
+	}
+
+	if bitCount <= 64 {
+		w.Write64(lo, bitCount)
+		return
+	}
+
+	w.Write64(lo, 64)
+	w.Write64(hi, bitCount-64)
+}
+
+// Read128 reads a value written with Write128 and returns its hi and lo words.
+func (r *Reader) Read128(bitCount byte) (hi, lo uint64, err error) {
+	if bitCount > 128 {
+		return 0, 0, ErrBitCountTooBig
+	}
+
+	if bitCount <= 64 {
+		lo, err = r.Read64(bitCount)
+		return
+	}
+
+	if lo, err = r.Read64(64); err != nil {
+		return 0, 0, err
+	}
+	if hi, err = r.Read64(bitCount - 64); err != nil {
+		r.bitsRead -= 64
+		return 0, 0, err
+	}
+
+	return
+}
+
+func (r *ReaderError) Read128(bitCount byte) (hi, lo uint64) {
+	if r.err == nil {
+		hi, lo, r.err = r.reader.Read128(bitCount)
+	}
+	return
+}
@@ -0,0 +1,64 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestInt128(t *testing.T) {
+	tests := []struct {
+		name     string
+		hi, lo   uint64
+		bitCount byte
+		expHi    uint64
+		expLo    uint64
+	}{
+		{name: "zero-bits", hi: 1, lo: 1, bitCount: 0},
+		{name: "low-only", hi: 0xFFFF, lo: 0xABCDEF, bitCount: 24, expLo: 0xABCDEF},
+		{name: "one-word", hi: 0xFFFF, lo: ^uint64(0), bitCount: 64, expLo: ^uint64(0)},
+		{name: "partial-hi", hi: 0xFFFF, lo: 0x0123456789ABCDEF, bitCount: 72, expHi: 0xFF, expLo: 0x0123456789ABCDEF},
+		{name: "full", hi: 0x20010DB800000000, lo: 0x0000000000000001, bitCount: 128, expHi: 0x20010DB800000000, expLo: 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w := NewWriter()
+			w.Write8(0b11, 2)
+			w.Write128(test.hi, test.lo, test.bitCount)
+			w.WriteBool(true)
+
+			if want, got := 3+uint(test.bitCount), w.BitsWritten(); want != got {
+				t.Errorf("bits written mismatch: want=%d got=%d", want, got)
+			}
+
+			r := NewReaderError(w.BitData())
+			r.Skip(2)
+			hi, lo := r.Read128(test.bitCount)
+			if hi != test.expHi || lo != test.expLo {
+				t.Errorf("value mismatch: want=%x:%x got=%x:%x", test.expHi, test.expLo, hi, lo)
+			}
+			if !r.ReadBool() {
+				t.Errorf("trailing bit mismatch")
+			}
+			if err := r.Error(); err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		})
+	}
+
+	r := NewReader(make(BitData, 16))
+	if _, _, err := r.Read128(129); !errors.Is(err, ErrBitCountTooBig) {
+		t.Errorf("expected ErrBitCountTooBig, got %v", err)
+	}
+
+	r.Skip(1)
+	if _, _, err := r.Read128(128); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("expected EOF, got %v", err)
+	}
+	if r.BitsRead() != 1 {
+		t.Errorf("reader advanced on error: %d", r.BitsRead())
+	}
+}
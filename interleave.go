@@ -0,0 +1,90 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+// InterleaveBlock splits the first bitCount bits into blocks of rows*cols bits. Bits of each block
+// are written into a matrix row by row and read out column by column.
+// The bit count must be a multiple of the block size.
+func InterleaveBlock(d BitData, bitCount uint, rows, cols int) (BitData, error) {
+	return permuteBlocks(d, bitCount, rows, cols, false)
+}
+
+// DeinterleaveBlock reverses InterleaveBlock with the same dimensions.
+func DeinterleaveBlock(d BitData, bitCount uint, rows, cols int) (BitData, error) {
+	return permuteBlocks(d, bitCount, rows, cols, true)
+}
+
+func permuteBlocks(d BitData, bitCount uint, rows, cols int, inverse bool) (BitData, error) {
+	if rows <= 0 || cols <= 0 {
+		return nil, ErrInvalidRange
+	}
+
+	size := uint(rows * cols)
+	if bitCount%size != 0 || bitCount > uint(len(d))*8 {
+		return nil, ErrInvalidRange
+	}
+
+	out := make(BitData, (bitCount+7)/8)
+	for base := uint(0); base < bitCount; base += size {
+		for r := uint(0); r < uint(rows); r++ {
+			for c := uint(0); c < uint(cols); c++ {
+				src, dst := base+r*uint(cols)+c, base+c*uint(rows)+r
+				if inverse {
+					src, dst = dst, src
+				}
+				setBit(out, dst, getBit(d, src))
+			}
+		}
+	}
+
+	return out, nil
+}
+
+// InterleaveConvolutional applies a convolutional interleaver with the given number of branches.
+// Consecutive bits are distributed over the branches in turn and branch i delays its bits
+// by i*depth positions. The delay lines start filled with zeros, so the output has the same length
+// as the input and the last bits are still in the delay lines. To flush them, append
+// branches*(branches-1)*depth zero bits to the input. It returns ErrInvalidRange if branches is less
+// than 1, depth is negative or d is shorter than bitCount bits.
+func InterleaveConvolutional(d BitData, bitCount uint, branches, depth int) (BitData, error) {
+	return convolve(d, bitCount, branches, depth, false)
+}
+
+// DeinterleaveConvolutional reverses InterleaveConvolutional with the same parameters.
+// Branch i delays its bits by (branches-1-i)*depth positions, so the output lags the original
+// input by branches*(branches-1)*depth bits.
+func DeinterleaveConvolutional(d BitData, bitCount uint, branches, depth int) (BitData, error) {
+	return convolve(d, bitCount, branches, depth, true)
+}
+
+func convolve(d BitData, bitCount uint, branches, depth int, inverse bool) (BitData, error) {
+	if branches <= 0 || depth < 0 || bitCount > uint(len(d))*8 {
+		return nil, ErrInvalidRange
+	}
+
+	lines := make([][]bool, branches)
+	positions := make([]int, branches)
+	for i := range lines {
+		delay := i
+		if inverse {
+			delay = branches - 1 - i
+		}
+		lines[i] = make([]bool, delay*depth)
+	}
+
+	out := make(BitData, (bitCount+7)/8)
+	for i := uint(0); i < bitCount; i++ {
+		b := i % uint(branches)
+		v := getBit(d, i)
+
+		if line := lines[b]; len(line) > 0 {
+			p := positions[b]
+			line[p], v = v, line[p]
+			positions[b] = (p + 1) % len(line)
+		}
+
+		setBit(out, i, v)
+	}
+
+	return out, nil
+}
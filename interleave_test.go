@@ -0,0 +1,95 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"bytes"
+	"errors"
+	"math/rand/v2"
+	"testing"
+)
+
+func TestInterleaveBlock(t *testing.T) {
+	d, n, _ := ParseBits("111 000 101 010")
+
+	got, err := InterleaveBlock(d, n, 4, 3)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	want, _, _ := ParseBits("1010 1001 1010")
+	if !bytes.Equal(got, want) {
+		t.Errorf("want=%s got=%s", want.FormatBinary(n, 4), got.FormatBinary(n, 4))
+	}
+
+	back, err := DeinterleaveBlock(got, n, 4, 3)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	if !bytes.Equal(back, d) {
+		t.Errorf("want=%s got=%s", d.FormatBinary(n, 3), back.FormatBinary(n, 3))
+	}
+
+	if _, err := InterleaveBlock(d, n, 5, 5); !errors.Is(err, ErrInvalidRange) {
+		t.Errorf("expected error, got %v", err)
+	}
+}
+
+func TestInterleaveBlockBurst(t *testing.T) {
+	const rows, cols = 8, 16
+
+	d := make(BitData, rows*cols/8)
+	interleaved, _ := InterleaveBlock(d, rows*cols, rows, cols)
+
+	// a burst of errors in the interleaved stream spreads across rows
+	for i := uint(40); i < 48; i++ {
+		flipBit(interleaved, i)
+	}
+
+	back, _ := DeinterleaveBlock(interleaved, rows*cols, rows, cols)
+	for r := uint(0); r < rows; r++ {
+		if errs := onesCount(back, r*cols, cols); errs > 1 {
+			t.Errorf("row %d has %d errors", r, errs)
+		}
+	}
+}
+
+func TestInterleaveConvolutional(t *testing.T) {
+	const (
+		branches = 4
+		depth    = 3
+		latency  = branches * (branches - 1) * depth
+		bitCount = 200
+	)
+
+	w := NewWriter()
+	for i := 0; i < bitCount; i++ {
+		w.WriteBool(rand.N(2) == 1)
+	}
+	input := w.BitData()
+	w.Write64(0, latency)
+
+	interleaved, err := InterleaveConvolutional(w.BitData(), bitCount+latency, branches, depth)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if bytes.Equal(interleaved, w.BitData()) {
+		t.Error("interleaving had no effect")
+	}
+
+	out, err := DeinterleaveConvolutional(interleaved, bitCount+latency, branches, depth)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	for i := uint(0); i < bitCount; i++ {
+		if getBit(out, latency+i) != getBit(input, i) {
+			t.Errorf("bit %d mismatch", i)
+		}
+	}
+
+	for _, args := range [][3]int{{0, 3, 8}, {4, -1, 8}, {4, 3, 9}} {
+		if _, err := InterleaveConvolutional(BitData{0xA5}, uint(args[2]), args[0], args[1]); !errors.Is(err, ErrInvalidRange) {
+			t.Errorf("%v: expected ErrInvalidRange, got %v", args, err)
+		}
+	}
+}
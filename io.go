@@ -0,0 +1,178 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+var (
+	_ io.Writer     = (*Writer)(nil)
+	_ io.ByteWriter = (*Writer)(nil)
+	_ io.Reader     = (*Reader)(nil)
+	_ io.ByteReader = (*Reader)(nil)
+	_ io.Seeker     = (*Reader)(nil)
+)
+
+// Write appends the bytes of p, 8 bits each, at the current bit offset. It implements io.Writer
+// and never returns an error.
+func (w *Writer) Write(p []byte) (int, error) {
+	w.WriteBitData(p, uint(len(p))*8)
+	return len(p), nil
+}
+
+// WriteByte appends the 8 bits of c at the current bit offset. It implements io.ByteWriter.
+func (w *Writer) WriteByte(c byte) error {
+	w.Write8(c, 8)
+	return nil
+}
+
+// Read reads whole bytes, 8 bits each, from the current bit offset into p. It implements io.Reader.
+// It returns io.EOF when fewer than 8 bits remain; such trailing bits are left unread.
+func (r *Reader) Read(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	if err := r.probe(uint(len(p)) * 8); err != nil {
+		return 0, err
+	}
+
+	var remain uint
+	if r.bitsRead < r.end {
+		remain = (r.end - r.bitsRead) / 8
+	}
+	if remain == 0 {
+		return 0, io.EOF
+	}
+
+	n := int(min(uint(len(p)), remain))
+
+	if r.bitsRead%8 == 0 && r.trace == nil {
+		d, err := r.bytes(r.bitsRead/8, r.bitsRead/8+uint(n))
+		if err != nil {
+			return 0, err
+		}
+		copy(p, d)
+		r.bitsRead += uint(n) * 8
+		return n, nil
+	}
+
+	for i := 0; i < n; i++ {
+		var err error
+		if p[i], err = r.Read8(8); err != nil {
+			return i, err
+		}
+	}
+
+	return n, nil
+}
+
+// ReadBytesInto fills dst with whole bytes, 8 bits each, read from the current bit offset, without allocating.
+// Unlike Read, it returns io.ErrUnexpectedEOF and leaves the read position unchanged if fewer than
+// len(dst) bytes remain.
+func (r *Reader) ReadBytesInto(dst []byte) error {
+	n := uint(len(dst)) * 8
+	if ok, err := r.ensure(n); !ok {
+		if err == nil {
+			clear(dst)
+		}
+		return err
+	}
+
+	start := r.bitsRead
+	if r.trace != nil {
+		for i := range dst {
+			var err error
+			if dst[i], err = r.Read8(8); err != nil {
+				r.bitsRead = start
+				return err
+			}
+		}
+		return nil
+	}
+
+	if r.bitsRead%8 == 0 {
+		d, err := r.bytes(r.bitsRead/8, r.bitsRead/8+uint(len(dst)))
+		if err != nil {
+			return err
+		}
+		copy(dst, d)
+		r.bitsRead += n
+		return nil
+	}
+
+	for len(dst) >= 8 {
+		v, err := read[uint64](r, 64)
+		if err != nil {
+			r.bitsRead = start
+			return err
+		}
+		if r.order == MSBFirst {
+			binary.BigEndian.PutUint64(dst, v)
+		} else {
+			store64(dst, v)
+		}
+		dst = dst[8:]
+	}
+	for i := range dst {
+		v, err := read[byte](r, 8)
+		if err != nil {
+			r.bitsRead = start
+			return err
+		}
+		dst[i] = v
+	}
+
+	return nil
+}
+
+func (r *ReaderError) ReadBytesInto(dst []byte) {
+	if r.err == nil {
+		r.err = r.reader.ReadBytesInto(dst)
+	}
+}
+
+// ReadByte reads 8 bits from the current bit offset. It implements io.ByteReader.
+// It returns io.EOF when fewer than 8 bits remain.
+func (r *Reader) ReadByte() (byte, error) {
+	if r.bitsRead+8 > r.end {
+		return 0, io.EOF
+	}
+	return r.Read8(8)
+}
+
+// Seek sets the read position and implements io.Seeker, but with offsets in bits instead of bytes.
+// The whence io.SeekStart is the start of the whole data, also for readers returned by ReadMessage,
+// and io.SeekEnd is the end of the reader. Seeking past the end is allowed; reads then fail.
+// Since Read works from the current bit offset, the position doesn't need to be byte aligned.
+// Byte-oriented users of io.Seeker must multiply their offsets by 8.
+func (r *Reader) Seek(offset int64, whence int) (int64, error) {
+	var base int64
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		base = int64(r.bitsRead)
+	case io.SeekEnd:
+		if e, ok := r.src.(sourceEnder); ok {
+			end, ended := e.bitEnd()
+			if !ended {
+				return int64(r.bitsRead), fmt.Errorf("%w: the end of the source isn't known yet", ErrInvalidRange)
+			}
+			r.end = min(r.end, end)
+		}
+		base = int64(r.end)
+	default:
+		return int64(r.bitsRead), fmt.Errorf("%w: invalid whence %d", ErrInvalidRange, whence)
+	}
+
+	pos := base + offset
+	if pos < 0 {
+		return int64(r.bitsRead), fmt.Errorf("%w: negative position %d", ErrInvalidRange, pos)
+	}
+
+	r.bitsRead = uint(pos)
+
+	return pos, nil
+}
@@ -0,0 +1,156 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"bytes"
+	"compress/flate"
+	"fmt"
+	"io"
+	"testing"
+)
+
+func TestWriterIO(t *testing.T) {
+	w := NewWriter()
+	w.Write8(0b101, 3)
+	_, _ = fmt.Fprintf(w, "id=%d", 42)
+	_ = w.WriteByte('!')
+
+	if want, got := uint(3+8*6), w.BitsWritten(); want != got {
+		t.Errorf("bits written mismatch: want=%d got=%d", want, got)
+	}
+
+	r := NewReaderError(w.BitData())
+	r.Skip(3)
+	var got []byte
+	for i := 0; i < 6; i++ {
+		got = append(got, r.Read8(8))
+	}
+	if string(got) != "id=42!" {
+		t.Errorf("value mismatch: %q", got)
+	}
+}
+
+func TestWriterFlate(t *testing.T) {
+	payload := bytes.Repeat([]byte("bitdata "), 100)
+
+	w := NewWriter()
+	w.Write8(0b1, 1)
+	fw, _ := flate.NewWriter(w, flate.BestCompression)
+	_, _ = fw.Write(payload)
+	_ = fw.Close()
+
+	compressed, _ := w.BitData().ShiftRight(w.BitsWritten(), 1)
+	got, err := io.ReadAll(flate.NewReader(bytes.NewReader(compressed)))
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	if !bytes.Equal(payload, got) {
+		t.Errorf("payload mismatch")
+	}
+}
+
+func TestReaderIO(t *testing.T) {
+	for _, prefix := range []byte{0, 5} {
+		w := NewWriter()
+		w.Write8(0b10101, prefix)
+		_, _ = w.Write([]byte("hello, world"))
+		w.Write8(0b11, 2)
+
+		r := NewReader(w.BitData())
+		r.Skip(uint(prefix))
+
+		b, err := r.ReadByte()
+		if err != nil || b != 'h' {
+			t.Errorf("prefix %d: ReadByte: got=%q err=%v", prefix, b, err)
+		}
+
+		got, err := io.ReadAll(io.LimitReader(r, 11))
+		if err != nil || string(got) != "ello, world" {
+			t.Errorf("prefix %d: ReadAll: got=%q err=%v", prefix, got, err)
+		}
+
+		if v, err := r.Read8(2); err != nil || v != 0b11 {
+			t.Errorf("prefix %d: trailing bits mismatch: %b", prefix, v)
+		}
+		if _, err := r.ReadByte(); err != io.EOF {
+			t.Errorf("prefix %d: expected EOF, got %v", prefix, err)
+		}
+		if n, err := r.Read(make([]byte, 4)); n != 0 || err != io.EOF {
+			t.Errorf("prefix %d: expected EOF, got n=%d err=%v", prefix, n, err)
+		}
+	}
+}
+
+func TestReaderSeek(t *testing.T) {
+	w := NewWriter()
+	w.Write8(0b101, 3)
+	_, _ = w.Write([]byte("abc"))
+	r := NewReader(w.BitData()) // 27 bits of content, 32 bits of data
+
+	tests := []struct {
+		name   string
+		offset int64
+		whence int
+		exp    int64
+		expErr bool
+	}{
+		{name: "start", offset: 3, whence: io.SeekStart, exp: 3},
+		{name: "current", offset: 8, whence: io.SeekCurrent, exp: 11},
+		{name: "current-back", offset: -8, whence: io.SeekCurrent, exp: 3},
+		{name: "end", offset: -21, whence: io.SeekEnd, exp: 11},
+		{name: "past-end", offset: 100, whence: io.SeekEnd, exp: 132},
+		{name: "negative", offset: -1, whence: io.SeekStart, exp: 132, expErr: true},
+		{name: "bad-whence", offset: 0, whence: 7, exp: 132, expErr: true},
+	}
+
+	for _, test := range tests {
+		pos, err := r.Seek(test.offset, test.whence)
+		if (err != nil) != test.expErr || pos != test.exp || uint(pos) != r.BitsRead() {
+			t.Errorf("%s: want=%d got=%d err=%v", test.name, test.exp, pos, err)
+		}
+	}
+
+	_, _ = r.Seek(11, io.SeekStart)
+	if b, err := r.ReadByte(); err != nil || b != 'b' {
+		t.Errorf("read after seek: got=%q err=%v", b, err)
+	}
+}
+
+func TestReadBytesInto(t *testing.T) {
+	want := []byte("0123456789abcdefXYZ")
+
+	for _, o := range []BitOrder{LSBFirst, MSBFirst} {
+		for _, skip := range []uint{0, 3} {
+			w := NewWriter(WithBitOrder(o))
+			w.Write8(0, byte(skip))
+			_, _ = w.Write(want)
+
+			r := NewReader(w.BitData(), WithBitOrder(o))
+			r.Skip(skip)
+
+			got := make([]byte, len(want))
+			if err := r.ReadBytesInto(got); err != nil || !bytes.Equal(want, got) {
+				t.Errorf("order %d skip %d: got=%q err=%v", o, skip, got, err)
+			}
+
+			if err := r.ReadBytesInto(got[:1]); err != io.ErrUnexpectedEOF {
+				t.Errorf("order %d skip %d: expected io.ErrUnexpectedEOF, got %v", o, skip, err)
+			}
+			if r.BitsRead() != skip+uint(len(want))*8 {
+				t.Errorf("order %d skip %d: position %d", o, skip, r.BitsRead())
+			}
+		}
+	}
+
+	d := append(BitData{0xFF}, want...)
+	var buf [16]byte
+	r := NewReader(d)
+	allocs := testing.AllocsPerRun(100, func() {
+		_, _ = r.Seek(5, io.SeekStart)
+		_ = r.ReadBytesInto(buf[:])
+	})
+	if allocs != 0 {
+		t.Errorf("ReadBytesInto allocates: %v", allocs)
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"errors"
+	"net"
+	"net/netip"
+)
+
+var ErrInvalidPrefix = errors.New("invalid prefix length")
+
+// WriteAddr writes an IP address as a 1-bit family flag followed by the 32 bits of an IPv4 address or
+// the 128 bits of an IPv6 address, in network byte order. IPv4-mapped IPv6 addresses stay IPv6;
+// use Addr.Unmap to store them as IPv4. The zone is not written and an invalid address is written as 0.0.0.0.
+func (w *Writer) WriteAddr(a netip.Addr) {
+	if !a.IsValid() {
+		a = netip.IPv4Unspecified()
+	}
+
+	if a.Is4() {
+		w.WriteBool(false)
+		b := a.As4()
+		writeAddrBytes(w, b[:])
+		return
+	}
+
+	w.WriteBool(true)
+	b := a.As16()
+	writeAddrBytes(w, b[:])
+}
+
+func (r *Reader) ReadAddr() (netip.Addr, error) {
+	is6, err := r.ReadBool()
+	if err != nil {
+		return netip.Addr{}, err
+	}
+
+	if !is6 {
+		var b [4]byte
+		if err := readAddrBytes(r, b[:]); err != nil {
+			r.bitsRead--
+			return netip.Addr{}, err
+		}
+		return netip.AddrFrom4(b), nil
+	}
+
+	var b [16]byte
+	if err := readAddrBytes(r, b[:]); err != nil {
+		r.bitsRead--
+		return netip.Addr{}, err
+	}
+	return netip.AddrFrom16(b), nil
+}
+
+// WritePrefix writes the address of the prefix as WriteAddr does, followed by the prefix length
+// in 6 bits for IPv4 or 8 bits for IPv6. An invalid prefix is written as 0.0.0.0/0.
+func (w *Writer) WritePrefix(p netip.Prefix) {
+	bits := p.Bits()
+	if bits < 0 {
+		bits = 0
+	}
+
+	a := p.Addr()
+	w.WriteAddr(a)
+	if a.Is6() {
+		w.Write8(uint8(bits), 8)
+	} else {
+		w.Write8(uint8(bits), 6)
+	}
+}
+
+func (r *Reader) ReadPrefix() (netip.Prefix, error) {
+	start := r.bitsRead
+
+	a, err := r.ReadAddr()
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+
+	width := byte(6)
+	if a.Is6() {
+		width = 8
+	}
+
+	bits, err := r.Read8(width)
+	if err != nil {
+		r.bitsRead = start
+		return netip.Prefix{}, err
+	}
+	if int(bits) > a.BitLen() {
+		r.bitsRead = start
+		return netip.Prefix{}, ErrInvalidPrefix
+	}
+
+	return netip.PrefixFrom(a, int(bits)), nil
+}
+
+// WriteIP writes a net.IP in the same format as WriteAddr. Addresses for which To4 succeeds,
+// including the 16-byte form of IPv4 addresses, are written as IPv4.
+func (w *Writer) WriteIP(ip net.IP) {
+	if ip4 := ip.To4(); ip4 != nil {
+		var b [4]byte
+		copy(b[:], ip4)
+		w.WriteAddr(netip.AddrFrom4(b))
+		return
+	}
+
+	a, _ := netip.AddrFromSlice(ip)
+	w.WriteAddr(a)
+}
+
+// ReadIP reads an address written with WriteIP or WriteAddr. IPv4 addresses are returned in the 4-byte form.
+func (r *Reader) ReadIP() (net.IP, error) {
+	a, err := r.ReadAddr()
+	if err != nil {
+		return nil, err
+	}
+	return net.IP(a.AsSlice()), nil
+}
+
+func (r *ReaderError) ReadAddr() (v netip.Addr) {
+	if r.err == nil {
+		v, r.err = r.reader.ReadAddr()
+	}
+	return
+}
+
+func (r *ReaderError) ReadPrefix() (v netip.Prefix) {
+	if r.err == nil {
+		v, r.err = r.reader.ReadPrefix()
+	}
+	return
+}
+
+func (r *ReaderError) ReadIP() (v net.IP) {
+	if r.err == nil {
+		v, r.err = r.reader.ReadIP()
+	}
+	return
+}
+
+func writeAddrBytes(w *Writer, b []byte) {
+	for _, c := range b {
+		w.Write8(c, 8)
+	}
+}
+
+func readAddrBytes(r *Reader, b []byte) error {
+	return r.ReadBytesInto(b)
+}
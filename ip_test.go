@@ -0,0 +1,110 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"io"
+	"net"
+	"net/netip"
+	"testing"
+)
+
+func TestAddr(t *testing.T) {
+	tests := []struct {
+		name    string
+		addr    netip.Addr
+		exp     netip.Addr
+		expBits uint
+	}{
+		{name: "ipv4", addr: netip.MustParseAddr("192.168.1.20"), exp: netip.MustParseAddr("192.168.1.20"), expBits: 33},
+		{name: "ipv6", addr: netip.MustParseAddr("2001:db8::1"), exp: netip.MustParseAddr("2001:db8::1"), expBits: 129},
+		{name: "ipv4-mapped", addr: netip.MustParseAddr("::ffff:10.0.0.1"), exp: netip.MustParseAddr("::ffff:10.0.0.1"), expBits: 129},
+		{name: "zone", addr: netip.MustParseAddr("fe80::1%eth0"), exp: netip.MustParseAddr("fe80::1"), expBits: 129},
+		{name: "invalid", addr: netip.Addr{}, exp: netip.IPv4Unspecified(), expBits: 33},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w := NewWriter()
+			w.Write8(0b101, 3)
+			w.WriteAddr(test.addr)
+
+			if want, got := 3+test.expBits, w.BitsWritten(); want != got {
+				t.Errorf("bits written mismatch: want=%d got=%d", want, got)
+			}
+
+			r := NewReaderError(w.BitData())
+			r.Skip(3)
+			if got := r.ReadAddr(); got != test.exp {
+				t.Errorf("value mismatch: want=%s got=%s", test.exp, got)
+			}
+			if err := r.Error(); err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		})
+	}
+
+	w := NewWriter()
+	w.WriteAddr(netip.MustParseAddr("2001:db8::1"))
+	r := NewReader(w.BitData()[:10])
+	if _, err := r.ReadAddr(); err != io.ErrUnexpectedEOF {
+		t.Errorf("expected EOF, got %v", err)
+	}
+	if r.BitsRead() != 0 {
+		t.Errorf("reader advanced on error: %d", r.BitsRead())
+	}
+}
+
+func TestPrefix(t *testing.T) {
+	prefixes := []string{"10.0.0.0/8", "0.0.0.0/0", "192.168.1.1/32", "2001:db8::/32", "::/0", "::1/128"}
+
+	for _, s := range prefixes {
+		p := netip.MustParsePrefix(s)
+
+		w := NewWriter()
+		w.WritePrefix(p)
+
+		r := NewReaderError(w.BitData())
+		if got := r.ReadPrefix(); got != p {
+			t.Errorf("value mismatch: want=%s got=%s", p, got)
+		}
+		if err := r.Error(); err != nil {
+			t.Errorf("%s: unexpected error: %s", s, err)
+		}
+	}
+
+	w := NewWriter()
+	w.WriteAddr(netip.MustParseAddr("10.0.0.0"))
+	w.Write8(33, 6)
+	r := NewReader(w.BitData())
+	if _, err := r.ReadPrefix(); err != ErrInvalidPrefix {
+		t.Errorf("expected ErrInvalidPrefix, got %v", err)
+	}
+	if r.BitsRead() != 0 {
+		t.Errorf("reader advanced on error: %d", r.BitsRead())
+	}
+}
+
+func TestIP(t *testing.T) {
+	tests := []struct {
+		ip  net.IP
+		exp net.IP
+	}{
+		{ip: net.IPv4(127, 0, 0, 1), exp: net.IP{127, 0, 0, 1}},
+		{ip: net.IP{8, 8, 4, 4}, exp: net.IP{8, 8, 4, 4}},
+		{ip: net.ParseIP("2001:db8::2"), exp: net.ParseIP("2001:db8::2")},
+	}
+
+	for _, test := range tests {
+		w := NewWriter()
+		w.WriteIP(test.ip)
+
+		r := NewReaderError(w.BitData())
+		if got := r.ReadIP(); !got.Equal(test.exp) || len(got) != len(test.exp) {
+			t.Errorf("value mismatch: want=%v got=%v", test.exp, got)
+		}
+		if err := r.Error(); err != nil {
+			t.Errorf("unexpected error: %s", err)
+		}
+	}
+}
@@ -0,0 +1,31 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"iter"
+)
+
+// Values64 returns an iterator over successive width bits wide fields. The iteration stops when
+// fewer than width bits remain, so padding shorter than width at the end of the data is skipped.
+// Padding of width or more bits yields zero fields; read from a bounded reader, such as the one
+// returned by ReadMessage, to avoid that.
+// A width above 64 yields a single ErrBitCountTooBig; a zero width yields nothing.
+func (r *Reader) Values64(width byte) iter.Seq2[uint64, error] {
+	return func(yield func(uint64, error) bool) {
+		if width > 64 {
+			yield(0, ErrBitCountTooBig)
+			return
+		}
+		if width == 0 {
+			return
+		}
+
+		for r.bitsRead+uint(width) <= r.end {
+			v, err := r.Read64(width)
+			if !yield(v, err) || err != nil {
+				return
+			}
+		}
+	}
+}
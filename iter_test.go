@@ -0,0 +1,48 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestValues64(t *testing.T) {
+	values := []uint64{1, 31, 0, 17, 8, 3} // 30 bits, 2 bits of padding
+
+	w := NewWriter()
+	for _, v := range values {
+		w.Write8(uint8(v), 5)
+	}
+
+	var got []uint64
+	for v, err := range NewReader(w.BitData()).Values64(5) {
+		if err != nil {
+			t.Errorf("unexpected error: %s", err)
+			break
+		}
+		got = append(got, v)
+	}
+	if !slices.Equal(values, got) {
+		t.Errorf("value mismatch: want=%v got=%v", values, got)
+	}
+
+	r := NewReader(w.BitData())
+	for v := range r.Values64(5) {
+		if v == 0 {
+			break
+		}
+	}
+	if r.BitsRead() != 15 {
+		t.Errorf("position mismatch after break: want=%d got=%d", 15, r.BitsRead())
+	}
+
+	for _, err := range NewReader(w.BitData()).Values64(65) {
+		if err != ErrBitCountTooBig {
+			t.Errorf("expected ErrBitCountTooBig, got %v", err)
+		}
+	}
+	for range NewReader(w.BitData()).Values64(0) {
+		t.Errorf("zero width yielded a value")
+	}
+}
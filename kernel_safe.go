@@ -0,0 +1,24 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+//go:build !bitdata_unsafe || !(amd64 || arm64 || 386 || riscv64 || loong64 || ppc64le || wasm)
+
+package bitdata
+
+import (
+	"encoding/binary"
+)
+
+// load64 returns the first 8 bytes of b as a little-endian value. The b must have at least 8 bytes.
+func load64(b []byte) uint64 {
+	return binary.LittleEndian.Uint64(b)
+}
+
+// load64BE returns the first 8 bytes of b as a big-endian value. The b must have at least 8 bytes.
+func load64BE(b []byte) uint64 {
+	return binary.BigEndian.Uint64(b)
+}
+
+// store64 stores v into the first 8 bytes of b in little-endian order. The b must have at least 8 bytes.
+func store64(b []byte, v uint64) {
+	binary.LittleEndian.PutUint64(b, v)
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"encoding/binary"
+	"math/rand/v2"
+	"testing"
+)
+
+// TestKernel compares the load and store kernels with the encoding/binary reference. Run it also
+// with the bitdata_unsafe build tag to cover the unsafe implementation.
+func TestKernel(t *testing.T) {
+	rnd := rand.New(rand.NewPCG(3, 5))
+
+	buf := make([]byte, 64)
+	for i := range buf {
+		buf[i] = byte(rnd.Uint32())
+	}
+
+	for i := 0; i+8 <= len(buf); i++ {
+		if want, got := binary.LittleEndian.Uint64(buf[i:]), load64(buf[i:]); want != got {
+			t.Errorf("load64 at %d: want=%#x got=%#x", i, want, got)
+		}
+		if want, got := binary.BigEndian.Uint64(buf[i:]), load64BE(buf[i:]); want != got {
+			t.Errorf("load64BE at %d: want=%#x got=%#x", i, want, got)
+		}
+
+		v := rnd.Uint64()
+		want := binary.LittleEndian.AppendUint64(nil, v)
+		got := make([]byte, 8)
+		store64(got, v)
+		if string(want) != string(got) {
+			t.Errorf("store64: want=%x got=%x", want, got)
+		}
+	}
+}
+
+// TestKernelReadWrite writes and reads values of random widths at every alignment through the kernels.
+func TestKernelReadWrite(t *testing.T) {
+	rnd := rand.New(rand.NewPCG(7, 11))
+
+	for _, o := range []BitOrder{LSBFirst, MSBFirst} {
+		values := make([]uint64, 1000)
+		widths := make([]byte, len(values))
+
+		w := NewWriter(WithBitOrder(o))
+		for i := range values {
+			widths[i] = byte(rnd.IntN(64) + 1)
+			values[i] = rnd.Uint64() & mask[uint64](widths[i])
+			w.Write64(values[i], widths[i])
+		}
+
+		r := NewReader(w.BitData(), WithBitOrder(o))
+		for i := range values {
+			if v, err := r.Read64(widths[i]); err != nil || v != values[i] {
+				t.Fatalf("order %d value %d: want=%#x got=%#x err=%v", o, i, values[i], v, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,26 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+//go:build bitdata_unsafe && (amd64 || arm64 || 386 || riscv64 || loong64 || ppc64le || wasm)
+
+package bitdata
+
+import (
+	"math/bits"
+	"unsafe"
+)
+
+// This file replaces kernel_safe.go when built with the bitdata_unsafe tag on little-endian architectures
+// that allow unaligned loads. The loads and stores skip the bounds checks, so the callers must make sure
+// that at least 8 bytes are available.
+
+func load64(b []byte) uint64 {
+	return *(*uint64)(unsafe.Pointer(unsafe.SliceData(b)))
+}
+
+func load64BE(b []byte) uint64 {
+	return bits.ReverseBytes64(*(*uint64)(unsafe.Pointer(unsafe.SliceData(b))))
+}
+
+func store64(b []byte, v uint64) {
+	*(*uint64)(unsafe.Pointer(unsafe.SliceData(b))) = v
+}
@@ -0,0 +1,51 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"io"
+)
+
+// ApplyKeystream XORs the bits of the data from the bit offset startBit to its end with a keystream read
+// from ks, in place. The keystream bits are taken from its bytes in the LSBFirst order, so the first key byte
+// is applied to the 8 bits at startBit. Applying the same keystream again restores the data, which makes
+// it usable for scrambling and whitening. It returns io.ErrUnexpectedEOF if ks ends too early,
+// in which case the data is partially changed, and panics if startBit is out of bounds.
+func ApplyKeystream(data BitData, startBit uint, ks io.Reader) error {
+	if startBit > uint(len(data))*8 {
+		panic("bitdata: bit range out of bounds")
+	}
+
+	idx := startBit / 8
+	ofs := startBit % 8
+	remain := (uint(len(data))*8 - startBit + 7) / 8
+
+	var buf [512]byte
+	for remain > 0 {
+		key := buf[:min(remain, uint(len(buf)))]
+		if _, err := io.ReadFull(ks, key); err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return err
+		}
+
+		if ofs == 0 {
+			for i, k := range key {
+				data[idx+uint(i)] ^= k
+			}
+		} else {
+			for i, k := range key {
+				data[idx+uint(i)] ^= k << ofs
+				if j := idx + uint(i) + 1; j < uint(len(data)) {
+					data[j] ^= k >> (8 - ofs)
+				}
+			}
+		}
+
+		idx += uint(len(key))
+		remain -= uint(len(key))
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/rand"
+	"testing"
+)
+
+func TestApplyKeystream(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	orig := make(BitData, 1500)
+	rnd.Read(orig)
+	key := make([]byte, 1500)
+	rnd.Read(key)
+
+	for _, start := range []uint{0, 3, 8, 13, 1000, 11999, 12000} {
+		d := bytes.Clone(orig)
+		if err := ApplyKeystream(d, start, bytes.NewReader(key)); err != nil {
+			t.Fatalf("start=%d: %v", start, err)
+		}
+
+		kr := NewReader(key)
+		for i := uint(0); i < uint(len(d))*8; i++ {
+			want := getBit(orig, i)
+			if i >= start {
+				k, _ := kr.ReadBool()
+				want = want != k
+			}
+			if getBit(d, i) != want {
+				t.Fatalf("start=%d: bit %d differs", start, i)
+			}
+		}
+
+		if err := ApplyKeystream(d, start, bytes.NewReader(key)); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(d, orig) {
+			t.Errorf("start=%d: applying the keystream twice didn't restore the data", start)
+		}
+	}
+
+	err := ApplyKeystream(bytes.Clone(orig), 5, bytes.NewReader(key[:100]))
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("expected io.ErrUnexpectedEOF, got %v", err)
+	}
+}
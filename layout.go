@@ -0,0 +1,167 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strings"
+)
+
+// The layout renderers draw fields as a grid of bits, rowBits bits per row, like the packet diagrams
+// of RFCs. Bits not covered by any field are drawn as gaps and bits covered by several fields are
+// drawn as overlaps, which makes layout errors easy to spot.
+
+const (
+	layoutGap     = -1
+	layoutOverlap = -2
+)
+
+// layoutSegment is a run of bits within a row that belong to the same field, gap or overlap.
+type layoutSegment struct {
+	row, col, width int
+	owner           int // index of the field, layoutGap or layoutOverlap
+}
+
+func layoutSegments(fields []Field, rowBits int) []layoutSegment {
+	if rowBits <= 0 {
+		panic("bitdata: invalid layout row width")
+	}
+
+	var total uint
+	for _, f := range fields {
+		total = max(total, f.Offset+uint(f.Width))
+	}
+
+	owners := make([]int, total)
+	for i := range owners {
+		owners[i] = layoutGap
+	}
+	for i, f := range fields {
+		for b := f.Offset; b < f.Offset+uint(f.Width); b++ {
+			if owners[b] == layoutGap {
+				owners[b] = i
+			} else {
+				owners[b] = layoutOverlap
+			}
+		}
+	}
+
+	var segments []layoutSegment
+	for b, owner := range owners {
+		row, col := b/rowBits, b%rowBits
+		if n := len(segments); n > 0 && col > 0 && segments[n-1].owner == owner {
+			segments[n-1].width++
+			continue
+		}
+		segments = append(segments, layoutSegment{row: row, col: col, width: 1, owner: owner})
+	}
+
+	return segments
+}
+
+func layoutText(fields []Field, s layoutSegment) (label, value string) {
+	switch s.owner {
+	case layoutGap:
+		return "", ""
+	case layoutOverlap:
+		return "overlap", ""
+	}
+
+	f := fields[s.owner]
+	return f.Label, fmt.Sprintf("%#x", f.Value)
+}
+
+func layoutClass(owner int) string {
+	switch owner {
+	case layoutGap:
+		return "gap"
+	case layoutOverlap:
+		return "overlap"
+	}
+	return "field"
+}
+
+// LayoutHTML writes the layout of the fields as an HTML table with a column for every bit of a row.
+// The cells have the classes "field", "gap" and "overlap" for styling.
+func LayoutHTML(w io.Writer, fields []Field, rowBits int) error {
+	segments := layoutSegments(fields, rowBits)
+
+	var sb strings.Builder
+	sb.WriteString("<table class=\"bitlayout\">\n<tr>")
+	for i := 0; i < rowBits; i++ {
+		fmt.Fprintf(&sb, "<th>%d</th>", i)
+	}
+	sb.WriteString("</tr>\n")
+
+	for i, s := range segments {
+		if i == 0 || s.row != segments[i-1].row {
+			if i > 0 {
+				sb.WriteString("</tr>\n")
+			}
+			sb.WriteString("<tr>")
+		}
+
+		label, value := layoutText(fields, s)
+		fmt.Fprintf(&sb, "<td colspan=\"%d\" class=\"%s\">", s.width, layoutClass(s.owner))
+		sb.WriteString(html.EscapeString(label))
+		if value != "" {
+			sb.WriteString("<br>" + value)
+		}
+		sb.WriteString("</td>")
+	}
+	if len(segments) > 0 {
+		sb.WriteString("</tr>\n")
+	}
+	sb.WriteString("</table>\n")
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// LayoutSVG writes the layout of the fields as an SVG image.
+func LayoutSVG(w io.Writer, fields []Field, rowBits int) error {
+	const (
+		cellWidth  = 24
+		cellHeight = 40
+		header     = 20
+	)
+
+	segments := layoutSegments(fields, rowBits)
+	rows := 0
+	if len(segments) > 0 {
+		rows = segments[len(segments)-1].row + 1
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%d\" height=\"%d\" font-family=\"monospace\" font-size=\"11\">\n",
+		rowBits*cellWidth+1, header+rows*cellHeight+1)
+
+	for i := 0; i < rowBits; i++ {
+		fmt.Fprintf(&sb, "<text x=\"%d\" y=\"%d\" text-anchor=\"middle\">%d</text>\n", i*cellWidth+cellWidth/2, header-6, i)
+	}
+
+	fill := map[int]string{layoutGap: "#eeeeee", layoutOverlap: "#f7c6c6"}
+	for _, s := range segments {
+		x, y := s.col*cellWidth, header+s.row*cellHeight
+		color, ok := fill[s.owner]
+		if !ok {
+			color = "#dde8f7"
+		}
+
+		label, value := layoutText(fields, s)
+		fmt.Fprintf(&sb, "<g class=\"%s\"><title>%s</title>", layoutClass(s.owner), html.EscapeString(strings.TrimSpace(label+" "+value)))
+		fmt.Fprintf(&sb, "<rect x=\"%d\" y=\"%d\" width=\"%d\" height=\"%d\" fill=\"%s\" stroke=\"#333333\"/>",
+			x, y, s.width*cellWidth, cellHeight, color)
+		fmt.Fprintf(&sb, "<text x=\"%d\" y=\"%d\" text-anchor=\"middle\">%s</text>",
+			x+s.width*cellWidth/2, y+cellHeight/2-2, html.EscapeString(label))
+		fmt.Fprintf(&sb, "<text x=\"%d\" y=\"%d\" text-anchor=\"middle\">%s</text></g>\n",
+			x+s.width*cellWidth/2, y+cellHeight/2+12, value)
+	}
+
+	sb.WriteString("</svg>\n")
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
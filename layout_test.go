@@ -0,0 +1,72 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestLayoutSegments(t *testing.T) {
+	fields := []Field{
+		{Offset: 0, Width: 4, Value: 4, Label: "version"},
+		{Offset: 6, Width: 12, Value: 0x123, Label: "length"},
+		{Offset: 16, Width: 4, Value: 1, Label: "flags"},
+	}
+
+	want := []layoutSegment{
+		{row: 0, col: 0, width: 4, owner: 0},
+		{row: 0, col: 4, width: 2, owner: layoutGap},
+		{row: 0, col: 6, width: 2, owner: 1},
+		{row: 1, col: 0, width: 8, owner: 1},
+		{row: 2, col: 0, width: 2, owner: layoutOverlap},
+		{row: 2, col: 2, width: 2, owner: 2},
+	}
+	if got := layoutSegments(fields, 8); !slices.Equal(got, want) {
+		t.Errorf("want=%v got=%v", want, got)
+	}
+}
+
+func TestLayoutHTML(t *testing.T) {
+	w := NewWriter()
+	w.SetRecording(true)
+	w.WriteLabeled64(2, 3, "a<b")
+	w.WriteLabeled64(9, 5, "len")
+
+	var sb strings.Builder
+	if err := LayoutHTML(&sb, w.Fields(), 8); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "<table class=\"bitlayout\">\n" +
+		"<tr><th>0</th><th>1</th><th>2</th><th>3</th><th>4</th><th>5</th><th>6</th><th>7</th></tr>\n" +
+		"<tr><td colspan=\"3\" class=\"field\">a&lt;b<br>0x2</td><td colspan=\"5\" class=\"field\">len<br>0x9</td></tr>\n" +
+		"</table>\n"
+	if sb.String() != want {
+		t.Errorf("want:\n%s\ngot:\n%s", want, sb.String())
+	}
+}
+
+func TestLayoutSVG(t *testing.T) {
+	fields := []Field{
+		{Offset: 0, Width: 8, Value: 0xAB, Label: "tag"},
+		{Offset: 4, Width: 4, Value: 1, Label: "len"},
+		{Offset: 10, Width: 2, Value: 3, Label: "flags"},
+	}
+
+	var sb strings.Builder
+	if err := LayoutSVG(&sb, fields, 16); err != nil {
+		t.Fatal(err)
+	}
+	svg := sb.String()
+
+	if !strings.HasPrefix(svg, "<svg ") || !strings.HasSuffix(svg, "</svg>\n") {
+		t.Errorf("not an svg document:\n%s", svg)
+	}
+	for _, s := range []string{`<g class="overlap">`, `<g class="gap">`, ">tag<", ">0xab<", `width="96"`} {
+		if !strings.Contains(svg, s) {
+			t.Errorf("missing %q in:\n%s", s, svg)
+		}
+	}
+}
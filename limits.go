@@ -0,0 +1,140 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+	"unsafe"
+)
+
+var ErrLimitExceeded = errors.New("decode limit exceeded")
+
+// Limits bounds the sizes a reader accepts from the stream, so that untrusted input can't make a decoder
+// allocate or recurse without bounds. A zero field means no limit.
+type Limits struct {
+	// MaxLen is the maximum number of elements of a slice read by ReadSlice, of entries of a map read
+	// by ReadMap, and of bytes of a string read by ReadDictString.
+	MaxLen int
+
+	// MaxBits is the maximum length in bits of a message read by ReadMessage, which includes the values
+	// of TLV records.
+	MaxBits uint
+
+	// MaxDepth is the maximum number of values being decoded by Decode, DecodeVersion or DecodeDelta inside each other, counting
+	// the struct fields of struct types and the values of interface fields.
+	MaxDepth int
+
+	// MaxDecodeBits is the maximum number of bits a call of Decode, DecodeVersion or DecodeDelta may read,
+	// including the values decoded inside it.
+	MaxDecodeBits uint
+
+	// MaxAlloc is the maximum number of bytes a call of Decode, DecodeVersion or DecodeDelta may allocate
+	// for the slices and maps read by ReadSlice, ReadMap and ReadDictionary, the strings read by ReadDictString,
+	// the data read by ReadSparse and ReadLZSS, the bitmaps read by ReadRoaring, the indexes read by
+	// ReadRecordIndex and the column segments copied by ReadColumnSegment. Outside of them, it limits
+	// the allocations of the reader as a whole. A Cursor continues with its own count, so the cursors used
+	// by DecodeBlocks don't share it.
+	MaxAlloc uint64
+}
+
+// LimitError is returned when a value read from the stream exceeds a limit set with WithLimits.
+// It wraps ErrLimitExceeded.
+type LimitError struct {
+	Limit string
+	Value uint64
+	Max   uint64
+}
+
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("%s %d exceeds the limit of %d", e.Limit, e.Value, e.Max)
+}
+
+func (e *LimitError) Unwrap() error {
+	return ErrLimitExceeded
+}
+
+// WithLimits sets the limits a reader checks the lengths and the nesting read from the stream against.
+// The readers returned by ReadMessage and similar methods inherit them. Writers ignore it.
+func WithLimits(l Limits) Option {
+	return func(c *config) {
+		c.limits = l
+	}
+}
+
+// checkLen returns a LimitError if n exceeds the MaxLen limit of the reader.
+func (r *Reader) checkLen(n uint64) error {
+	if r.limits.MaxLen > 0 && n > uint64(r.limits.MaxLen) {
+		return &LimitError{Limit: "length", Value: n, Max: uint64(r.limits.MaxLen)}
+	}
+	return nil
+}
+
+// charge counts the allocation of count values of size bytes against the MaxAlloc limit of the reader.
+func (r *Reader) charge(count, size uint64) error {
+	if r.limits.MaxAlloc == 0 {
+		return nil
+	}
+
+	hi, n := bits.Mul64(count, size)
+	total, carry := bits.Add64(*r.allocated, n, 0)
+	if hi != 0 || carry != 0 {
+		total = ^uint64(0)
+	}
+	if total > r.limits.MaxAlloc {
+		return &LimitError{Limit: "allocation", Value: total, Max: r.limits.MaxAlloc}
+	}
+	*r.allocated = total
+
+	return nil
+}
+
+// byteSize returns the size of a value of the type T in bytes.
+func byteSize[T any]() uint64 {
+	var v T
+	return uint64(unsafe.Sizeof(v))
+}
+
+// enter increases the nesting depth of the reader, or returns a LimitError if it would exceed the limit.
+// At the top level it starts a decode operation: the reader ends at the bit budget and the allocations
+// are counted from zero. Every successful call must be followed by a call to leave.
+func (r *Reader) enter() error {
+	if r.limits.MaxDepth > 0 && r.depth >= r.limits.MaxDepth {
+		return &LimitError{Limit: "depth", Value: uint64(r.depth) + 1, Max: uint64(r.limits.MaxDepth)}
+	}
+
+	if r.depth == 0 {
+		if r.allocated != nil {
+			*r.allocated = 0
+		}
+		if m := r.limits.MaxDecodeBits; m > 0 && r.bitsRead <= r.end && r.end-r.bitsRead > m {
+			r.decodeStart, r.decodeEnd, r.fullEnd = r.bitsRead, r.bitsRead+m, r.end
+			r.end = r.decodeEnd
+		}
+	}
+	r.depth++
+
+	return nil
+}
+
+func (r *Reader) leave() {
+	r.depth--
+
+	if r.depth == 0 && r.decodeEnd != 0 {
+		// A source that found its end during the operation has already moved the end before the budget.
+		if r.end == r.decodeEnd {
+			r.end = r.fullEnd
+		}
+		r.decodeEnd = 0
+	}
+}
+
+// pastEnd returns the error of a read of bitCount bits that doesn't fit before the end of the reader:
+// a LimitError if the read crosses the bit budget of a decode operation, and nil otherwise.
+func (r *Reader) pastEnd(bitCount uint) error {
+	if r.decodeEnd == 0 || r.bitsRead <= r.decodeEnd && bitCount <= r.decodeEnd-r.bitsRead {
+		return nil
+	}
+	return &LimitError{Limit: "decode bits", Value: uint64(r.bitsRead-r.decodeStart) + uint64(bitCount), Max: uint64(r.limits.MaxDecodeBits)}
+}
@@ -0,0 +1,248 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"testing"
+)
+
+func TestLimitsLen(t *testing.T) {
+	w := NewWriter()
+	_ = WriteSlice(w, []uint8{1, 2, 3, 4}, 0, func(w *Writer, v uint8) error { w.Write8(v, 8); return nil })
+	_ = WriteMap(w, map[uint8]uint8{1: 2, 3: 4}, 4,
+		func(w *Writer, k uint8) error { w.Write8(k, 8); return nil },
+		func(w *Writer, v uint8) error { w.Write8(v, 8); return nil })
+	d := w.BitData()
+
+	read8 := func(r *Reader) (uint8, error) { return r.Read8(8) }
+
+	r := NewReader(d, WithLimits(Limits{MaxLen: 3}))
+	var le *LimitError
+	if _, err := ReadSlice(r, 0, read8); !errors.As(err, &le) || !errors.Is(err, ErrLimitExceeded) || le.Value != 4 || le.Max != 3 {
+		t.Fatalf("expected a length limit error, got %v", err)
+	}
+
+	r = NewReader(d, WithLimits(Limits{MaxLen: 4}))
+	if s, err := ReadSlice(r, 0, read8); err != nil || len(s) != 4 {
+		t.Fatalf("unexpected %v, %v", s, err)
+	}
+	if m, err := ReadMap(r, 4, read8, read8); err != nil || len(m) != 2 {
+		t.Fatalf("unexpected %v, %v", m, err)
+	}
+
+	sd := NewStringDict("ab")
+	w = NewWriter()
+	w.WriteDictString(sd, "abcab")
+	if _, err := NewReader(w.BitData(), WithLimits(Limits{MaxLen: 4})).ReadDictString(sd); !errors.Is(err, ErrLimitExceeded) {
+		t.Errorf("expected ErrLimitExceeded, got %v", err)
+	}
+}
+
+func TestLimitsMessage(t *testing.T) {
+	f := TLVFormat{TagBits: 4, LenBits: 8}
+	w := NewWriter()
+	_ = w.WriteTLV(f, 1, BitData{0xFF}, 6)
+	_ = w.WriteTLV(f, 2, BitData{0xFF, 0xFF}, 12)
+
+	it := NewReader(w.BitData(), WithLimits(Limits{MaxBits: 8})).TLVs(f)
+	if !it.Next() || it.Tag() != 1 {
+		t.Fatalf("expected the first record, got %v", it.Err())
+	}
+	if it.Next() || !errors.Is(it.Err(), ErrLimitExceeded) {
+		t.Errorf("expected ErrLimitExceeded, got %v", it.Err())
+	}
+
+	r := NewReader(w.BitData(), WithLimits(Limits{MaxBits: 8}))
+	r.Skip(18)
+	if _, err := r.ReadMessage(8); !errors.Is(err, ErrLimitExceeded) || r.BitsRead() != 18 {
+		t.Errorf("unexpected result: err=%v pos=%d", err, r.BitsRead())
+	}
+}
+
+// nestedList decodes itself as a flag followed, if set, by another nestedList.
+type nestedList struct {
+	next *nestedList
+}
+
+func (l *nestedList) DecodeBits(r *Reader) error {
+	more, err := r.ReadBool()
+	if err != nil || !more {
+		return err
+	}
+	l.next = &nestedList{}
+	return r.Decode(l.next)
+}
+
+func TestLimitsDepth(t *testing.T) {
+	d := BitData{0b0111}
+
+	if err := NewReader(d, WithLimits(Limits{MaxDepth: 4})).Decode(&nestedList{}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	r := NewReader(d, WithLimits(Limits{MaxDepth: 3}))
+	if err := r.Decode(&nestedList{}); !errors.Is(err, ErrLimitExceeded) {
+		t.Errorf("expected ErrLimitExceeded, got %v", err)
+	}
+	if r.depth != 0 {
+		t.Errorf("depth %d after decoding", r.depth)
+	}
+}
+
+// byteList decodes itself as a slice of bytes with a varint length.
+type byteList []byte
+
+func (l *byteList) DecodeBits(r *Reader) (err error) {
+	*l, err = ReadSlice(r, 0, func(r *Reader) (byte, error) { return r.Read8(8) })
+	return
+}
+
+func TestLimitsDecodeBits(t *testing.T) {
+	d := BitData{0b0111, 0xFF}
+
+	r := NewReader(d, WithLimits(Limits{MaxDecodeBits: 3}))
+	var le *LimitError
+	if err := r.Decode(&nestedList{}); !errors.As(err, &le) || le.Limit != "decode bits" || le.Value != 4 {
+		t.Fatalf("expected a decode bits limit error, got %v", err)
+	}
+	if r.end != 16 || r.decodeEnd != 0 {
+		t.Errorf("the end %d, budget end %d after decoding", r.end, r.decodeEnd)
+	}
+
+	r = NewReader(d, WithLimits(Limits{MaxDecodeBits: 4}))
+	if err := r.Decode(&nestedList{}); err != nil || r.BitsRead() != 4 {
+		t.Fatalf("unexpected result: err=%v pos=%d", err, r.BitsRead())
+	}
+	if v, err := r.Read8(8); err != nil || v != 0xF0 {
+		t.Errorf("unexpected %x, %v after decoding", v, err)
+	}
+	if err := r.Decode(&nestedList{}); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("expected io.ErrUnexpectedEOF at the end, got %v", err)
+	}
+}
+
+func TestLimitsAlloc(t *testing.T) {
+	w := NewWriter()
+	for range 2 {
+		_ = WriteSlice(w, []byte("abcd"), 0, func(w *Writer, v byte) error { w.Write8(v, 8); return nil })
+	}
+	w.WriteUvarint(1 << 30)
+	d := w.BitData()
+
+	r := NewReader(d, WithLimits(Limits{MaxAlloc: 4}))
+	for range 2 {
+		var l byteList
+		if err := r.Decode(&l); err != nil || string(l) != "abcd" {
+			t.Fatalf("unexpected %q, %v", l, err)
+		}
+	}
+	var l byteList
+	if err := r.Decode(&l); !errors.Is(err, ErrLimitExceeded) {
+		t.Errorf("expected ErrLimitExceeded, got %v", err)
+	}
+
+	r = NewReader(d, WithLimits(Limits{MaxAlloc: 7}))
+	if _, err := ReadSlice(r, 0, func(r *Reader) (byte, error) { return r.Read8(8) }); err != nil {
+		t.Fatal(err)
+	}
+	var le *LimitError
+	if _, err := ReadSlice(r, 0, func(r *Reader) (byte, error) { return r.Read8(8) }); !errors.As(err, &le) || le.Value != 8 {
+		t.Errorf("expected an allocation limit error, got %v", err)
+	}
+}
+
+func TestLimitsAllocBlocks(t *testing.T) {
+	w := NewWriter()
+	if err := EncodeBlocks(w, 100, 4, func(i int, w *Writer) error {
+		for range 10 {
+			_ = WriteSlice(w, []byte("abcd"), 0, func(w *Writer, v byte) error { w.Write8(v, 8); return nil })
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	r := NewReader(w.BitData(), WithLimits(Limits{MaxAlloc: 4}))
+	_, err := DecodeBlocks(r, 4, func(i int, r *Reader) (bool, error) {
+		for range 10 {
+			runtime.Gosched()
+			var l byteList
+			if err := r.Decode(&l); err != nil || string(l) != "abcd" {
+				return false, fmt.Errorf("unexpected %q, %w", l, err)
+			}
+		}
+		return true, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLimitsAllocReaders(t *testing.T) {
+	dict := NewWriter()
+	dict.WriteBitData(BitData{1, 0x01}, 16)
+	dict.WriteUvarint(1 << 27)
+
+	lzss := NewWriter()
+	lzss.WriteLZSS(DefaultLZSS, make([]byte, 1<<16))
+
+	b := NewRoaring()
+	for v := range uint32(5000) {
+		b.Add(v * 2)
+	}
+	roaring := NewWriter()
+	roaring.WriteRoaring(b)
+
+	index := NewWriter()
+	x := NewRecordIndex(1)
+	for range 1000 {
+		x.Mark(index)
+	}
+	index.WriteRecordIndex(x)
+
+	tests := []struct {
+		name string
+		data BitData
+		read func(r *Reader) error
+	}{
+		{
+			name: "ReadDictionary",
+			data: dict.BitData(),
+			read: func(r *Reader) error {
+				_, err := ReadDictionary(r, func(r *Reader) (byte, error) { return r.Read8(8) })
+				return err
+			},
+		},
+		{
+			name: "ReadLZSS",
+			data: lzss.BitData(),
+			read: func(r *Reader) error { _, err := r.ReadLZSS(DefaultLZSS); return err },
+		},
+		{
+			name: "ReadRoaring",
+			data: roaring.BitData(),
+			read: func(r *Reader) error { _, err := r.ReadRoaring(); return err },
+		},
+		{
+			name: "ReadRecordIndex",
+			data: index.BitData(),
+			read: func(r *Reader) error { _, err := r.ReadRecordIndex(); return err },
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := NewReader(test.data, WithLimits(Limits{MaxAlloc: 1 << 10}))
+			if err := test.read(r); !errors.Is(err, ErrLimitExceeded) {
+				t.Errorf("expected ErrLimitExceeded, got %v", err)
+			}
+			if r.BitsRead() != 0 {
+				t.Errorf("read position moved to %d", r.BitsRead())
+			}
+		})
+	}
+}
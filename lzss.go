@@ -0,0 +1,155 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"errors"
+)
+
+var ErrInvalidLZSS = errors.New("invalid LZSS data")
+
+// LZSSFormat sets the widths of the fields of LZSS matches. A match refers to up to 1<<OffsetBits bytes back,
+// and is from MinMatch to MinMatch+1<<LengthBits-1 bytes long.
+type LZSSFormat struct {
+	OffsetBits byte
+	LengthBits byte
+	MinMatch   int
+}
+
+// DefaultLZSS uses a 4 KiB window and matches of 3 to 18 bytes.
+var DefaultLZSS = LZSSFormat{OffsetBits: 12, LengthBits: 4, MinMatch: 3}
+
+// lzssMaxChain limits the number of earlier positions the encoder compares with the current one.
+const lzssMaxChain = 64
+
+func (f LZSSFormat) check() {
+	if f.OffsetBits == 0 || f.OffsetBits > 24 || f.LengthBits == 0 || f.LengthBits > 16 || f.MinMatch < 1 {
+		panic("bitdata: invalid LZSS format")
+	}
+}
+
+// WriteLZSS compresses the data with LZSS and writes it: the length of the data as an LEB128 varint,
+// followed by tokens. A token is a zero bit and a literal byte, or a one bit, the distance minus one
+// in OffsetBits bits and the length minus MinMatch in LengthBits bits. It panics if the format is invalid.
+func (w *Writer) WriteLZSS(f LZSSFormat, data []byte) {
+	f.check()
+
+	w.WriteUvarint(uint64(len(data)))
+
+	window := 1 << f.OffsetBits
+	maxLen := f.MinMatch + 1<<f.LengthBits - 1
+
+	// head holds the last position of every 3-byte hash, prev the previous position with the same hash.
+	head := make(map[uint32]int)
+	prev := make([]int, len(data))
+	insert := func(i int) {
+		if i+3 > len(data) {
+			return
+		}
+		h := uint32(data[i]) | uint32(data[i+1])<<8 | uint32(data[i+2])<<16
+		if j, ok := head[h]; ok {
+			prev[i] = j
+		} else {
+			prev[i] = -1
+		}
+		head[h] = i
+	}
+
+	for i := 0; i < len(data); {
+		bestLen, bestDist := 0, 0
+
+		if i+3 <= len(data) {
+			h := uint32(data[i]) | uint32(data[i+1])<<8 | uint32(data[i+2])<<16
+			j, ok := head[h]
+			for chain := 0; ok && j >= 0 && i-j <= window && chain < lzssMaxChain; chain++ {
+				n := 0
+				for n < maxLen && i+n < len(data) && data[j+n] == data[i+n] {
+					n++
+				}
+				if n > bestLen {
+					bestLen, bestDist = n, i-j
+				}
+				j = prev[j]
+			}
+		}
+
+		if bestLen < f.MinMatch {
+			w.WriteBool(false)
+			w.Write8(data[i], 8)
+			insert(i)
+			i++
+			continue
+		}
+
+		w.WriteBool(true)
+		w.Write32(uint32(bestDist-1), f.OffsetBits)
+		w.Write16(uint16(bestLen-f.MinMatch), f.LengthBits)
+		for end := i + bestLen; i < end; i++ {
+			insert(i)
+		}
+	}
+}
+
+// ReadLZSS reads and decompresses data written with WriteLZSS using the same format. It returns
+// ErrInvalidLZSS if a match refers to data before the start. On error the read position is left unchanged.
+// It panics if the format is invalid.
+func (r *Reader) ReadLZSS(f LZSSFormat) ([]byte, error) {
+	f.check()
+
+	start := r.bitsRead
+
+	rr := ReaderError{reader: *r}
+	data, err := readLZSS(&rr, f)
+	if rr.err != nil {
+		err = rr.err
+	}
+	if err != nil {
+		r.bitsRead = start
+		return nil, err
+	}
+
+	*r = rr.reader
+
+	return data, nil
+}
+
+func readLZSS(r *ReaderError, f LZSSFormat) ([]byte, error) {
+	n := r.ReadUvarint()
+	if n > uint64(maxInt) {
+		return nil, ErrLengthOverflow
+	}
+	if err := r.reader.charge(n, 1); err != nil {
+		return nil, err
+	}
+
+	data := make([]byte, 0, preallocSize(int(n)))
+	for uint64(len(data)) < n && r.err == nil {
+		if !r.ReadBool() {
+			data = append(data, r.Read8(8))
+			continue
+		}
+
+		dist := int(r.Read32(f.OffsetBits)) + 1
+		length := int(r.Read16(f.LengthBits)) + f.MinMatch
+		if r.err != nil {
+			break
+		}
+		if dist > len(data) || uint64(len(data)+length) > n {
+			return nil, ErrInvalidLZSS
+		}
+
+		for from := len(data) - dist; length > 0; length-- {
+			data = append(data, data[from])
+			from++
+		}
+	}
+
+	return data, nil
+}
+
+func (r *ReaderError) ReadLZSS(f LZSSFormat) (data []byte) {
+	if r.err == nil {
+		data, r.err = r.reader.ReadLZSS(f)
+	}
+	return
+}
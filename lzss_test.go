@@ -0,0 +1,82 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"testing"
+)
+
+func TestLZSS(t *testing.T) {
+	rnd := rand.New(rand.NewPCG(4, 8))
+
+	random := make([]byte, 3000)
+	for i := range random {
+		random[i] = byte(rnd.Uint32())
+	}
+
+	text := bytes.Repeat([]byte("the quick brown fox jumps over the lazy dog; "), 40)
+
+	tests := []struct {
+		name string
+		f    LZSSFormat
+		data []byte
+	}{
+		{name: "empty", f: DefaultLZSS, data: nil},
+		{name: "short", f: DefaultLZSS, data: []byte("ab")},
+		{name: "run", f: DefaultLZSS, data: bytes.Repeat([]byte{'z'}, 1000)},
+		{name: "text", f: DefaultLZSS, data: text},
+		{name: "random", f: DefaultLZSS, data: random},
+		{name: "small-window", f: LZSSFormat{OffsetBits: 5, LengthBits: 3, MinMatch: 2}, data: text},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w := NewWriter()
+			w.WriteLZSS(test.f, test.data)
+			w.WriteBool(true)
+
+			r := NewReader(w.BitData())
+			got, err := r.ReadLZSS(test.f)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !bytes.Equal(test.data, got) {
+				t.Errorf("decompressed data mismatch")
+			}
+			if b, _ := r.ReadBool(); !b {
+				t.Errorf("reader not positioned after the data")
+			}
+		})
+	}
+
+	w := NewWriter()
+	w.WriteLZSS(DefaultLZSS, text)
+	if w.BitsWritten() > uint(len(text))*8/4 {
+		t.Errorf("text not compressed: %d bits for %d bytes", w.BitsWritten(), len(text))
+	}
+}
+
+func TestLZSSErrors(t *testing.T) {
+	w := NewWriter()
+	w.WriteUvarint(5)
+	w.WriteBool(true)
+	w.Write16(0, 12)
+	w.Write8(0, 4)
+
+	r := NewReader(w.BitData())
+	if _, err := r.ReadLZSS(DefaultLZSS); !errors.Is(err, ErrInvalidLZSS) || r.BitsRead() != 0 {
+		t.Errorf("expected ErrInvalidLZSS at 0, got %v at %d", err, r.BitsRead())
+	}
+
+	w = NewWriter()
+	w.WriteLZSS(DefaultLZSS, []byte("hello, hello, hello"))
+
+	r = NewReader(w.BitData()[:4])
+	if _, err := r.ReadLZSS(DefaultLZSS); !errors.Is(err, io.ErrUnexpectedEOF) || r.BitsRead() != 0 {
+		t.Errorf("expected io.ErrUnexpectedEOF at 0, got %v at %d", err, r.BitsRead())
+	}
+}
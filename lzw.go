@@ -0,0 +1,207 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+var ErrInvalidLZW = errors.New("invalid LZW data")
+
+// LZWFormat describes a variant of LZW with codes growing from LitWidth+1 to 12 bits. Codes below 1<<LitWidth
+// are literals, followed by the clear code and the end of information code. With EarlyChange, as in TIFF,
+// the code width grows one code earlier than in GIF. The bit order of the codes is the order of the stream:
+// GIF uses LSBFirst and TIFF MSBFirst.
+type LZWFormat struct {
+	LitWidth    byte
+	EarlyChange bool
+}
+
+var (
+	// GIFLZW is the LZW variant of GIF images with 8-bit literals; use with LSBFirst streams.
+	GIFLZW = LZWFormat{LitWidth: 8}
+
+	// TIFFLZW is the LZW variant of TIFF images; use with MSBFirst streams.
+	TIFFLZW = LZWFormat{LitWidth: 8, EarlyChange: true}
+)
+
+const (
+	lzwMaxWidth = 12
+	lzwMaxCode  = 1<<lzwMaxWidth - 1
+	lzwInvalid  = 0xFFFF
+)
+
+func (f LZWFormat) check() {
+	if f.LitWidth < 2 || f.LitWidth > 8 {
+		panic("bitdata: invalid LZW format")
+	}
+}
+
+func (f LZWFormat) early() uint16 {
+	if f.EarlyChange {
+		return 1
+	}
+	return 0
+}
+
+// WriteLZW compresses the data with LZW and writes it as codes, starting with the clear code and
+// ending with the end of information code. The table is cleared whenever it becomes full.
+// It panics if the format is invalid or if a byte of the data doesn't fit in LitWidth bits.
+func (w *Writer) WriteLZW(f LZWFormat, data []byte) {
+	f.check()
+
+	clearCode := uint16(1) << f.LitWidth
+	eoi := clearCode + 1
+	early := f.early()
+
+	width := f.LitWidth + 1
+	hi, overflow := eoi, uint16(1)<<width
+	table := make(map[uint32]uint16)
+
+	// incHi advances the last used code after each code written and reports whether the table was cleared.
+	incHi := func() bool {
+		hi++
+		if hi+early == overflow && width < lzwMaxWidth {
+			width++
+			overflow <<= 1
+		}
+		if hi == lzwMaxCode {
+			w.Write16(clearCode, width)
+			width = f.LitWidth + 1
+			hi, overflow = eoi, uint16(1)<<width
+			clear(table)
+			return true
+		}
+		return false
+	}
+
+	w.Write16(clearCode, width)
+	if len(data) == 0 {
+		w.Write16(eoi, width)
+		return
+	}
+
+	literal := func(b byte) uint16 {
+		if uint16(b) >= clearCode {
+			panic("bitdata: LZW literal out of range")
+		}
+		return uint16(b)
+	}
+
+	code := literal(data[0])
+	for _, b := range data[1:] {
+		key := uint32(code)<<8 | uint32(literal(b))
+		if c, ok := table[key]; ok {
+			code = c
+			continue
+		}
+
+		w.Write16(code, width)
+		code = uint16(b)
+		if !incHi() {
+			table[key] = hi
+		}
+	}
+
+	w.Write16(code, width)
+	incHi()
+	w.Write16(eoi, width)
+}
+
+// ReadLZW reads codes written by WriteLZW, or by other encoders of the format, up to the end of information
+// code and returns the decompressed data. It returns ErrInvalidLZW for a code that isn't defined yet
+// and io.ErrUnexpectedEOF if the data ends without the end of information code. On error the read position
+// is left unchanged.
+func (r *Reader) ReadLZW(f LZWFormat) ([]byte, error) {
+	f.check()
+
+	start := r.bitsRead
+	fail := func(err error) ([]byte, error) {
+		r.bitsRead = start
+		return nil, err
+	}
+
+	clearCode := uint16(1) << f.LitWidth
+	eoi := clearCode + 1
+	early := f.early()
+
+	width := f.LitWidth + 1
+	hi, overflow := eoi, uint16(1)<<width
+	last := uint16(lzwInvalid)
+
+	var (
+		prefix [lzwMaxCode + 1]uint16
+		suffix [lzwMaxCode + 1]byte
+		out    []byte
+		stack  []byte
+	)
+
+	for {
+		code, err := r.Read16(width)
+		if err != nil {
+			return fail(err)
+		}
+
+		switch {
+		case code < clearCode:
+			out = append(out, byte(code))
+			if last != lzwInvalid {
+				prefix[hi], suffix[hi] = last, byte(code)
+			}
+
+		case code == clearCode:
+			width = f.LitWidth + 1
+			hi, overflow = eoi, uint16(1)<<width
+			last = lzwInvalid
+			continue
+
+		case code == eoi:
+			return out, nil
+
+		case code < hi || code == hi && last != lzwInvalid:
+			c := code
+			if code == hi {
+				c = last
+			}
+
+			stack = stack[:0]
+			for c >= clearCode {
+				stack = append(stack, suffix[c])
+				c = prefix[c]
+			}
+			stack = append(stack, byte(c))
+			slices.Reverse(stack)
+
+			out = append(out, stack...)
+			if code == hi {
+				out = append(out, stack[0])
+			}
+			if last != lzwInvalid {
+				prefix[hi], suffix[hi] = last, stack[0]
+			}
+
+		default:
+			return fail(fmt.Errorf("%w: code %d at bit offset %d", ErrInvalidLZW, code, r.bitsRead-uint(width)))
+		}
+
+		last, hi = code, hi+1
+		if hi+early >= overflow {
+			if width == lzwMaxWidth {
+				last = lzwInvalid
+				hi--
+			} else {
+				width++
+				overflow <<= 1
+			}
+		}
+	}
+}
+
+func (r *ReaderError) ReadLZW(f LZWFormat) (data []byte) {
+	if r.err == nil {
+		data, r.err = r.reader.ReadLZW(f)
+	}
+	return
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"bytes"
+	"compress/lzw"
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+func TestLZW(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	random := make([]byte, 20000)
+	rnd.Read(random)
+	text := bytes.Repeat([]byte("TOBEORNOTTOBEORTOBEORNOT#"), 400)
+
+	for _, data := range [][]byte{nil, {7}, {1, 1, 1, 1, 1, 1, 1}, text, random} {
+		for _, order := range []BitOrder{LSBFirst, MSBFirst} {
+			for _, f := range []LZWFormat{GIFLZW, TIFFLZW} {
+				w := NewWriter(WithBitOrder(order))
+				w.WriteLZW(f, data)
+
+				if !f.EarlyChange {
+					var want bytes.Buffer
+					lzwOrder := lzw.LSB
+					if order == MSBFirst {
+						lzwOrder = lzw.MSB
+					}
+					zw := lzw.NewWriter(&want, lzwOrder, 8)
+					zw.Write(data)
+					zw.Close()
+					if !bytes.Equal(w.BitData(), want.Bytes()) {
+						t.Fatalf("%v, %d bytes: differs from compress/lzw", order, len(data))
+					}
+				}
+
+				r := NewReader(w.BitData(), WithBitOrder(order))
+				got, err := r.ReadLZW(f)
+				if err != nil {
+					t.Fatalf("%v, %+v, %d bytes: %v", order, f, len(data), err)
+				}
+				if !bytes.Equal(got, data) {
+					t.Fatalf("%v, %+v, %d bytes: data differs", order, f, len(data))
+				}
+			}
+		}
+	}
+}
+
+func TestLZWLitWidth(t *testing.T) {
+	data := make([]byte, 5000)
+	for i := range data {
+		data[i] = byte(i*i) % 4
+	}
+
+	w := NewWriter()
+	w.WriteLZW(LZWFormat{LitWidth: 2}, data)
+
+	got, err := NewReader(w.BitData()).ReadLZW(LZWFormat{LitWidth: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, data) {
+		t.Error("data differs")
+	}
+}
+
+func TestLZWErrors(t *testing.T) {
+	w := NewWriter()
+	w.WriteLZW(GIFLZW, []byte("abcabc"))
+	d := w.BitData()
+
+	r := NewReader(d[:len(d)-2])
+	if _, err := r.ReadLZW(GIFLZW); err == nil || r.bitsRead != 0 {
+		t.Errorf("truncated: got %v at bit %d", err, r.bitsRead)
+	}
+
+	w = NewWriter()
+	w.Write16(256, 9)
+	w.Write16(300, 9)
+	if _, err := NewReader(w.BitData()).ReadLZW(GIFLZW); !errors.Is(err, ErrInvalidLZW) {
+		t.Errorf("expected ErrInvalidLZW, got %v", err)
+	}
+}
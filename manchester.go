@@ -0,0 +1,113 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"errors"
+)
+
+var ErrInvalidManchester = errors.New("invalid Manchester code")
+
+// ManchesterConvention selects the pair of half-bit levels that encodes a logical one.
+type ManchesterConvention byte
+
+const (
+	// ManchesterIEEE is the IEEE 802.3 convention: a one is a low to high transition (01), a zero is 10.
+	ManchesterIEEE ManchesterConvention = iota
+	// ManchesterThomas is the G. E. Thomas convention: a one is a high to low transition (10), a zero is 01.
+	ManchesterThomas
+)
+
+// ManchesterEncode returns the first bitCount bits of the data in Manchester code, two bits for every bit,
+// and the length of the code in bits.
+func ManchesterEncode(d BitData, bitCount uint, c ManchesterConvention) (BitData, uint) {
+	if bitCount > uint(len(d))*8 {
+		panic("bitdata: bit range out of bounds")
+	}
+
+	r := NewReader(d)
+	w := NewWriter()
+	for i := uint(0); i < bitCount; i++ {
+		b, _ := r.ReadBool()
+		first := b == (c == ManchesterThomas)
+		w.WriteBool(first)
+		w.WriteBool(!first)
+	}
+
+	return w.BitData(), w.bitsWritten
+}
+
+// ManchesterDecode decodes the first bitCount bits of Manchester code and returns the data and its length
+// in bits. It returns ErrInvalidManchester if bitCount is odd or a pair of half-bits has no transition.
+func ManchesterDecode(d BitData, bitCount uint, c ManchesterConvention) (BitData, uint, error) {
+	if bitCount > uint(len(d))*8 {
+		panic("bitdata: bit range out of bounds")
+	}
+	if bitCount%2 != 0 {
+		return nil, 0, ErrInvalidManchester
+	}
+
+	r := NewReader(d)
+	w := NewWriter()
+	for i := uint(0); i < bitCount; i += 2 {
+		first, _ := r.ReadBool()
+		second, _ := r.ReadBool()
+		if first == second {
+			return nil, 0, ErrInvalidManchester
+		}
+		w.WriteBool(first == (c == ManchesterThomas))
+	}
+
+	return w.BitData(), w.bitsWritten, nil
+}
+
+// DiffManchesterEncode returns the first bitCount bits of the data in differential Manchester code and
+// the length of the code in bits. Every bit has a transition in its middle; a zero also has a transition
+// at its start and a one doesn't. The line is assumed to be low before the first bit.
+func DiffManchesterEncode(d BitData, bitCount uint) (BitData, uint) {
+	if bitCount > uint(len(d))*8 {
+		panic("bitdata: bit range out of bounds")
+	}
+
+	r := NewReader(d)
+	w := NewWriter()
+	level := false
+	for i := uint(0); i < bitCount; i++ {
+		b, _ := r.ReadBool()
+		if !b {
+			level = !level
+		}
+		w.WriteBool(level)
+		level = !level
+		w.WriteBool(level)
+	}
+
+	return w.BitData(), w.bitsWritten
+}
+
+// DiffManchesterDecode decodes the first bitCount bits of differential Manchester code written with
+// DiffManchesterEncode. It returns ErrInvalidManchester if bitCount is odd or a bit has no transition
+// in its middle.
+func DiffManchesterDecode(d BitData, bitCount uint) (BitData, uint, error) {
+	if bitCount > uint(len(d))*8 {
+		panic("bitdata: bit range out of bounds")
+	}
+	if bitCount%2 != 0 {
+		return nil, 0, ErrInvalidManchester
+	}
+
+	r := NewReader(d)
+	w := NewWriter()
+	level := false
+	for i := uint(0); i < bitCount; i += 2 {
+		first, _ := r.ReadBool()
+		second, _ := r.ReadBool()
+		if first == second {
+			return nil, 0, ErrInvalidManchester
+		}
+		w.WriteBool(first == level)
+		level = second
+	}
+
+	return w.BitData(), w.bitsWritten, nil
+}
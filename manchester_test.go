@@ -0,0 +1,65 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestManchester(t *testing.T) {
+	tests := []struct {
+		in     string
+		ieee   string
+		thomas string
+		diff   string
+	}{
+		{in: "", ieee: "", thomas: "", diff: ""},
+		{in: "1", ieee: "01", thomas: "10", diff: "01"},
+		{in: "0", ieee: "10", thomas: "01", diff: "10"},
+		{in: "1011", ieee: "01100101", thomas: "10011010", diff: "01011001"},
+		{in: "0000", ieee: "10101010", thomas: "01010101", diff: "10101010"},
+	}
+
+	for _, test := range tests {
+		d, n := fromBitString(test.in, LSBFirst)
+
+		for c, want := range map[ManchesterConvention]string{ManchesterIEEE: test.ieee, ManchesterThomas: test.thomas} {
+			e, en := ManchesterEncode(d, n, c)
+			if got := bitString(e, en, LSBFirst); got != want {
+				t.Errorf("convention %d: encode mismatch for %s: want=%s got=%s", c, test.in, want, got)
+			}
+
+			dd, dn, err := ManchesterDecode(e, en, c)
+			if err != nil {
+				t.Errorf("convention %d: decode failed: %v", c, err)
+			} else if got := bitString(dd, dn, LSBFirst); got != test.in {
+				t.Errorf("convention %d: decode mismatch: want=%s got=%s", c, test.in, got)
+			}
+		}
+
+		e, en := DiffManchesterEncode(d, n)
+		if got := bitString(e, en, LSBFirst); got != test.diff {
+			t.Errorf("differential encode mismatch for %s: want=%s got=%s", test.in, test.diff, got)
+		}
+
+		dd, dn, err := DiffManchesterDecode(e, en)
+		if err != nil {
+			t.Errorf("differential decode failed: %v", err)
+		} else if got := bitString(dd, dn, LSBFirst); got != test.in {
+			t.Errorf("differential decode mismatch: want=%s got=%s", test.in, got)
+		}
+	}
+}
+
+func TestManchesterInvalid(t *testing.T) {
+	for _, s := range []string{"0", "0100", "011", "1100"} {
+		d, n := fromBitString(s, LSBFirst)
+		if _, _, err := ManchesterDecode(d, n, ManchesterIEEE); !errors.Is(err, ErrInvalidManchester) {
+			t.Errorf("%s: expected ErrInvalidManchester, got %v", s, err)
+		}
+		if _, _, err := DiffManchesterDecode(d, n); !errors.Is(err, ErrInvalidManchester) {
+			t.Errorf("%s: expected ErrInvalidManchester from differential decode, got %v", s, err)
+		}
+	}
+}
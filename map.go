@@ -0,0 +1,101 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"cmp"
+	"errors"
+	"slices"
+)
+
+var ErrDuplicateKey = errors.New("duplicate map key")
+
+// WriteMap writes the number of entries of m followed by its keys and values, in map iteration order.
+// The count prefix is lenBits wide; if lenBits is zero, the count is written as a varint.
+func WriteMap[K comparable, V any](w *Writer, m map[K]V, lenBits byte,
+	writeKey func(w *Writer, k K) error, writeValue func(w *Writer, v V) error,
+) error {
+	if err := writeLength(w, len(m), lenBits); err != nil {
+		return err
+	}
+
+	for k, v := range m {
+		if err := writeEntry(w, k, v, writeKey, writeValue); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// WriteMapSorted is like WriteMap, but writes the entries in ascending key order,
+// so equal maps always produce identical bitstreams.
+func WriteMapSorted[K cmp.Ordered, V any](w *Writer, m map[K]V, lenBits byte,
+	writeKey func(w *Writer, k K) error, writeValue func(w *Writer, v V) error,
+) error {
+	return WriteMapFunc(w, m, lenBits, cmp.Compare[K], writeKey, writeValue)
+}
+
+// WriteMapFunc is like WriteMapSorted, but orders the keys with the provided compare function.
+func WriteMapFunc[K comparable, V any](w *Writer, m map[K]V, lenBits byte, compare func(a, b K) int,
+	writeKey func(w *Writer, k K) error, writeValue func(w *Writer, v V) error,
+) error {
+	if err := writeLength(w, len(m), lenBits); err != nil {
+		return err
+	}
+
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.SortFunc(keys, compare)
+
+	for _, k := range keys {
+		if err := writeEntry(w, k, m[k], writeKey, writeValue); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func writeEntry[K, V any](w *Writer, k K, v V, writeKey func(w *Writer, k K) error, writeValue func(w *Writer, v V) error) error {
+	if err := writeKey(w, k); err != nil {
+		return err
+	}
+	return writeValue(w, v)
+}
+
+// ReadMap reads a map written by WriteMap, WriteMapSorted or WriteMapFunc.
+// It returns ErrDuplicateKey if a key appears more than once.
+func ReadMap[K comparable, V any](r *Reader, lenBits byte,
+	readKey func(r *Reader) (K, error), readValue func(r *Reader) (V, error),
+) (map[K]V, error) {
+	n, err := readLength(r, lenBits)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.charge(uint64(n), byteSize[K]()+byteSize[V]()); err != nil {
+		return nil, err
+	}
+
+	m := make(map[K]V, preallocSize(n))
+	for i := 0; i < n; i++ {
+		k, err := readKey(r)
+		if err != nil {
+			return nil, err
+		}
+
+		v, err := readValue(r)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := m[k]; ok {
+			return nil, ErrDuplicateKey
+		}
+		m[k] = v
+	}
+
+	return m, nil
+}
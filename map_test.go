@@ -0,0 +1,101 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"bytes"
+	"errors"
+	"maps"
+	"testing"
+)
+
+func writeKey(w *Writer, k uint16) error {
+	w.Write16(k, 12)
+	return nil
+}
+
+func readKey(r *Reader) (uint16, error) {
+	return r.Read16(12)
+}
+
+func writeValue(w *Writer, v int64) error {
+	w.WriteVarint(v)
+	return nil
+}
+
+func readValue(r *Reader) (int64, error) {
+	return r.ReadVarint()
+}
+
+func TestMap(t *testing.T) {
+	m := map[uint16]int64{1: -1, 40: 1000, 4095: 0, 7: -123456}
+
+	w := NewWriter()
+	if err := WriteMap(w, m, 0, writeKey, writeValue); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	got, err := ReadMap(NewReader(w.BitData()), 0, readKey, readValue)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	if !maps.Equal(m, got) {
+		t.Errorf("mismatch: want=%v got=%v", m, got)
+	}
+}
+
+func TestMapSorted(t *testing.T) {
+	m := make(map[uint16]int64)
+	for i := 0; i < 50; i++ {
+		m[uint16(i*83%4096)] = int64(i)
+	}
+
+	var first BitData
+	for i := 0; i < 10; i++ {
+		w := NewWriter()
+		if err := WriteMapSorted(w, m, 8, writeKey, writeValue); err != nil {
+			t.Errorf("unexpected error: %s", err)
+		}
+
+		if first == nil {
+			first = w.BitData()
+		} else if !bytes.Equal(first, w.BitData()) {
+			t.Error("sorted map encoding is not deterministic")
+		}
+	}
+
+	r := NewReader(first)
+	r.Skip(8)
+	prev := -1
+	for i := 0; i < len(m); i++ {
+		k, _ := readKey(r)
+		_, _ = readValue(r)
+		if int(k) <= prev {
+			t.Errorf("keys not sorted: %d after %d", k, prev)
+		}
+		prev = int(k)
+	}
+
+	got, err := ReadMap(NewReader(first), 8, readKey, readValue)
+	if err != nil || !maps.Equal(m, got) {
+		t.Errorf("mismatch: %v", err)
+	}
+}
+
+func TestMapErrors(t *testing.T) {
+	w := NewWriter()
+	w.Write8(2, 4)
+	_ = writeKey(w, 5)
+	_ = writeValue(w, 1)
+	_ = writeKey(w, 5)
+	_ = writeValue(w, 2)
+
+	if _, err := ReadMap(NewReader(w.BitData()), 4, readKey, readValue); !errors.Is(err, ErrDuplicateKey) {
+		t.Errorf("expected error, got %v", err)
+	}
+
+	m := map[uint16]int64{1: 1, 2: 2}
+	if err := WriteMapSorted(NewWriter(), m, 1, writeKey, writeValue); !errors.Is(err, ErrLengthOverflow) {
+		t.Errorf("expected error, got %v", err)
+	}
+}
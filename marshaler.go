@@ -0,0 +1,82 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"errors"
+)
+
+var (
+	ErrUnsupportedType = errors.New("unsupported type")
+	ErrInvalidTag      = errors.New("invalid struct tag")
+	ErrIncompatible    = errors.New("incompatible layout")
+)
+
+// Marshal encodes a struct, or a pointer to a struct, into BitData.
+//
+// Exported fields are encoded in declaration order. The width of a field is set with the "bits" struct tag,
+// for example `bits:"12"`; without it a field takes the full size of its type. A field tagged with `bits:"-"`
+// is skipped. Supported field types are bool (1 bit), all integer types (signed integers are stored
+// in two's complement and sign-extended on decode), float32 and float64 (always full width),
+// arrays (the tag applies to each element) and nested structs. Types implementing BitMarshaler
+// and BitUnmarshaler are encoded by their own methods. A field of an interface type is encoded with
+// DefaultRegistry, as the tag of the type of its value followed by the value.
+//
+// The width can be followed by comma separated modifiers, for example `bits:"16,be"` or `bits:",msb"`.
+// The modifiers "be" and "le" select the byte order of a number field whose width is a multiple of 8 bits,
+// the modifiers "msb" and "lsb" select the bit order of any field. A field with a bit order different
+// from the stream's must start and end on a byte boundary, otherwise ErrUnaligned is returned: LSBFirst
+// fills a byte from its lowest bit and MSBFirst from its highest one, so the two orders can't share a byte.
+// This covers formats that switch the order between whole bytes, such as CAN frames where Intel and
+// Motorola signals occupy separate bytes. Signals of both orders packed into the same byte can't be
+// described with the tags; read such a frame as a number and extract the signals with shifts and masks.
+//
+// The modifiers "since=N" and "default=V" describe a layout that grows over versions: a field with
+// since=N was added in the version N and the fields must be in the order of their versions. EncodeVersion
+// and DecodeVersion use the layout of an older version, and DecodeVersion sets the newer fields to their
+// default values, or to zero without one. Marshal and Encode always use the latest version.
+//
+// With the bitdata_noreflect build tag, for example for small TinyGo or WASM builds, the package doesn't
+// use reflection to encode structs: only types implementing BitMarshaler and BitUnmarshaler are supported
+// and other values return ErrUnsupportedType.
+func Marshal(v any) (BitData, error) {
+	w := NewWriter()
+	if err := w.Encode(v); err != nil {
+		return nil, err
+	}
+	return w.BitData(), nil
+}
+
+// Unmarshal decodes BitData produced by Marshal into the struct pointed to by v.
+func Unmarshal(data BitData, v any) error {
+	return NewReader(data).Decode(v)
+}
+
+// BitMarshaler is implemented by types that write their own packed representation.
+// Marshal and Encode use it for struct fields, array elements and the top level value.
+type BitMarshaler interface {
+	EncodeBits(w *Writer) error
+}
+
+// BitUnmarshaler is implemented by types that read the representation written by their EncodeBits method.
+// The method must have a pointer receiver, since it modifies the value.
+type BitUnmarshaler interface {
+	DecodeBits(r *Reader) error
+}
+
+// WriteMarshaler writes v with its EncodeBits method. It can be passed to WriteSlice and WriteMap,
+// for example WriteSlice(w, points, 0, WriteMarshaler[Point]).
+func WriteMarshaler[T BitMarshaler](w *Writer, v T) error {
+	return v.EncodeBits(w)
+}
+
+// ReadUnmarshaler reads a value with its DecodeBits method. It can be passed to ReadSlice and ReadMap,
+// for example ReadSlice(r, 0, ReadUnmarshaler[Point]).
+func ReadUnmarshaler[T any, PT interface {
+	*T
+	BitUnmarshaler
+}](r *Reader) (T, error) {
+	var v T
+	err := PT(&v).DecodeBits(r)
+	return v, err
+}
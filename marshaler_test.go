@@ -0,0 +1,89 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+//go:build !bitdata_noreflect
+
+package bitdata
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+var errNegativeLevel = errors.New("negative level")
+
+// level is encoded as a unary number.
+type level int
+
+func (l level) EncodeBits(w *Writer) error {
+	if l < 0 {
+		return errNegativeLevel
+	}
+	for range l {
+		w.WriteBool(true)
+	}
+	w.WriteBool(false)
+	return nil
+}
+
+func (l *level) DecodeBits(r *Reader) error {
+	for *l = 0; ; *l++ {
+		b, err := r.ReadBool()
+		if err != nil || !b {
+			return err
+		}
+	}
+}
+
+func TestMarshaler(t *testing.T) {
+	type record struct {
+		Kind   uint8 `bits:"3"`
+		Level  level
+		Levels [2]level
+	}
+
+	in := record{Kind: 5, Level: 3, Levels: [2]level{0, 2}}
+
+	d, err := Marshal(in)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want, got := "1011110 0110", d.FormatBinary(11, 7); want != got {
+		t.Errorf("want=%s got=%s", want, got)
+	}
+
+	var out record
+	if err := Unmarshal(d, &out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if in != out {
+		t.Errorf("want=%+v got=%+v", in, out)
+	}
+
+	if _, err := Marshal(record{Level: -1}); !errors.Is(err, errNegativeLevel) {
+		t.Errorf("expected errNegativeLevel, got %v", err)
+	}
+
+	if _, err := Marshal(struct {
+		L level `bits:"3"`
+	}{}); !errors.Is(err, ErrInvalidTag) {
+		t.Errorf("expected ErrInvalidTag, got %v", err)
+	}
+}
+
+func TestMarshalerSlice(t *testing.T) {
+	in := []level{2, 0, 1}
+
+	w := NewWriter()
+	if err := WriteSlice(w, in, 4, WriteMarshaler[level]); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	out, err := ReadSlice(NewReader(w.BitData()), 4, ReadUnmarshaler[level])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Equal(in, out) {
+		t.Errorf("want=%v got=%v", in, out)
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import "errors"
+
+var ErrNoOpenMessage = errors.New("no open message")
+
+type openMessage struct {
+	prefix  uint
+	lenBits byte
+}
+
+// BeginMessage starts a nested message. It reserves lenBits bits for the message length,
+// which EndMessage fills with the number of bits written in between. Messages can be nested.
+func (w *Writer) BeginMessage(lenBits byte) {
+	if lenBits == 0 || lenBits > 64 {
+		panic("bitdata: invalid message length prefix width")
+	}
+
+	w.openMessages = append(w.openMessages, openMessage{prefix: w.bitsWritten, lenBits: lenBits})
+	write[uint64](w, 0, lenBits)
+}
+
+// EndMessage ends the most recently started message and writes its length in bits into the reserved prefix.
+// It returns ErrLengthOverflow if the length doesn't fit into the prefix.
+func (w *Writer) EndMessage() error {
+	n := len(w.openMessages)
+	if n == 0 {
+		return ErrNoOpenMessage
+	}
+
+	m := w.openMessages[n-1]
+	w.openMessages = w.openMessages[:n-1]
+
+	length := w.bitsWritten - m.prefix - uint(m.lenBits)
+	if uint64(length) > mask[uint64](m.lenBits) {
+		return ErrLengthOverflow
+	}
+
+	w.putBits(m.prefix, uint64(length), m.lenBits)
+
+	return nil
+}
+
+// ReadMessage reads the length prefix of a nested message written with BeginMessage and EndMessage,
+// and returns a reader limited to the message content. The reader r continues after the message.
+// Bit offsets of the returned reader are relative to the start of the whole data.
+func (r *Reader) ReadMessage(lenBits byte) (*Reader, error) {
+	start := r.bitsRead
+
+	n, err := r.Read64(lenBits)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.limits.MaxBits > 0 && n > uint64(r.limits.MaxBits) {
+		r.bitsRead = start
+		return nil, &LimitError{Limit: "message length", Value: n, Max: uint64(r.limits.MaxBits)}
+	}
+	ok, err := r.ensureCount(n, 1)
+	if err != nil {
+		r.bitsRead = start
+		return nil, err
+	}
+	if !ok {
+		// The message is cut by the optional end, so all of its fields read as missing.
+		n = 0
+	}
+
+	sub := *r
+	sub.end = r.bitsRead + uint(n)
+
+	r.bitsRead = sub.end
+
+	return &sub, nil
+}
+
+// ReadMessage is like Reader.ReadMessage. If an error occurs, the returned reader holds the error.
+func (r *ReaderError) ReadMessage(lenBits byte) *ReaderError {
+	if r.err != nil {
+		return &ReaderError{err: r.err}
+	}
+
+	sub, err := r.reader.ReadMessage(lenBits)
+	if err != nil {
+		r.err = err
+		return &ReaderError{err: err}
+	}
+
+	return &ReaderError{reader: *sub}
+}
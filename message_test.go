@@ -0,0 +1,115 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestMessage(t *testing.T) {
+	w := NewWriter()
+	w.Write8(0b101, 3)
+	w.BeginMessage(10)
+	w.Write16(0x1234, 16)
+	w.BeginMessage(6)
+	w.Write8(0b11, 2)
+	if err := w.EndMessage(); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	w.WriteBool(true)
+	if err := w.EndMessage(); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	w.Write8(0b111, 3)
+
+	r := NewReader(w.BitData())
+	if v, _ := r.Read8(3); v != 0b101 {
+		t.Errorf("value mismatch: %b", v)
+	}
+
+	msg, err := r.ReadMessage(10)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+		return
+	}
+
+	if v, _ := msg.Read16(16); v != 0x1234 {
+		t.Errorf("value mismatch: %x", v)
+	}
+
+	inner, err := msg.ReadMessage(6)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+		return
+	}
+	if v, _ := inner.Read8(2); v != 0b11 {
+		t.Errorf("value mismatch: %b", v)
+	}
+	if _, err := inner.ReadBool(); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("expected EOF at the end of the message, got %v", err)
+	}
+
+	if v, _ := msg.ReadBool(); !v {
+		t.Error("value mismatch")
+	}
+	if _, err := msg.ReadBool(); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("expected EOF at the end of the message, got %v", err)
+	}
+
+	if v, _ := r.Read8(3); v != 0b111 {
+		t.Errorf("value mismatch: %b", v)
+	}
+}
+
+func TestMessageErrors(t *testing.T) {
+	w := NewWriter()
+	if err := w.EndMessage(); !errors.Is(err, ErrNoOpenMessage) {
+		t.Errorf("expected error, got %v", err)
+	}
+
+	w.BeginMessage(3)
+	w.Write8(0, 8)
+	if err := w.EndMessage(); !errors.Is(err, ErrLengthOverflow) {
+		t.Errorf("expected error, got %v", err)
+	}
+
+	// length prefix claims more bits than available
+	w = NewWriter()
+	w.Write8(20, 8)
+	w.Write8(0, 8)
+
+	r := NewReader(w.BitData())
+	if _, err := r.ReadMessage(8); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("expected EOF, got %v", err)
+	}
+	if r.BitsRead() != 0 {
+		t.Errorf("reader advanced on error: %d", r.BitsRead())
+	}
+}
+
+func TestMessageReaderError(t *testing.T) {
+	w := NewWriter()
+	w.BeginMessage(8)
+	w.Write8(7, 4)
+	_ = w.EndMessage()
+
+	r := NewReaderError(w.BitData())
+	msg := r.ReadMessage(8)
+	if v := msg.Read8(4); v != 7 {
+		t.Errorf("value mismatch: %d", v)
+	}
+	msg.Read8(1)
+	if err := msg.Error(); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("expected EOF, got %v", err)
+	}
+	if err := r.Error(); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	msg = r.ReadMessage(8)
+	if msg.Error() == nil || r.Error() == nil {
+		t.Error("expected error")
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+// Interleave2 returns the Morton (Z-order) code of the lowest width bits of x and y: bit i of x is
+// bit 2*i of the code and bit i of y is bit 2*i+1. It panics if width is greater than 32.
+func Interleave2(x, y uint64, width byte) uint64 {
+	if width > 32 {
+		panic("bitdata: Morton width too big")
+	}
+	m := mask[uint64](width)
+	return spread2(x&m) | spread2(y&m)<<1
+}
+
+// Deinterleave2 returns the coordinates of a Morton code made by Interleave2 with the given width.
+func Deinterleave2(code uint64, width byte) (x, y uint64) {
+	if width > 32 {
+		panic("bitdata: Morton width too big")
+	}
+	m := mask[uint64](width)
+	return compact2(code) & m, compact2(code>>1) & m
+}
+
+// Interleave3 returns the Morton (Z-order) code of the lowest width bits of x, y and z: bit i of x is
+// bit 3*i of the code, bit i of y is bit 3*i+1 and bit i of z is bit 3*i+2. It panics if width is greater than 21.
+func Interleave3(x, y, z uint64, width byte) uint64 {
+	if width > 21 {
+		panic("bitdata: Morton width too big")
+	}
+	m := mask[uint64](width)
+	return spread3(x&m) | spread3(y&m)<<1 | spread3(z&m)<<2
+}
+
+// Deinterleave3 returns the coordinates of a Morton code made by Interleave3 with the given width.
+func Deinterleave3(code uint64, width byte) (x, y, z uint64) {
+	if width > 21 {
+		panic("bitdata: Morton width too big")
+	}
+	m := mask[uint64](width)
+	return compact3(code) & m, compact3(code>>1) & m, compact3(code>>2) & m
+}
+
+// spread2 moves bit i of the lowest 32 bits of v to bit 2*i.
+func spread2(v uint64) uint64 {
+	v = (v | v<<16) & 0x0000FFFF0000FFFF
+	v = (v | v<<8) & 0x00FF00FF00FF00FF
+	v = (v | v<<4) & 0x0F0F0F0F0F0F0F0F
+	v = (v | v<<2) & 0x3333333333333333
+	v = (v | v<<1) & 0x5555555555555555
+	return v
+}
+
+// compact2 is the inverse of spread2; it ignores the odd bits.
+func compact2(v uint64) uint64 {
+	v &= 0x5555555555555555
+	v = (v | v>>1) & 0x3333333333333333
+	v = (v | v>>2) & 0x0F0F0F0F0F0F0F0F
+	v = (v | v>>4) & 0x00FF00FF00FF00FF
+	v = (v | v>>8) & 0x0000FFFF0000FFFF
+	v = (v | v>>16) & 0x00000000FFFFFFFF
+	return v
+}
+
+// spread3 moves bit i of the lowest 21 bits of v to bit 3*i.
+func spread3(v uint64) uint64 {
+	v = (v | v<<32) & 0x001F00000000FFFF
+	v = (v | v<<16) & 0x001F0000FF0000FF
+	v = (v | v<<8) & 0x100F00F00F00F00F
+	v = (v | v<<4) & 0x10C30C30C30C30C3
+	v = (v | v<<2) & 0x1249249249249249
+	return v
+}
+
+// compact3 is the inverse of spread3; it ignores the bits not at multiples of 3.
+func compact3(v uint64) uint64 {
+	v &= 0x1249249249249249
+	v = (v | v>>2) & 0x10C30C30C30C30C3
+	v = (v | v>>4) & 0x100F00F00F00F00F
+	v = (v | v>>8) & 0x001F0000FF0000FF
+	v = (v | v>>16) & 0x001F00000000FFFF
+	v = (v | v>>32) & 0x00000000001FFFFF
+	return v
+}
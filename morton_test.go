@@ -0,0 +1,51 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestMorton(t *testing.T) {
+	if got := Interleave2(0b101, 0b011, 3); got != 0b011011 {
+		t.Errorf("Interleave2: got %b", got)
+	}
+	if got := Interleave3(0b11, 0b01, 0b10, 2); got != 0b101011 {
+		t.Errorf("Interleave3: got %b", got)
+	}
+
+	rnd := rand.New(rand.NewSource(1))
+	for range 1000 {
+		x, y, z := rnd.Uint64(), rnd.Uint64(), rnd.Uint64()
+
+		w2 := byte(rnd.Intn(33))
+		code := Interleave2(x, y, w2)
+		for i := range w2 {
+			if code>>(2*i)&1 != x>>i&1 || code>>(2*i+1)&1 != y>>i&1 {
+				t.Fatalf("Interleave2 width %d: bit %d differs", w2, i)
+			}
+		}
+		if code>>(2*uint(w2)) != 0 {
+			t.Fatalf("Interleave2 width %d: bits beyond the width", w2)
+		}
+		if gx, gy := Deinterleave2(code, w2); gx != x&mask[uint64](w2) || gy != y&mask[uint64](w2) {
+			t.Fatalf("Deinterleave2 width %d: got %x %x", w2, gx, gy)
+		}
+
+		w3 := byte(rnd.Intn(22))
+		code = Interleave3(x, y, z, w3)
+		for i := range w3 {
+			if code>>(3*i)&1 != x>>i&1 || code>>(3*i+1)&1 != y>>i&1 || code>>(3*i+2)&1 != z>>i&1 {
+				t.Fatalf("Interleave3 width %d: bit %d differs", w3, i)
+			}
+		}
+		if code>>(3*uint(w3)) != 0 {
+			t.Fatalf("Interleave3 width %d: bits beyond the width", w3)
+		}
+		m := mask[uint64](w3)
+		if gx, gy, gz := Deinterleave3(code, w3); gx != x&m || gy != y&m || gz != z&m {
+			t.Fatalf("Deinterleave3 width %d: got %x %x %x", w3, gx, gy, gz)
+		}
+	}
+}
@@ -0,0 +1,301 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"errors"
+)
+
+var (
+	ErrAudioSync          = errors.New("missing audio frame sync")
+	ErrInvalidAudioHeader = errors.New("invalid audio frame header")
+)
+
+// MPEG audio versions, as coded in the frame header.
+const (
+	MPEG25 = 0
+	MPEG2  = 2
+	MPEG1  = 3
+)
+
+// MPEGAudioHeader is the 4-byte header of an MPEG audio (MP1, MP2, MP3) frame, followed by
+// a 16-bit CRC if the frame is protected. The audio functions expect a writer or reader in the MSBFirst order.
+type MPEGAudioHeader struct {
+	Version         byte // MPEG1, MPEG2 or MPEG25
+	Layer           byte // 1, 2 or 3
+	Protected       bool // the inverse of protection_bit
+	BitrateIndex    byte // 4 bits, 0 is the free format
+	SampleRateIndex byte // 2 bits
+	Padding         bool
+	Private         bool
+	ChannelMode     byte // 2 bits, 3 is mono
+	ModeExtension   byte // 2 bits
+	Copyright       bool
+	Original        bool
+	Emphasis        byte // 2 bits
+	CRC             uint16
+}
+
+var mpegAudioBitrates = [2][3][15]uint16{
+	{ // MPEG-1, layers I, II and III
+		{0, 32, 64, 96, 128, 160, 192, 224, 256, 288, 320, 352, 384, 416, 448},
+		{0, 32, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320, 384},
+		{0, 32, 40, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320},
+	},
+	{ // MPEG-2 and MPEG-2.5
+		{0, 32, 48, 56, 64, 80, 96, 112, 128, 144, 160, 176, 192, 224, 256},
+		{0, 8, 16, 24, 32, 40, 48, 56, 64, 80, 96, 112, 128, 144, 160},
+		{0, 8, 16, 24, 32, 40, 48, 56, 64, 80, 96, 112, 128, 144, 160},
+	},
+}
+
+var mpegAudioSampleRates = [4][3]int{
+	MPEG25: {11025, 12000, 8000},
+	MPEG2:  {22050, 24000, 16000},
+	MPEG1:  {44100, 48000, 32000},
+}
+
+func (h MPEGAudioHeader) valid() bool {
+	return h.Version != 1 && h.Layer >= 1 && h.Layer <= 3 && h.BitrateIndex < 15 && h.SampleRateIndex < 3
+}
+
+// Bitrate returns the bitrate in bits per second, zero for the free format.
+func (h MPEGAudioHeader) Bitrate() int {
+	table := 0
+	if h.Version != MPEG1 {
+		table = 1
+	}
+	return int(mpegAudioBitrates[table][h.Layer-1][h.BitrateIndex]) * 1000
+}
+
+func (h MPEGAudioHeader) SampleRate() int {
+	return mpegAudioSampleRates[h.Version][h.SampleRateIndex]
+}
+
+// FrameSize returns the size of the whole frame in bytes, including the header, zero for the free format.
+func (h MPEGAudioHeader) FrameSize() int {
+	var padding int
+	if h.Padding {
+		padding = 1
+	}
+
+	switch {
+	case h.Layer == 1:
+		return (12*h.Bitrate()/h.SampleRate() + padding) * 4
+	case h.Layer == 3 && h.Version != MPEG1:
+		return 72*h.Bitrate()/h.SampleRate() + padding
+	default:
+		return 144*h.Bitrate()/h.SampleRate() + padding
+	}
+}
+
+// SideInfoSize returns the size in bytes of the side information of a Layer III frame,
+// which is the data protected by the CRC.
+func (h MPEGAudioHeader) SideInfoSize() int {
+	mono := h.ChannelMode == 3
+	switch {
+	case h.Version == MPEG1 && mono:
+		return 17
+	case h.Version == MPEG1:
+		return 32
+	case mono:
+		return 9
+	default:
+		return 17
+	}
+}
+
+// WriteMPEGAudioHeader writes the frame header and, for a protected frame, the CRC.
+// It panics if the header has a reserved version, layer or index.
+func (w *Writer) WriteMPEGAudioHeader(h MPEGAudioHeader) {
+	if !h.valid() {
+		panic("bitdata: invalid MPEG audio header")
+	}
+
+	w.Write16(0x7FF, 11)
+	w.Write8(h.Version, 2)
+	w.Write8(4-h.Layer, 2)
+	w.WriteBool(!h.Protected)
+	w.Write8(h.BitrateIndex, 4)
+	w.Write8(h.SampleRateIndex, 2)
+	w.WriteBool(h.Padding)
+	w.WriteBool(h.Private)
+	w.Write8(h.ChannelMode, 2)
+	w.Write8(h.ModeExtension, 2)
+	w.WriteBool(h.Copyright)
+	w.WriteBool(h.Original)
+	w.Write8(h.Emphasis, 2)
+
+	if h.Protected {
+		w.Write16(h.CRC, 16)
+	}
+}
+
+// ReadMPEGAudioHeader reads a frame header and, for a protected frame, the CRC. It returns ErrAudioSync
+// if the header doesn't start with the frame sync and ErrInvalidAudioHeader if it has a reserved version,
+// layer or index. On error the read position is left unchanged.
+func (r *Reader) ReadMPEGAudioHeader() (MPEGAudioHeader, error) {
+	var h MPEGAudioHeader
+
+	rr := ReaderError{reader: *r}
+	if sync := rr.Read16(11); rr.err == nil && sync != 0x7FF {
+		return h, ErrAudioSync
+	}
+	h.Version = rr.Read8(2)
+	h.Layer = 4 - rr.Read8(2)
+	h.Protected = !rr.ReadBool()
+	h.BitrateIndex = rr.Read8(4)
+	h.SampleRateIndex = rr.Read8(2)
+	h.Padding = rr.ReadBool()
+	h.Private = rr.ReadBool()
+	h.ChannelMode = rr.Read8(2)
+	h.ModeExtension = rr.Read8(2)
+	h.Copyright = rr.ReadBool()
+	h.Original = rr.ReadBool()
+	h.Emphasis = rr.Read8(2)
+	if h.Protected {
+		h.CRC = rr.Read16(16)
+	}
+
+	if rr.err != nil {
+		return MPEGAudioHeader{}, rr.err
+	}
+	if !h.valid() {
+		return MPEGAudioHeader{}, ErrInvalidAudioHeader
+	}
+
+	*r = rr.reader
+
+	return h, nil
+}
+
+// MPEGAudioCRC computes the CRC of a protected MPEG audio frame in the MSBFirst order: over the last 16 bits
+// of the header and the protectedBits bits following the CRC. For Layer III these are the side information bits.
+func MPEGAudioCRC(frame BitData, protectedBits uint) uint16 {
+	w := NewWriterMSB()
+	writeBits(w, frame, 16, 16)
+	writeBits(w, frame, 48, protectedBits)
+	return uint16(CRC16MPEGAudio.Checksum(lsbFirst(w.BitData(), MSBFirst), 0, w.bitsWritten))
+}
+
+// ADTSHeader is the header of an ADTS (Audio Data Transport Stream) AAC frame,
+// followed by a 16-bit CRC if the frame is protected.
+type ADTSHeader struct {
+	MPEG2            bool // ID: MPEG-2 if set, MPEG-4 otherwise
+	Protected        bool // the inverse of protection_absent
+	Profile          byte // 2 bits, the MPEG-4 audio object type minus one
+	SampleRateIndex  byte // 4 bits
+	Private          bool
+	ChannelConfig    byte // 3 bits
+	Original         bool
+	Home             bool
+	CopyrightIDBit   bool
+	CopyrightIDStart bool
+	FrameLength      uint16 // 13 bits, the frame size in bytes including the header
+	BufferFullness   uint16 // 11 bits, 0x7FF for a variable bitrate
+	RawDataBlocks    byte   // 2 bits, the number of raw data blocks minus one
+	CRC              uint16
+}
+
+var adtsSampleRates = [13]int{96000, 88200, 64000, 48000, 44100, 32000, 24000, 22050, 16000, 12000, 11025, 8000, 7350}
+
+// HeaderSize returns the size of the header in bytes, including the CRC.
+func (h ADTSHeader) HeaderSize() int {
+	if h.Protected {
+		return 9
+	}
+	return 7
+}
+
+// SampleRate returns the sample rate, zero for a reserved or explicit index.
+func (h ADTSHeader) SampleRate() int {
+	if int(h.SampleRateIndex) >= len(adtsSampleRates) {
+		return 0
+	}
+	return adtsSampleRates[h.SampleRateIndex]
+}
+
+func (w *Writer) WriteADTSHeader(h ADTSHeader) {
+	w.Write16(0xFFF, 12)
+	w.WriteBool(h.MPEG2)
+	w.Write8(0, 2) // layer
+	w.WriteBool(!h.Protected)
+	w.Write8(h.Profile, 2)
+	w.Write8(h.SampleRateIndex, 4)
+	w.WriteBool(h.Private)
+	w.Write8(h.ChannelConfig, 3)
+	w.WriteBool(h.Original)
+	w.WriteBool(h.Home)
+	w.WriteBool(h.CopyrightIDBit)
+	w.WriteBool(h.CopyrightIDStart)
+	w.Write16(h.FrameLength, 13)
+	w.Write16(h.BufferFullness, 11)
+	w.Write8(h.RawDataBlocks, 2)
+
+	if h.Protected {
+		w.Write16(h.CRC, 16)
+	}
+}
+
+// ReadADTSHeader reads an ADTS header and, for a protected frame, the CRC. It returns ErrAudioSync if the header
+// doesn't start with the sync word and ErrInvalidAudioHeader if its layer isn't zero or the frame length is
+// shorter than the header. On error the read position is left unchanged.
+func (r *Reader) ReadADTSHeader() (ADTSHeader, error) {
+	var h ADTSHeader
+
+	rr := ReaderError{reader: *r}
+	if sync := rr.Read16(12); rr.err == nil && sync != 0xFFF {
+		return h, ErrAudioSync
+	}
+	h.MPEG2 = rr.ReadBool()
+	layer := rr.Read8(2)
+	h.Protected = !rr.ReadBool()
+	h.Profile = rr.Read8(2)
+	h.SampleRateIndex = rr.Read8(4)
+	h.Private = rr.ReadBool()
+	h.ChannelConfig = rr.Read8(3)
+	h.Original = rr.ReadBool()
+	h.Home = rr.ReadBool()
+	h.CopyrightIDBit = rr.ReadBool()
+	h.CopyrightIDStart = rr.ReadBool()
+	h.FrameLength = rr.Read16(13)
+	h.BufferFullness = rr.Read16(11)
+	h.RawDataBlocks = rr.Read8(2)
+	if h.Protected {
+		h.CRC = rr.Read16(16)
+	}
+
+	if rr.err != nil {
+		return ADTSHeader{}, rr.err
+	}
+	if layer != 0 || int(h.FrameLength) < h.HeaderSize() {
+		return ADTSHeader{}, ErrInvalidAudioHeader
+	}
+
+	*r = rr.reader
+
+	return h, nil
+}
+
+// ADTSCRC computes the CRC of a protected ADTS frame in the MSBFirst order: over the 56 bits of the header
+// before the CRC and the protectedBits bits following the CRC.
+func ADTSCRC(frame BitData, protectedBits uint) uint16 {
+	w := NewWriterMSB()
+	writeBits(w, frame, 0, 56)
+	writeBits(w, frame, 72, protectedBits)
+	return uint16(CRC16MPEGAudio.Checksum(lsbFirst(w.BitData(), MSBFirst), 0, w.bitsWritten))
+}
+
+func (r *ReaderError) ReadMPEGAudioHeader() (h MPEGAudioHeader) {
+	if r.err == nil {
+		h, r.err = r.reader.ReadMPEGAudioHeader()
+	}
+	return
+}
+
+func (r *ReaderError) ReadADTSHeader() (h ADTSHeader) {
+	if r.err == nil {
+		h, r.err = r.reader.ReadADTSHeader()
+	}
+	return
+}
@@ -0,0 +1,156 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestCRC16MPEGAudio(t *testing.T) {
+	d := BitData("123456789").ReverseByteBits()
+	if got := CRC16MPEGAudio.Checksum(d, 0, 72); got != 0xAEE7 {
+		t.Errorf("check value mismatch: want=%#x got=%#x", 0xAEE7, got)
+	}
+}
+
+func TestMPEGAudioHeader(t *testing.T) {
+	r := NewReaderMSB(BitData{0xFF, 0xFB, 0x90, 0x64})
+	h, err := r.ReadMPEGAudioHeader()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	want := MPEGAudioHeader{
+		Version:         MPEG1,
+		Layer:           3,
+		BitrateIndex:    9,
+		SampleRateIndex: 0,
+		ChannelMode:     1,
+		ModeExtension:   2,
+		Original:        true,
+	}
+	if h != want {
+		t.Errorf("header mismatch: want=%+v got=%+v", want, h)
+	}
+	if h.Bitrate() != 128000 || h.SampleRate() != 44100 || h.FrameSize() != 417 {
+		t.Errorf("unexpected frame: %d %d %d", h.Bitrate(), h.SampleRate(), h.FrameSize())
+	}
+
+	w := NewWriterMSB()
+	w.WriteMPEGAudioHeader(h)
+	if want := []byte{0xFF, 0xFB, 0x90, 0x64}; !bytes.Equal(want, w.BitData()) {
+		t.Errorf("encoding mismatch: want=%x got=%x", want, w.BitData())
+	}
+}
+
+func TestMPEGAudioCRC(t *testing.T) {
+	h := MPEGAudioHeader{
+		Version:         MPEG2,
+		Layer:           3,
+		Protected:       true,
+		BitrateIndex:    8,
+		SampleRateIndex: 1,
+		ChannelMode:     3,
+	}
+
+	w := NewWriterMSB()
+	w.WriteMPEGAudioHeader(h)
+	for i := 0; i < h.SideInfoSize(); i++ {
+		w.Write8(byte(i*37), 8)
+	}
+	frame := w.AppendBitData(nil)
+
+	crc := MPEGAudioCRC(frame, uint(h.SideInfoSize())*8)
+	frame[4], frame[5] = byte(crc>>8), byte(crc)
+
+	r := NewReaderMSB(frame)
+	got, err := r.ReadMPEGAudioHeader()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got.CRC != crc || r.BitsRead() != 48 {
+		t.Errorf("CRC mismatch: want=%#x got=%#x", crc, got.CRC)
+	}
+
+	// the CRC doesn't depend on the sync bits and the CRC field
+	frame[0], frame[4] = 0, 0
+	if c := MPEGAudioCRC(frame, uint(h.SideInfoSize())*8); c != crc {
+		t.Errorf("CRC mismatch: want=%#x got=%#x", crc, c)
+	}
+	frame[6] ^= 1
+	if c := MPEGAudioCRC(frame, uint(h.SideInfoSize())*8); c == crc {
+		t.Error("CRC didn't change")
+	}
+}
+
+func TestMPEGAudioHeaderInvalid(t *testing.T) {
+	tests := []struct {
+		d   BitData
+		err error
+	}{
+		{d: BitData{0xFF, 0x1B, 0x90, 0x64}, err: ErrAudioSync},
+		{d: BitData{0xFF, 0xEB, 0x90, 0x64}, err: ErrInvalidAudioHeader}, // reserved version
+		{d: BitData{0xFF, 0xF9, 0x90, 0x64}, err: ErrInvalidAudioHeader}, // reserved layer
+		{d: BitData{0xFF, 0xFB, 0xF0, 0x64}, err: ErrInvalidAudioHeader}, // bad bitrate
+	}
+
+	for _, test := range tests {
+		r := NewReaderMSB(test.d)
+		if _, err := r.ReadMPEGAudioHeader(); !errors.Is(err, test.err) {
+			t.Errorf("want=%v for %x got=%v", test.err, test.d, err)
+		}
+		if r.BitsRead() != 0 {
+			t.Errorf("read position changed: %d", r.BitsRead())
+		}
+	}
+}
+
+func TestADTSHeader(t *testing.T) {
+	h := ADTSHeader{
+		Profile:         1,
+		SampleRateIndex: 4,
+		ChannelConfig:   2,
+		FrameLength:     371,
+		BufferFullness:  0x7FF,
+	}
+
+	w := NewWriterMSB()
+	w.WriteADTSHeader(h)
+	if want := []byte{0xFF, 0xF1, 0x50, 0x80, 0x2E, 0x7F, 0xFC}; !bytes.Equal(want, w.BitData()) {
+		t.Errorf("encoding mismatch: want=%x got=%x", want, w.BitData())
+	}
+
+	r := NewReaderErrorMSB(w.BitData())
+	if got := r.ReadADTSHeader(); got != h {
+		t.Errorf("header mismatch: want=%+v got=%+v", h, got)
+	}
+	if err := r.Error(); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	if h.SampleRate() != 44100 || h.HeaderSize() != 7 {
+		t.Errorf("unexpected header: %d %d", h.SampleRate(), h.HeaderSize())
+	}
+
+	h.Protected = true
+	w = NewWriterMSB()
+	w.WriteADTSHeader(h)
+	w.Write16(0xBEEF, 16)
+	frame := w.AppendBitData(nil)
+	h.CRC = ADTSCRC(frame, 16)
+	frame[7], frame[8] = byte(h.CRC>>8), byte(h.CRC)
+
+	r = NewReaderErrorMSB(frame)
+	if got := r.ReadADTSHeader(); got != h {
+		t.Errorf("header mismatch: want=%+v got=%+v", h, got)
+	}
+	if ADTSCRC(frame, 16) != h.CRC {
+		t.Error("CRC mismatch")
+	}
+
+	_, err := NewReaderMSB(BitData{0xFF, 0xF3, 0x50, 0x80, 0x2E, 0x7F, 0xFC}).ReadADTSHeader()
+	if !errors.Is(err, ErrInvalidAudioHeader) {
+		t.Errorf("want=%v got=%v", ErrInvalidAudioHeader, err)
+	}
+}
@@ -0,0 +1,245 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+var (
+	ErrTSSync                 = errors.New("missing MPEG-TS sync byte")
+	ErrInvalidAdaptationField = errors.New("invalid MPEG-TS adaptation field")
+)
+
+const (
+	TSPacketSize = 188
+	TSSyncByte   = 0x47
+)
+
+// TSHeader is the 4-byte header of an MPEG-TS packet. The TS functions expect a writer or reader
+// in the MSBFirst order.
+type TSHeader struct {
+	TransportError    bool
+	PayloadUnitStart  bool
+	Priority          bool
+	PID               uint16 // 13 bits
+	Scrambling        byte   // transport_scrambling_control, 2 bits
+	HasAdaptation     bool
+	HasPayload        bool
+	ContinuityCounter byte // 4 bits
+}
+
+// TSAdaptationField is the adaptation field of an MPEG-TS packet. The PCR and OPCR hold
+// the 27 MHz clock value, base*300 + extension.
+type TSAdaptationField struct {
+	Discontinuity bool
+	RandomAccess  bool
+	ESPriority    bool
+
+	HasPCR  bool
+	PCR     uint64
+	HasOPCR bool
+	OPCR    uint64
+
+	HasSpliceCountdown bool
+	SpliceCountdown    int8
+
+	PrivateData []byte // transport_private_data, nil if absent
+	Extension   []byte // adaptation_field_extension without its length byte, nil if absent
+}
+
+// Len returns the smallest adaptation_field_length that fits the field.
+func (a *TSAdaptationField) Len() int {
+	n := 1
+	if a.HasPCR {
+		n += 6
+	}
+	if a.HasOPCR {
+		n += 6
+	}
+	if a.HasSpliceCountdown {
+		n++
+	}
+	if a.PrivateData != nil {
+		n += 1 + len(a.PrivateData)
+	}
+	if a.Extension != nil {
+		n += 1 + len(a.Extension)
+	}
+	return n
+}
+
+func (w *Writer) WriteTSHeader(h TSHeader) {
+	w.Write8(TSSyncByte, 8)
+	w.WriteBool(h.TransportError)
+	w.WriteBool(h.PayloadUnitStart)
+	w.WriteBool(h.Priority)
+	w.Write16(h.PID, 13)
+	w.Write8(h.Scrambling, 2)
+	w.WriteBool(h.HasAdaptation)
+	w.WriteBool(h.HasPayload)
+	w.Write8(h.ContinuityCounter, 4)
+}
+
+// ReadTSHeader reads a packet header and returns ErrTSSync if it doesn't start with the sync byte.
+// On error the read position is left unchanged.
+func (r *Reader) ReadTSHeader() (TSHeader, error) {
+	var h TSHeader
+	rr := ReaderError{reader: *r}
+	if sync := rr.Read8(8); rr.err == nil && sync != TSSyncByte {
+		return h, fmt.Errorf("%w: %#x", ErrTSSync, sync)
+	}
+	h.TransportError = rr.ReadBool()
+	h.PayloadUnitStart = rr.ReadBool()
+	h.Priority = rr.ReadBool()
+	h.PID = rr.Read16(13)
+	h.Scrambling = rr.Read8(2)
+	h.HasAdaptation = rr.ReadBool()
+	h.HasPayload = rr.ReadBool()
+	h.ContinuityCounter = rr.Read8(4)
+
+	if rr.err != nil {
+		return TSHeader{}, rr.err
+	}
+
+	*r = rr.reader
+
+	return h, nil
+}
+
+// WriteTSAdaptationField writes the adaptation field with the given adaptation_field_length,
+// filling the bytes after the fields with stuffing bytes. A length of zero writes an empty field,
+// which is a single byte of stuffing. It panics if the fields don't fit into the length.
+func (w *Writer) WriteTSAdaptationField(a TSAdaptationField, length byte) {
+	w.Write8(length, 8)
+	if length == 0 {
+		return
+	}
+
+	n := a.Len()
+	if n > int(length) {
+		panic("bitdata: adaptation field doesn't fit into its length")
+	}
+
+	w.WriteBool(a.Discontinuity)
+	w.WriteBool(a.RandomAccess)
+	w.WriteBool(a.ESPriority)
+	w.WriteBool(a.HasPCR)
+	w.WriteBool(a.HasOPCR)
+	w.WriteBool(a.HasSpliceCountdown)
+	w.WriteBool(a.PrivateData != nil)
+	w.WriteBool(a.Extension != nil)
+
+	if a.HasPCR {
+		writeTSClock(w, a.PCR)
+	}
+	if a.HasOPCR {
+		writeTSClock(w, a.OPCR)
+	}
+	if a.HasSpliceCountdown {
+		w.Write8(uint8(a.SpliceCountdown), 8)
+	}
+	if a.PrivateData != nil {
+		w.Write8(uint8(len(a.PrivateData)), 8)
+		w.WriteBitData(a.PrivateData, uint(len(a.PrivateData))*8)
+	}
+	if a.Extension != nil {
+		w.Write8(uint8(len(a.Extension)), 8)
+		w.WriteBitData(a.Extension, uint(len(a.Extension))*8)
+	}
+
+	w.WriteRepeat(0xFF, 8, int(length)-n)
+}
+
+// ReadTSAdaptationField reads an adaptation field including its stuffing bytes. It returns
+// ErrInvalidAdaptationField if the fields don't fit into the adaptation_field_length.
+// On error the read position is left unchanged.
+func (r *Reader) ReadTSAdaptationField() (a TSAdaptationField, err error) {
+	start := r.bitsRead
+	defer func() {
+		if err != nil {
+			r.bitsRead = start
+			a = TSAdaptationField{}
+		}
+	}()
+
+	length, err := r.Read8(8)
+	if err != nil || length == 0 {
+		return
+	}
+
+	field := make(BitData, length)
+	if _, err = io.ReadFull(r, field); err != nil {
+		return
+	}
+
+	f := NewReaderErrorMSB(field)
+	a.Discontinuity = f.ReadBool()
+	a.RandomAccess = f.ReadBool()
+	a.ESPriority = f.ReadBool()
+	a.HasPCR = f.ReadBool()
+	a.HasOPCR = f.ReadBool()
+	a.HasSpliceCountdown = f.ReadBool()
+	hasPrivateData := f.ReadBool()
+	hasExtension := f.ReadBool()
+
+	if a.HasPCR {
+		a.PCR = readTSClock(f)
+	}
+	if a.HasOPCR {
+		a.OPCR = readTSClock(f)
+	}
+	if a.HasSpliceCountdown {
+		a.SpliceCountdown = int8(f.Read8(8))
+	}
+	if hasPrivateData {
+		a.PrivateData = readTSBytes(f, f.Read8(8))
+	}
+	if hasExtension {
+		a.Extension = readTSBytes(f, f.Read8(8))
+	}
+
+	if f.err != nil {
+		err = ErrInvalidAdaptationField
+	}
+
+	return
+}
+
+func (r *ReaderError) ReadTSHeader() (h TSHeader) {
+	if r.err == nil {
+		h, r.err = r.reader.ReadTSHeader()
+	}
+	return
+}
+
+func (r *ReaderError) ReadTSAdaptationField() (a TSAdaptationField) {
+	if r.err == nil {
+		a, r.err = r.reader.ReadTSAdaptationField()
+	}
+	return
+}
+
+// writeTSClock writes a program clock reference: a 33-bit base, 6 reserved bits and a 9-bit extension.
+func writeTSClock(w *Writer, v uint64) {
+	w.Write64(v/300, 33)
+	w.Write8(0x3F, 6)
+	w.Write16(uint16(v%300), 9)
+}
+
+func readTSClock(r *ReaderError) uint64 {
+	base := r.Read64(33)
+	r.Skip(6)
+	ext := r.Read16(9)
+	return base*300 + uint64(ext)
+}
+
+func readTSBytes(r *ReaderError, n byte) []byte {
+	p := make([]byte, n)
+	if r.err == nil {
+		_, r.err = io.ReadFull(&r.reader, p)
+	}
+	return p
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestTSPacket(t *testing.T) {
+	h := TSHeader{
+		PayloadUnitStart:  true,
+		PID:               0x1FFE,
+		HasAdaptation:     true,
+		HasPayload:        true,
+		ContinuityCounter: 7,
+	}
+	a := TSAdaptationField{
+		RandomAccess:       true,
+		HasPCR:             true,
+		PCR:                (1<<33-1)*300 + 299,
+		HasSpliceCountdown: true,
+		SpliceCountdown:    -3,
+		PrivateData:        []byte{0xAB, 0xCD},
+	}
+
+	payload := bytes.Repeat([]byte{0x55}, 160)
+
+	w := NewWriterMSB()
+	w.WriteTSHeader(h)
+	w.WriteTSAdaptationField(a, byte(TSPacketSize-4-1-len(payload)))
+	w.WriteBitData(payload, uint(len(payload))*8)
+
+	d := w.BitData()
+	if len(d) != TSPacketSize {
+		t.Fatalf("packet size mismatch: %d", len(d))
+	}
+	if want := []byte{0x47, 0x5F, 0xFE, 0x37, 23, 0x56}; !bytes.Equal(want, d[:6]) {
+		t.Errorf("encoding mismatch: want=%x got=%x", want, d[:6])
+	}
+	if want := []byte{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0x2B}; !bytes.Equal(want, d[6:12]) {
+		t.Errorf("PCR mismatch: want=%x got=%x", want, d[6:12])
+	}
+
+	r := NewReaderErrorMSB(d)
+	if got := r.ReadTSHeader(); got != h {
+		t.Errorf("header mismatch: want=%+v got=%+v", h, got)
+	}
+	if got := r.ReadTSAdaptationField(); !reflect.DeepEqual(a, got) {
+		t.Errorf("adaptation field mismatch: want=%+v got=%+v", a, got)
+	}
+	if err := r.Error(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if r.reader.BitsRead() != uint(TSPacketSize-len(payload))*8 {
+		t.Errorf("payload offset mismatch: %d", r.reader.BitsRead()/8)
+	}
+}
+
+func TestTSAdaptationFieldStuffing(t *testing.T) {
+	w := NewWriterMSB()
+	w.WriteTSAdaptationField(TSAdaptationField{}, 0)
+	w.WriteTSAdaptationField(TSAdaptationField{Discontinuity: true}, 3)
+
+	if want := []byte{0, 3, 0x80, 0xFF, 0xFF}; !bytes.Equal(want, w.BitData()) {
+		t.Errorf("encoding mismatch: want=%x got=%x", want, w.BitData())
+	}
+
+	r := NewReaderErrorMSB(w.BitData())
+	if a := r.ReadTSAdaptationField(); !reflect.DeepEqual(a, TSAdaptationField{}) {
+		t.Errorf("unexpected field: %+v", a)
+	}
+	if a := r.ReadTSAdaptationField(); !a.Discontinuity {
+		t.Errorf("unexpected field: %+v", a)
+	}
+	if err := r.Error(); err != nil || r.reader.BitsRead() != 40 {
+		t.Errorf("unexpected state: %v %d", err, r.reader.BitsRead())
+	}
+}
+
+func TestTSErrors(t *testing.T) {
+	r := NewReaderMSB(BitData{0x48, 0, 0, 0})
+	if _, err := r.ReadTSHeader(); !errors.Is(err, ErrTSSync) {
+		t.Errorf("want=%v got=%v", ErrTSSync, err)
+	}
+
+	// PCR flag set, but only 2 bytes of the field
+	r = NewReaderMSB(BitData{2, 0x10, 0, 0})
+	if _, err := r.ReadTSAdaptationField(); !errors.Is(err, ErrInvalidAdaptationField) {
+		t.Errorf("want=%v got=%v", ErrInvalidAdaptationField, err)
+	}
+	if r.BitsRead() != 0 {
+		t.Errorf("read position changed: %d", r.BitsRead())
+	}
+}
@@ -0,0 +1,33 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+// The Must methods are for data produced in the same process, where a failed read is a programming error.
+// They panic instead of returning the error.
+
+func (r *Reader) MustReadBool() bool {
+	return must(r.ReadBool())
+}
+
+func (r *Reader) MustRead8(bitCount byte) uint8 {
+	return must(r.Read8(bitCount))
+}
+
+func (r *Reader) MustRead16(bitCount byte) uint16 {
+	return must(r.Read16(bitCount))
+}
+
+func (r *Reader) MustRead32(bitCount byte) uint32 {
+	return must(r.Read32(bitCount))
+}
+
+func (r *Reader) MustRead64(bitCount byte) uint64 {
+	return must(r.Read64(bitCount))
+}
+
+func must[T any](v T, err error) T {
+	if err != nil {
+		panic("bitdata: " + err.Error())
+	}
+	return v
+}
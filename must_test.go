@@ -0,0 +1,40 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"testing"
+)
+
+func TestMustRead(t *testing.T) {
+	w := NewWriter()
+	w.WriteBool(true)
+	w.Write8(0x5, 3)
+	w.Write16(0x1234, 13)
+	w.Write32(0xABCDE, 20)
+	w.Write64(0x123456789, 36)
+
+	r := NewReader(w.BitData())
+	if v := r.MustReadBool(); !v {
+		t.Errorf("bool mismatch")
+	}
+	if v := r.MustRead8(3); v != 0x5 {
+		t.Errorf("8 mismatch: %x", v)
+	}
+	if v := r.MustRead16(13); v != 0x1234 {
+		t.Errorf("16 mismatch: %x", v)
+	}
+	if v := r.MustRead32(20); v != 0xABCDE {
+		t.Errorf("32 mismatch: %x", v)
+	}
+	if v := r.MustRead64(36); v != 0x123456789 {
+		t.Errorf("64 mismatch: %x", v)
+	}
+
+	defer func() {
+		if p := recover(); p != "bitdata: Read8 of 8 bits at bit offset 73: unexpected EOF" {
+			t.Errorf("unexpected panic: %v", p)
+		}
+	}()
+	r.MustRead8(8)
+}
@@ -0,0 +1,116 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+// AckFormat sets the widths of the fields of an AckHeader: the sequence numbers have SeqBits bits,
+// 1 to 32, usually 16 or 32, and wrap around; the ack bitfield has AckBits bits, 0 to 64.
+type AckFormat struct {
+	SeqBits byte
+	AckBits byte
+}
+
+// DefaultAck uses 16-bit sequence numbers and a 32-bit ack bitfield.
+var DefaultAck = AckFormat{SeqBits: 16, AckBits: 32}
+
+func (f AckFormat) check() {
+	if f.SeqBits < 1 || f.SeqBits > 32 || f.AckBits > 64 {
+		panic("bitdata: invalid ack format")
+	}
+}
+
+// AckHeader is the packet header of the classic reliable-UDP pattern: the sequence number of the packet,
+// the most recent sequence number received from the peer and a bitfield in which bit i tells whether
+// the packet Ack-1-i was received too.
+type AckHeader struct {
+	Sequence uint32
+	Ack      uint32
+	AckBits  uint64
+}
+
+// Receive records in the Ack and AckBits fields that the packet with the sequence number seq was received.
+// Packets older than the bitfield covers are ignored.
+func (h *AckHeader) Receive(seq uint32, f AckFormat) {
+	f.check()
+
+	d := SeqDiff(seq, h.Ack, f.SeqBits)
+	switch {
+	case d > 0:
+		if d > 64 {
+			h.AckBits = 0
+		} else {
+			h.AckBits = (h.AckBits<<1 | 1) << (d - 1)
+		}
+		h.Ack = seq & mask[uint32](f.SeqBits)
+	case d < 0 && -d <= int64(f.AckBits):
+		h.AckBits |= 1 << (-d - 1)
+	}
+	h.AckBits &= mask[uint64](f.AckBits)
+}
+
+// Acked reports whether the header acknowledges the packet with the sequence number seq.
+func (h AckHeader) Acked(seq uint32, f AckFormat) bool {
+	f.check()
+
+	d := SeqDiff(h.Ack, seq, f.SeqBits)
+	return d == 0 || d > 0 && d <= int64(f.AckBits) && h.AckBits>>(d-1)&1 == 1
+}
+
+// WriteAckHeader writes the sequence number, the ack and the ack bitfield with the widths of the format.
+func (w *Writer) WriteAckHeader(f AckFormat, h AckHeader) {
+	f.check()
+
+	w.Write32(h.Sequence, f.SeqBits)
+	w.Write32(h.Ack, f.SeqBits)
+	w.Write64(h.AckBits, f.AckBits)
+}
+
+// ReadAckHeader reads a header written by WriteAckHeader. It returns io.ErrUnexpectedEOF and leaves
+// the read position unchanged if the header is incomplete.
+func (r *Reader) ReadAckHeader(f AckFormat) (AckHeader, error) {
+	f.check()
+
+	if ok, err := r.ensure(2*uint(f.SeqBits) + uint(f.AckBits)); !ok {
+		return AckHeader{}, err
+	}
+
+	start := r.bitsRead
+	var h AckHeader
+	var err error
+	if h.Sequence, err = r.Read32(f.SeqBits); err == nil {
+		if h.Ack, err = r.Read32(f.SeqBits); err == nil {
+			h.AckBits, err = r.Read64(f.AckBits)
+		}
+	}
+	if err != nil {
+		r.bitsRead = start
+		return AckHeader{}, err
+	}
+
+	return h, nil
+}
+
+func (r *ReaderError) ReadAckHeader(f AckFormat) (h AckHeader) {
+	if r.err == nil {
+		h, r.err = r.reader.ReadAckHeader(f)
+	}
+	return
+}
+
+// SeqDiff returns the signed distance from the sequence number b to a, both bits wide, accounting for
+// wrap-around: it's positive if a is more recent than b. Distances of half the range are positive.
+func SeqDiff(a, b uint32, bits byte) int64 {
+	if bits < 1 || bits > 32 {
+		panic("bitdata: invalid sequence number width")
+	}
+
+	d := int64((a - b) & mask[uint32](bits))
+	if d > 1<<(bits-1) {
+		d -= 1 << bits
+	}
+	return d
+}
+
+// SeqNewer reports whether the sequence number a, bits wide, is more recent than b.
+func SeqNewer(a, b uint32, bits byte) bool {
+	return SeqDiff(a, b, bits) > 0
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestSeqDiff(t *testing.T) {
+	for _, tt := range []struct {
+		a, b uint32
+		bits byte
+		want int64
+	}{
+		{a: 5, b: 3, bits: 16, want: 2},
+		{a: 3, b: 5, bits: 16, want: -2},
+		{a: 1, b: 65535, bits: 16, want: 2},
+		{a: 65535, b: 1, bits: 16, want: -2},
+		{a: 32768, b: 0, bits: 16, want: 32768},
+		{a: 0, b: 0xFFFFFFFF, bits: 32, want: 1},
+		{a: 0, b: 7, bits: 3, want: 1},
+	} {
+		if got := SeqDiff(tt.a, tt.b, tt.bits); got != tt.want {
+			t.Errorf("SeqDiff(%d, %d, %d): got %d, want %d", tt.a, tt.b, tt.bits, got, tt.want)
+		}
+		if got := SeqNewer(tt.a, tt.b, tt.bits); got != (tt.want > 0) {
+			t.Errorf("SeqNewer(%d, %d, %d): got %t", tt.a, tt.b, tt.bits, got)
+		}
+	}
+}
+
+func TestAckHeader(t *testing.T) {
+	f := DefaultAck
+
+	h := AckHeader{Ack: 65530}
+	for _, seq := range []uint32{65533, 65531, 2, 65535, 1, 20} {
+		h.Receive(seq, f)
+	}
+	if h.Ack != 20 {
+		t.Fatalf("got ack %d", h.Ack)
+	}
+	for seq, want := range map[uint32]bool{20: true, 2: true, 1: true, 0: false, 65535: true, 65534: false, 65533: true, 65532: false, 21: false} {
+		if got := h.Acked(seq, f); got != want {
+			t.Errorf("Acked(%d): got %t, want %t", seq, got, want)
+		}
+	}
+
+	h.Sequence = 12345
+	w := NewWriter()
+	w.WriteAckHeader(f, h)
+	if w.bitsWritten != 64 {
+		t.Errorf("wrote %d bits", w.bitsWritten)
+	}
+
+	r := NewReader(w.BitData())
+	got, err := r.ReadAckHeader(f)
+	if err != nil || got != h {
+		t.Errorf("got %+v, %v, want %+v", got, err, h)
+	}
+	if _, err := r.ReadAckHeader(f); !errors.Is(err, io.ErrUnexpectedEOF) || r.bitsRead != 64 {
+		t.Errorf("expected io.ErrUnexpectedEOF, got %v", err)
+	}
+}
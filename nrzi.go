@@ -0,0 +1,40 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+// NRZIEncode returns the first bitCount bits of the data in NRZI code: a one is sent as a change of the line
+// level and a zero keeps the level. The initial argument is the line level before the first bit.
+func NRZIEncode(d BitData, bitCount uint, initial bool) BitData {
+	if bitCount > uint(len(d))*8 {
+		panic("bitdata: bit range out of bounds")
+	}
+
+	r := NewReader(d)
+	w := NewWriter()
+	level := initial
+	for i := uint(0); i < bitCount; i++ {
+		b, _ := r.ReadBool()
+		level = level != b
+		w.WriteBool(level)
+	}
+
+	return w.BitData()
+}
+
+// NRZIDecode reverses NRZIEncode for the first bitCount line levels, given the level before the first one.
+func NRZIDecode(d BitData, bitCount uint, initial bool) BitData {
+	if bitCount > uint(len(d))*8 {
+		panic("bitdata: bit range out of bounds")
+	}
+
+	r := NewReader(d)
+	w := NewWriter()
+	level := initial
+	for i := uint(0); i < bitCount; i++ {
+		b, _ := r.ReadBool()
+		w.WriteBool(b != level)
+		level = b
+	}
+
+	return w.BitData()
+}
@@ -0,0 +1,35 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"testing"
+)
+
+func TestNRZI(t *testing.T) {
+	tests := []struct {
+		in      string
+		initial bool
+		out     string
+	}{
+		{in: "", initial: false, out: ""},
+		{in: "0000", initial: false, out: "0000"},
+		{in: "0000", initial: true, out: "1111"},
+		{in: "1111", initial: false, out: "1010"},
+		{in: "10110", initial: false, out: "11011"},
+		{in: "10110", initial: true, out: "00100"},
+	}
+
+	for _, test := range tests {
+		d, n := fromBitString(test.in, LSBFirst)
+
+		e := NRZIEncode(d, n, test.initial)
+		if got := bitString(e, n, LSBFirst); got != test.out {
+			t.Errorf("encode mismatch for %s,%t: want=%s got=%s", test.in, test.initial, test.out, got)
+		}
+
+		if got := bitString(NRZIDecode(e, n, test.initial), n, LSBFirst); got != test.in {
+			t.Errorf("decode mismatch for %s,%t: got=%s", test.in, test.initial, got)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+// The optional helpers write a presence bit followed by the value if it's not nil.
+
+func (w *Writer) WriteOptionalBool(v *bool) {
+	w.WriteBool(v != nil)
+	if v != nil {
+		w.WriteBool(*v)
+	}
+}
+
+func (w *Writer) WriteOptional8(v *uint8, bitCount byte) {
+	w.WriteBool(v != nil)
+	if v != nil {
+		w.Write8(*v, bitCount)
+	}
+}
+
+func (w *Writer) WriteOptional16(v *uint16, bitCount byte) {
+	w.WriteBool(v != nil)
+	if v != nil {
+		w.Write16(*v, bitCount)
+	}
+}
+
+func (w *Writer) WriteOptional32(v *uint32, bitCount byte) {
+	w.WriteBool(v != nil)
+	if v != nil {
+		w.Write32(*v, bitCount)
+	}
+}
+
+func (w *Writer) WriteOptional64(v *uint64, bitCount byte) {
+	w.WriteBool(v != nil)
+	if v != nil {
+		w.Write64(*v, bitCount)
+	}
+}
+
+func (r *Reader) ReadOptionalBool() (*bool, error) {
+	return readOptional(r, r.ReadBool)
+}
+
+func (r *Reader) ReadOptional8(bitCount byte) (*uint8, error) {
+	return readOptional(r, func() (uint8, error) { return r.Read8(bitCount) })
+}
+
+func (r *Reader) ReadOptional16(bitCount byte) (*uint16, error) {
+	return readOptional(r, func() (uint16, error) { return r.Read16(bitCount) })
+}
+
+func (r *Reader) ReadOptional32(bitCount byte) (*uint32, error) {
+	return readOptional(r, func() (uint32, error) { return r.Read32(bitCount) })
+}
+
+func (r *Reader) ReadOptional64(bitCount byte) (*uint64, error) {
+	return readOptional(r, func() (uint64, error) { return r.Read64(bitCount) })
+}
+
+func (r *ReaderError) ReadOptionalBool() (v *bool) {
+	if r.err == nil {
+		v, r.err = r.reader.ReadOptionalBool()
+	}
+	return
+}
+
+func (r *ReaderError) ReadOptional8(bitCount byte) (v *uint8) {
+	if r.err == nil {
+		v, r.err = r.reader.ReadOptional8(bitCount)
+	}
+	return
+}
+
+func (r *ReaderError) ReadOptional16(bitCount byte) (v *uint16) {
+	if r.err == nil {
+		v, r.err = r.reader.ReadOptional16(bitCount)
+	}
+	return
+}
+
+func (r *ReaderError) ReadOptional32(bitCount byte) (v *uint32) {
+	if r.err == nil {
+		v, r.err = r.reader.ReadOptional32(bitCount)
+	}
+	return
+}
+
+func (r *ReaderError) ReadOptional64(bitCount byte) (v *uint64) {
+	if r.err == nil {
+		v, r.err = r.reader.ReadOptional64(bitCount)
+	}
+	return
+}
+
+func readOptional[T any](r *Reader, read func() (T, error)) (*T, error) {
+	present, err := r.ReadBool()
+	if err != nil || !present {
+		return nil, err
+	}
+
+	v, err := read()
+	if err != nil {
+		return nil, err
+	}
+
+	return &v, nil
+}
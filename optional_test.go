@@ -0,0 +1,74 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestOptional(t *testing.T) {
+	b := true
+	v8 := uint8(5)
+	v16 := uint16(1000)
+	v32 := uint32(123456)
+	v64 := uint64(1) << 40
+
+	w := NewWriter()
+	w.WriteOptionalBool(&b)
+	w.WriteOptionalBool(nil)
+	w.WriteOptional8(&v8, 3)
+	w.WriteOptional8(nil, 3)
+	w.WriteOptional16(&v16, 10)
+	w.WriteOptional32(nil, 20)
+	w.WriteOptional32(&v32, 20)
+	w.WriteOptional64(&v64, 41)
+	w.WriteOptional64(nil, 41)
+
+	if want, got := uint(2+1+4+1+11+1+21+42+1), w.BitsWritten(); want != got {
+		t.Errorf("size mismatch: want=%d got=%d", want, got)
+	}
+
+	r := NewReaderError(w.BitData())
+
+	if v := r.ReadOptionalBool(); v == nil || *v != b {
+		t.Errorf("bool mismatch: %v", v)
+	}
+	if v := r.ReadOptionalBool(); v != nil {
+		t.Errorf("expected nil, got %v", *v)
+	}
+	if v := r.ReadOptional8(3); v == nil || *v != v8 {
+		t.Errorf("uint8 mismatch: %v", v)
+	}
+	if v := r.ReadOptional8(3); v != nil {
+		t.Errorf("expected nil, got %v", *v)
+	}
+	if v := r.ReadOptional16(10); v == nil || *v != v16 {
+		t.Errorf("uint16 mismatch: %v", v)
+	}
+	if v := r.ReadOptional32(20); v != nil {
+		t.Errorf("expected nil, got %v", *v)
+	}
+	if v := r.ReadOptional32(20); v == nil || *v != v32 {
+		t.Errorf("uint32 mismatch: %v", v)
+	}
+	if v := r.ReadOptional64(41); v == nil || *v != v64 {
+		t.Errorf("uint64 mismatch: %v", v)
+	}
+	if v := r.ReadOptional64(41); v != nil {
+		t.Errorf("expected nil, got %v", *v)
+	}
+
+	if err := r.Error(); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	// present flag, but the value is missing
+	w = NewWriter()
+	w.WriteBool(true)
+
+	if v, err := NewReader(w.BitData()).ReadOptional16(16); v != nil || !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("expected error, got %v, %v", v, err)
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+// Option configures a writer or a reader created with NewWriter, NewReader, NewReaderError or the other
+// reader constructors, such as NewReaderAt and NewRandomReader.
+type Option func(*config)
+
+type config struct {
+	order    BitOrder
+	capacity int
+	strict   bool
+	padding  PaddingPolicy
+	alloc    Allocator
+	optional bool
+	limits   Limits
+}
+
+// WithBitOrder sets the bit order of the stream. The default is LSBFirst.
+func WithBitOrder(o BitOrder) Option {
+	return func(c *config) {
+		c.order = o
+	}
+}
+
+// WithCapacity preallocates n bytes for the data of a writer. Readers ignore it.
+func WithCapacity(n int) Option {
+	return func(c *config) {
+		c.capacity = n
+	}
+}
+
+// WithStrict makes a writer panic when a value passed to Write8, Write16, Write32 or Write64 doesn't fit
+// into the bit count, instead of silently dropping its higher bits. Readers ignore it.
+func WithStrict() Option {
+	return func(c *config) {
+		c.strict = true
+	}
+}
+
+// WithPadding sets the padding policy that Close checks the end of the stream with. The default is PaddingNone.
+func WithPadding(p PaddingPolicy) Option {
+	return func(c *config) {
+		c.padding = p
+	}
+}
+
+// WithOptionalTail makes a reader treat the fields after the end of the stream as optional: a read that
+// starts exactly at the end returns zero instead of io.ErrUnexpectedEOF, and EndedEarly reports it.
+// The bulk reads, such as ReadBytesInto and ReadBools, return zero values in the same way.
+// With WithPadding(PaddingZeros), zero padding up to the next byte boundary counts as the end as well.
+// This lets a message gain fields at its end that older writers don't send. Writers ignore it.
+func WithOptionalTail() Option {
+	return func(c *config) {
+		c.optional = true
+	}
+}
+
+func newConfig(opts []Option) config {
+	var c config
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return c
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+//go:build !bitdata_noreflect
+
+package bitdata
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestOptions(t *testing.T) {
+	w := NewWriter(WithBitOrder(MSBFirst), WithCapacity(100), WithPadding(PaddingZeros))
+	if w.BitOrder() != MSBFirst {
+		t.Errorf("writer bit order not set")
+	}
+	if len(w.chunks) != 1 || cap(w.chunks[0]) != 100 {
+		t.Errorf("capacity not preallocated")
+	}
+
+	w.Write8(0b101, 3)
+	if err := w.Close(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if got := w.BitData(); len(got) != 1 || got[0] != 0b10100000 {
+		t.Errorf("data mismatch: %08b", got)
+	}
+
+	r := NewReader(w.BitData(), WithBitOrder(MSBFirst), WithPadding(PaddingZeros))
+	if v, _ := r.Read8(3); v != 0b101 {
+		t.Errorf("value mismatch: %b", v)
+	}
+	if err := r.Close(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	rr := NewReaderError(w.BitData(), WithBitOrder(MSBFirst))
+	if v := rr.Read8(3); v != 0b101 {
+		t.Errorf("value mismatch: %b", v)
+	}
+
+	r = NewReader(w.BitData())
+	r.Skip(3)
+	if err := r.Close(); !errors.Is(err, ErrUnreadBits) {
+		t.Errorf("expected ErrUnreadBits, got %v", err)
+	}
+}
+
+func TestStrict(t *testing.T) {
+	expectPanic := func(name string, fn func()) {
+		t.Helper()
+		defer func() {
+			if recover() == nil {
+				t.Errorf("%s: expected panic", name)
+			}
+		}()
+		fn()
+	}
+
+	w := NewWriter(WithStrict())
+	w.Write8(7, 3)
+	w.Write64(1<<63, 64)
+	expectPanic("Write8", func() { w.Write8(8, 3) })
+	expectPanic("Write64", func() { w.Write64(1<<40, 40) })
+	w.WriteRepeat(5, 3, 10000)
+	expectPanic("WriteRepeat", func() { w.WriteRepeat(8, 3, 10) })
+	expectPanic("WriteRepeat fast path", func() { w.WriteRepeat(8, 3, 10000) })
+
+	type signed struct {
+		V int16 `bits:"4"`
+	}
+	if err := w.Encode(signed{V: -8}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	expectPanic("Encode", func() { _ = w.Encode(signed{V: 8}) })
+
+	lenient := NewWriter()
+	lenient.Write8(8, 3)
+	if lenient.BitData()[0] != 0 {
+		t.Errorf("higher bits must be dropped without WithStrict")
+	}
+}
@@ -0,0 +1,298 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"errors"
+	"net/netip"
+)
+
+var ErrInvalidPacketHeader = errors.New("invalid packet header")
+
+// IPv4Header is the header of an IPv4 packet. The packet header functions expect a writer or reader
+// in the MSBFirst order, which gives the network byte order of the multi-byte fields.
+type IPv4Header struct {
+	DSCP           byte // 6 bits
+	ECN            byte // 2 bits
+	TotalLength    uint16
+	ID             uint16
+	Flags          byte   // 3 bits: reserved, don't fragment, more fragments
+	FragmentOffset uint16 // 13 bits, in units of 8 bytes
+	TTL            byte
+	Protocol       byte
+	Checksum       uint16
+	Src            netip.Addr
+	Dst            netip.Addr
+	Options        []byte // a multiple of 4 bytes, at most 40
+}
+
+const (
+	IPv4DontFragment  = 0b010
+	IPv4MoreFragments = 0b001
+)
+
+// Len returns the size of the header in bytes.
+func (h *IPv4Header) Len() int {
+	return 20 + len(h.Options)
+}
+
+// ComputeChecksum returns the header checksum of h, computed with the Checksum field as zero.
+func (h *IPv4Header) ComputeChecksum() uint16 {
+	c := *h
+	c.Checksum = 0
+	w := NewWriterMSB()
+	w.WriteIPv4Header(c)
+	return InternetChecksum(w.BitData())
+}
+
+// WriteIPv4Header writes the header with the Checksum field as it is; set it with ComputeChecksum first.
+// It panics if an address isn't IPv4 or the options aren't a multiple of 4 bytes up to 40 bytes.
+func (w *Writer) WriteIPv4Header(h IPv4Header) {
+	if !h.Src.Is4() || !h.Dst.Is4() || len(h.Options)%4 != 0 || len(h.Options) > 40 {
+		panic("bitdata: invalid IPv4 header")
+	}
+
+	w.Write8(4, 4)
+	w.Write8(byte(h.Len()/4), 4)
+	w.Write8(h.DSCP, 6)
+	w.Write8(h.ECN, 2)
+	w.Write16(h.TotalLength, 16)
+	w.Write16(h.ID, 16)
+	w.Write8(h.Flags, 3)
+	w.Write16(h.FragmentOffset, 13)
+	w.Write8(h.TTL, 8)
+	w.Write8(h.Protocol, 8)
+	w.Write16(h.Checksum, 16)
+
+	src, dst := h.Src.As4(), h.Dst.As4()
+	writeAddrBytes(w, src[:])
+	writeAddrBytes(w, dst[:])
+	writeAddrBytes(w, h.Options)
+}
+
+// ReadIPv4Header reads an IPv4 header including its options. It returns ErrInvalidPacketHeader
+// if the version isn't 4 or the header length is less than 20 bytes. The checksum isn't verified.
+// On error the read position is left unchanged.
+func (r *Reader) ReadIPv4Header() (IPv4Header, error) {
+	var h IPv4Header
+
+	rr := ReaderError{reader: *r}
+	version := rr.Read8(4)
+	ihl := rr.Read8(4)
+	if rr.err == nil && (version != 4 || ihl < 5) {
+		return h, ErrInvalidPacketHeader
+	}
+	h.DSCP = rr.Read8(6)
+	h.ECN = rr.Read8(2)
+	h.TotalLength = rr.Read16(16)
+	h.ID = rr.Read16(16)
+	h.Flags = rr.Read8(3)
+	h.FragmentOffset = rr.Read16(13)
+	h.TTL = rr.Read8(8)
+	h.Protocol = rr.Read8(8)
+	h.Checksum = rr.Read16(16)
+
+	var src, dst [4]byte
+	rr.readBytes(src[:])
+	rr.readBytes(dst[:])
+	h.Src, h.Dst = netip.AddrFrom4(src), netip.AddrFrom4(dst)
+
+	if ihl > 5 {
+		h.Options = make([]byte, (int(ihl)-5)*4)
+		rr.readBytes(h.Options)
+	}
+
+	if rr.err != nil {
+		return IPv4Header{}, rr.err
+	}
+
+	*r = rr.reader
+
+	return h, nil
+}
+
+// TCPHeader is the header of a TCP segment.
+type TCPHeader struct {
+	SrcPort  uint16
+	DstPort  uint16
+	Seq      uint32
+	Ack      uint32
+	Flags    uint16 // 12 bits, the reserved bits followed by the TCPFlag bits
+	Window   uint16
+	Checksum uint16
+	Urgent   uint16
+	Options  []byte // a multiple of 4 bytes, at most 40
+}
+
+const (
+	TCPFlagFIN = 1 << iota
+	TCPFlagSYN
+	TCPFlagRST
+	TCPFlagPSH
+	TCPFlagACK
+	TCPFlagURG
+	TCPFlagECE
+	TCPFlagCWR
+)
+
+// Len returns the size of the header in bytes.
+func (h *TCPHeader) Len() int {
+	return 20 + len(h.Options)
+}
+
+// WriteTCPHeader writes the header with the Checksum field as it is; see TransportChecksum.
+// It panics if the options aren't a multiple of 4 bytes up to 40 bytes.
+func (w *Writer) WriteTCPHeader(h TCPHeader) {
+	if len(h.Options)%4 != 0 || len(h.Options) > 40 {
+		panic("bitdata: invalid TCP header")
+	}
+
+	w.Write16(h.SrcPort, 16)
+	w.Write16(h.DstPort, 16)
+	w.Write32(h.Seq, 32)
+	w.Write32(h.Ack, 32)
+	w.Write8(byte(h.Len()/4), 4)
+	w.Write16(h.Flags, 12)
+	w.Write16(h.Window, 16)
+	w.Write16(h.Checksum, 16)
+	w.Write16(h.Urgent, 16)
+	writeAddrBytes(w, h.Options)
+}
+
+// ReadTCPHeader reads a TCP header including its options. It returns ErrInvalidPacketHeader
+// if the data offset is less than 20 bytes. On error the read position is left unchanged.
+func (r *Reader) ReadTCPHeader() (TCPHeader, error) {
+	var h TCPHeader
+
+	rr := ReaderError{reader: *r}
+	h.SrcPort = rr.Read16(16)
+	h.DstPort = rr.Read16(16)
+	h.Seq = rr.Read32(32)
+	h.Ack = rr.Read32(32)
+	offset := rr.Read8(4)
+	if rr.err == nil && offset < 5 {
+		return h, ErrInvalidPacketHeader
+	}
+	h.Flags = rr.Read16(12)
+	h.Window = rr.Read16(16)
+	h.Checksum = rr.Read16(16)
+	h.Urgent = rr.Read16(16)
+
+	if offset > 5 {
+		h.Options = make([]byte, (int(offset)-5)*4)
+		rr.readBytes(h.Options)
+	}
+
+	if rr.err != nil {
+		return TCPHeader{}, rr.err
+	}
+
+	*r = rr.reader
+
+	return h, nil
+}
+
+// UDPHeader is the header of a UDP datagram.
+type UDPHeader struct {
+	SrcPort  uint16
+	DstPort  uint16
+	Length   uint16 // the datagram size in bytes, including the header
+	Checksum uint16
+}
+
+// WriteUDPHeader writes the header with the Checksum field as it is; see TransportChecksum.
+func (w *Writer) WriteUDPHeader(h UDPHeader) {
+	w.Write16(h.SrcPort, 16)
+	w.Write16(h.DstPort, 16)
+	w.Write16(h.Length, 16)
+	w.Write16(h.Checksum, 16)
+}
+
+func (r *Reader) ReadUDPHeader() (UDPHeader, error) {
+	var h UDPHeader
+
+	rr := ReaderError{reader: *r}
+	h.SrcPort = rr.Read16(16)
+	h.DstPort = rr.Read16(16)
+	h.Length = rr.Read16(16)
+	h.Checksum = rr.Read16(16)
+
+	if rr.err != nil {
+		return UDPHeader{}, rr.err
+	}
+
+	*r = rr.reader
+
+	return h, nil
+}
+
+// InternetChecksum returns the ones' complement checksum of RFC 1071 used by IPv4, TCP and UDP.
+// Computed over data that includes a correct checksum, it returns zero.
+func InternetChecksum(data []byte) uint16 {
+	return ^foldChecksum(sumChecksum(0, data))
+}
+
+// TransportChecksum returns the TCP or UDP checksum of the segment, the header with a zero Checksum field
+// followed by the payload, sent from src to dst with the IP protocol number proto. The pseudo-header
+// is the IPv4 or IPv6 one depending on the addresses. Computed over a segment with a correct checksum,
+// it returns zero.
+//
+// UDP gives the Checksum value zero a special meaning, which TransportChecksum leaves to the caller:
+// a sender that computes zero must send 0xFFFF instead, and a received Checksum of zero means that
+// the sender computed none. That is allowed over IPv4 only; over IPv6 the UDP checksum is mandatory.
+func TransportChecksum(src, dst netip.Addr, proto byte, segment []byte) uint16 {
+	s, d := src.AsSlice(), dst.AsSlice()
+
+	sum := sumChecksum(0, s)
+	sum = sumChecksum(sum, d)
+	sum += uint64(proto) + uint64(len(segment))
+	sum = sumChecksum(sum, segment)
+
+	return ^foldChecksum(sum)
+}
+
+// sumChecksum adds the data as big-endian 16-bit words to sum.
+func sumChecksum(sum uint64, data []byte) uint64 {
+	for len(data) >= 2 {
+		sum += uint64(data[0])<<8 | uint64(data[1])
+		data = data[2:]
+	}
+	if len(data) == 1 {
+		sum += uint64(data[0]) << 8
+	}
+	return sum
+}
+
+func foldChecksum(sum uint64) uint16 {
+	for sum > 0xFFFF {
+		sum = sum>>16 + sum&0xFFFF
+	}
+	return uint16(sum)
+}
+
+func (r *ReaderError) ReadIPv4Header() (h IPv4Header) {
+	if r.err == nil {
+		h, r.err = r.reader.ReadIPv4Header()
+	}
+	return
+}
+
+func (r *ReaderError) ReadTCPHeader() (h TCPHeader) {
+	if r.err == nil {
+		h, r.err = r.reader.ReadTCPHeader()
+	}
+	return
+}
+
+func (r *ReaderError) ReadUDPHeader() (h UDPHeader) {
+	if r.err == nil {
+		h, r.err = r.reader.ReadUDPHeader()
+	}
+	return
+}
+
+func (r *ReaderError) readBytes(b []byte) {
+	if r.err == nil {
+		r.err = readAddrBytes(&r.reader, b)
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"bytes"
+	"errors"
+	"net/netip"
+	"reflect"
+	"testing"
+)
+
+func TestIPv4Header(t *testing.T) {
+	// the example header from the Wikipedia article on the IPv4 header checksum
+	raw := []byte{0x45, 0x00, 0x00, 0x73, 0x00, 0x00, 0x40, 0x00, 0x40, 0x11, 0xB8, 0x61, 0xC0, 0xA8, 0x00, 0x01, 0xC0, 0xA8, 0x00, 0xC7}
+
+	r := NewReaderMSB(raw)
+	h, err := r.ReadIPv4Header()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	want := IPv4Header{
+		TotalLength: 0x73,
+		Flags:       IPv4DontFragment,
+		TTL:         64,
+		Protocol:    17,
+		Checksum:    0xB861,
+		Src:         netip.MustParseAddr("192.168.0.1"),
+		Dst:         netip.MustParseAddr("192.168.0.199"),
+	}
+	if !reflect.DeepEqual(want, h) {
+		t.Errorf("header mismatch: want=%+v got=%+v", want, h)
+	}
+	if c := h.ComputeChecksum(); c != 0xB861 {
+		t.Errorf("checksum mismatch: want=%#x got=%#x", 0xB861, c)
+	}
+	if c := InternetChecksum(raw); c != 0 {
+		t.Errorf("checksum of a valid header: %#x", c)
+	}
+
+	h.Options = []byte{1, 1, 1, 0}
+	w := NewWriterMSB()
+	w.WriteIPv4Header(h)
+	if w.BitData()[0] != 0x46 || len(w.BitData()) != 24 {
+		t.Errorf("unexpected header: %x", w.BitData())
+	}
+
+	got, err := NewReaderMSB(w.BitData()).ReadIPv4Header()
+	if err != nil || !reflect.DeepEqual(h, got) {
+		t.Errorf("header mismatch: want=%+v got=%+v err=%v", h, got, err)
+	}
+
+	r = NewReaderMSB(BitData{0x65, 0, 0, 0})
+	if _, err := r.ReadIPv4Header(); !errors.Is(err, ErrInvalidPacketHeader) {
+		t.Errorf("want=%v got=%v", ErrInvalidPacketHeader, err)
+	}
+}
+
+func TestTCPHeader(t *testing.T) {
+	h := TCPHeader{
+		SrcPort: 443,
+		DstPort: 50000,
+		Seq:     0x01020304,
+		Ack:     0x0A0B0C0D,
+		Flags:   TCPFlagSYN | TCPFlagACK,
+		Window:  65535,
+		Options: []byte{2, 4, 0x05, 0xB4},
+	}
+
+	w := NewWriterMSB()
+	w.WriteTCPHeader(h)
+
+	d := w.BitData()
+	if want := []byte{0x60, 0x12}; !bytes.Equal(want, d[12:14]) {
+		t.Errorf("offset and flags mismatch: want=%x got=%x", want, d[12:14])
+	}
+
+	got, err := NewReaderMSB(d).ReadTCPHeader()
+	if err != nil || !reflect.DeepEqual(h, got) {
+		t.Errorf("header mismatch: want=%+v got=%+v err=%v", h, got, err)
+	}
+}
+
+func TestUDPChecksum(t *testing.T) {
+	src, dst := netip.MustParseAddr("10.0.0.1"), netip.MustParseAddr("10.0.0.2")
+	payload := []byte("hello")
+
+	h := UDPHeader{SrcPort: 1234, DstPort: 53, Length: uint16(8 + len(payload))}
+
+	w := NewWriterMSB()
+	w.WriteUDPHeader(h)
+	w.WriteBitData(payload, uint(len(payload))*8)
+	segment := w.AppendBitData(nil)
+
+	h.Checksum = TransportChecksum(src, dst, 17, segment)
+	segment[6], segment[7] = byte(h.Checksum>>8), byte(h.Checksum)
+
+	// with the checksum in place, the sum over the pseudo-header and the segment is zero
+	if c := TransportChecksum(src, dst, 17, segment); c != 0 {
+		t.Errorf("checksum of a valid segment: %#x", c)
+	}
+
+	got, err := NewReaderMSB(segment).ReadUDPHeader()
+	if err != nil || got != h {
+		t.Errorf("header mismatch: want=%+v got=%+v err=%v", h, got, err)
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"errors"
+)
+
+var ErrParity = errors.New("parity error")
+
+type Parity byte
+
+const (
+	EvenParity Parity = iota
+	OddParity
+)
+
+func (p Parity) bit(d BitData, from, n uint) bool {
+	odd := onesCount(d, from, n)%2 == 1
+	return odd != (p == OddParity)
+}
+
+// WriteParity writes a parity bit computed over the previous span bits. With EvenParity the total
+// number of one bits, including the parity bit, is even; with OddParity it is odd.
+func (w *Writer) WriteParity(p Parity, span uint) {
+	if span > w.bitsWritten {
+		panic("bitdata: parity span out of bounds")
+	}
+	from := w.bitsWritten - span
+	w.WriteBool(p.bit(lsbFirst(w.bytes(from/8, w.size), w.order), from%8, span))
+}
+
+// CheckParity reads a parity bit and checks it against the previous span bits.
+// It returns ErrParity if the parity doesn't match.
+func (r *Reader) CheckParity(p Parity, span uint) error {
+	if span > r.bitsRead || r.bitsRead > r.end {
+		return ErrInvalidRange
+	}
+
+	from := r.bitsRead - span
+	d, err := r.bytes(from/8, (r.bitsRead+7)/8)
+	if err != nil {
+		return err
+	}
+	want := p.bit(lsbFirst(d, r.order), from%8, span)
+
+	got, err := r.ReadBool()
+	if err != nil {
+		return err
+	}
+
+	if want != got {
+		return ErrParity
+	}
+
+	return nil
+}
+
+func (r *ReaderError) CheckParity(p Parity, span uint) {
+	if r.err == nil {
+		r.err = r.reader.CheckParity(p, span)
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParity(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  uint16
+		bits   byte
+		parity Parity
+		exp    bool
+	}{
+		{name: "even-zero", value: 0, bits: 7, parity: EvenParity, exp: false},
+		{name: "odd-zero", value: 0, bits: 7, parity: OddParity, exp: true},
+		{name: "even-three-ones", value: 0b1011, bits: 7, parity: EvenParity, exp: true},
+		{name: "odd-three-ones", value: 0b1011, bits: 7, parity: OddParity, exp: false},
+		{name: "even-wide", value: 0xFFF0, bits: 16, parity: EvenParity, exp: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w := NewWriter()
+			w.Write8(0b101, 3) // not covered by parity
+			w.Write16(test.value, test.bits)
+			w.WriteParity(test.parity, uint(test.bits))
+
+			r := NewReader(w.BitData())
+			r.Skip(3 + uint(test.bits))
+			if v, _ := r.ReadBool(); v != test.exp {
+				t.Errorf("parity bit mismatch: want=%t got=%t", test.exp, v)
+			}
+
+			r = NewReader(w.BitData())
+			r.Skip(3 + uint(test.bits))
+			if err := r.CheckParity(test.parity, uint(test.bits)); err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+
+			d := w.BitData()
+			d[0] ^= 0b1000 // flip a covered bit
+
+			r = NewReader(d)
+			r.Skip(3 + uint(test.bits))
+			if err := r.CheckParity(test.parity, uint(test.bits)); !errors.Is(err, ErrParity) {
+				t.Errorf("expected parity error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestParityReaderError(t *testing.T) {
+	w := NewWriter()
+	for i := 0; i < 4; i++ {
+		w.Write8(byte(i*37), 8)
+		w.WriteParity(OddParity, 8)
+	}
+
+	r := NewReaderError(w.BitData())
+	for i := 0; i < 4; i++ {
+		if v := r.Read8(8); v != byte(i*37) {
+			t.Errorf("value mismatch: want=%d got=%d", i*37, v)
+		}
+		r.CheckParity(OddParity, 8)
+	}
+
+	if err := r.Error(); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"fmt"
+	"io"
+)
+
+// ErrNeedMoreData is returned by the reads of a partial reader that run past the data received so far.
+// It wraps io.ErrUnexpectedEOF.
+var ErrNeedMoreData = fmt.Errorf("need more data: %w", io.ErrUnexpectedEOF)
+
+// NewPartialReader returns a reader of a stream whose data arrives in chunks, for example from a network
+// connection. The chunks are added with Append. A read that needs more bits than received so far fails
+// with ErrNeedMoreData and leaves the read position unchanged, so it can be retried after the next Append.
+// A message made of several fields is retried from the position saved before its first field.
+//
+//	for {
+//		pos, _ := r.Seek(0, io.SeekCurrent)
+//		msg, err := readMessage(r)
+//		if errors.Is(err, bitdata.ErrNeedMoreData) {
+//			r.Seek(pos, io.SeekStart)
+//			n, _ := conn.Read(buf)
+//			r.Append(buf[:n])
+//			continue
+//		}
+//		...
+//	}
+func NewPartialReader(opts ...Option) *Reader {
+	r := NewReader(nil, opts...)
+	r.partial = true
+	return r
+}
+
+// Append adds bytes to the end of the data of a partial reader. It panics for other readers.
+func (r *Reader) Append(p []byte) {
+	if !r.partial {
+		panic("bitdata: Append on a reader that isn't partial")
+	}
+	r.data = append(r.data, p...)
+	r.end = uint(len(r.data)) * 8
+}
+
+// Compact drops the whole bytes of a partial reader before the read position, which keeps the memory
+// of a long stream bounded. Bit offsets, such as the read position, are reduced by the returned number of bits.
+// It panics for readers that aren't partial.
+func (r *Reader) Compact() uint {
+	if !r.partial {
+		panic("bitdata: Compact on a reader that isn't partial")
+	}
+
+	n := min(r.bitsRead, r.end) / 8
+	r.data = append(r.data[:0], r.data[n:]...)
+	r.bitsRead -= n * 8
+	r.end -= n * 8
+	r.bufBits = 0
+
+	return n * 8
+}
@@ -0,0 +1,186 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestPartialReader(t *testing.T) {
+	w := NewWriter()
+	for i := range 100 {
+		w.WriteUvarint(uint64(i * 1000))
+		w.Write8(byte(i%8), 3)
+	}
+	data := w.BitData()
+
+	r := NewPartialReader()
+	chunk := 0
+	for i := 0; i < 100; {
+		start := r.bitsRead
+		rr := ReaderError{reader: *r}
+		v := rr.ReadUvarint()
+		k := rr.Read8(3)
+		*r = rr.reader
+
+		if err := rr.Error(); err != nil {
+			if !errors.Is(err, ErrNeedMoreData) || !errors.Is(err, io.ErrUnexpectedEOF) {
+				t.Fatalf("expected ErrNeedMoreData, got %v", err)
+			}
+			if chunk >= len(data) {
+				t.Fatal("ran out of data")
+			}
+			r.bitsRead = start
+			r.Append(data[chunk:min(chunk+3, len(data))])
+			chunk += 3
+			continue
+		}
+
+		if v != uint64(i*1000) || k != byte(i%8) {
+			t.Fatalf("value %d: got %d %d", i, v, k)
+		}
+		r.Compact()
+		i++
+	}
+
+	if len(r.data) > 3 {
+		t.Errorf("compacted data is %d bytes long", len(r.data))
+	}
+
+	if _, err := NewReader(nil).Read8(1); errors.Is(err, ErrNeedMoreData) {
+		t.Error("unexpected ErrNeedMoreData from a complete reader")
+	}
+}
+
+func TestPartialReaderBulk(t *testing.T) {
+	r := NewPartialReader()
+	r.Append([]byte{1, 2, 3})
+	r.Skip(4)
+
+	checks := []struct {
+		name string
+		read func() error
+	}{
+		{"ReadBytesInto", func() error { return r.ReadBytesInto(make([]byte, 3)) }},
+		{"ReadBools", func() error { _, err := r.ReadBools(21); return err }},
+		{"SkipChecked", func() error { return r.SkipChecked(21) }},
+		{"ReadNibbles", func() error { return r.ReadNibbles(make([]byte, 6)) }},
+		{"ReadIP", func() error { _, err := r.ReadIP(); return err }},
+	}
+	for _, c := range checks {
+		if err := c.read(); !errors.Is(err, ErrNeedMoreData) {
+			t.Errorf("%s: expected ErrNeedMoreData, got %v", c.name, err)
+		}
+		if r.BitsRead() != 4 {
+			t.Errorf("%s: read position moved to %d", c.name, r.BitsRead())
+		}
+	}
+
+	r.Append([]byte{4})
+	if err := r.ReadBytesInto(make([]byte, 3)); err != nil {
+		t.Errorf("got %v after Append", err)
+	}
+}
+
+func TestPartialReaderLength(t *testing.T) {
+	writeByte := func(w *Writer, v byte) error { w.Write8(v, 8); return nil }
+	readByte := func(r *Reader) (byte, error) { return r.Read8(8) }
+	payload := make([]byte, 40)
+
+	tests := []struct {
+		name  string
+		order BitOrder
+		write func(w *Writer)
+		read  func(r *Reader) error
+	}{
+		{
+			name:  "ReadRice",
+			write: func(w *Writer) { w.WriteRice(1000, 2) },
+			read:  func(r *Reader) error { _, err := r.ReadRice(2); return err },
+		},
+		{
+			name:  "ReadExpGolomb",
+			write: func(w *Writer) { w.WriteExpGolomb(1 << 40) },
+			read:  func(r *Reader) error { _, err := r.ReadExpGolomb(); return err },
+		},
+		{
+			name:  "ReadProtoField",
+			write: func(w *Writer) { w.WriteProtoBytes(1, payload) },
+			read:  func(r *Reader) error { _, err := r.ReadProtoField(); return err },
+		},
+		{
+			name:  "ReadProtoMessage",
+			write: func(w *Writer) { w.WriteProtoMessage(payload) },
+			read:  func(r *Reader) error { _, err := r.ReadProtoMessage(); return err },
+		},
+		{
+			name:  "ReadCBOR",
+			write: func(w *Writer) { _ = w.WriteCBOR(append([]byte{0x58, 40}, payload...)) },
+			read:  func(r *Reader) error { _, err := r.ReadCBOR(); return err },
+		},
+		{
+			name:  "ReadColumnSegment",
+			write: func(w *Writer) { w.WriteColumnSegment(make([]uint64, 40), nil) },
+			read:  func(r *Reader) error { _, err := r.ReadColumnSegment(); return err },
+		},
+		{
+			name:  "ReadDictionary",
+			write: func(w *Writer) { _ = WriteDictionary(w, append(make([]byte, 99), 1), writeByte) },
+			read:  func(r *Reader) error { _, err := ReadDictionary(r, readByte); return err },
+		},
+		{
+			name:  "ReadADStructures",
+			write: func(w *Writer) { _ = w.WriteADStructures([]ADStructure{{Type: 0xFF, Data: payload}}) },
+			read:  func(r *Reader) error { _, err := r.ReadADStructures(len(payload) + 2); return err },
+		},
+		{
+			name:  "ReadOBU",
+			order: MSBFirst,
+			write: func(w *Writer) { w.WriteOBU(OBUHeader{Type: 6}, payload) },
+			read:  func(r *Reader) error { _, _, err := r.ReadOBU(); return err },
+		},
+		{
+			name: "DecodeBlocks",
+			write: func(w *Writer) {
+				_ = EncodeBlocks(w, 2, 1, func(i int, w *Writer) error { w.WriteBitData(payload, 320); return nil })
+			},
+			read: func(r *Reader) error {
+				_, err := DecodeBlocks(r, 1, func(i int, r *Reader) (int, error) { return 0, nil })
+				return err
+			},
+		},
+		{
+			name: "ReadMessage",
+			write: func(w *Writer) {
+				w.BeginMessage(16)
+				w.WriteBitData(payload, 320)
+				_ = w.EndMessage()
+			},
+			read: func(r *Reader) error { _, err := r.ReadMessage(16); return err },
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w := NewWriter(WithBitOrder(test.order))
+			test.write(w)
+			data := w.BitData()
+
+			r := NewPartialReader(WithBitOrder(test.order))
+			r.Append(data[:len(data)/2])
+			if err := test.read(r); !errors.Is(err, ErrNeedMoreData) {
+				t.Fatalf("expected ErrNeedMoreData, got %v", err)
+			}
+			if r.BitsRead() != 0 {
+				t.Fatalf("read position moved to %d", r.BitsRead())
+			}
+
+			r.Append(data[len(data)/2:])
+			if err := test.read(r); err != nil {
+				t.Errorf("got %v after Append", err)
+			}
+		})
+	}
+}
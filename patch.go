@@ -0,0 +1,50 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+var ErrReaderAtRequired = errors.New("patching a partial byte requires an io.ReaderAt")
+
+// PatchAt overwrites the given number of bits of w, up to 64, at the bit offset bitOffset with the lowest
+// bits of value, in the default LSBFirst bit order. Only the affected bytes are written, so a header of
+// a large file can be fixed without rewriting the file. If the range doesn't start and end on a byte
+// boundary, the bytes at its ends are read first to preserve the other bits, which requires w to
+// implement io.ReaderAt as *os.File does; otherwise ErrReaderAtRequired is returned.
+func PatchAt(w io.WriterAt, bitOffset uint64, value uint64, bits byte) error {
+	if bits > 64 {
+		return fmt.Errorf("%w: %d", ErrBitCountTooBig, bits)
+	}
+	if bits == 0 {
+		return nil
+	}
+
+	ofs := uint(bitOffset % 8)
+	buf := make(BitData, (ofs+uint(bits)+7)/8)
+	pos := int64(bitOffset / 8)
+
+	if ofs != 0 || bits%8 != 0 {
+		ra, ok := w.(io.ReaderAt)
+		if !ok {
+			return ErrReaderAtRequired
+		}
+
+		if _, err := ra.ReadAt(buf[:1], pos); err != nil {
+			return err
+		}
+		if last := len(buf) - 1; last > 0 {
+			if _, err := ra.ReadAt(buf[last:], pos+int64(last)); err != nil {
+				return err
+			}
+		}
+	}
+
+	putBits(buf, ofs, value, bits)
+
+	_, err := w.WriteAt(buf, pos)
+	return err
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPatchAt(t *testing.T) {
+	w := NewWriter()
+	w.Write8(0xFF, 8)
+	w.Write16(0, 12)
+	w.Write32(0xFFFFFFFF, 32)
+	w.Write8(0, 4)
+	data := w.BitData()
+
+	f, err := os.Create(filepath.Join(t.TempDir(), "patch"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(data); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := PatchAt(f, 8, 0xABC, 12); err != nil {
+		t.Fatal(err)
+	}
+	if err := PatchAt(f, 20, 0x12345678, 32); err != nil {
+		t.Fatal(err)
+	}
+	if err := PatchAt(f, 0, 0x5A, 8); err != nil {
+		t.Fatal(err)
+	}
+
+	want := NewWriter()
+	want.Write8(0x5A, 8)
+	want.Write16(0xABC, 12)
+	want.Write32(0x12345678, 32)
+	want.Write8(0, 4)
+
+	got, err := os.ReadFile(f.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want.BitData()) {
+		t.Errorf("got %x, want %x", got, want.BitData())
+	}
+}
+
+func TestPatchAtWriterOnly(t *testing.T) {
+	f := writerAtOnly{}
+
+	if err := PatchAt(f, 8, 0xAB, 8); err != nil {
+		t.Errorf("unexpected error for a whole byte: %v", err)
+	}
+	if err := PatchAt(f, 3, 1, 1); !errors.Is(err, ErrReaderAtRequired) {
+		t.Errorf("expected ErrReaderAtRequired, got %v", err)
+	}
+	if err := PatchAt(f, 0, 0, 65); err == nil {
+		t.Error("expected an error for 65 bits")
+	}
+}
+
+type writerAtOnly struct{}
+
+func (writerAtOnly) WriteAt(p []byte, _ int64) (int, error) { return len(p), nil }
@@ -0,0 +1,73 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+// Planes writes several logical sub-streams, planes, that are stored separately instead of interleaved,
+// for example the control bits apart from the payload bits. Keeping similar bits together usually makes
+// the result compress better.
+//
+//	p := NewPlanes(2)
+//	for _, v := range values {
+//		p.Plane(0).WriteBool(v.Flag)
+//		p.Plane(1).Write16(v.Value, 12)
+//	}
+//	w.WritePlanes(p)
+type Planes struct {
+	planes []*Writer
+}
+
+// NewPlanes returns n empty planes with the bit order of the options.
+func NewPlanes(n int, opts ...Option) *Planes {
+	p := &Planes{planes: make([]*Writer, n)}
+	for i := range p.planes {
+		p.planes[i] = NewWriter(opts...)
+	}
+	return p
+}
+
+// Plane returns the writer of the plane i.
+func (p *Planes) Plane(i int) *Writer {
+	return p.planes[i]
+}
+
+// Len returns the number of planes.
+func (p *Planes) Len() int {
+	return len(p.planes)
+}
+
+// WritePlanes writes the planes one after another, preceded by the number of planes and the bit length
+// of every plane as LEB128 varints, the same way EncodeBlocks writes its blocks.
+func (w *Writer) WritePlanes(p *Planes) {
+	w.WriteUvarint(uint64(len(p.planes)))
+	for _, pw := range p.planes {
+		w.WriteUvarint(uint64(pw.bitsWritten))
+	}
+	for _, pw := range p.planes {
+		w.WriteBitData(pw.BitData(), pw.bitsWritten)
+	}
+}
+
+// ReadPlanes reads planes written with WritePlanes and returns a reader limited to each of them.
+// The reader r continues after the last plane. On error the read position is left unchanged.
+func (r *Reader) ReadPlanes() ([]*Reader, error) {
+	start := r.bitsRead
+
+	index, err := readBlockIndex(r)
+	if err != nil {
+		r.bitsRead = start
+		return nil, err
+	}
+
+	planes := make([]*Reader, len(index))
+	for i, span := range index {
+		planes[i] = r.Cursor()
+		planes[i].bitsRead = span[0]
+		planes[i].end = span[1]
+	}
+
+	if len(index) > 0 {
+		r.bitsRead = index[len(index)-1][1]
+	}
+
+	return planes, nil
+}
@@ -0,0 +1,54 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestPlanes(t *testing.T) {
+	type sample struct {
+		flag  bool
+		value uint16
+	}
+
+	samples := []sample{{true, 100}, {false, 4095}, {true, 0}, {true, 7}}
+
+	for _, o := range []BitOrder{LSBFirst, MSBFirst} {
+		p := NewPlanes(3, WithBitOrder(o))
+		for _, s := range samples {
+			p.Plane(0).WriteBool(s.flag)
+			p.Plane(1).Write16(s.value, 12)
+		}
+
+		w := NewWriter(WithBitOrder(o))
+		w.WritePlanes(p)
+		w.Write8(0x5, 3)
+
+		r := NewReader(w.BitData(), WithBitOrder(o))
+		planes, err := r.ReadPlanes()
+		if err != nil || len(planes) != 3 {
+			t.Fatalf("order %d: planes=%d err=%v", o, len(planes), err)
+		}
+
+		for i, s := range samples {
+			flag, _ := planes[0].ReadBool()
+			value, _ := planes[1].Read16(12)
+			if flag != s.flag || value != s.value {
+				t.Errorf("order %d sample %d: got %v %d", o, i, flag, value)
+			}
+		}
+		if _, err := planes[0].ReadBool(); !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Errorf("order %d: plane not limited: %v", o, err)
+		}
+		if planes[2].end != planes[2].BitsRead() {
+			t.Errorf("order %d: plane 2 not empty", o)
+		}
+
+		if v, err := r.Read8(3); err != nil || v != 0x5 {
+			t.Errorf("order %d: reader not positioned after the planes: v=%d err=%v", o, v, err)
+		}
+	}
+}
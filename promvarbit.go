@@ -0,0 +1,130 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"math/bits"
+)
+
+// varbitBuckets are the widths of the values after the prefixes 10, 110, ..., 11111110 of the varbit
+// encoding of Prometheus TSDB. The prefix 11111111 is followed by 64 bits.
+var varbitBuckets = [7]byte{3, 6, 9, 12, 18, 25, 56}
+
+// WriteVarbitInt writes v in the varbit encoding of Prometheus TSDB: a zero bit for zero, and otherwise
+// a prefix of 2 to 8 one bits ended by a zero bit, except for the longest, followed by v in 3, 6, 9, 12,
+// 18, 25, 56 or 64 bits. A bucket of n bits holds the values from -(2^(n-1)-1) to 2^(n-1).
+// Prometheus chunks are MSBFirst streams; together with a FloatEncoder, which writes the XOR encoding
+// of Prometheus chunk values, this reads and writes them.
+func (w *Writer) WriteVarbitInt(v int64) {
+	if v == 0 {
+		w.WriteBool(false)
+		return
+	}
+
+	for i, n := range varbitBuckets {
+		if -(1<<(n-1)-1) <= v && v <= 1<<(n-1) {
+			writeVarbitPrefix(w, i)
+			w.Write64(uint64(v), n)
+			return
+		}
+	}
+
+	writeVarbitPrefix(w, len(varbitBuckets))
+	w.Write64(uint64(v), 64)
+}
+
+// WriteVarbitUint writes v in the unsigned varbit encoding of Prometheus TSDB, with the prefixes
+// of WriteVarbitInt and a bucket of n bits holding the values below 2^n.
+func (w *Writer) WriteVarbitUint(v uint64) {
+	if v == 0 {
+		w.WriteBool(false)
+		return
+	}
+
+	for i, n := range varbitBuckets {
+		if bits.Len64(v) <= int(n) {
+			writeVarbitPrefix(w, i)
+			w.Write64(v, n)
+			return
+		}
+	}
+
+	writeVarbitPrefix(w, len(varbitBuckets))
+	w.Write64(v, 64)
+}
+
+// writeVarbitPrefix writes the prefix of the bucket i: i+1 one bits, and a zero bit unless it's the last.
+func writeVarbitPrefix(w *Writer, i int) {
+	for range i + 1 {
+		w.WriteBool(true)
+	}
+	if i < len(varbitBuckets) {
+		w.WriteBool(false)
+	}
+}
+
+// ReadVarbitInt reads a value written by WriteVarbitInt. On error the read position is left unchanged.
+func (r *Reader) ReadVarbitInt() (int64, error) {
+	v, n, err := readVarbit(r)
+	if err != nil || n == 64 || n == 0 {
+		return int64(v), err
+	}
+	if v > 1<<(n-1) {
+		return int64(v) - 1<<n, nil
+	}
+	return int64(v), nil
+}
+
+// ReadVarbitUint reads a value written by WriteVarbitUint. On error the read position is left unchanged.
+func (r *Reader) ReadVarbitUint() (uint64, error) {
+	v, _, err := readVarbit(r)
+	return v, err
+}
+
+func (r *ReaderError) ReadVarbitInt() (v int64) {
+	if r.err == nil {
+		v, r.err = r.reader.ReadVarbitInt()
+	}
+	return
+}
+
+func (r *ReaderError) ReadVarbitUint() (v uint64) {
+	if r.err == nil {
+		v, r.err = r.reader.ReadVarbitUint()
+	}
+	return
+}
+
+// readVarbit reads a varbit prefix and the bits of its bucket, and returns them with their count.
+func readVarbit(r *Reader) (uint64, byte, error) {
+	start := r.bitsRead
+
+	ones := 0
+	for ones <= len(varbitBuckets) {
+		b, err := r.ReadBool()
+		if err != nil {
+			r.bitsRead = start
+			return 0, 0, err
+		}
+		if !b {
+			break
+		}
+		ones++
+	}
+	if ones == 0 {
+		return 0, 0, nil
+	}
+
+	n := byte(64)
+	if ones <= len(varbitBuckets) {
+		n = varbitBuckets[ones-1]
+	}
+
+	v, err := r.Read64(n)
+	if err != nil {
+		r.bitsRead = start
+		return 0, 0, err
+	}
+
+	return v, n, nil
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math"
+	"testing"
+)
+
+func TestVarbitInt(t *testing.T) {
+	for _, tt := range []struct {
+		v    int64
+		want BitData
+	}{
+		{v: 0, want: BitData{0b00000000}},
+		{v: 4, want: BitData{0b10100000}},
+		{v: -3, want: BitData{0b10101000}},
+		{v: 5, want: BitData{0b11000010, 0b10000000}},
+		{v: -4, want: BitData{0b11011110, 0b00000000}},
+	} {
+		w := NewWriter(WithBitOrder(MSBFirst))
+		w.WriteVarbitInt(tt.v)
+		if got := w.BitData(); !bytes.Equal(got, tt.want) {
+			t.Errorf("%d: got %08b, want %08b", tt.v, got, tt.want)
+		}
+	}
+
+	values := []int64{0, 1, -1, 4, -3, 32, -31, 33, 256, -255, 2048, -2047, 131072, -131071,
+		1 << 24, -(1<<24 - 1), 1 << 55, -(1<<55 - 1), 1<<55 + 1, math.MaxInt64, math.MinInt64}
+	for _, order := range []BitOrder{MSBFirst, LSBFirst} {
+		w := NewWriter(WithBitOrder(order))
+		for _, v := range values {
+			w.WriteVarbitInt(v)
+		}
+		r := NewReader(w.BitData(), WithBitOrder(order))
+		for _, v := range values {
+			if got, err := r.ReadVarbitInt(); err != nil || got != v {
+				t.Fatalf("%v: got %d, %v, want %d", order, got, err, v)
+			}
+		}
+	}
+}
+
+func TestVarbitUint(t *testing.T) {
+	values := []uint64{0, 1, 7, 8, 63, 64, 511, 4095, 1<<18 - 1, 1 << 18, 1<<25 - 1, 1<<56 - 1, 1 << 56, math.MaxUint64}
+
+	w := NewWriter(WithBitOrder(MSBFirst))
+	for _, v := range values {
+		w.WriteVarbitUint(v)
+	}
+	r := NewReader(w.BitData(), WithBitOrder(MSBFirst))
+	for _, v := range values {
+		if got, err := r.ReadVarbitUint(); err != nil || got != v {
+			t.Fatalf("got %d, %v, want %d", got, err, v)
+		}
+	}
+
+	w = NewWriter(WithBitOrder(MSBFirst))
+	w.WriteVarbitUint(7)
+	if got := w.BitData(); !bytes.Equal(got, BitData{0b10111000}) {
+		t.Errorf("got %08b", got)
+	}
+
+	r = NewReader(BitData{0xFF}, WithBitOrder(MSBFirst))
+	if _, err := r.ReadVarbitUint(); !errors.Is(err, io.ErrUnexpectedEOF) || r.bitsRead != 0 {
+		t.Errorf("expected io.ErrUnexpectedEOF, got %v", err)
+	}
+}
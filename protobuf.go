@@ -0,0 +1,174 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"errors"
+	"io"
+)
+
+var ErrInvalidProto = errors.New("invalid protobuf wire data")
+
+// WireType is the wire type of a protobuf field. Groups aren't supported.
+type WireType byte
+
+const (
+	WireVarint  WireType = 0
+	WireFixed64 WireType = 1
+	WireBytes   WireType = 2
+	WireFixed32 WireType = 5
+)
+
+// maxProtoField is the largest protobuf field number.
+const maxProtoField = 1<<29 - 1
+
+// ProtoField is a field of a protobuf message. Value holds the value of the varint and the fixed types,
+// Bytes the content of a length-delimited field.
+type ProtoField struct {
+	Number uint32
+	Type   WireType
+	Value  uint64
+	Bytes  []byte
+}
+
+// WriteProtoVarint writes a protobuf field of the varint wire type. The protobuf fields are written
+// as whole bytes from the current position, which doesn't need to be on a byte boundary.
+func (w *Writer) WriteProtoVarint(field uint32, v uint64) {
+	w.writeProtoTag(field, WireVarint)
+	w.WriteUvarint(v)
+}
+
+func (w *Writer) WriteProtoFixed32(field uint32, v uint32) {
+	w.writeProtoTag(field, WireFixed32)
+	writeLittleEndian(w, uint64(v), 4)
+}
+
+func (w *Writer) WriteProtoFixed64(field uint32, v uint64) {
+	w.writeProtoTag(field, WireFixed64)
+	writeLittleEndian(w, v, 8)
+}
+
+// WriteProtoBytes writes a length-delimited protobuf field, such as a string or an embedded message.
+func (w *Writer) WriteProtoBytes(field uint32, p []byte) {
+	w.writeProtoTag(field, WireBytes)
+	w.WriteUvarint(uint64(len(p)))
+	w.WriteBitData(p, uint(len(p))*8)
+}
+
+func (w *Writer) writeProtoTag(field uint32, t WireType) {
+	if field == 0 || field > maxProtoField {
+		panic("bitdata: invalid protobuf field number")
+	}
+	w.WriteUvarint(uint64(field)<<3 | uint64(t))
+}
+
+// WriteProtoMessage embeds a serialized protobuf message: it pads the data with zero bits
+// to the next byte boundary and writes the message length as a varint followed by the message.
+func (w *Writer) WriteProtoMessage(msg []byte) {
+	w.AlignToByte()
+	w.WriteUvarint(uint64(len(msg)))
+	w.WriteBitData(msg, uint(len(msg))*8)
+}
+
+// ReadProtoField reads a protobuf field. It returns ErrInvalidProto for a zero field number
+// or an unsupported wire type. On error the read position is left unchanged.
+func (r *Reader) ReadProtoField() (f ProtoField, err error) {
+	start := r.bitsRead
+	defer func() {
+		if err != nil {
+			r.bitsRead = start
+			f = ProtoField{}
+		}
+	}()
+
+	tag, err := r.ReadUvarint()
+	if err != nil {
+		return
+	}
+	if tag>>3 == 0 || tag>>3 > maxProtoField {
+		err = ErrInvalidProto
+		return
+	}
+
+	f.Number = uint32(tag >> 3)
+	f.Type = WireType(tag & 7)
+
+	switch f.Type {
+	case WireVarint:
+		f.Value, err = r.ReadUvarint()
+	case WireFixed64:
+		f.Value, err = readLittleEndian(r, 8)
+	case WireFixed32:
+		f.Value, err = readLittleEndian(r, 4)
+	case WireBytes:
+		f.Bytes, err = readProtoBytes(r)
+	default:
+		err = ErrInvalidProto
+	}
+
+	return
+}
+
+// ReadProtoMessage extracts a protobuf message embedded with WriteProtoMessage. It skips the padding
+// up to the next byte boundary and returns a copy of the message bytes. On error the read position is left unchanged.
+func (r *Reader) ReadProtoMessage() ([]byte, error) {
+	start := r.bitsRead
+	r.AlignToByte()
+
+	p, err := readProtoBytes(r)
+	if err != nil {
+		r.bitsRead = start
+		return nil, err
+	}
+
+	return p, nil
+}
+
+func (r *ReaderError) ReadProtoField() (f ProtoField) {
+	if r.err == nil {
+		f, r.err = r.reader.ReadProtoField()
+	}
+	return
+}
+
+func (r *ReaderError) ReadProtoMessage() (p []byte) {
+	if r.err == nil {
+		p, r.err = r.reader.ReadProtoMessage()
+	}
+	return
+}
+
+// readProtoBytes reads a varint length followed by that many bytes.
+func readProtoBytes(r *Reader) ([]byte, error) {
+	n, err := r.ReadUvarint()
+	if err != nil {
+		return nil, err
+	}
+	if ok, err := r.ensureCount(n, 8); !ok {
+		return nil, err
+	}
+
+	p := make([]byte, n)
+	_, err = io.ReadFull(r, p)
+
+	return p, err
+}
+
+// writeLittleEndian writes the lowest n bytes of v, least significant byte first, in either bit order.
+func writeLittleEndian(w *Writer, v uint64, n int) {
+	for i := 0; i < n; i++ {
+		w.Write8(byte(v>>(8*i)), 8)
+	}
+}
+
+func readLittleEndian(r *Reader, n int) (uint64, error) {
+	var v uint64
+	for i := 0; i < n; i++ {
+		b, err := r.Read8(8)
+		if err != nil {
+			return 0, err
+		}
+		v |= uint64(b) << (8 * i)
+	}
+	return v, nil
+}
@@ -0,0 +1,102 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+)
+
+func TestProtoFields(t *testing.T) {
+	w := NewWriter()
+	w.WriteProtoVarint(1, 150)
+	w.WriteProtoBytes(2, []byte("testing"))
+	w.WriteProtoFixed32(3, 1)
+	w.WriteProtoFixed64(4, 0x0102030405060708)
+
+	want := []byte{
+		0x08, 0x96, 0x01,
+		0x12, 0x07, 't', 'e', 's', 't', 'i', 'n', 'g',
+		0x1D, 0x01, 0x00, 0x00, 0x00,
+		0x21, 0x08, 0x07, 0x06, 0x05, 0x04, 0x03, 0x02, 0x01,
+	}
+	if !bytes.Equal(want, w.BitData()) {
+		t.Errorf("encoding mismatch: want=%x got=%x", want, w.BitData())
+	}
+
+	fields := []ProtoField{
+		{Number: 1, Type: WireVarint, Value: 150},
+		{Number: 2, Type: WireBytes, Bytes: []byte("testing")},
+		{Number: 3, Type: WireFixed32, Value: 1},
+		{Number: 4, Type: WireFixed64, Value: 0x0102030405060708},
+	}
+
+	// the same bytes read in the other bit order, from an unaligned position
+	m := NewWriterMSB()
+	m.Write8(1, 3)
+	m.WriteBitData(want, uint(len(want))*8)
+
+	for _, r := range []*ReaderError{NewReaderError(w.BitData()), NewReaderErrorMSB(m.BitData())} {
+		if r.reader.order == MSBFirst {
+			r.Skip(3)
+		}
+		for _, f := range fields {
+			if got := r.ReadProtoField(); !reflect.DeepEqual(f, got) {
+				t.Errorf("field mismatch: want=%+v got=%+v", f, got)
+			}
+		}
+		if err := r.Error(); err != nil {
+			t.Errorf("unexpected error: %s", err)
+		}
+	}
+}
+
+func TestProtoMessage(t *testing.T) {
+	msg := []byte{0x08, 0x96, 0x01}
+
+	w := NewWriter()
+	w.Write8(0b101, 3)
+	w.WriteProtoMessage(msg)
+	w.Write8(0b11, 2)
+
+	if want := []byte{0b101, 3, 0x08, 0x96, 0x01, 0b11}; !bytes.Equal(want, w.BitData()) {
+		t.Errorf("encoding mismatch: want=%x got=%x", want, w.BitData())
+	}
+
+	r := NewReaderError(w.BitData())
+	r.Skip(3)
+	if got := r.ReadProtoMessage(); !bytes.Equal(msg, got) {
+		t.Errorf("message mismatch: want=%x got=%x", msg, got)
+	}
+	if v := r.Read8(2); v != 0b11 {
+		t.Errorf("want=%b got=%b", 0b11, v)
+	}
+	if err := r.Error(); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
+
+func TestProtoErrors(t *testing.T) {
+	tests := []struct {
+		d   BitData
+		err error
+	}{
+		{d: BitData{0x00, 0x01}, err: ErrInvalidProto},           // field number 0
+		{d: BitData{0x0B}, err: ErrInvalidProto},                 // group
+		{d: BitData{0x12, 0x05, 1, 2}, err: io.ErrUnexpectedEOF}, // short bytes
+		{d: BitData{0x1D, 0x01}, err: io.ErrUnexpectedEOF},       // short fixed32
+	}
+
+	for _, test := range tests {
+		r := NewReader(test.d)
+		if _, err := r.ReadProtoField(); !errors.Is(err, test.err) {
+			t.Errorf("want=%v for %x got=%v", test.err, test.d, err)
+		}
+		if r.BitsRead() != 0 {
+			t.Errorf("read position changed: %d", r.BitsRead())
+		}
+	}
+}
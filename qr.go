@@ -0,0 +1,152 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"errors"
+	"strings"
+)
+
+// Helpers for the data bit stream of QR codes (ISO/IEC 18004). The QR stream is packed starting with the
+// most significant bit, so the writer should use MSBFirst, and the stream must start at its beginning.
+
+var (
+	ErrInvalidQRData = errors.New("invalid QR segment data")
+	ErrQRCapacity    = errors.New("QR data exceeds capacity")
+)
+
+// QR segment mode indicators.
+const (
+	QRModeNumeric      = 0b0001
+	QRModeAlphanumeric = 0b0010
+	QRModeByte         = 0b0100
+	QRModeECI          = 0b0111
+	QRModeKanji        = 0b1000
+)
+
+const qrAlphanumeric = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ $%*+-./:"
+
+// QRCountBits returns the width of the character count of a segment of the mode in a symbol of the version.
+// It panics if the version isn't from 1 to 40 or the mode has no character count.
+func QRCountBits(mode byte, version int) byte {
+	if version < 1 || version > 40 {
+		panic("bitdata: invalid QR version")
+	}
+
+	size := 0
+	if version >= 27 {
+		size = 2
+	} else if version >= 10 {
+		size = 1
+	}
+
+	switch mode {
+	case QRModeNumeric:
+		return [3]byte{10, 12, 14}[size]
+	case QRModeAlphanumeric:
+		return [3]byte{9, 11, 13}[size]
+	case QRModeByte:
+		return [3]byte{8, 16, 16}[size]
+	case QRModeKanji:
+		return [3]byte{8, 10, 12}[size]
+	}
+
+	panic("bitdata: invalid QR mode")
+}
+
+// WriteQRNumeric writes a numeric segment of the decimal digits of s: the mode indicator, the character
+// count and the digits packed in groups of three into 10 bits, with the remaining two or one digit
+// in 7 or 4 bits. It returns ErrInvalidQRData if s has another character or too many of them.
+func (w *Writer) WriteQRNumeric(s string, version int) error {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return ErrInvalidQRData
+		}
+	}
+	if err := w.writeQRHeader(QRModeNumeric, len(s), version); err != nil {
+		return err
+	}
+
+	for i := 0; i < len(s); i += 3 {
+		n := min(3, len(s)-i)
+		var v uint16
+		for _, c := range s[i : i+n] {
+			v = v*10 + uint16(c-'0')
+		}
+		w.Write16(v, byte(3*n+1))
+	}
+
+	return nil
+}
+
+// WriteQRAlphanumeric writes an alphanumeric segment: the mode indicator, the character count and the
+// characters packed in pairs into 11 bits, with the last odd character in 6 bits. The allowed characters
+// are the digits, the upper case letters and " $%*+-./:". It returns ErrInvalidQRData for any other
+// character or too many of them.
+func (w *Writer) WriteQRAlphanumeric(s string, version int) error {
+	values := make([]uint16, len(s))
+	for i := 0; i < len(s); i++ {
+		idx := strings.IndexByte(qrAlphanumeric, s[i])
+		if idx < 0 {
+			return ErrInvalidQRData
+		}
+		values[i] = uint16(idx)
+	}
+	if err := w.writeQRHeader(QRModeAlphanumeric, len(s), version); err != nil {
+		return err
+	}
+
+	for i := 0; i+1 < len(values); i += 2 {
+		w.Write16(values[i]*45+values[i+1], 11)
+	}
+	if len(values)%2 == 1 {
+		w.Write16(values[len(values)-1], 6)
+	}
+
+	return nil
+}
+
+// WriteQRBytes writes a byte segment: the mode indicator, the byte count and the bytes of p.
+// It returns ErrInvalidQRData if p has too many bytes.
+func (w *Writer) WriteQRBytes(p []byte, version int) error {
+	if err := w.writeQRHeader(QRModeByte, len(p), version); err != nil {
+		return err
+	}
+
+	for _, b := range p {
+		w.Write8(b, 8)
+	}
+
+	return nil
+}
+
+func (w *Writer) writeQRHeader(mode byte, count, version int) error {
+	width := QRCountBits(mode, version)
+	if count >= 1<<width {
+		return ErrInvalidQRData
+	}
+
+	w.Write8(mode, 4)
+	w.Write16(uint16(count), width)
+
+	return nil
+}
+
+// WriteQRPadding completes the data stream to dataCodewords bytes, the data capacity of the symbol:
+// it writes the terminator of up to four zero bits, zero bits up to the byte boundary, and then
+// the pad bytes 0xEC and 0x11 alternately. It returns ErrQRCapacity if the stream is already too long.
+func (w *Writer) WriteQRPadding(dataCodewords int) error {
+	capacity := uint(dataCodewords) * 8
+	if w.bitsWritten > capacity {
+		return ErrQRCapacity
+	}
+
+	w.Write8(0, byte(min(4, capacity-w.bitsWritten)))
+	w.AlignToByte()
+
+	for i := 0; w.bitsWritten < capacity; i++ {
+		w.Write8([2]byte{0xEC, 0x11}[i%2], 8)
+	}
+
+	return nil
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestQRCountBits(t *testing.T) {
+	tests := []struct {
+		mode    byte
+		version int
+		want    byte
+	}{
+		{mode: QRModeNumeric, version: 1, want: 10},
+		{mode: QRModeNumeric, version: 10, want: 12},
+		{mode: QRModeNumeric, version: 40, want: 14},
+		{mode: QRModeAlphanumeric, version: 9, want: 9},
+		{mode: QRModeAlphanumeric, version: 26, want: 11},
+		{mode: QRModeByte, version: 1, want: 8},
+		{mode: QRModeByte, version: 27, want: 16},
+		{mode: QRModeKanji, version: 27, want: 12},
+	}
+
+	for _, test := range tests {
+		if got := QRCountBits(test.mode, test.version); got != test.want {
+			t.Errorf("mode %d version %d: want=%d got=%d", test.mode, test.version, test.want, got)
+		}
+	}
+}
+
+func TestQRSegments(t *testing.T) {
+	tests := []struct {
+		name  string
+		write func(w *Writer) error
+		bits  string
+	}{
+		{
+			name:  "numeric",
+			write: func(w *Writer) error { return w.WriteQRNumeric("01234567", 1) },
+			bits:  "0001" + "0000001000" + "0000001100" + "0101011001" + "1000011",
+		},
+		{
+			name:  "alphanumeric",
+			write: func(w *Writer) error { return w.WriteQRAlphanumeric("AC-42", 1) },
+			bits:  "0010" + "000000101" + "00111001110" + "11100111001" + "000010",
+		},
+		{
+			name:  "bytes",
+			write: func(w *Writer) error { return w.WriteQRBytes([]byte{0xA5}, 10) },
+			bits:  "0100" + "0000000000000001" + "10100101",
+		},
+	}
+
+	for _, test := range tests {
+		w := NewWriterMSB()
+		if err := test.write(w); err != nil {
+			t.Errorf("%s: write failed: %v", test.name, err)
+			continue
+		}
+		if got := bitString(w.BitData(), w.BitsWritten(), MSBFirst); got != test.bits {
+			t.Errorf("%s: want=%s got=%s", test.name, test.bits, got)
+		}
+	}
+}
+
+func TestQRPadding(t *testing.T) {
+	// The ISO/IEC 18004 example: "01234567" in a 1-M symbol with 16 data codewords.
+	w := NewWriterMSB()
+	if err := w.WriteQRNumeric("01234567", 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.WriteQRPadding(16); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []byte{0x10, 0x20, 0x0C, 0x56, 0x61, 0x80, 0xEC, 0x11, 0xEC, 0x11, 0xEC, 0x11, 0xEC, 0x11, 0xEC, 0x11}
+	if got := w.BitData(); !bytes.Equal(got, want) {
+		t.Errorf("want=% X got=% X", want, got)
+	}
+
+	// The terminator is shortened when the capacity is nearly full.
+	w = NewWriterMSB()
+	w.Write8(0, 6)
+	if err := w.WriteQRPadding(1); err != nil || w.BitsWritten() != 8 {
+		t.Errorf("short terminator: bits=%d err=%v", w.BitsWritten(), err)
+	}
+
+	w = NewWriterMSB()
+	w.Write16(0, 9)
+	if err := w.WriteQRPadding(1); !errors.Is(err, ErrQRCapacity) {
+		t.Errorf("expected ErrQRCapacity, got %v", err)
+	}
+}
+
+func TestQRInvalid(t *testing.T) {
+	w := NewWriterMSB()
+	if err := w.WriteQRNumeric("12a", 1); !errors.Is(err, ErrInvalidQRData) {
+		t.Errorf("expected ErrInvalidQRData for numeric, got %v", err)
+	}
+	if err := w.WriteQRAlphanumeric("abc", 1); !errors.Is(err, ErrInvalidQRData) {
+		t.Errorf("expected ErrInvalidQRData for alphanumeric, got %v", err)
+	}
+	if err := w.WriteQRBytes(make([]byte, 256), 1); !errors.Is(err, ErrInvalidQRData) {
+		t.Errorf("expected ErrInvalidQRData for bytes, got %v", err)
+	}
+	if w.BitsWritten() != 0 {
+		t.Errorf("invalid segments must not be written")
+	}
+}
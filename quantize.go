@@ -0,0 +1,58 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"math"
+)
+
+// WriteQuantized writes v as the index of one of 2^bitCount equally sized buckets covering the range [lo, hi].
+// Values outside the range are clamped, NaN is written as lo.
+func (w *Writer) WriteQuantized(v, lo, hi float64, bitCount byte) {
+	w.Write64(quantize(v, lo, hi, bitCount), bitCount)
+}
+
+// ReadQuantized reads a value written with WriteQuantized and returns the midpoint of its bucket.
+func (r *Reader) ReadQuantized(lo, hi float64, bitCount byte) (float64, error) {
+	idx, err := r.Read64(bitCount)
+	if err != nil {
+		return 0, err
+	}
+
+	return dequantize(idx, lo, hi, bitCount), nil
+}
+
+func (r *ReaderError) ReadQuantized(lo, hi float64, bitCount byte) (v float64) {
+	if r.err == nil {
+		v, r.err = r.reader.ReadQuantized(lo, hi, bitCount)
+	}
+	return
+}
+
+func quantize(v, lo, hi float64, bitCount byte) uint64 {
+	if bitCount == 0 || bitCount > 64 || !(lo < hi) {
+		panic("bitdata: invalid quantization parameters")
+	}
+
+	if math.IsNaN(v) || v <= lo {
+		return 0
+	}
+
+	last := mask[uint64](bitCount)
+	if v >= hi {
+		return last
+	}
+
+	buckets := math.Ldexp(1, int(bitCount))
+	idx := math.Floor((v - lo) / (hi - lo) * buckets)
+	if idx >= buckets {
+		return last
+	}
+
+	return uint64(idx)
+}
+
+func dequantize(idx uint64, lo, hi float64, bitCount byte) float64 {
+	buckets := math.Ldexp(1, int(bitCount))
+	return lo + (float64(idx)+0.5)*(hi-lo)/buckets
+}
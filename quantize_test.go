@@ -0,0 +1,74 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+)
+
+func TestQuantized(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  float64
+		lo, hi float64
+		bits   byte
+		exp    float64
+	}{
+		{name: "lowest-bucket", value: 0, lo: 0, hi: 8, bits: 3, exp: 0.5},
+		{name: "highest-bucket", value: 7.9, lo: 0, hi: 8, bits: 3, exp: 7.5},
+		{name: "upper-bound", value: 8, lo: 0, hi: 8, bits: 3, exp: 7.5},
+		{name: "clamp-low", value: -100, lo: -1, hi: 1, bits: 1, exp: -0.5},
+		{name: "clamp-high", value: 100, lo: -1, hi: 1, bits: 1, exp: 0.5},
+		{name: "nan", value: math.NaN(), lo: 10, hi: 20, bits: 2, exp: 11.25},
+		{name: "inf", value: math.Inf(1), lo: 10, hi: 20, bits: 2, exp: 18.75},
+		{name: "middle", value: 3.3, lo: 0, hi: 10, bits: 10, exp: 3.3 - math.Mod(3.3, 10.0/1024) + 5.0/1024},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w := NewWriter()
+			w.WriteQuantized(test.value, test.lo, test.hi, test.bits)
+
+			if w.BitsWritten() != uint(test.bits) {
+				t.Errorf("size mismatch: want=%d got=%d", test.bits, w.BitsWritten())
+			}
+
+			v, err := NewReader(w.BitData()).ReadQuantized(test.lo, test.hi, test.bits)
+			if err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+			if math.Abs(v-test.exp) > 1e-9 {
+				t.Errorf("value mismatch: want=%g got=%g", test.exp, v)
+			}
+		})
+	}
+}
+
+func TestQuantizedError(t *testing.T) {
+	const (
+		lo, hi = -50.0, 150.0
+		bits   = 12
+	)
+
+	values := make([]float64, 100)
+	w := NewWriter()
+	for i := range values {
+		values[i] = lo + rand.Float64()*(hi-lo)
+		w.WriteQuantized(values[i], lo, hi, bits)
+	}
+
+	maxErr := (hi - lo) / (1 << bits) / 2
+
+	r := NewReaderError(w.BitData())
+	for i, want := range values {
+		if got := r.ReadQuantized(lo, hi, bits); math.Abs(got-want) > maxErr {
+			t.Errorf("value %d: error too big: want=%g got=%g", i, want, got)
+		}
+	}
+
+	if err := r.Error(); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"fmt"
+	"io"
+	"math/bits"
+	"math/rand/v2"
+	"slices"
+)
+
+// NewRandomReader returns an endless reader of the bytes of src, meant for random sources such as
+// crypto/rand.Reader. Only the bits that are read are taken from src, so reading 5-bit values consumes
+// 5 bits of entropy each, and ReadUint64N draws values in a range without modulo bias.
+// The read position can't be moved back before the last read. An error of src is returned by the read
+// that caused it, and if src ends, the reads past its end fail with io.ErrUnexpectedEOF. A Cursor of
+// the reader continues with its own bits drawn from src, but it can't be used concurrently with the reader.
+func NewRandomReader(src io.Reader, opts ...Option) *Reader {
+	r := NewReader(nil, opts...)
+	r.end = ^uint(0) >> 1
+	r.src = &streamSource{r: src}
+	return r
+}
+
+// NewPRNGReader returns an endless reader of the bits generated by src, such as rand.NewPCG(seed1, seed2).
+// The bits are reproducible: readers of sources with the same seed return the same values for the same
+// sequence of reads. As for NewRandomReader, only the bits that are read are taken from src.
+func NewPRNGReader(src rand.Source, opts ...Option) *Reader {
+	return NewRandomReader(&prngStream{src: src}, opts...)
+}
+
+// ReadUint64N returns a uniformly distributed value in the range [0, n). It reads as many bits as
+// needed to represent n-1 and rejects values out of the range, so on average fewer than two attempts
+// are needed. It panics if n is zero.
+func (r *Reader) ReadUint64N(n uint64) (uint64, error) {
+	if n == 0 {
+		panic("bitdata: ReadUint64N with zero n")
+	}
+
+	k := byte(bits.Len64(n - 1))
+	for {
+		v, err := read[uint64](r, k)
+		if err != nil || v < n {
+			return v, err
+		}
+	}
+}
+
+func (r *ReaderError) ReadUint64N(n uint64) (v uint64) {
+	if r.err == nil {
+		v, r.err = r.reader.ReadUint64N(n)
+	}
+	return
+}
+
+// streamSource is a source that reads an io.Reader sequentially, keeping only the bytes from
+// the last requested offset. The end is recorded when the io.Reader runs out of bytes.
+type streamSource struct {
+	r     io.Reader
+	base  uint
+	buf   []byte
+	end   uint
+	ended bool
+}
+
+func (c *streamSource) bytes(from, to uint) ([]byte, error) {
+	if from < c.base {
+		return nil, fmt.Errorf("%w: data before byte %d is discarded", ErrInvalidRange, c.base)
+	}
+
+	if skip := from - c.base; skip < uint(len(c.buf)) {
+		c.buf = c.buf[:copy(c.buf, c.buf[skip:])]
+	} else {
+		if n, err := io.CopyN(io.Discard, c.r, int64(skip-uint(len(c.buf)))); err != nil {
+			c.setEnd(err, c.base+uint(len(c.buf))+uint(n))
+			return nil, err
+		}
+		c.buf = c.buf[:0]
+	}
+	c.base = from
+
+	if have, need := uint(len(c.buf)), to-from; have < need {
+		c.buf = slices.Grow(c.buf, int(need-have))[:need]
+		if n, err := io.ReadFull(c.r, c.buf[have:]); err != nil {
+			c.buf = c.buf[:have+uint(n)]
+			c.setEnd(err, c.base+uint(len(c.buf)))
+			return nil, err
+		}
+	}
+
+	return c.buf[:to-from], nil
+}
+
+// setEnd records the byte offset size as the end of the stream if err tells that the io.Reader ran out of bytes.
+func (c *streamSource) setEnd(err error, size uint) {
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		c.end, c.ended = size*8, true
+	}
+}
+
+func (c *streamSource) bitEnd() (uint, bool) {
+	return c.end, c.ended
+}
+
+// cursor returns a source that draws its bytes from the stream after the ones already taken,
+// so the cursor doesn't repeat the bits of the parent. Reader.Cursor positions it at the read position.
+func (c *streamSource) cursor() source {
+	return &streamSource{r: c.r}
+}
+
+// prngStream is an io.Reader of the values of a rand.Source in little-endian byte order.
+type prngStream struct {
+	src  rand.Source
+	v    uint64
+	left int
+}
+
+func (s *prngStream) Read(p []byte) (int, error) {
+	for i := range p {
+		if s.left == 0 {
+			s.v = s.src.Uint64()
+			s.left = 8
+		}
+		p[i] = byte(s.v)
+		s.v >>= 8
+		s.left--
+	}
+	return len(p), nil
+}
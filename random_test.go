@@ -0,0 +1,131 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"io"
+	mathrand "math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestRandomReader(t *testing.T) {
+	src := make([]byte, 64)
+	for i := range src {
+		src[i] = byte(i * 37)
+	}
+
+	r := NewRandomReader(bytes.NewReader(src))
+	want := NewReader(src)
+	for i := 0; want.bitsRead < 400; i++ {
+		n := byte(i%13 + 1)
+		v, err := r.Read16(n)
+		w, _ := want.Read16(n)
+		if err != nil || v != w {
+			t.Fatalf("read %d: got %d, %v, want %d", i, v, err, w)
+		}
+		if i%5 == 0 {
+			r.Skip(11)
+			want.Skip(11)
+		}
+	}
+
+	var err error
+	for i := 0; i < 4 && err == nil; i++ {
+		_, err = r.Read64(64)
+	}
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("expected io.ErrUnexpectedEOF at the end of the source, got %v", err)
+	}
+
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Read8(8); !errors.Is(err, ErrInvalidRange) {
+		t.Errorf("expected ErrInvalidRange after seeking back, got %v", err)
+	}
+}
+
+func TestReadUint64N(t *testing.T) {
+	r := NewRandomReader(rand.Reader)
+
+	var counts [6]int
+	for range 6000 {
+		v, err := r.ReadUint64N(6)
+		if err != nil {
+			t.Fatal(err)
+		}
+		counts[v]++
+	}
+	for v, c := range counts {
+		if c < 800 || c > 1200 {
+			t.Errorf("value %d drawn %d times", v, c)
+		}
+	}
+
+	if v, _ := r.ReadUint64N(1); v != 0 {
+		t.Errorf("got %d for n=1", v)
+	}
+	if _, err := r.ReadUint64N(1 << 63); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRandomReaderCursor(t *testing.T) {
+	src := make([]byte, 64)
+	for i := range src {
+		src[i] = byte(i*37 + 5)
+	}
+
+	r := NewRandomReader(bytes.NewReader(src))
+	r.Read8(1)
+	c := r.Cursor()
+
+	// The parent has taken bytes 0-7 of the stream, so the cursor continues with byte 8.
+	want := NewReaderBits(src, 8*8+61)
+	want.Skip(8*8 + 1)
+	if v, err := c.Read64(60); err != nil || v != want.MustRead64(60) {
+		t.Errorf("cursor got %x, %v", v, err)
+	}
+
+	want.Seek(1, io.SeekStart)
+	if v, err := r.Read64(60); err != nil || v != want.MustRead64(60) {
+		t.Errorf("parent got %x, %v", v, err)
+	}
+}
+
+func TestPRNGReader(t *testing.T) {
+	draw := func(seed uint64) []uint64 {
+		r := NewPRNGReader(mathrand.NewPCG(seed, 7))
+		var values []uint64
+		for i := range 100 {
+			v, err := r.Read64(byte(i%64 + 1))
+			if err != nil {
+				t.Fatal(err)
+			}
+			values = append(values, v)
+		}
+		return values
+	}
+
+	a, b, c := draw(1), draw(1), draw(2)
+	for i := range a {
+		if a[i] != b[i] {
+			t.Fatalf("value %d differs for the same seed: %d != %d", i, a[i], b[i])
+		}
+	}
+	if slices.Equal(a, c) {
+		t.Error("different seeds gave the same values")
+	}
+
+	pcg := mathrand.NewPCG(3, 4)
+	r := NewPRNGReader(mathrand.NewPCG(3, 4))
+	for range 10 {
+		if v, _ := r.Read64(64); v != pcg.Uint64() {
+			t.Fatal("values differ from the source")
+		}
+	}
+}
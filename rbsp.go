@@ -0,0 +1,127 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"errors"
+)
+
+var ErrTrailingBits = errors.New("invalid rbsp trailing bits")
+
+// RemoveEmulationPrevention returns a copy of the payload of an H.264 or H.265 NAL unit
+// with the emulation prevention byte of every 0x000003 sequence removed, which is the RBSP.
+func RemoveEmulationPrevention(d BitData) BitData {
+	out := make(BitData, 0, len(d))
+	zeros := 0
+	for _, b := range d {
+		if zeros >= 2 && b == 0x03 {
+			zeros = 0
+			continue
+		}
+
+		if b == 0 {
+			zeros++
+		} else {
+			zeros = 0
+		}
+		out = append(out, b)
+	}
+	return out
+}
+
+// AddEmulationPrevention returns a copy of the RBSP d with an emulation prevention byte 0x03 inserted
+// wherever two zero bytes are followed by a byte not greater than 0x03, or end the data.
+// The result can't contain a start code.
+func AddEmulationPrevention(d BitData) BitData {
+	out := make(BitData, 0, len(d)+len(d)/64+1)
+	zeros := 0
+	for _, b := range d {
+		if zeros >= 2 && b <= 0x03 {
+			out = append(out, 0x03)
+			zeros = 0
+		}
+
+		if b == 0 {
+			zeros++
+		} else {
+			zeros = 0
+		}
+		out = append(out, b)
+	}
+	if zeros >= 2 {
+		out = append(out, 0x03)
+	}
+	return out
+}
+
+// WriteRBSPTrailingBits writes the rbsp_trailing_bits: a stop bit of one followed by zero bits
+// up to the next byte boundary.
+func (w *Writer) WriteRBSPTrailingBits() {
+	w.WriteBool(true)
+	if ofs := w.bitsWritten % 8; ofs > 0 {
+		w.Write8(0, byte(8-ofs))
+	}
+}
+
+// ReadRBSPTrailingBits reads the rbsp_trailing_bits and returns ErrTrailingBits if they aren't a stop bit
+// of one followed by zero bits up to the next byte boundary. On error the read position is left unchanged.
+func (r *Reader) ReadRBSPTrailingBits() error {
+	start := r.bitsRead
+
+	stop, err := read[byte](r, 1)
+	if err != nil {
+		return err
+	}
+
+	var align byte
+	if ofs := r.bitsRead % 8; ofs > 0 {
+		if align, err = read[byte](r, byte(8-ofs)); err != nil {
+			r.bitsRead = start
+			return err
+		}
+	}
+
+	if stop != 1 || align != 0 {
+		r.bitsRead = start
+		return ErrTrailingBits
+	}
+
+	return nil
+}
+
+// MoreRBSPData reports whether there is more data before the rbsp_trailing_bits, the more_rbsp_data()
+// of H.264 and H.265. It looks for the last one bit of the data, which is the stop bit.
+func (r *Reader) MoreRBSPData() bool {
+	var (
+		b   byte
+		idx = ^uint(0)
+	)
+
+	for pos := r.end; pos > r.bitsRead; pos-- {
+		i := pos - 1
+		if i/8 != idx {
+			idx = i / 8
+			buf, err := r.bytes(idx, idx+1)
+			if err != nil {
+				return false
+			}
+			b = buf[0]
+		}
+
+		if bitAt(b, i%8, r.order) {
+			return i > r.bitsRead
+		}
+	}
+
+	return false
+}
+
+func (r *ReaderError) ReadRBSPTrailingBits() {
+	if r.err == nil {
+		r.err = r.reader.ReadRBSPTrailingBits()
+	}
+}
+
+func (r *ReaderError) MoreRBSPData() bool {
+	return r.err == nil && r.reader.MoreRBSPData()
+}
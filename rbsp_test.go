@@ -0,0 +1,101 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestEmulationPrevention(t *testing.T) {
+	tests := []struct {
+		rbsp, ebsp []byte
+	}{
+		{rbsp: []byte{1, 2, 3}, ebsp: []byte{1, 2, 3}},
+		{rbsp: []byte{0, 0, 0}, ebsp: []byte{0, 0, 3, 0}},
+		{rbsp: []byte{0, 0, 1, 0, 0, 2}, ebsp: []byte{0, 0, 3, 1, 0, 0, 3, 2}},
+		{rbsp: []byte{0, 0, 3, 0, 0, 4}, ebsp: []byte{0, 0, 3, 3, 0, 0, 4}},
+		{rbsp: []byte{0, 0, 0, 0}, ebsp: []byte{0, 0, 3, 0, 0, 3}},
+		{rbsp: []byte{0x80, 0, 0}, ebsp: []byte{0x80, 0, 0, 3}},
+	}
+
+	for _, test := range tests {
+		if got := AddEmulationPrevention(test.rbsp); !bytes.Equal(test.ebsp, got) {
+			t.Errorf("insert mismatch for %x: want=%x got=%x", test.rbsp, test.ebsp, got)
+		}
+		if got := RemoveEmulationPrevention(test.ebsp); !bytes.Equal(test.rbsp, got) {
+			t.Errorf("remove mismatch for %x: want=%x got=%x", test.ebsp, test.rbsp, got)
+		}
+	}
+}
+
+func TestRBSP(t *testing.T) {
+	values := []uint64{0, 0, 5, 0, 0, 0, 0, 0, 0}
+
+	w := NewWriterMSB()
+	w.Write8(66, 8) // profile_idc
+	w.Write16(0, 16)
+	w.Write8(1, 8) // level_idc
+	for _, v := range values {
+		w.WriteExpGolomb(v)
+	}
+	w.WriteRBSPTrailingBits()
+
+	if w.BitsWritten()%8 != 0 {
+		t.Fatalf("trailing bits not aligned: %d", w.BitsWritten())
+	}
+
+	nal := AddEmulationPrevention(w.BitData())
+	if !bytes.Equal(nal[1:5], []byte{0, 0, 3, 1}) {
+		t.Errorf("emulation prevention missing: %x", nal)
+	}
+
+	r := NewReaderErrorMSB(RemoveEmulationPrevention(nal))
+	if v := r.Read8(8); v != 66 {
+		t.Errorf("want=66 got=%d", v)
+	}
+	r.Skip(16)
+	if v := r.Read8(8); v != 1 {
+		t.Errorf("want=1 got=%d", v)
+	}
+	var got []uint64
+	for r.MoreRBSPData() {
+		got = append(got, r.ReadExpGolomb())
+	}
+	r.ReadRBSPTrailingBits()
+
+	if err := r.Error(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(got) != len(values) {
+		t.Fatalf("value count mismatch: want=%d got=%d", len(values), len(got))
+	}
+	for i := range values {
+		if values[i] != got[i] {
+			t.Errorf("value %d mismatch: want=%d got=%d", i, values[i], got[i])
+		}
+	}
+}
+
+func TestRBSPTrailingBitsInvalid(t *testing.T) {
+	r := NewReaderMSB(BitData{0b01010000})
+	r.Skip(1)
+	if err := r.ReadRBSPTrailingBits(); !errors.Is(err, ErrTrailingBits) {
+		t.Errorf("want=%v got=%v", ErrTrailingBits, err)
+	}
+	if r.BitsRead() != 1 {
+		t.Errorf("read position changed: %d", r.BitsRead())
+	}
+	if !r.MoreRBSPData() {
+		t.Error("more data expected")
+	}
+
+	r.Skip(2)
+	if r.MoreRBSPData() {
+		t.Error("no more data expected")
+	}
+	if err := r.ReadRBSPTrailingBits(); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+)
+
+// Field describes a single value in a bitstream: its bit offset, width, value and an optional label.
+type Field struct {
+	Offset uint
+	Width  byte
+	Value  uint64
+	Label  string
+}
+
+// SetRecording turns the recording of writes on or off. While recording is on,
+// every write is logged as a Field and can be retrieved with Fields or Dump.
+func (w *Writer) SetRecording(enabled bool) {
+	w.recording = enabled
+}
+
+// Fields returns the writes logged while recording was on.
+func (w *Writer) Fields() []Field {
+	return w.fields
+}
+
+func (w *Writer) WriteLabeled64(v uint64, bitCount byte, label string) {
+	if w.trace != nil {
+		w.traceWrite("WriteLabeled64", v, bitCount)
+	}
+	n := len(w.fields)
+	write[uint64](w, v, bitCount)
+	if len(w.fields) > n {
+		w.fields[n].Label = label
+	}
+}
+
+// Dump returns a human-readable table of the writes logged while recording was on.
+func (w *Writer) Dump() string {
+	return DumpFields(w.fields)
+}
+
+// DumpFields returns a human-readable table of the fields with their offsets, widths and values.
+func DumpFields(fields []Field) string {
+	var sb strings.Builder
+
+	tw := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', tabwriter.AlignRight)
+	_, _ = fmt.Fprintln(tw, "offset\twidth\tvalue\tbinary\t label")
+	for _, f := range fields {
+		_, _ = fmt.Fprintf(tw, "%d\t%d\t%#x\t%0*b\t %s\n", f.Offset, f.Width, f.Value, f.Width, f.Value, f.Label)
+	}
+	_ = tw.Flush()
+
+	return sb.String()
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRecording(t *testing.T) {
+	w := NewWriter()
+	w.Write8(1, 3) // not recorded
+
+	w.SetRecording(true)
+	w.WriteLabeled64(29, 5, "day")
+	w.WriteBool(true)
+	w.Write16(0xABC, 12)
+	w.Write8(0, 0) // zero width, not recorded
+
+	w.SetRecording(false)
+	w.Write8(1, 3) // not recorded
+
+	want := []Field{
+		{Offset: 3, Width: 5, Value: 29, Label: "day"},
+		{Offset: 8, Width: 1, Value: 1},
+		{Offset: 9, Width: 12, Value: 0xABC},
+	}
+
+	got := w.Fields()
+	if len(got) != len(want) {
+		t.Errorf("field count mismatch: want=%d got=%d", len(want), len(got))
+		return
+	}
+
+	for i := range want {
+		if want[i] != got[i] {
+			t.Errorf("field %d mismatch: want=%+v got=%+v", i, want[i], got[i])
+		}
+	}
+
+	dump := w.Dump()
+	lines := strings.Split(strings.TrimRight(dump, "\n"), "\n")
+	if len(lines) != 4 {
+		t.Errorf("line count mismatch: want=%d got=%d\n%s", 4, len(lines), dump)
+		return
+	}
+	if !strings.Contains(lines[1], "11101") || !strings.HasSuffix(lines[1], "day") {
+		t.Errorf("unexpected dump line: %q", lines[1])
+	}
+	if !strings.Contains(lines[3], "0xabc") || !strings.Contains(lines[3], "101010111100") {
+		t.Errorf("unexpected dump line: %q", lines[3])
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+var ErrTornRecord = errors.New("torn record at the end of log")
+
+// A record log is a sequence of records of any bit length, each stored as the bit length as an LEB128 varint,
+// the bits padded with zeros to a byte boundary, and the CRC32 of all that, so every record starts
+// on a byte boundary and a record cut short by a crash can be detected.
+
+// RecordWriter appends records to an io.Writer.
+type RecordWriter struct {
+	w io.Writer
+}
+
+func NewRecordWriter(w io.Writer) *RecordWriter {
+	return &RecordWriter{w: w}
+}
+
+// Append writes a record with the first bitCount bits of the data with a single Write call.
+func (rw *RecordWriter) Append(d BitData, bitCount uint) error {
+	buf := NewWriter()
+	buf.WriteUvarint(uint64(bitCount))
+	buf.WriteBitData(d, bitCount)
+	buf.AlignToByte()
+	buf.WriteCRC(CRC32, 0)
+
+	_, err := rw.w.Write(buf.BitData())
+	return err
+}
+
+// RecordReader reads the records of a record log from an io.Reader.
+type RecordReader struct {
+	r      *bufio.Reader
+	offset int64
+}
+
+func NewRecordReader(r io.Reader) *RecordReader {
+	return &RecordReader{r: bufio.NewReader(r)}
+}
+
+// Offset returns the byte offset of the next record, which after an error is the offset of the bad record.
+func (rr *RecordReader) Offset() int64 {
+	return rr.offset
+}
+
+// Next returns the next record and its length in bits. It returns io.EOF at the end of the log.
+// A record that is incomplete, or fails the CRC check and ends at the end of the log, gives ErrTornRecord.
+// A record failing the CRC check elsewhere gives ErrCRCMismatch.
+func (rr *RecordReader) Next() (BitData, uint, error) {
+	var rec bytes.Buffer
+
+	n, err := rr.readUvarint(&rec)
+	if err == io.EOF && rec.Len() == 0 {
+		return nil, 0, io.EOF
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+
+	size := (n + 7) / 8
+	if _, err := io.CopyN(&rec, rr.r, int64(size)+4); errors.Is(err, io.EOF) {
+		return nil, 0, ErrTornRecord
+	} else if err != nil {
+		return nil, 0, err
+	}
+
+	r := NewReader(rec.Bytes())
+	header, _ := r.ReadUvarint()
+	start := r.bitsRead
+	r.Skip(uint(size) * 8)
+	if err := r.VerifyCRC(CRC32, 0); err != nil {
+		if _, perr := rr.r.Peek(1); perr == io.EOF {
+			return nil, 0, ErrTornRecord
+		}
+		return nil, 0, fmt.Errorf("record at byte offset %d: %w", rr.offset, err)
+	}
+
+	rr.offset += int64(rec.Len())
+
+	return rec.Bytes()[start/8 : start/8+uint(size)], uint(header), nil
+}
+
+// readUvarint reads an LEB128 varint bit length, also storing its bytes to rec.
+func (rr *RecordReader) readUvarint(rec *bytes.Buffer) (uint64, error) {
+	var v uint64
+	for i := 0; i < maxVarintBytes; i++ {
+		b, err := rr.r.ReadByte()
+		if err == io.EOF && i > 0 {
+			return 0, ErrTornRecord
+		}
+		if err != nil {
+			return 0, err
+		}
+		rec.WriteByte(b)
+
+		v |= uint64(b&0x7F) << (7 * i)
+		if b < 0x80 {
+			if v > uint64(maxInt)/8 {
+				return 0, ErrVarintOverflow
+			}
+			return v, nil
+		}
+	}
+	return 0, ErrVarintOverflow
+}
+
+// RepairRecordLog reads all records of the log file and truncates a torn record at its end.
+// It returns the size of the valid part of the log, and leaves the file offset at its end, ready for appending.
+func RepairRecordLog(f *os.File) (int64, error) {
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return 0, err
+	}
+
+	rr := NewRecordReader(f)
+	for {
+		_, _, err := rr.Next()
+		if err == io.EOF {
+			break
+		}
+		if errors.Is(err, ErrTornRecord) {
+			if err := f.Truncate(rr.offset); err != nil {
+				return 0, err
+			}
+			break
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	if _, err := f.Seek(rr.offset, io.SeekStart); err != nil {
+		return 0, err
+	}
+
+	return rr.offset, nil
+}
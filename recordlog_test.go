@@ -0,0 +1,105 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRecordLog(t *testing.T) {
+	records := []string{"1", "", "1010 0101 1100 0011 1", "0000 0000"}
+
+	var buf bytes.Buffer
+	rw := NewRecordWriter(&buf)
+	for _, rec := range records {
+		d, n, _ := ParseBits(rec)
+		if err := rw.Append(d, n); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	rr := NewRecordReader(bytes.NewReader(buf.Bytes()))
+	for i, want := range records {
+		d, n, err := rr.Next()
+		if err != nil {
+			t.Fatalf("record %d: unexpected error: %v", i, err)
+		}
+		if got := d.FormatBinary(n, 4); got != want {
+			t.Errorf("record %d: want=%q got=%q", i, want, got)
+		}
+	}
+	if _, _, err := rr.Next(); err != io.EOF {
+		t.Errorf("expected io.EOF, got %v", err)
+	}
+	if rr.Offset() != int64(buf.Len()) {
+		t.Errorf("offset %d, size %d", rr.Offset(), buf.Len())
+	}
+
+	// A record corrupted in the middle of the log isn't torn.
+	d := bytes.Clone(buf.Bytes())
+	d[7] ^= 1
+	rr = NewRecordReader(bytes.NewReader(d))
+	_, _, _ = rr.Next()
+	if _, _, err := rr.Next(); !errors.Is(err, ErrCRCMismatch) {
+		t.Errorf("expected ErrCRCMismatch, got %v", err)
+	}
+
+	// A partially written last record is torn.
+	for cut := 1; cut < 6; cut++ {
+		rr = NewRecordReader(bytes.NewReader(buf.Bytes()[:buf.Len()-cut]))
+		var err error
+		for err == nil {
+			_, _, err = rr.Next()
+		}
+		if !errors.Is(err, ErrTornRecord) {
+			t.Errorf("cut %d: expected ErrTornRecord, got %v", cut, err)
+		}
+	}
+}
+
+func TestRepairRecordLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "log")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	rw := NewRecordWriter(f)
+	_ = rw.Append(BitData{0xAB}, 8)
+	_ = rw.Append(BitData{0xCD, 0x01}, 9)
+	good, _ := f.Seek(0, io.SeekCurrent)
+
+	_, _ = f.Write([]byte{40, 0xFF, 0xFF})
+
+	size, err := RepairRecordLog(f)
+	if err != nil || size != good {
+		t.Fatalf("size=%d want=%d err=%v", size, good, err)
+	}
+
+	_ = NewRecordWriter(f).Append(BitData{0x07}, 3)
+
+	_, _ = f.Seek(0, io.SeekStart)
+	rr := NewRecordReader(f)
+	var got []string
+	for {
+		d, n, err := rr.Next()
+		if err != nil {
+			if err != io.EOF {
+				t.Errorf("unexpected error: %v", err)
+			}
+			break
+		}
+		got = append(got, d.FormatBinary(n, 0))
+	}
+
+	want := []string{"11010101", "101100111", "111"}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] || got[2] != want[2] {
+		t.Errorf("want=%q got=%q", want, got)
+	}
+}
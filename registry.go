@@ -0,0 +1,155 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+var (
+	ErrUnknownType           = errors.New("type not registered")
+	ErrUnknownTag            = errors.New("tag not registered")
+	ErrDuplicateRegistration = errors.New("duplicate registration")
+)
+
+// Registry maps numeric tags to types and their codecs, so that values whose concrete type is chosen
+// by a tag in the stream can be decoded. It is safe for concurrent use. The zero value is an empty registry.
+type Registry struct {
+	mu     sync.RWMutex
+	byType map[typeKey][]*registration
+	byTag  map[uint64]*registration
+}
+
+// registration is a registered type. Without reflection the types of the same name share a typeKey,
+// so id, the nil pointer to the type, tells the registrations apart, and has matches the values.
+type registration struct {
+	tag    uint64
+	id     any
+	has    func(v any) bool
+	encode func(w *Writer, v any) error
+	decode func(r *Reader) (any, error)
+}
+
+// DefaultRegistry is used by Marshal and Unmarshal for struct fields of interface types.
+var DefaultRegistry = &Registry{}
+
+// Register adds the type T with the tag to the registry. If encode or decode is nil, the value is encoded
+// with Encode or decoded with Decode, so T must be a struct or implement BitMarshaler and BitUnmarshaler.
+// It returns ErrDuplicateRegistration if the type or the tag is already registered.
+func Register[T any](reg *Registry, tag uint64, encode func(w *Writer, v T) error, decode func(r *Reader) (T, error)) error {
+	if encode == nil {
+		encode = func(w *Writer, v T) error { return w.Encode(v) }
+	}
+	if decode == nil {
+		decode = func(r *Reader) (v T, err error) {
+			err = r.Decode(&v)
+			return
+		}
+	}
+
+	t := typeKeyFor[T]()
+	e := &registration{
+		tag: tag,
+		id:  (*T)(nil),
+		has: func(v any) bool {
+			_, ok := v.(T)
+			return ok
+		},
+		encode: func(w *Writer, v any) error { return encode(w, v.(T)) },
+		decode: func(r *Reader) (any, error) { return decode(r) },
+	}
+
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+
+	for _, other := range reg.byType[t] {
+		if other.id == e.id {
+			return fmt.Errorf("%w: %s", ErrDuplicateRegistration, t)
+		}
+	}
+	if _, ok := reg.byTag[tag]; ok {
+		return fmt.Errorf("%w: tag %d", ErrDuplicateRegistration, tag)
+	}
+
+	if reg.byType == nil {
+		reg.byType = make(map[typeKey][]*registration)
+		reg.byTag = make(map[uint64]*registration)
+	}
+	reg.byType[t] = append(reg.byType[t], e)
+	reg.byTag[tag] = e
+
+	return nil
+}
+
+func (reg *Registry) lookupType(v any) (*registration, error) {
+	reg.mu.RLock()
+	defer reg.mu.RUnlock()
+
+	for _, e := range reg.byType[typeKeyOf(v)] {
+		if e.has(v) {
+			return e, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %T", ErrUnknownType, v)
+}
+
+func (reg *Registry) lookupTag(tag uint64) (*registration, error) {
+	reg.mu.RLock()
+	e, ok := reg.byTag[tag]
+	reg.mu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("%w: %d", ErrUnknownTag, tag)
+	}
+	return e, nil
+}
+
+// Encode writes the tag of the type of v as an LEB128 varint followed by the value.
+func (reg *Registry) Encode(w *Writer, v any) error {
+	e, err := reg.lookupType(v)
+	if err != nil {
+		return err
+	}
+
+	w.WriteUvarint(e.tag)
+
+	return e.encode(w, v)
+}
+
+// Decode reads a value written with Encode. On error the read position is left unchanged.
+func (reg *Registry) Decode(r *Reader) (any, error) {
+	start := r.bitsRead
+
+	tag, err := r.ReadUvarint()
+	if err != nil {
+		return nil, err
+	}
+
+	v, err := reg.DecodeValue(r, tag)
+	if err != nil {
+		r.bitsRead = start
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// EncodeValue writes only the value, without the tag, and returns the tag of its type.
+func (reg *Registry) EncodeValue(w *Writer, v any) (uint64, error) {
+	e, err := reg.lookupType(v)
+	if err != nil {
+		return 0, err
+	}
+	return e.tag, e.encode(w, v)
+}
+
+// DecodeValue reads a value of the type registered with the tag.
+func (reg *Registry) DecodeValue(r *Reader, tag uint64) (any, error) {
+	e, err := reg.lookupTag(tag)
+	if err != nil {
+		return nil, err
+	}
+	return e.decode(r)
+}
@@ -0,0 +1,128 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+//go:build !bitdata_noreflect
+
+package bitdata
+
+import (
+	"errors"
+	"testing"
+)
+
+type registryShape interface {
+	area() int
+}
+
+type registrySquare struct {
+	Side uint8
+}
+
+type registryRect struct {
+	W, H uint8 `bits:"4"`
+}
+
+func (s registrySquare) area() int { return int(s.Side) * int(s.Side) }
+func (r registryRect) area() int   { return int(r.W) * int(r.H) }
+
+func init() {
+	if err := Register[registrySquare](DefaultRegistry, 1, nil, nil); err != nil {
+		panic(err)
+	}
+	if err := Register[registryRect](DefaultRegistry, 2, nil, nil); err != nil {
+		panic(err)
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	reg := &Registry{}
+	_ = Register(reg, 7, func(w *Writer, v uint16) error {
+		w.Write16(v, 12)
+		return nil
+	}, func(r *Reader) (uint16, error) {
+		return r.Read16(12)
+	})
+	_ = Register[registrySquare](reg, 9, nil, nil)
+
+	if err := Register[registryRect](reg, 7, nil, nil); !errors.Is(err, ErrDuplicateRegistration) {
+		t.Errorf("expected ErrDuplicateRegistration, got %v", err)
+	}
+
+	w := NewWriter()
+	_ = reg.Encode(w, uint16(0xABC))
+	_ = reg.Encode(w, registrySquare{Side: 5})
+	if err := reg.Encode(w, "text"); !errors.Is(err, ErrUnknownType) {
+		t.Errorf("expected ErrUnknownType, got %v", err)
+	}
+
+	r := NewReader(w.BitData())
+	if v, err := reg.Decode(r); err != nil || v != uint16(0xABC) {
+		t.Errorf("v=%v err=%v", v, err)
+	}
+	if v, err := reg.Decode(r); err != nil || v != (registrySquare{Side: 5}) {
+		t.Errorf("v=%v err=%v", v, err)
+	}
+
+	r = NewReader(BitData{3})
+	if _, err := reg.Decode(r); !errors.Is(err, ErrUnknownTag) || r.BitsRead() != 0 {
+		t.Errorf("expected ErrUnknownTag, got %v at %d", err, r.BitsRead())
+	}
+}
+
+func TestRegistryTLV(t *testing.T) {
+	reg := &Registry{}
+	_ = Register[registrySquare](reg, 1, nil, nil)
+	_ = Register[registryRect](reg, 2, nil, nil)
+
+	f := TLVFormat{TagBits: 4, LenBits: 8}
+	in := []any{registryRect{W: 3, H: 4}, registrySquare{Side: 7}}
+
+	w := NewWriter()
+	for _, v := range in {
+		if err := w.WriteTLVValue(f, reg, v); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	var out []any
+	it := NewReader(w.BitData()).TLVs(f)
+	for it.Next() {
+		v, err := it.Decode(reg)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		out = append(out, v)
+	}
+
+	if len(out) != len(in) || out[0] != in[0] || out[1] != in[1] {
+		t.Errorf("want=%v got=%v", in, out)
+	}
+}
+
+func TestRegistryCodec(t *testing.T) {
+	type drawing struct {
+		Color  uint8 `bits:"3"`
+		Shapes [2]registryShape
+	}
+
+	in := drawing{Color: 5, Shapes: [2]registryShape{registryRect{W: 2, H: 9}, registrySquare{Side: 4}}}
+
+	d, err := Marshal(in)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var out drawing
+	if err := Unmarshal(d, &out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if in != out {
+		t.Errorf("want=%+v got=%+v", in, out)
+	}
+	if out.Shapes[0].area() != 18 {
+		t.Errorf("area mismatch: %d", out.Shapes[0].area())
+	}
+
+	if _, err := Marshal(drawing{}); !errors.Is(err, ErrUnknownType) {
+		t.Errorf("expected ErrUnknownType, got %v", err)
+	}
+}
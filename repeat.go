@@ -0,0 +1,73 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+// repeatBlockBytes is the minimal size of the byte pattern WriteRepeat builds for its fast path.
+const repeatBlockBytes = 64
+
+// WriteRepeat writes the value v count times, each time with bitCount bits.
+//
+// Long runs are written by copying a precomputed byte pattern instead of writing value by value.
+// While recording or tracing is on, every value is written and logged individually.
+func (w *Writer) WriteRepeat(v uint64, bitCount byte, count int) {
+	if bitCount == 0 || count <= 0 {
+		return
+	}
+
+	if w.recording || w.trace != nil || uint(count)*uint(bitCount) < 4*repeatBlockBytes*8 {
+		for i := 0; i < count; i++ {
+			w.Write64(v, bitCount)
+		}
+		return
+	}
+
+	if w.strict {
+		checkFits(v, bitCount)
+	}
+
+	// The bytes after the first one repeat with a period of lcm(bitCount, 8) bits.
+	ofs := w.bitsWritten % 8
+	period := uint(bitCount) / gcd(uint(bitCount), 8)
+	blockBytes := (repeatBlockBytes + period - 1) / period * period
+
+	pattern := &Writer{order: w.order, alloc: w.alloc}
+	defer pattern.Release()
+
+	pattern.Write8(0, byte(ofs))
+	for pattern.bitsWritten < (1+blockBytes)*8 {
+		write[uint64](pattern, v, bitCount)
+	}
+	head := pattern.BitData()[0]
+	block := pattern.BitData()[1 : 1+blockBytes]
+
+	end := w.bitsWritten + uint(count)*uint(bitCount)
+
+	if ofs > 0 {
+		*w.byteAt(w.bitsWritten / 8) |= head
+	} else {
+		w.appendByte(head)
+	}
+
+	remain := (end+7)/8 - w.size
+	for remain >= blockBytes {
+		w.appendBytes(block)
+		remain -= blockBytes
+	}
+	w.appendBytes(block[:remain])
+
+	if n := end % 8; n > 0 && w.order == MSBFirst {
+		*w.byteAt(end / 8) &^= 0xFF >> n
+	} else if n > 0 {
+		*w.byteAt(end / 8) &= mask[byte](byte(n))
+	}
+
+	w.bitsWritten = end
+	w.checkBudget()
+}
+
+func gcd(a, b uint) uint {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteRepeat(t *testing.T) {
+	tests := []struct {
+		name     string
+		prefix   byte
+		v        uint64
+		bitCount byte
+		count    int
+	}{
+		{name: "short", prefix: 3, v: 0b101, bitCount: 3, count: 10},
+		{name: "aligned-bytes", prefix: 0, v: 0xAB, bitCount: 8, count: 5000},
+		{name: "aligned-odd-width", prefix: 0, v: 0x5A5, bitCount: 11, count: 3001},
+		{name: "unaligned-odd-width", prefix: 5, v: 0x12345, bitCount: 19, count: 2000},
+		{name: "unaligned-even-width", prefix: 1, v: 0b10, bitCount: 2, count: 10007},
+		{name: "single-bits", prefix: 7, v: 1, bitCount: 1, count: 4099},
+		{name: "wide", prefix: 3, v: 0xDEADBEEFCAFEF00D, bitCount: 64, count: 333},
+		{name: "masked", prefix: 2, v: 0xFFFF, bitCount: 5, count: 1000},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			want := NewWriter()
+			want.Write8(0x7F, test.prefix)
+			for i := 0; i < test.count; i++ {
+				want.Write64(test.v, test.bitCount)
+			}
+			want.Write8(0b1101, 4)
+
+			got := NewWriter()
+			got.Write8(0x7F, test.prefix)
+			got.WriteRepeat(test.v, test.bitCount, test.count)
+			got.Write8(0b1101, 4)
+
+			if want.BitsWritten() != got.BitsWritten() {
+				t.Errorf("bits written mismatch: want=%d got=%d", want.BitsWritten(), got.BitsWritten())
+			}
+			if !bytes.Equal(want.BitData(), got.BitData()) {
+				t.Errorf("data mismatch")
+			}
+		})
+	}
+
+	w := NewWriter()
+	w.SetRecording(true)
+	w.WriteRepeat(1, 3, 5000)
+	if len(w.Fields()) != 5000 {
+		t.Errorf("recorded field count mismatch: want=%d got=%d", 5000, len(w.Fields()))
+	}
+}
+
+func BenchmarkWriteRepeat(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		w := NewWriter()
+		w.WriteBool(true)
+		w.WriteRepeat(0b101, 3, 10000)
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"errors"
+	"io"
+)
+
+var ErrMissingMarker = errors.New("resync marker expected")
+
+// Resync markers split a long stream into segments a reader can recover at after corrupted or lost data.
+// A marker is a zero bit, sixteen one bits and a zero bit. The segments are escaped by inserting a zero bit
+// after every fifteen consecutive one bits, so the marker never appears inside them.
+//
+//	for _, seg := range segments {
+//		w.WriteMarked(seg.Data, seg.Bits)
+//	}
+//	w.WriteMarker()
+
+const (
+	// MarkerBits is the length of a resync marker in bits.
+	MarkerBits = 18
+
+	marker    = 0xFFFF << 1
+	markerRun = 15
+)
+
+// WriteMarker writes a resync marker. A marker is needed after the last segment to close it.
+func (w *Writer) WriteMarker() {
+	w.Write32(marker, MarkerBits)
+}
+
+// WriteMarked writes a resync marker followed by the first bitCount bits of the data, escaped.
+// The data bits are taken in the bit order of the writer.
+func (w *Writer) WriteMarked(d BitData, bitCount uint) {
+	if bitCount > uint(len(d))*8 {
+		panic("bitdata: bit range out of bounds")
+	}
+
+	r := NewReader(d)
+	r.order = w.order
+
+	w.WriteMarker()
+	stuffBits(w, r, bitCount, markerRun)
+}
+
+// ReadMarked reads a segment written with WriteMarked, which must start at the current position,
+// and returns its content and its length in bits. The marker closing the segment is left unread,
+// because it opens the next segment. It returns ErrMissingMarker if there's no marker at the current
+// position, io.ErrUnexpectedEOF if the segment isn't closed and ErrInvalidStuffing if it's corrupted.
+// After an error, Resync finds the next segment. On error the read position is left unchanged.
+func (r *Reader) ReadMarked() (BitData, uint, error) {
+	start := r.bitsRead
+
+	if pos, ok := r.Find(marker, MarkerBits); !ok || pos != start {
+		return nil, 0, ErrMissingMarker
+	}
+	r.bitsRead += MarkerBits
+
+	end, ok := r.Find(marker, MarkerBits)
+	if !ok {
+		r.bitsRead = start
+		return nil, 0, io.ErrUnexpectedEOF
+	}
+
+	w := &Writer{order: r.order}
+	if err := unstuffBits(w, r, end-r.bitsRead, markerRun); err != nil {
+		r.bitsRead = start
+		return nil, 0, err
+	}
+
+	return w.BitData(), w.bitsWritten, nil
+}
+
+// Resync moves the reader to the next resync marker at or after the current position and returns
+// the number of bits skipped. It returns io.ErrUnexpectedEOF if there's no marker, leaving the position unchanged.
+func (r *Reader) Resync() (uint, error) {
+	pos, ok := r.Find(marker, MarkerBits)
+	if !ok {
+		return 0, io.ErrUnexpectedEOF
+	}
+
+	skipped := pos - r.bitsRead
+	r.bitsRead = pos
+
+	return skipped, nil
+}
+
+func (r *ReaderError) ReadMarked() (d BitData, n uint) {
+	if r.err == nil {
+		d, n, r.err = r.reader.ReadMarked()
+	}
+	return
+}
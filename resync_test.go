@@ -0,0 +1,73 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestResync(t *testing.T) {
+	segments := []BitData{
+		{0xFF, 0xFF, 0xFF, 0xFF},
+		{0x12, 0x34},
+		{0x7F, 0xFE, 0xFF},
+		{},
+		{0xAA},
+	}
+
+	for _, o := range []BitOrder{LSBFirst, MSBFirst} {
+		w := NewWriter(WithBitOrder(o))
+		for _, seg := range segments {
+			w.WriteMarked(seg, uint(len(seg))*8)
+		}
+		w.WriteMarker()
+
+		r := NewReader(w.BitData(), WithBitOrder(o))
+		for i, want := range segments {
+			got, n, err := r.ReadMarked()
+			if err != nil || n != uint(len(want))*8 || !bytes.Equal(got, want) {
+				t.Errorf("order %d segment %d: got=%x n=%d err=%v", o, i, got, n, err)
+			}
+		}
+		if _, _, err := r.ReadMarked(); !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Errorf("order %d: expected io.ErrUnexpectedEOF, got %v", o, err)
+		}
+	}
+}
+
+func TestResyncCorrupted(t *testing.T) {
+	w := NewWriter()
+	w.WriteMarked(BitData{0x11, 0x22}, 16)
+	w.WriteMarked(BitData{0x33, 0x44}, 16)
+	w.WriteMarked(BitData{0x55, 0x66}, 16)
+	w.WriteMarker()
+
+	// Damage the first marker.
+	d := w.BitData()
+	d[0] ^= 0x20
+
+	r := NewReader(d)
+	if _, _, err := r.ReadMarked(); !errors.Is(err, ErrMissingMarker) || r.BitsRead() != 0 {
+		t.Fatalf("expected ErrMissingMarker at 0, got %v at %d", err, r.BitsRead())
+	}
+
+	skipped, err := r.Resync()
+	if err != nil || skipped != MarkerBits+16 {
+		t.Fatalf("skipped=%d err=%v", skipped, err)
+	}
+
+	for _, want := range []BitData{{0x33, 0x44}, {0x55, 0x66}} {
+		got, n, err := r.ReadMarked()
+		if err != nil || n != 16 || !bytes.Equal(got, want) {
+			t.Errorf("got=%x n=%d err=%v", got, n, err)
+		}
+	}
+
+	r.Skip(1)
+	if _, err := r.Resync(); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("expected io.ErrUnexpectedEOF, got %v", err)
+	}
+}
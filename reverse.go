@@ -0,0 +1,39 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"math/bits"
+)
+
+// ReverseByteBits returns a copy of the data with the bit order reversed within every byte.
+// It converts between this package's LSB-first order and the MSB-first order of other formats.
+func (d BitData) ReverseByteBits() BitData {
+	out := make(BitData, len(d))
+	for i, b := range d {
+		out[i] = bits.Reverse8(b)
+	}
+	return out
+}
+
+// ReverseBits returns a copy of the first bitCount bits of the data in reverse stream order:
+// the last bit becomes the first one. The bits after bitCount in the last byte are zero.
+func (d BitData) ReverseBits(bitCount uint) BitData {
+	n := (bitCount + 7) / 8
+	if n > uint(len(d)) {
+		panic("bitdata: bit range out of bounds")
+	}
+
+	// Reversing the bytes and the bits within each byte reverses the whole padded stream,
+	// which leaves the padding at the start; shifting right by its size drops it.
+	out := make(BitData, n)
+	for i := uint(0); i < n; i++ {
+		out[n-1-i] = bits.Reverse8(d[i])
+	}
+
+	if pad := n*8 - bitCount; pad > 0 {
+		out, _ = out.ShiftRight(n*8, pad)
+	}
+
+	return out
+}
@@ -0,0 +1,44 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestReverseByteBits(t *testing.T) {
+	d := BitData{0b00000001, 0b11010000, 0xFF}
+	want := BitData{0b10000000, 0b00001011, 0xFF}
+
+	if got := d.ReverseByteBits(); !bytes.Equal(want, got) {
+		t.Errorf("want=%x got=%x", want, got)
+	}
+	if got := d.ReverseByteBits().ReverseByteBits(); !bytes.Equal(d, got) {
+		t.Errorf("double reverse mismatch: want=%x got=%x", d, got)
+	}
+}
+
+func TestReverseBits(t *testing.T) {
+	tests := []struct {
+		input string
+		exp   string
+	}{
+		{input: "", exp: ""},
+		{input: "1", exp: "1"},
+		{input: "1000 0000", exp: "0000 0001"},
+		{input: "1101 0", exp: "0101 1"},
+		{input: "1110 0000 0101", exp: "1010 0000 0111"},
+		{input: "1000 0000 0000 0000 0000 1", exp: "1000 0000 0000 0000 0000 1"},
+	}
+
+	for _, test := range tests {
+		d, n, _ := ParseBits(test.input)
+		exp, _, _ := ParseBits(test.exp)
+
+		got := d.ReverseBits(n)
+		if !bytes.Equal(exp, got) {
+			t.Errorf("%q: want=%s got=%s", test.input, test.exp, got.FormatBinary(n, 4))
+		}
+	}
+}
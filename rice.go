@@ -0,0 +1,113 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"io"
+	"math/bits"
+)
+
+// WriteRice writes v as a Golomb-Rice code with the parameter k: the quotient v>>k in unary, as that many
+// zero bits followed by a one bit, and then the lowest k bits of v with the most significant bit first.
+// The code reads the same in both bit orders.
+func (w *Writer) WriteRice(v uint64, k byte) {
+	if k > 64 {
+		panic("bitdata: invalid Rice parameter")
+	}
+
+	var q uint64
+	if k < 64 {
+		q = v >> k
+	}
+	writeZeros(w, uint(q))
+	w.WriteBool(true)
+	w.Write64(msbFirst(v&mask[uint64](k), k, w.order), k)
+}
+
+// WriteSignedRice writes v zigzag encoded as a Golomb-Rice code, the way FLAC codes residuals.
+func (w *Writer) WriteSignedRice(v int64, k byte) {
+	w.WriteRice(zigzag(v), k)
+}
+
+// ReadRice reads a Golomb-Rice code with the parameter k. It returns ErrVarintOverflow
+// if the value doesn't fit into 64 bits. On error the read position is left unchanged.
+func (r *Reader) ReadRice(k byte) (uint64, error) {
+	if k > 64 {
+		return 0, ErrBitCountTooBig
+	}
+
+	start := r.bitsRead
+
+	q, err := readUnary(r)
+	if err == nil && k < 64 && q > mask[uint64](64-k) || k == 64 && q > 0 {
+		err = ErrVarintOverflow
+	}
+	if err != nil {
+		r.bitsRead = start
+		return 0, err
+	}
+
+	rem, err := read[uint64](r, k)
+	if err != nil {
+		r.bitsRead = start
+		return 0, err
+	}
+
+	return q<<k | msbFirst(rem, k, r.order), nil
+}
+
+func (r *Reader) ReadSignedRice(k byte) (int64, error) {
+	v, err := r.ReadRice(k)
+	return unzigzag(v), err
+}
+
+func (r *ReaderError) ReadRice(k byte) (v uint64) {
+	if r.err == nil {
+		v, r.err = r.reader.ReadRice(k)
+	}
+	return
+}
+
+func (r *ReaderError) ReadSignedRice(k byte) (v int64) {
+	if r.err == nil {
+		v, r.err = r.reader.ReadSignedRice(k)
+	}
+	return
+}
+
+// readUnary reads zero bits up to and including the next one bit and returns their count.
+// A code that starts at the end of an optional tail reads as zero, a code cut by the end is an error.
+func readUnary(r *Reader) (uint64, error) {
+	start, ended := r.bitsRead, r.ended
+
+	var q uint64
+	for {
+		if ok, err := r.ensure(1); !ok {
+			if err == nil && r.bitsRead != start {
+				r.ended, err = ended, io.ErrUnexpectedEOF
+			}
+			return 0, err
+		}
+
+		if r.bufPos != r.bitsRead || r.bufBits == 0 {
+			if err := r.fill(); err != nil {
+				return 0, err
+			}
+		}
+
+		var zeros byte
+		if r.order == MSBFirst {
+			zeros = byte(bits.LeadingZeros64(r.buf))
+		} else {
+			zeros = byte(bits.TrailingZeros64(r.buf))
+		}
+
+		if zeros < r.bufBits {
+			read[uint64](r, zeros+1)
+			return q + uint64(zeros), nil
+		}
+
+		q += uint64(r.bufBits)
+		read[uint64](r, r.bufBits)
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"errors"
+	"io"
+	"math"
+	"testing"
+)
+
+func TestRice(t *testing.T) {
+	tests := []struct {
+		v    uint64
+		k    byte
+		code string
+	}{
+		{v: 0, k: 0, code: "1"},
+		{v: 3, k: 0, code: "0001"},
+		{v: 5, k: 2, code: "0101"},
+		{v: 9, k: 3, code: "01001"},
+	}
+
+	for _, test := range tests {
+		for _, o := range []BitOrder{LSBFirst, MSBFirst} {
+			w := &Writer{order: o}
+			w.WriteRice(test.v, test.k)
+
+			r := &Reader{data: w.BitData(), end: w.BitsWritten(), order: o}
+			var code string
+			for r.bitsRead < r.end {
+				if b, _ := r.ReadBool(); b {
+					code += "1"
+				} else {
+					code += "0"
+				}
+			}
+			if code != test.code {
+				t.Errorf("code mismatch for %d,%d: want=%s got=%s", test.v, test.k, test.code, code)
+			}
+
+			r = &Reader{data: w.BitData(), end: w.BitsWritten(), order: o}
+			if v, err := r.ReadRice(test.k); err != nil || v != test.v {
+				t.Errorf("value mismatch: want=%d got=%d err=%v", test.v, v, err)
+			}
+		}
+	}
+}
+
+func TestRiceLong(t *testing.T) {
+	values := []int64{0, -1, 1, 1000, -1000, math.MaxInt64, math.MinInt64}
+
+	w := NewWriter()
+	w.WriteBool(true)
+	for _, v := range values[:5] {
+		w.WriteSignedRice(v, 2)
+	}
+	for _, v := range values[5:] {
+		w.WriteSignedRice(v, 60)
+	}
+
+	r := NewReaderError(w.BitData())
+	r.Skip(1)
+	for _, v := range values[:5] {
+		if got := r.ReadSignedRice(2); got != v {
+			t.Errorf("value mismatch: want=%d got=%d", v, got)
+		}
+	}
+	for _, v := range values[5:] {
+		if got := r.ReadSignedRice(60); got != v {
+			t.Errorf("value mismatch: want=%d got=%d", v, got)
+		}
+	}
+	if err := r.Error(); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
+
+func TestRiceErrors(t *testing.T) {
+	r := NewReader(BitData{0, 0, 0})
+	if _, err := r.ReadRice(3); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("want=%v got=%v", io.ErrUnexpectedEOF, err)
+	}
+
+	w := NewWriter()
+	w.WriteRice(1<<5, 0)
+	r = NewReader(w.BitData())
+	if _, err := r.ReadRice(60); !errors.Is(err, ErrVarintOverflow) {
+		t.Errorf("want=%v got=%v", ErrVarintOverflow, err)
+	}
+	if r.BitsRead() != 0 {
+		t.Errorf("read position changed: %d", r.BitsRead())
+	}
+}
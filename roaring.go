@@ -0,0 +1,429 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"errors"
+	"math/bits"
+	"slices"
+)
+
+var ErrInvalidRoaring = errors.New("invalid compressed bitmap")
+
+// Roaring is a compressed bitmap of uint32 values. The values are split by their upper 16 bits
+// into containers, each of which is stored as a sorted array, a plain bitmap or a list of runs,
+// whichever fits its content. The zero value is an empty bitmap.
+type Roaring struct {
+	keys       []uint16
+	containers []*roaringContainer
+}
+
+const (
+	roaringArray byte = iota
+	roaringBitmap
+	roaringRuns
+)
+
+const (
+	roaringArrayMax = 4096
+	roaringWords    = 1 << 16 / 64
+)
+
+type roaringContainer struct {
+	kind   byte
+	array  []uint16
+	bitmap []uint64
+	runs   []roaringRun
+}
+
+// roaringRun is a run of consecutive values from start to last, inclusive.
+type roaringRun struct {
+	start, last uint16
+}
+
+// NewRoaring returns a compressed bitmap with the values.
+func NewRoaring(values ...uint32) *Roaring {
+	b := &Roaring{}
+	for _, v := range values {
+		b.Add(v)
+	}
+	return b
+}
+
+// Add adds the value to the bitmap.
+func (b *Roaring) Add(v uint32) {
+	hi := uint16(v >> 16)
+	i, ok := slices.BinarySearch(b.keys, hi)
+	if !ok {
+		b.keys = slices.Insert(b.keys, i, hi)
+		b.containers = slices.Insert(b.containers, i, &roaringContainer{kind: roaringArray})
+	}
+	b.containers[i].add(uint16(v))
+}
+
+// Contains reports whether the value is in the bitmap.
+func (b *Roaring) Contains(v uint32) bool {
+	i, ok := slices.BinarySearch(b.keys, uint16(v>>16))
+	return ok && b.containers[i].contains(uint16(v))
+}
+
+// Cardinality returns the number of values in the bitmap.
+func (b *Roaring) Cardinality() int {
+	n := 0
+	for _, c := range b.containers {
+		n += c.cardinality()
+	}
+	return n
+}
+
+// Values returns the values of the bitmap in ascending order.
+func (b *Roaring) Values() []uint32 {
+	values := make([]uint32, 0, b.Cardinality())
+	for i, c := range b.containers {
+		hi := uint32(b.keys[i]) << 16
+		for j, word := range c.words() {
+			for word != 0 {
+				values = append(values, hi|uint32(j*64+bits.TrailingZeros64(word)))
+				word &= word - 1
+			}
+		}
+	}
+	return values
+}
+
+// And returns a new bitmap with the values present in both bitmaps.
+func (b *Roaring) And(o *Roaring) *Roaring {
+	res := &Roaring{}
+	for i, j := 0, 0; i < len(b.keys) && j < len(o.keys); {
+		switch {
+		case b.keys[i] < o.keys[j]:
+			i++
+		case b.keys[i] > o.keys[j]:
+			j++
+		default:
+			x, y := b.containers[i].words(), o.containers[j].words()
+			for k := range x {
+				x[k] &= y[k]
+			}
+			if c := roaringFromWords(x); c != nil {
+				res.keys = append(res.keys, b.keys[i])
+				res.containers = append(res.containers, c)
+			}
+			i++
+			j++
+		}
+	}
+	return res
+}
+
+// Or returns a new bitmap with the values present in either bitmap.
+func (b *Roaring) Or(o *Roaring) *Roaring {
+	res := &Roaring{}
+	for i, j := 0, 0; i < len(b.keys) || j < len(o.keys); {
+		switch {
+		case j == len(o.keys) || i < len(b.keys) && b.keys[i] < o.keys[j]:
+			res.keys = append(res.keys, b.keys[i])
+			res.containers = append(res.containers, b.containers[i].clone())
+			i++
+		case i == len(b.keys) || b.keys[i] > o.keys[j]:
+			res.keys = append(res.keys, o.keys[j])
+			res.containers = append(res.containers, o.containers[j].clone())
+			j++
+		default:
+			x, y := b.containers[i].words(), o.containers[j].words()
+			for k := range x {
+				x[k] |= y[k]
+			}
+			res.keys = append(res.keys, b.keys[i])
+			res.containers = append(res.containers, roaringFromWords(x))
+			i++
+			j++
+		}
+	}
+	return res
+}
+
+// RunOptimize converts the containers that are smaller as lists of runs to that form.
+func (b *Roaring) RunOptimize() {
+	for _, c := range b.containers {
+		if c.kind == roaringRuns {
+			continue
+		}
+
+		runs := roaringRunsOf(c.words())
+		if len(runs)*4 < c.size() {
+			*c = roaringContainer{kind: roaringRuns, runs: runs}
+		}
+	}
+}
+
+func (c *roaringContainer) add(lo uint16) {
+	switch c.kind {
+	case roaringArray:
+		i, ok := slices.BinarySearch(c.array, lo)
+		if ok {
+			return
+		}
+		if len(c.array) < roaringArrayMax {
+			c.array = slices.Insert(c.array, i, lo)
+			return
+		}
+		*c = roaringContainer{kind: roaringBitmap, bitmap: c.words()}
+	case roaringRuns:
+		if c.contains(lo) {
+			return
+		}
+		*c = *roaringFromWords(c.words())
+		c.add(lo)
+		return
+	}
+
+	c.bitmap[lo/64] |= 1 << (lo % 64)
+}
+
+func (c *roaringContainer) contains(lo uint16) bool {
+	switch c.kind {
+	case roaringArray:
+		_, ok := slices.BinarySearch(c.array, lo)
+		return ok
+	case roaringBitmap:
+		return c.bitmap[lo/64]>>(lo%64)&1 == 1
+	}
+
+	i, _ := slices.BinarySearchFunc(c.runs, lo, func(r roaringRun, lo uint16) int {
+		if r.last < lo {
+			return -1
+		}
+		return 1
+	})
+	return i < len(c.runs) && c.runs[i].start <= lo
+}
+
+func (c *roaringContainer) cardinality() int {
+	switch c.kind {
+	case roaringArray:
+		return len(c.array)
+	case roaringBitmap:
+		n := 0
+		for _, w := range c.bitmap {
+			n += bits.OnesCount64(w)
+		}
+		return n
+	}
+
+	n := 0
+	for _, r := range c.runs {
+		n += int(r.last-r.start) + 1
+	}
+	return n
+}
+
+// size returns the size of the container content in bytes.
+func (c *roaringContainer) size() int {
+	switch c.kind {
+	case roaringArray:
+		return 2 * len(c.array)
+	case roaringBitmap:
+		return 8 * roaringWords
+	}
+	return 4 * len(c.runs)
+}
+
+// words returns the content of the container as a new plain bitmap.
+func (c *roaringContainer) words() []uint64 {
+	if c.kind == roaringBitmap {
+		return slices.Clone(c.bitmap)
+	}
+
+	words := make([]uint64, roaringWords)
+	for _, v := range c.array {
+		words[v/64] |= 1 << (v % 64)
+	}
+	for _, r := range c.runs {
+		for v := uint(r.start); v <= uint(r.last); v++ {
+			words[v/64] |= 1 << (v % 64)
+		}
+	}
+	return words
+}
+
+func (c *roaringContainer) clone() *roaringContainer {
+	return &roaringContainer{
+		kind:   c.kind,
+		array:  slices.Clone(c.array),
+		bitmap: slices.Clone(c.bitmap),
+		runs:   slices.Clone(c.runs),
+	}
+}
+
+// roaringFromWords returns an array or a bitmap container with the values of the plain bitmap,
+// or nil if there are none.
+func roaringFromWords(words []uint64) *roaringContainer {
+	n := 0
+	for _, w := range words {
+		n += bits.OnesCount64(w)
+	}
+
+	switch {
+	case n == 0:
+		return nil
+	case n > roaringArrayMax:
+		return &roaringContainer{kind: roaringBitmap, bitmap: words}
+	}
+
+	array := make([]uint16, 0, n)
+	for i, w := range words {
+		for w != 0 {
+			array = append(array, uint16(i*64+bits.TrailingZeros64(w)))
+			w &= w - 1
+		}
+	}
+	return &roaringContainer{kind: roaringArray, array: array}
+}
+
+// roaringRunsOf returns the runs of one bits of the plain bitmap.
+func roaringRunsOf(words []uint64) []roaringRun {
+	var runs []roaringRun
+	inRun := false
+	for v := 0; v < 1<<16; v++ {
+		set := words[v/64]>>(v%64)&1 == 1
+		if set && !inRun {
+			runs = append(runs, roaringRun{start: uint16(v)})
+		}
+		if !set && inRun {
+			runs[len(runs)-1].last = uint16(v - 1)
+		}
+		inRun = set
+	}
+	if inRun {
+		runs[len(runs)-1].last = 1<<16 - 1
+	}
+	return runs
+}
+
+// WriteRoaring writes the compressed bitmap: the number of containers as an LEB128 varint and then
+// for every container its key in 16 bits, its kind in 2 bits and its content. An array is written
+// as the varint count and the values in 16 bits, a bitmap as 1024 64-bit words, and runs as
+// the varint count and the first and the last value of every run in 16 bits.
+func (w *Writer) WriteRoaring(b *Roaring) {
+	w.WriteUvarint(uint64(len(b.keys)))
+	for i, c := range b.containers {
+		w.Write16(b.keys[i], 16)
+		w.Write8(c.kind, 2)
+
+		switch c.kind {
+		case roaringArray:
+			w.WriteUvarint(uint64(len(c.array)))
+			for _, v := range c.array {
+				w.Write16(v, 16)
+			}
+		case roaringBitmap:
+			for _, word := range c.bitmap {
+				w.Write64(word, 64)
+			}
+		case roaringRuns:
+			w.WriteUvarint(uint64(len(c.runs)))
+			for _, r := range c.runs {
+				w.Write16(r.start, 16)
+				w.Write16(r.last, 16)
+			}
+		}
+	}
+}
+
+// ReadRoaring reads a compressed bitmap written with WriteRoaring. It returns ErrInvalidRoaring if
+// the containers or their values aren't in ascending order. On error the read position is left unchanged.
+func (r *Reader) ReadRoaring() (*Roaring, error) {
+	start := r.bitsRead
+
+	rr := ReaderError{reader: *r}
+	b, err := readRoaring(&rr)
+	if rr.err != nil {
+		err = rr.err
+	}
+	if err != nil {
+		r.bitsRead = start
+		return nil, err
+	}
+
+	*r = rr.reader
+
+	return b, nil
+}
+
+func readRoaring(r *ReaderError) (*Roaring, error) {
+	n := r.ReadUvarint()
+	if n > uint64(r.reader.end-r.reader.bitsRead)/18 {
+		return nil, ErrInvalidRoaring
+	}
+	if err := r.reader.charge(n, byteSize[uint16]()+byteSize[*roaringContainer]()+byteSize[roaringContainer]()); err != nil {
+		return nil, err
+	}
+
+	b := &Roaring{
+		keys:       make([]uint16, 0, n),
+		containers: make([]*roaringContainer, 0, n),
+	}
+	for i := uint64(0); i < n && r.err == nil; i++ {
+		key := r.Read16(16)
+		if i > 0 && key <= b.keys[i-1] {
+			return nil, ErrInvalidRoaring
+		}
+
+		c := &roaringContainer{kind: r.Read8(2)}
+		switch c.kind {
+		case roaringArray:
+			count := r.ReadUvarint()
+			if count == 0 || count > roaringArrayMax {
+				return nil, ErrInvalidRoaring
+			}
+			if err := r.reader.charge(count, byteSize[uint16]()); err != nil {
+				return nil, err
+			}
+			c.array = make([]uint16, count)
+			for j := range c.array {
+				c.array[j] = r.Read16(16)
+				if j > 0 && c.array[j] <= c.array[j-1] {
+					return nil, ErrInvalidRoaring
+				}
+			}
+		case roaringBitmap:
+			if err := r.reader.charge(roaringWords, byteSize[uint64]()); err != nil {
+				return nil, err
+			}
+			c.bitmap = make([]uint64, roaringWords)
+			for j := range c.bitmap {
+				c.bitmap[j] = r.Read64(64)
+			}
+		case roaringRuns:
+			count := r.ReadUvarint()
+			if count == 0 || count > 1<<15 {
+				return nil, ErrInvalidRoaring
+			}
+			if err := r.reader.charge(count, byteSize[roaringRun]()); err != nil {
+				return nil, err
+			}
+			c.runs = make([]roaringRun, count)
+			for j := range c.runs {
+				c.runs[j] = roaringRun{start: r.Read16(16), last: r.Read16(16)}
+				if c.runs[j].last < c.runs[j].start || j > 0 && uint32(c.runs[j].start) <= uint32(c.runs[j-1].last)+1 {
+					return nil, ErrInvalidRoaring
+				}
+			}
+		default:
+			return nil, ErrInvalidRoaring
+		}
+
+		b.keys = append(b.keys, key)
+		b.containers = append(b.containers, c)
+	}
+
+	return b, nil
+}
+
+func (r *ReaderError) ReadRoaring() (b *Roaring) {
+	if r.err == nil {
+		b, r.err = r.reader.ReadRoaring()
+	}
+	return
+}
@@ -0,0 +1,159 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"errors"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// roaringSample returns a bitmap with sparse, dense and run-heavy containers and the set of its values.
+func roaringSample(rnd *rand.Rand) (*Roaring, map[uint32]bool) {
+	b := &Roaring{}
+	set := map[uint32]bool{}
+	add := func(v uint32) {
+		b.Add(v)
+		set[v] = true
+	}
+
+	for i := 0; i < 100; i++ {
+		add(rnd.Uint32())
+	}
+	for i := 0; i < 10000; i++ {
+		add(0x30000 | uint32(rnd.Intn(1<<16)))
+	}
+	for v := uint32(0x50000); v < 0x58000; v++ {
+		add(v)
+	}
+
+	return b, set
+}
+
+func TestRoaring(t *testing.T) {
+	rnd := rand.New(rand.NewSource(42))
+	b, set := roaringSample(rnd)
+
+	if b.Cardinality() != len(set) {
+		t.Errorf("cardinality: want=%d got=%d", len(set), b.Cardinality())
+	}
+
+	for v := range set {
+		if !b.Contains(v) {
+			t.Errorf("missing value %d", v)
+		}
+	}
+	for i := 0; i < 1000; i++ {
+		v := rnd.Uint32()
+		if b.Contains(v) != set[v] {
+			t.Errorf("contains mismatch for %d", v)
+		}
+	}
+
+	values := b.Values()
+	if len(values) != len(set) || !slices.IsSorted(values) {
+		t.Errorf("values aren't sorted or complete")
+	}
+
+	b.RunOptimize()
+	if i, _ := slices.BinarySearch(b.keys, 5); b.containers[i].kind != roaringRuns {
+		t.Errorf("the run container wasn't optimized")
+	}
+	if !slices.Equal(b.Values(), values) {
+		t.Errorf("values changed by RunOptimize")
+	}
+
+	b.Add(0x58000)
+	b.Add(0x5FFFF)
+	if !b.Contains(0x58000) || !b.Contains(0x57FFF) || !b.Contains(0x5FFFF) || b.Contains(0x58001) {
+		t.Errorf("add to a run container failed")
+	}
+}
+
+func TestRoaringAndOr(t *testing.T) {
+	rnd := rand.New(rand.NewSource(7))
+	a, setA := roaringSample(rnd)
+	b, setB := roaringSample(rnd)
+	b.RunOptimize()
+
+	and, or := a.And(b), a.Or(b)
+
+	var wantAnd, wantOr []uint32
+	for v := range setA {
+		wantOr = append(wantOr, v)
+		if setB[v] {
+			wantAnd = append(wantAnd, v)
+		}
+	}
+	for v := range setB {
+		if !setA[v] {
+			wantOr = append(wantOr, v)
+		}
+	}
+	slices.Sort(wantAnd)
+	slices.Sort(wantOr)
+
+	if !slices.Equal(and.Values(), wantAnd) {
+		t.Errorf("And mismatch: want %d values, got %d", len(wantAnd), and.Cardinality())
+	}
+	if !slices.Equal(or.Values(), wantOr) {
+		t.Errorf("Or mismatch: want %d values, got %d", len(wantOr), or.Cardinality())
+	}
+
+	or.Add(1)
+	if a.Contains(1) != setA[1] || b.Contains(1) != setB[1] {
+		t.Errorf("Or result shares containers with its operands")
+	}
+}
+
+func TestRoaringSerialization(t *testing.T) {
+	rnd := rand.New(rand.NewSource(42))
+	b, _ := roaringSample(rnd)
+	b.RunOptimize()
+
+	for _, o := range []BitOrder{LSBFirst, MSBFirst} {
+		w := &Writer{order: o}
+		w.Write8(5, 3)
+		w.WriteRoaring(b)
+		w.WriteRoaring(&Roaring{})
+
+		r := &Reader{data: w.BitData(), end: w.BitsWritten(), order: o}
+		r.Skip(3)
+
+		got, err := r.ReadRoaring()
+		if err != nil {
+			t.Fatalf("order %d: read failed: %v", o, err)
+		}
+		if !slices.Equal(got.Values(), b.Values()) {
+			t.Errorf("order %d: values mismatch", o)
+		}
+
+		if empty, err := r.ReadRoaring(); err != nil || empty.Cardinality() != 0 {
+			t.Errorf("order %d: empty bitmap: %v", o, err)
+		}
+	}
+}
+
+func TestRoaringInvalid(t *testing.T) {
+	w := NewWriter()
+	w.WriteUvarint(1)
+	w.Write16(0, 16)
+	w.Write8(roaringArray, 2)
+	w.WriteUvarint(2)
+	w.Write16(7, 16)
+	w.Write16(3, 16)
+
+	r := NewReader(w.BitData())
+	if _, err := r.ReadRoaring(); !errors.Is(err, ErrInvalidRoaring) {
+		t.Errorf("expected ErrInvalidRoaring, got %v", err)
+	}
+	if r.BitsRead() != 0 {
+		t.Errorf("position changed on error")
+	}
+
+	r = NewReader(w.BitData()[:3])
+	if _, err := r.ReadRoaring(); err == nil {
+		t.Errorf("expected an error for truncated data")
+	}
+}
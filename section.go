@@ -0,0 +1,78 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+)
+
+// Section holds the number of bits written inside all sections with the same name.
+// Names of nested sections are joined with a slash, for example "header/flags".
+type Section struct {
+	Name  string
+	Bits  uint
+	Count int
+}
+
+type openSection struct {
+	name  string
+	start uint
+}
+
+// BeginSection opens a named section. Sections can be nested and must be closed with EndSection.
+func (w *Writer) BeginSection(name string) {
+	if n := len(w.openSections); n > 0 {
+		name = w.openSections[n-1].name + "/" + name
+	}
+	w.openSections = append(w.openSections, openSection{name: name, start: w.bitsWritten})
+}
+
+// EndSection closes the most recently opened section and adds its size to the section statistics.
+func (w *Writer) EndSection() {
+	n := len(w.openSections)
+	if n == 0 {
+		panic("bitdata: EndSection called without BeginSection")
+	}
+
+	s := w.openSections[n-1]
+	w.openSections = w.openSections[:n-1]
+
+	bits := w.bitsWritten - s.start
+	for i := range w.sections {
+		if w.sections[i].Name == s.name {
+			w.sections[i].Bits += bits
+			w.sections[i].Count++
+			return
+		}
+	}
+
+	w.sections = append(w.sections, Section{Name: s.name, Bits: bits, Count: 1})
+}
+
+// Sections returns the statistics of all closed sections in the order they were first closed,
+// so a nested section comes before the section that contains it.
+func (w *Writer) Sections() []Section {
+	return w.sections
+}
+
+// SectionReport returns a human-readable table of the section statistics
+// with each section's share of the total number of bits written.
+func (w *Writer) SectionReport() string {
+	var sb strings.Builder
+
+	tw := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', tabwriter.AlignRight)
+	_, _ = fmt.Fprintln(tw, "bits\tcount\tshare\t section")
+	for _, s := range w.sections {
+		var share float64
+		if w.bitsWritten > 0 {
+			share = 100 * float64(s.Bits) / float64(w.bitsWritten)
+		}
+		_, _ = fmt.Fprintf(tw, "%d\t%d\t%.1f%%\t %s\n", s.Bits, s.Count, share, s.Name)
+	}
+	_, _ = fmt.Fprintf(tw, "%d\t\t\t total\n", w.bitsWritten)
+	_ = tw.Flush()
+
+	return sb.String()
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSections(t *testing.T) {
+	w := NewWriter()
+
+	w.BeginSection("header")
+	w.Write8(1, 4)
+	w.BeginSection("flags")
+	w.WriteBool(true)
+	w.WriteBool(false)
+	w.EndSection()
+	w.EndSection()
+
+	for i := 0; i < 3; i++ {
+		w.BeginSection("item")
+		w.Write16(uint16(i), 10)
+		w.EndSection()
+	}
+
+	w.Write8(0, 2) // outside any section
+
+	want := []Section{
+		{Name: "header/flags", Bits: 2, Count: 1},
+		{Name: "header", Bits: 6, Count: 1},
+		{Name: "item", Bits: 30, Count: 3},
+	}
+
+	got := w.Sections()
+	if len(got) != len(want) {
+		t.Errorf("section count mismatch: want=%d got=%d", len(want), len(got))
+		return
+	}
+
+	for i := range want {
+		if want[i] != got[i] {
+			t.Errorf("section %d mismatch: want=%+v got=%+v", i, want[i], got[i])
+		}
+	}
+
+	report := w.SectionReport()
+	if !strings.Contains(report, "78.9%") || !strings.Contains(report, "38") {
+		t.Errorf("unexpected report:\n%s", report)
+	}
+}
+
+func TestSectionsUnbalanced(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic")
+		}
+	}()
+
+	w := NewWriter()
+	w.EndSection()
+}
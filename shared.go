@@ -0,0 +1,130 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+// Shared is read-only bit data that any number of readers can use without copying it, for example
+// a decoded payload handed to several consumers. It has no methods that modify the data, and it never
+// exposes the underlying bytes, so they don't change while it's in use and it can be used concurrently.
+// A writer derived from it with Writer appends to the data without copying it either; only the bytes
+// that the writer modifies in place are copied first.
+type Shared struct {
+	data  BitData
+	bits  uint
+	order BitOrder
+}
+
+// Freeze returns the first bitCount bits of d, in the bit order of the options, as Shared.
+// It doesn't copy d, so the caller must not keep a reference to d that is used to modify it;
+// freeze a copy, such as slices.Clone(d), if the data may still change. It panics if d is shorter
+// than bitCount bits.
+func Freeze(d BitData, bitCount uint, opts ...Option) Shared {
+	if bitCount > uint(len(d))*8 {
+		panic("bitdata: bit range out of bounds")
+	}
+	c := newConfig(opts)
+	return Shared{data: d[:(bitCount+7)/8], bits: bitCount, order: c.order}
+}
+
+// Freeze returns the data written so far as Shared. The writer can still be used: its further writes
+// don't change the shared data.
+func (w *Writer) Freeze() Shared {
+	d := w.assemble()
+	s := Shared{data: d, bits: w.bitsWritten - w.base*8, order: w.order}
+	w.share(d)
+	return s
+}
+
+// Len returns the length of the data in bits.
+func (s Shared) Len() uint {
+	return s.bits
+}
+
+// AppendBitData appends a copy of the data to dst and returns the extended slice.
+func (s Shared) AppendBitData(dst BitData) BitData {
+	return append(dst, s.data...)
+}
+
+// Extract copies lengthBits bits starting at the bit offset offsetBits into a new BitData.
+// It panics if the range is out of bounds.
+func (s Shared) Extract(offsetBits, lengthBits uint) BitData {
+	if offsetBits+lengthBits > s.bits {
+		panic("bitdata: bit range out of bounds")
+	}
+	return s.data.Extract(offsetBits, lengthBits)
+}
+
+// Find returns the bit offset of the first occurrence of the pattern at or after the bit offset fromBit,
+// as BitData.Find does.
+func (s Shared) Find(pattern uint64, patternBits byte, fromBit uint) (uint, bool) {
+	return findBits(&Reader{data: s.data, bitsRead: fromBit, end: s.bits, order: s.order}, pattern, patternBits)
+}
+
+// OnesCount returns the number of set bits in the data.
+func (s Shared) OnesCount() int {
+	return s.data.OnesCount(0, s.bits)
+}
+
+// Equal reports whether both hold the same bits in the same bit order.
+func (s Shared) Equal(o Shared) bool {
+	if s.bits != o.bits || s.order != o.order {
+		return false
+	}
+
+	a, b := s.Reader(), o.Reader()
+	for n := s.bits; n > 0; {
+		k := byte(min(n, 64))
+		x, _ := a.Read64(k)
+		y, _ := b.Read64(k)
+		if x != y {
+			return false
+		}
+		n -= uint(k)
+	}
+
+	return true
+}
+
+// Reader returns a new reader of the data.
+func (s Shared) Reader() *Reader {
+	return NewReaderBits(s.data, s.bits, WithBitOrder(s.order))
+}
+
+// Writer returns a new writer positioned after the data, which continues it.
+func (s Shared) Writer() *Writer {
+	w := &Writer{bitsWritten: s.bits, size: uint(len(s.data)), order: s.order}
+	w.share(s.data)
+	return w
+}
+
+// share makes the writer use d, which holds all of its bits, without modifying it. The whole bytes are kept
+// in place and copied by unshare before they are modified; the partial last byte is moved to a new chunk.
+func (w *Writer) share(d BitData) {
+	full := w.bitsWritten/8 - w.base
+	tail := w.newChunk(64)
+	if n := byte(w.bitsWritten % 8); n > 0 {
+		b := d[full]
+		if w.order == MSBFirst {
+			b &^= 0xFF >> n
+		} else {
+			b &= mask[byte](n)
+		}
+		tail = append(tail, b)
+	}
+
+	w.chunks, w.shared = [][]byte{tail}, nil
+	if full > 0 {
+		w.shared = d[:full:full]
+		w.chunks = [][]byte{w.shared, tail}
+	}
+}
+
+// unshare replaces the shared chunk with a copy the writer owns.
+func (w *Writer) unshare() {
+	w.chunks[0] = append(w.newChunk(len(w.chunks[0])), w.chunks[0]...)
+	w.shared = nil
+}
+
+// isShared reports whether c is the shared chunk, which must not be modified or given to the allocator.
+func (w *Writer) isShared(c []byte) bool {
+	return w.shared != nil && cap(c) > 0 && &c[:1][0] == &w.shared[:1][0]
+}
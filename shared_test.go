@@ -0,0 +1,153 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+)
+
+func TestShared(t *testing.T) {
+	for _, order := range []BitOrder{LSBFirst, MSBFirst} {
+		ref := NewWriter(WithBitOrder(order))
+		w := NewWriter(WithBitOrder(order))
+		for i := range 100 {
+			ref.Write16(uint16(i*37), 11)
+			w.Write16(uint16(i*37), 11)
+		}
+
+		s := w.Freeze()
+		frozen := s.AppendBitData(nil)
+		if s.Len() != 1100 {
+			t.Errorf("expected 1100 bits, got %d", s.Len())
+		}
+
+		a, b := s.Writer(), s.Writer()
+		for i := range 50 {
+			ref.Write8(uint8(i), 7)
+			w.Write8(uint8(i), 7)
+			a.Write8(uint8(i), 7)
+			b.Write32(uint32(i), 23)
+		}
+
+		if !bytes.Equal(w.BitData(), ref.BitData()) {
+			t.Errorf("%v: writer data differs after Freeze", order)
+		}
+		if !bytes.Equal(a.BitData(), ref.BitData()) {
+			t.Errorf("%v: derived writer data differs", order)
+		}
+		if b.BitsWritten() != 1100+50*23 {
+			t.Errorf("%v: unexpected bit count %d", order, b.BitsWritten())
+		}
+		if !bytes.Equal(s.AppendBitData(nil), frozen) {
+			t.Errorf("%v: shared data modified", order)
+		}
+
+		r := s.Reader()
+		for i := range 100 {
+			if v, err := r.Read16(11); err != nil || v != uint16(i*37)&mask[uint16](11) {
+				t.Fatalf("%v: read %d: got %d, %v", order, i, v, err)
+			}
+		}
+		if _, err := r.Read8(1); err == nil {
+			t.Errorf("%v: expected the reader to end with the shared data", order)
+		}
+	}
+}
+
+func TestSharedCopyOnWrite(t *testing.T) {
+	d := BitData{0xFF, 0xFF, 0xFF}
+	s := Freeze(d, 20)
+
+	w := s.Writer()
+	w.putBits(0, 0, 8)
+	w.Write8(1, 4)
+
+	if got := w.BitData(); !bytes.Equal(got, BitData{0x00, 0xFF, 0x1F}) {
+		t.Errorf("unexpected writer data %x", got)
+	}
+	if !bytes.Equal(d, BitData{0xFF, 0xFF, 0xFF}) {
+		t.Errorf("shared data modified: %x", d)
+	}
+}
+
+func TestSharedAllocator(t *testing.T) {
+	a := &countingAllocator{}
+	w := NewWriter(WithAllocator(a))
+	for i := range 1000 {
+		w.Write64(uint64(i), 64)
+	}
+
+	s := w.Freeze()
+	w.Write8(1, 8)
+	w.Release()
+
+	if len(a.live) != 1 {
+		t.Errorf("expected the shared buffer to stay allocated, got %d live buffers", len(a.live))
+	}
+	if v, err := s.Reader().Read64(64); err != nil || v != 0 {
+		t.Errorf("unexpected first value %d, %v", v, err)
+	}
+}
+
+func TestSharedReadOnly(t *testing.T) {
+	w := NewWriter()
+	w.Write16(0b1011_0000_0110, 12)
+	s := w.Freeze()
+
+	if got := s.OnesCount(); got != 5 {
+		t.Errorf("expected 5 ones, got %d", got)
+	}
+	if pos, ok := s.Find(0b11, 2, 0); !ok || pos != 1 {
+		t.Errorf("expected a match at 1, got %d, %v", pos, ok)
+	}
+	if _, ok := s.Find(0b1111, 4, 0); ok {
+		t.Errorf("unexpected match")
+	}
+	if got := s.Extract(8, 4); !bytes.Equal(got, BitData{0b1011}) {
+		t.Errorf("unexpected extract %b", got)
+	}
+
+	o := Freeze(BitData{0b0000_0110, 0xFB}, 12)
+	if !s.Equal(o) {
+		t.Errorf("expected equal data")
+	}
+	if s.Equal(Freeze(BitData{0b0000_0110, 0xFB}, 13)) || s.Equal(Freeze(BitData{0b0000_0111, 0xFB}, 12)) {
+		t.Errorf("expected different data")
+	}
+}
+
+func TestSharedConcurrent(t *testing.T) {
+	w := NewWriter()
+	for i := range 1000 {
+		w.Write16(uint16(i), 13)
+	}
+	s := w.Freeze()
+	want := s.AppendBitData(nil)
+
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r := s.Reader()
+			for i := range 1000 {
+				if v, err := r.Read16(13); err != nil || v != uint16(i) {
+					t.Errorf("read %d: got %d, %v", i, v, err)
+					return
+				}
+			}
+		}()
+	}
+
+	for i := range 1000 {
+		w.putBits(uint(i)*13, 0, 13)
+		w.Write16(uint16(i), 13)
+	}
+	wg.Wait()
+
+	if !bytes.Equal(s.AppendBitData(nil), want) {
+		t.Errorf("shared data modified")
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+// The shift and rotate functions treat the first bitCount bits of the data as a little-endian number,
+// matching the bit order of Writer: shifting left moves the bits to later stream positions.
+
+// ShiftLeft returns a copy of the first bitCount bits of the data with n zero bits inserted
+// at the start, and the new bit length.
+func (d BitData) ShiftLeft(bitCount, n uint) (BitData, uint) {
+	w := NewWriter()
+	writeZeros(w, n)
+	writeBits(w, d, 0, bitCount)
+	return w.BitData(), w.bitsWritten
+}
+
+// ShiftRight returns a copy of the first bitCount bits of the data without the first n bits,
+// and the new bit length.
+func (d BitData) ShiftRight(bitCount, n uint) (BitData, uint) {
+	if n >= bitCount {
+		return BitData{}, 0
+	}
+	return d.Extract(n, bitCount-n), bitCount - n
+}
+
+// RotateLeft returns a copy of the first bitCount bits of the data rotated left by n bits:
+// the last n bits move to the start. To rotate right by n bits, rotate left by bitCount-n bits.
+func (d BitData) RotateLeft(bitCount, n uint) BitData {
+	if bitCount == 0 {
+		return BitData{}
+	}
+	n %= bitCount
+
+	w := NewWriter()
+	writeBits(w, d, bitCount-n, n)
+	writeBits(w, d, 0, bitCount-n)
+	return w.BitData()
+}
+
+// RotateRight returns a copy of the first bitCount bits of the data rotated right by n bits:
+// the first n bits move to the end.
+func (d BitData) RotateRight(bitCount, n uint) BitData {
+	if bitCount == 0 {
+		return BitData{}
+	}
+	return d.RotateLeft(bitCount, bitCount-n%bitCount)
+}
@@ -0,0 +1,42 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestShift(t *testing.T) {
+	d, n, _ := ParseBits("1011 0011 1")
+
+	tests := []struct {
+		name    string
+		fn      func() (BitData, uint)
+		exp     string
+		expBits uint
+	}{
+		{name: "left", fn: func() (BitData, uint) { return d.ShiftLeft(n, 3) }, exp: "0001 0110 0111", expBits: 12},
+		{name: "left-zero", fn: func() (BitData, uint) { return d.ShiftLeft(n, 0) }, exp: "1011 0011 1", expBits: 9},
+		{name: "left-long", fn: func() (BitData, uint) { return d.ShiftLeft(2, 70) }, exp: "00000000 00000000 00000000 00000000 00000000 00000000 00000000 00000000 000000 10", expBits: 72},
+		{name: "right", fn: func() (BitData, uint) { return d.ShiftRight(n, 3) }, exp: "1001 11", expBits: 6},
+		{name: "right-all", fn: func() (BitData, uint) { return d.ShiftRight(n, 9) }, exp: "", expBits: 0},
+		{name: "right-more", fn: func() (BitData, uint) { return d.ShiftRight(n, 20) }, exp: "", expBits: 0},
+		{name: "rotate-left", fn: func() (BitData, uint) { return d.RotateLeft(n, 2), n }, exp: "1110 1100 1", expBits: 9},
+		{name: "rotate-left-wrap", fn: func() (BitData, uint) { return d.RotateLeft(n, 11), n }, exp: "1110 1100 1", expBits: 9},
+		{name: "rotate-right", fn: func() (BitData, uint) { return d.RotateRight(n, 2), n }, exp: "1100 1111 0", expBits: 9},
+		{name: "rotate-zero", fn: func() (BitData, uint) { return d.RotateRight(n, 0), n }, exp: "1011 0011 1", expBits: 9},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, bits := test.fn()
+			if bits != test.expBits {
+				t.Errorf("bit count mismatch: want=%d got=%d", test.expBits, bits)
+			}
+			if exp, _, _ := ParseBits(test.exp); !bytes.Equal(exp, got) {
+				t.Errorf("want=%s got=%s", test.exp, got.FormatBinary(bits, 4))
+			}
+		})
+	}
+}
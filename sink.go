@@ -0,0 +1,159 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+var (
+	_ BitSink   = (*Writer)(nil)
+	_ BitSource = (*Reader)(nil)
+)
+
+// BitSink is a destination of bits, implemented by Writer.
+// Write64 appends the lowest bitCount bits of v, at most 64, and ignores its higher bits;
+// writing zero bits does nothing. BitsWritten returns the number of bits appended so far.
+type BitSink interface {
+	Write64(v uint64, bitCount byte)
+	BitsWritten() uint
+}
+
+// BitSource is an origin of bits, implemented by Reader.
+// Read64 returns the next bitCount bits, at most 64, in the order they were written to a sink; reading
+// zero bits returns zero. If fewer than bitCount bits remain, it returns an error that wraps
+// io.ErrUnexpectedEOF and reads nothing, and for more than 64 bits an error that wraps ErrBitCountTooBig.
+// BitsRead returns the number of bits read so far.
+type BitSource interface {
+	Read64(bitCount byte) (uint64, error)
+	BitsRead() uint
+}
+
+// NewWriterSink returns a writer that passes the written bits to the sink when Flush is called.
+// The whole bytes passed to the sink are dropped from the writer, so BitData and WriteTo only cover the data
+// written since; the bits can no longer be changed in place, for example by the length fields of messages.
+// The sink must take the values in the bit order of the writer.
+func NewWriterSink(sink BitSink, opts ...Option) *Writer {
+	w := NewWriter(opts...)
+	w.sink = sink
+	return w
+}
+
+// Flush passes the bits written since the last Flush to the sink of a writer created by NewWriterSink,
+// in values of up to 64 bits. It panics for other writers.
+func (w *Writer) Flush() {
+	if w.sink == nil {
+		panic("bitdata: Flush on a writer without a sink")
+	}
+
+	d := w.assemble()
+	r := &Reader{data: d, bitsRead: w.flushed - w.base*8, end: w.bitsWritten - w.base*8, order: w.order}
+	for r.bitsRead < r.end {
+		n := byte(min(r.end-r.bitsRead, 64))
+		v, _ := read[uint64](r, n)
+		w.sink.Write64(v, n)
+	}
+	w.flushed = w.bitsWritten
+
+	keep := w.bitsWritten / 8
+	tail := append(w.newChunk(max(len(d[keep-w.base:]), 64)), d[keep-w.base:]...)
+	w.freeChunks(w.chunks)
+	w.chunks = [][]byte{tail}
+	w.base = keep
+}
+
+// NewReaderSource returns a reader of the bits of the source, which makes the methods of Reader available
+// for any storage. The bits are taken from the source 8 at a time, as bytes in the bit order of the reader,
+// which must be the order of the source. The reader ends where the source does, and the errors of the source
+// other than its end are returned by the reads. The read position can't be moved back before the last read,
+// and a Cursor of the reader shares the source with it, so the two can't be used concurrently.
+func NewReaderSource(src BitSource, opts ...Option) *Reader {
+	r := NewReader(nil, opts...)
+	r.end = ^uint(0) >> 1
+	r.src = &bitSource{src: src, order: r.order}
+	return r
+}
+
+// sourceEnder is implemented by the sources that find their end only while reading.
+type sourceEnder interface {
+	// bitEnd returns the length of the data in bits if the end has been reached.
+	bitEnd() (uint, bool)
+}
+
+// bitSource is a source that reads a BitSource sequentially, keeping only the bytes from the last
+// requested offset. The last byte is partial if the source ends within it.
+type bitSource struct {
+	src   BitSource
+	order BitOrder
+	base  uint
+	buf   []byte
+	end   uint
+	ended bool
+}
+
+func (c *bitSource) bytes(from, to uint) ([]byte, error) {
+	if from < c.base {
+		return nil, fmt.Errorf("%w: data before byte %d is discarded", ErrInvalidRange, c.base)
+	}
+
+	if skip := from - c.base; skip < uint(len(c.buf)) {
+		c.buf = c.buf[:copy(c.buf, c.buf[skip:])]
+		c.base = from
+	}
+
+	for c.base+uint(len(c.buf)) < to && !c.ended {
+		v, err := c.src.Read64(8)
+		if err != nil {
+			if !errors.Is(err, io.ErrUnexpectedEOF) {
+				return nil, err
+			}
+			c.readTail()
+			break
+		}
+		c.buf = append(c.buf, byte(v))
+	}
+
+	if c.base+uint(len(c.buf)) < to {
+		return nil, io.ErrUnexpectedEOF
+	}
+	if from > c.base {
+		// The bytes between the buffer and from were skipped.
+		c.buf = c.buf[:copy(c.buf, c.buf[from-c.base:])]
+		c.base = from
+	}
+
+	return c.buf[:to-from], nil
+}
+
+// readTail reads the last bits of the source, fewer than 8, one at a time, and records the end.
+func (c *bitSource) readTail() {
+	var b byte
+	n := uint(0)
+	for ; n < 8; n++ {
+		v, err := c.src.Read64(1)
+		if err != nil {
+			break
+		}
+		if c.order == MSBFirst {
+			b |= byte(v) << (7 - n)
+		} else {
+			b |= byte(v) << n
+		}
+	}
+
+	c.end = (c.base+uint(len(c.buf)))*8 + n
+	c.ended = true
+	if n > 0 {
+		c.buf = append(c.buf, b)
+	}
+}
+
+func (c *bitSource) bitEnd() (uint, bool) {
+	return c.end, c.ended
+}
+
+func (c *bitSource) cursor() source {
+	return c
+}
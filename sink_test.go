@@ -0,0 +1,268 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+//go:build !bitdata_noreflect
+
+package bitdata
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"testing"
+)
+
+// bitQueue is a BitSink and BitSource keeping the bits in a slice of bools, in the LSBFirst order.
+type bitQueue struct {
+	bits    []bool
+	written uint
+	read    uint
+}
+
+func (q *bitQueue) Write64(v uint64, bitCount byte) {
+	for i := range bitCount {
+		q.bits = append(q.bits, v>>i&1 == 1)
+	}
+	q.written += uint(bitCount)
+}
+
+func (q *bitQueue) BitsWritten() uint { return q.written }
+
+func (q *bitQueue) Read64(bitCount byte) (uint64, error) {
+	if bitCount > 64 {
+		return 0, ErrBitCountTooBig
+	}
+	if uint(len(q.bits)) < uint(bitCount) {
+		return 0, io.ErrUnexpectedEOF
+	}
+
+	var v uint64
+	for i := range bitCount {
+		if q.bits[i] {
+			v |= 1 << i
+		}
+	}
+	q.bits = q.bits[bitCount:]
+	q.read += uint(bitCount)
+
+	return v, nil
+}
+
+func (q *bitQueue) BitsRead() uint { return q.read }
+
+func TestWriterSinkReaderSource(t *testing.T) {
+	type point struct {
+		X int16 `bits:"11"`
+		Y int16 `bits:"11"`
+	}
+
+	q := &bitQueue{}
+	w := NewWriterSink(q)
+	for i := range 100 {
+		w.WriteUvarint(uint64(i * 300))
+		w.WriteRice(uint64(i), 2)
+		if err := w.Encode(point{X: int16(i), Y: int16(-i)}); err != nil {
+			t.Fatal(err)
+		}
+		if i%7 == 0 {
+			w.Flush()
+			if len(w.BitData()) > 1 {
+				t.Fatalf("%d bytes kept after Flush", len(w.BitData()))
+			}
+		}
+	}
+	w.Write8(5, 3)
+	w.Flush()
+
+	if q.BitsWritten() != w.BitsWritten() {
+		t.Fatalf("sink got %d bits, writer wrote %d", q.BitsWritten(), w.BitsWritten())
+	}
+
+	r := NewReaderSource(q)
+	for i := range 100 {
+		if v, err := r.ReadUvarint(); err != nil || v != uint64(i*300) {
+			t.Fatalf("varint %d: got %d, %v", i, v, err)
+		}
+		if v, err := r.ReadRice(2); err != nil || v != uint64(i) {
+			t.Fatalf("rice %d: got %d, %v", i, v, err)
+		}
+		var p point
+		if err := r.Decode(&p); err != nil || p.X != int16(i) || p.Y != int16(-i) {
+			t.Fatalf("point %d: got %+v, %v", i, p, err)
+		}
+	}
+
+	if _, err := r.Read8(4); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("expected io.ErrUnexpectedEOF past the end, got %v", err)
+	}
+	if v, err := r.Read8(3); err != nil || v != 5 {
+		t.Errorf("got %d, %v at the end", v, err)
+	}
+	if _, err := r.Read8(1); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("expected io.ErrUnexpectedEOF at the end, got %v", err)
+	}
+}
+
+func TestReaderSourceOptionalTail(t *testing.T) {
+	q := &bitQueue{}
+	w := NewWriterSink(q)
+	w.Write16(0x1234, 16)
+	w.Flush()
+
+	r := NewReaderSource(q, WithOptionalTail())
+	if v, err := r.Read16(16); err != nil || v != 0x1234 {
+		t.Fatalf("unexpected %x, %v", v, err)
+	}
+	if v, err := r.Read8(8); err != nil || v != 0 || !r.EndedEarly() {
+		t.Errorf("expected the end, got %d, %v", v, err)
+	}
+}
+
+func TestReaderSourceReadRemaining(t *testing.T) {
+	q := &bitQueue{}
+	w, ref := NewWriterSink(q), NewWriter()
+	for i := range 50 {
+		w.Write8(uint8(i), 5)
+		ref.Write8(uint8(i), 5)
+	}
+	w.Flush()
+
+	r := NewReaderSource(q)
+	_, _ = r.Read8(3)
+	d, n := r.ReadRemaining()
+	if n != 247 {
+		t.Fatalf("expected 247 bits, got %d", n)
+	}
+
+	want := NewReader(ref.BitData())
+	_, _ = want.Read8(3)
+	got := NewReaderBits(d, n)
+	for i := range n {
+		a, _ := want.ReadBool()
+		b, _ := got.ReadBool()
+		if a != b {
+			t.Fatalf("bit %d differs", i)
+		}
+	}
+}
+
+func TestReaderSourceSkipBack(t *testing.T) {
+	q := &bitQueue{}
+	w := NewWriterSink(q)
+	w.Write32(0x87654321, 32)
+	w.Flush()
+
+	r := NewReaderSource(q)
+	_, _ = r.Read16(12)
+	if err := r.SkipBack(4); err != nil {
+		t.Fatal(err)
+	}
+	if v, err := r.Read8(8); err != nil || v != 0x43 {
+		t.Fatalf("unexpected %x, %v", v, err)
+	}
+	if err := r.SkipBack(9); !errors.Is(err, ErrInvalidRange) || r.BitsRead() != 16 {
+		t.Errorf("unexpected result: err=%v pos=%d", err, r.BitsRead())
+	}
+}
+
+func TestReaderSourceDecodeBits(t *testing.T) {
+	source := func() *bitQueue {
+		q := &bitQueue{}
+		w := NewWriterSink(q)
+		w.Write8(0b0111, 8)
+		w.Flush()
+		return q
+	}
+
+	r := NewReaderSource(source(), WithLimits(Limits{MaxDecodeBits: 3}))
+	if err := r.Decode(&nestedList{}); !errors.Is(err, ErrLimitExceeded) {
+		t.Fatalf("expected ErrLimitExceeded, got %v", err)
+	}
+
+	r = NewReaderSource(source(), WithLimits(Limits{MaxDecodeBits: 100}))
+	if err := r.Decode(&nestedList{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Read8(5); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("expected io.ErrUnexpectedEOF past the end, got %v", err)
+	}
+}
+
+func TestReaderSourceShort(t *testing.T) {
+	source := func() *Reader {
+		q := &bitQueue{}
+		w := NewWriterSink(q)
+		w.Write32(0xA5A5A5A5, 32)
+		w.Write8(0, 3)
+		w.Flush()
+		return NewReaderSource(q)
+	}
+
+	if _, err := source().ReadBools(40); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("ReadBools: expected io.ErrUnexpectedEOF, got %v", err)
+	}
+	if err := source().ReadBytesInto(make([]byte, 5)); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("ReadBytesInto: expected io.ErrUnexpectedEOF, got %v", err)
+	}
+	if err := source().SkipChecked(36); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("SkipChecked: expected io.ErrUnexpectedEOF, got %v", err)
+	}
+	if _, err := source().ReadIP(); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("ReadIP: expected io.ErrUnexpectedEOF, got %v", err)
+	}
+
+	r := source()
+	p := make([]byte, 8)
+	if n, err := r.Read(p); n != 4 || err != nil {
+		t.Errorf("Read: got %d, %v", n, err)
+	}
+	if err := r.Finish(PaddingNone); !errors.Is(err, ErrUnreadBits) {
+		t.Errorf("Finish: expected ErrUnreadBits, got %v", err)
+	}
+	if pos, err := r.Seek(0, io.SeekEnd); err != nil || pos != 35 {
+		t.Errorf("Seek: got %d, %v", pos, err)
+	}
+
+	r = source()
+	if _, err := r.Seek(0, io.SeekEnd); !errors.Is(err, ErrInvalidRange) {
+		t.Errorf("Seek: expected ErrInvalidRange before the end is known, got %v", err)
+	}
+	r.Skip(33)
+	if err := r.Finish(PaddingZeros); err != nil {
+		t.Errorf("Finish: got %v", err)
+	}
+}
+
+func TestReaderSourceDecodeBlocks(t *testing.T) {
+	ref := NewWriter()
+	if err := EncodeBlocks(ref, 200, 4, func(i int, w *Writer) error {
+		for j := range 20 {
+			w.WriteUvarint(uint64(i * j))
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	q := &bitQueue{}
+	q.Write64(0, 0)
+	rr := NewReader(ref.BitData())
+	for n := ref.BitsWritten(); n > 0; {
+		c := byte(min(n, 64))
+		q.Write64(rr.MustRead64(c), c)
+		n -= uint(c)
+	}
+
+	_, err := DecodeBlocks(NewReaderSource(q), 4, func(i int, r *Reader) (bool, error) {
+		for j := range 20 {
+			runtime.Gosched()
+			if v, err := r.ReadUvarint(); err != nil || v != uint64(i*j) {
+				return false, fmt.Errorf("value %d: got %d, %w", j, v, err)
+			}
+		}
+		return true, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"math"
+	"math/bits"
+)
+
+// SizeCalculator is a writer that only counts the bits written to it, for example to find the size
+// of a message before allocating a buffer for it. Any function that writes to a *Writer can write
+// to its Writer, and BitsWritten returns the exact size of the data.
+//
+// The written bytes are dropped as the writer goes, so its memory stays bounded. Methods that return
+// the written data, such as BitData, see only the most recent bytes.
+type SizeCalculator struct {
+	*Writer
+}
+
+// NewSizeCalculator returns a size calculator. The options should be those of the writer the data is meant for.
+func NewSizeCalculator(opts ...Option) *SizeCalculator {
+	w := NewWriter(opts...)
+	w.discard = true
+	return &SizeCalculator{Writer: w}
+}
+
+// Bytes returns the number of bytes the data written so far takes.
+func (c *SizeCalculator) Bytes() uint {
+	return (c.bitsWritten + 7) / 8
+}
+
+// Reset sets the count back to zero, keeping the options of the writer.
+func (c *SizeCalculator) Reset() {
+	c.Release()
+}
+
+// UvarintBits returns the number of bits WriteUvarint writes for v.
+func UvarintBits(v uint64) uint {
+	return 8 * max(1, uint(bits.Len64(v)+6)/7)
+}
+
+// VarintBits returns the number of bits WriteVarint writes for v.
+func VarintBits(v int64) uint {
+	return UvarintBits(zigzag(v))
+}
+
+// RiceBits returns the number of bits WriteRice writes for v with the parameter k.
+// It panics if k is greater than 64.
+func RiceBits(v uint64, k byte) uint {
+	if k > 64 {
+		panic("bitdata: invalid Rice parameter")
+	}
+
+	var q uint64
+	if k < 64 {
+		q = v >> k
+	}
+	return uint(q) + 1 + uint(k)
+}
+
+// ExpGolombBits returns the number of bits WriteExpGolomb writes for v.
+func ExpGolombBits(v uint64) uint {
+	if v == math.MaxUint64 {
+		return 129
+	}
+	return 2*uint(bits.Len64(v+1)) - 1
+}
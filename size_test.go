@@ -0,0 +1,72 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSizeHelpers(t *testing.T) {
+	values := []uint64{0, 1, 2, 3, 127, 128, 300, 1 << 20, math.MaxUint32, math.MaxUint64 - 1, math.MaxUint64}
+
+	for _, v := range values {
+		w := NewWriter()
+		w.WriteUvarint(v)
+		if got := UvarintBits(v); got != w.BitsWritten() {
+			t.Errorf("uvarint %d: want=%d got=%d", v, w.BitsWritten(), got)
+		}
+
+		w = NewWriter()
+		w.WriteVarint(-int64(v >> 1))
+		if got := VarintBits(-int64(v >> 1)); got != w.BitsWritten() {
+			t.Errorf("varint %d: want=%d got=%d", -int64(v>>1), w.BitsWritten(), got)
+		}
+
+		w = NewWriter()
+		w.WriteExpGolomb(v)
+		if got := ExpGolombBits(v); got != w.BitsWritten() {
+			t.Errorf("exp-golomb %d: want=%d got=%d", v, w.BitsWritten(), got)
+		}
+
+		for _, k := range []byte{50, 63, 64} {
+			w = NewWriter()
+			w.WriteRice(v, k)
+			if got := RiceBits(v, k); got != w.BitsWritten() {
+				t.Errorf("rice %d, %d: want=%d got=%d", v, k, w.BitsWritten(), got)
+			}
+		}
+	}
+}
+
+func TestSizeCalculator(t *testing.T) {
+	write := func(w *Writer) {
+		for i := range 100000 {
+			w.BeginMessage(12)
+			w.Write8(uint8(i), 3)
+			w.WriteUvarint(uint64(i))
+			w.EndMessage()
+			_, _ = w.Write([]byte("abc"))
+		}
+		w.WriteZeros(1 << 20)
+		w.WriteRice(5, 1)
+	}
+
+	w := NewWriter()
+	write(w)
+
+	c := NewSizeCalculator()
+	write(c.Writer)
+	if c.BitsWritten() != w.BitsWritten() || c.Bytes() != uint(len(w.BitData())) {
+		t.Errorf("want=%d bits got=%d bits, %d bytes", w.BitsWritten(), c.BitsWritten(), c.Bytes())
+	}
+	if n := len(c.BitData()); n > writerChunkSize {
+		t.Errorf("%d bytes kept", n)
+	}
+
+	c.Reset()
+	c.WriteUvarint(300)
+	if c.BitsWritten() != 16 {
+		t.Errorf("got %d bits after Reset", c.BitsWritten())
+	}
+}
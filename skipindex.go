@@ -0,0 +1,142 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrInvalidIndex = errors.New("invalid record index")
+
+// RecordIndex holds the bit offsets of every Interval-th record of a stream of variable-length records,
+// which lets a reader get to any record without decoding all the records before it.
+//
+//	x := NewRecordIndex(64)
+//	for _, rec := range records {
+//		x.Mark(w)
+//		writeRecord(w, rec)
+//	}
+//	indexOffset := w.BitsWritten()
+//	w.WriteRecordIndex(x)
+type RecordIndex struct {
+	interval int
+	count    int
+	offsets  []uint
+}
+
+// NewRecordIndex returns an empty index that keeps the offset of every interval-th record.
+// It panics if interval is less than 1.
+func NewRecordIndex(interval int) *RecordIndex {
+	if interval < 1 {
+		panic("bitdata: invalid record index interval")
+	}
+	return &RecordIndex{interval: interval}
+}
+
+// Mark must be called just before a record is written.
+func (x *RecordIndex) Mark(w *Writer) {
+	if x.count%x.interval == 0 {
+		x.offsets = append(x.offsets, w.bitsWritten)
+	}
+	x.count++
+}
+
+// Len returns the number of records.
+func (x *RecordIndex) Len() int {
+	return x.count
+}
+
+// WriteRecordIndex writes the index: the interval, the number of records and the differences
+// between consecutive indexed offsets, all as LEB128 varints.
+func (w *Writer) WriteRecordIndex(x *RecordIndex) {
+	w.WriteUvarint(uint64(x.interval))
+	w.WriteUvarint(uint64(x.count))
+
+	var last uint
+	for _, ofs := range x.offsets {
+		w.WriteUvarint(uint64(ofs - last))
+		last = ofs
+	}
+}
+
+// ReadRecordIndex reads an index written with WriteRecordIndex. It returns ErrInvalidIndex
+// if the index is inconsistent. On error the read position is left unchanged.
+func (r *Reader) ReadRecordIndex() (*RecordIndex, error) {
+	start := r.bitsRead
+
+	rr := ReaderError{reader: *r}
+	x, err := readRecordIndex(&rr)
+	if rr.err != nil {
+		err = rr.err
+	}
+	if err != nil {
+		r.bitsRead = start
+		return nil, err
+	}
+
+	*r = rr.reader
+
+	return x, nil
+}
+
+func readRecordIndex(r *ReaderError) (*RecordIndex, error) {
+	interval := r.ReadUvarint()
+	count := r.ReadUvarint()
+	if r.err != nil {
+		return nil, r.err
+	}
+	if interval == 0 || interval > uint64(maxInt) || count > uint64(maxInt) {
+		return nil, ErrInvalidIndex
+	}
+
+	n := (count + interval - 1) / interval
+	if n > uint64(r.reader.end-r.reader.bitsRead)/8 {
+		return nil, ErrInvalidIndex
+	}
+	if err := r.reader.charge(n, byteSize[uint]()); err != nil {
+		return nil, err
+	}
+
+	x := &RecordIndex{interval: int(interval), count: int(count), offsets: make([]uint, n)}
+
+	var last uint
+	for i := range x.offsets {
+		delta := r.ReadUvarint()
+		if delta > uint64(maxInt) {
+			return nil, ErrInvalidIndex
+		}
+		last += uint(delta)
+		x.offsets[i] = last
+	}
+
+	return x, nil
+}
+
+func (r *ReaderError) ReadRecordIndex() (x *RecordIndex) {
+	if r.err == nil {
+		x, r.err = r.reader.ReadRecordIndex()
+	}
+	return
+}
+
+// SeekRecord moves the reader to the start of the record k. It jumps to the nearest indexed record
+// before it and passes over the rest by calling skip once per record, which must read or skip one record.
+// It returns ErrInvalidRange if there's no record k. On error the read position is left unchanged.
+func (r *Reader) SeekRecord(x *RecordIndex, k int, skip func(r *Reader) error) error {
+	if k < 0 || k >= x.count {
+		return fmt.Errorf("%w: record %d of %d", ErrInvalidRange, k, x.count)
+	}
+
+	start := r.bitsRead
+	r.bitsRead = x.offsets[k/x.interval]
+
+	for i := k / x.interval * x.interval; i < k; i++ {
+		if err := skip(r); err != nil {
+			r.bitsRead = start
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRecordIndex(t *testing.T) {
+	const records = 1000
+
+	w := NewWriter()
+	x := NewRecordIndex(16)
+	for i := range records {
+		x.Mark(w)
+		w.WriteUvarint(uint64(i * i))
+	}
+	indexOffset := w.BitsWritten()
+	w.WriteRecordIndex(x)
+
+	r := NewReader(w.BitData())
+	r.Skip(indexOffset)
+	rx, err := r.ReadRecordIndex()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rx.Len() != records {
+		t.Errorf("record count: %d", rx.Len())
+	}
+
+	skipped := 0
+	skip := func(r *Reader) error {
+		skipped++
+		_, err := r.ReadUvarint()
+		return err
+	}
+
+	for _, k := range []int{0, 15, 16, 17, 500, 999} {
+		skipped = 0
+		if err := r.SeekRecord(rx, k, skip); err != nil {
+			t.Fatalf("record %d: unexpected error: %v", k, err)
+		}
+		if v, err := r.ReadUvarint(); err != nil || v != uint64(k*k) {
+			t.Errorf("record %d: v=%d err=%v", k, v, err)
+		}
+		if skipped != k%16 {
+			t.Errorf("record %d: skipped %d records", k, skipped)
+		}
+	}
+
+	pos := r.BitsRead()
+	if err := r.SeekRecord(rx, records, skip); !errors.Is(err, ErrInvalidRange) || r.BitsRead() != pos {
+		t.Errorf("expected ErrInvalidRange, got %v", err)
+	}
+
+	failing := func(r *Reader) error { return ErrInvalidIndex }
+	if err := r.SeekRecord(rx, 5, failing); !errors.Is(err, ErrInvalidIndex) || r.BitsRead() != pos {
+		t.Errorf("expected the skip error, got %v", err)
+	}
+}
+
+func TestRecordIndexInvalid(t *testing.T) {
+	w := NewWriter()
+	w.WriteUvarint(0)
+	w.WriteUvarint(10)
+
+	r := NewReader(w.BitData())
+	if _, err := r.ReadRecordIndex(); !errors.Is(err, ErrInvalidIndex) || r.BitsRead() != 0 {
+		t.Errorf("expected ErrInvalidIndex at 0, got %v at %d", err, r.BitsRead())
+	}
+}
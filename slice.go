@@ -0,0 +1,98 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"errors"
+)
+
+var ErrLengthOverflow = errors.New("length does not fit into the prefix")
+
+// maxPrealloc limits the capacity allocated up front for collections with a length read from the stream.
+const maxPrealloc = 1024
+
+// WriteSlice writes the length of s followed by its elements, each written by the function write.
+// The length prefix is lenBits wide; if lenBits is zero, the length is written as a varint.
+func WriteSlice[T any](w *Writer, s []T, lenBits byte, write func(w *Writer, v T) error) error {
+	if err := writeLength(w, len(s), lenBits); err != nil {
+		return err
+	}
+
+	for i := range s {
+		if err := write(w, s[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ReadSlice reads a slice written by WriteSlice, each element is read by the function read.
+func ReadSlice[T any](r *Reader, lenBits byte, read func(r *Reader) (T, error)) ([]T, error) {
+	n, err := readLength(r, lenBits)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.charge(uint64(n), byteSize[T]()); err != nil {
+		return nil, err
+	}
+
+	s := make([]T, 0, preallocSize(n))
+	for i := 0; i < n; i++ {
+		v, err := read(r)
+		if err != nil {
+			return nil, err
+		}
+		s = append(s, v)
+	}
+
+	return s, nil
+}
+
+func writeLength(w *Writer, n int, lenBits byte) error {
+	if lenBits == 0 {
+		w.WriteUvarint(uint64(n))
+		return nil
+	}
+
+	if lenBits > 64 || uint64(n) > mask[uint64](lenBits) {
+		return ErrLengthOverflow
+	}
+
+	w.Write64(uint64(n), lenBits)
+
+	return nil
+}
+
+func readLength(r *Reader, lenBits byte) (int, error) {
+	var (
+		n   uint64
+		err error
+	)
+	if lenBits == 0 {
+		n, err = r.ReadUvarint()
+	} else {
+		n, err = r.Read64(lenBits)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	if n > uint64(maxInt) {
+		return 0, ErrLengthOverflow
+	}
+	if err := r.checkLen(n); err != nil {
+		return 0, err
+	}
+
+	return int(n), nil
+}
+
+const maxInt = int(^uint(0) >> 1)
+
+func preallocSize(n int) int {
+	if n > maxPrealloc {
+		return maxPrealloc
+	}
+	return n
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+//go:build !bitdata_noreflect
+
+package bitdata
+
+import (
+	"errors"
+	"io"
+	"slices"
+	"testing"
+)
+
+func writePoint(w *Writer, p codecPoint) error {
+	return w.Encode(p)
+}
+
+func readPoint(r *Reader) (p codecPoint, err error) {
+	err = r.Decode(&p)
+	return
+}
+
+func TestSlice(t *testing.T) {
+	points := []codecPoint{{X: 1, Y: -1}, {X: 300, Y: 0}, {X: -511, Y: 511}}
+
+	for _, lenBits := range []byte{0, 2, 16} {
+		w := NewWriter()
+		if err := WriteSlice(w, points, lenBits, writePoint); err != nil {
+			t.Errorf("unexpected error: %s", err)
+			continue
+		}
+
+		prefix := uint(lenBits)
+		if lenBits == 0 {
+			prefix = 8
+		}
+		if want, got := prefix+uint(len(points))*20, w.BitsWritten(); want != got {
+			t.Errorf("size mismatch: want=%d got=%d", want, got)
+		}
+
+		got, err := ReadSlice(NewReader(w.BitData()), lenBits, readPoint)
+		if err != nil {
+			t.Errorf("unexpected error: %s", err)
+		}
+		if !slices.Equal(points, got) {
+			t.Errorf("mismatch: want=%v got=%v", points, got)
+		}
+	}
+}
+
+func TestSliceEmpty(t *testing.T) {
+	w := NewWriter()
+	_ = WriteSlice[codecPoint](w, nil, 4, writePoint)
+
+	got, err := ReadSlice(NewReader(w.BitData()), 4, readPoint)
+	if err != nil || len(got) != 0 {
+		t.Errorf("unexpected result: %v, %v", got, err)
+	}
+}
+
+func TestSliceErrors(t *testing.T) {
+	w := NewWriter()
+	if err := WriteSlice(w, make([]codecPoint, 4), 2, writePoint); !errors.Is(err, ErrLengthOverflow) {
+		t.Errorf("expected error, got %v", err)
+	}
+
+	// claims a huge length but holds no elements
+	w = NewWriter()
+	w.WriteUvarint(1 << 30)
+	if _, err := ReadSlice(NewReader(w.BitData()), 0, readPoint); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("expected EOF, got %v", err)
+	}
+}
@@ -0,0 +1,240 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// source provides the bytes of a Reader that doesn't keep all of its data in memory.
+type source interface {
+	// bytes returns the bytes from the byte offset from up to the byte offset to.
+	// The returned slice is only valid until the next call.
+	bytes(from, to uint) ([]byte, error)
+
+	// cursor returns a source of the same data that can be used concurrently with this one.
+	// The sources that read a stream sequentially share the stream with their cursors, so these
+	// can't be used concurrently.
+	cursor() source
+}
+
+const (
+	pageCacheSize  = 64 << 10
+	pageCacheLimit = 64
+)
+
+// NewReaderAt returns a reader of the first size bytes of ra. The data is loaded on demand
+// in 64 KiB pages, and at most 64 pages are kept in memory, so huge inputs can be read at any bit offset.
+// An I/O error of ra is returned by the read that caused it. Use Cursor for readers of the same data
+// that share the pages and can be used from other goroutines.
+func NewReaderAt(ra io.ReaderAt, size int64, opts ...Option) *Reader {
+	r := NewReader(nil, opts...)
+	r.end = uint(size) * 8
+	r.src = &pageCache{pageStore: &pageStore{ra: ra, size: uint(size), pages: make(map[uint][]byte)}}
+	return r
+}
+
+// NewReaderFile returns a reader of the file f as NewReaderAt does. The file must remain open while the reader is used.
+func NewReaderFile(f *os.File, opts ...Option) (*Reader, error) {
+	fi, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	return NewReaderAt(f, fi.Size(), opts...), nil
+}
+
+// NewReaderString returns a reader of the bytes of s. Unlike NewReader(BitData(s)),
+// it doesn't copy the string; only the few bytes needed by each read are copied.
+func NewReaderString(s string, opts ...Option) *Reader {
+	r := &Reader{end: uint(len(s)) * 8, src: &stringSource{s: s}}
+	r.configure(opts)
+	return r
+}
+
+// Cursor returns an independent reader of the same data at the same position. Reading with the cursor
+// doesn't affect r and the two readers can be used concurrently. The cursor has no trace function.
+// For readers created with NewReaderAt, the cursor shares the loaded pages with r, so the data is not copied.
+func (r *Reader) Cursor() *Reader {
+	c := *r
+	c.trace = nil
+	if r.allocated != nil {
+		// The cursor may be used concurrently with r, so it counts its allocations separately.
+		n := *r.allocated
+		c.allocated = &n
+	}
+	if r.src != nil {
+		c.src = r.src.cursor()
+		if s, ok := c.src.(*streamSource); ok {
+			// The bits buffered by r are not repeated: the cursor reads on with fresh bytes of the stream.
+			s.base = r.bitsRead / 8
+			c.buf, c.bufBits, c.bufPos = 0, 0, 0
+		}
+	}
+	return &c
+}
+
+// bytes returns the bytes of the reader from the byte offset from up to the byte offset to.
+func (r *Reader) bytes(from, to uint) (BitData, error) {
+	if r.src == nil {
+		return r.data[from:to], nil
+	}
+	return r.src.bytes(from, to)
+}
+
+// stringSource is a source that reads a string through a reusable buffer.
+type stringSource struct {
+	s   string
+	buf []byte
+}
+
+func (c *stringSource) bytes(from, to uint) ([]byte, error) {
+	if to > uint(len(c.s)) || from > to {
+		return nil, io.ErrUnexpectedEOF
+	}
+
+	c.buf = append(c.buf[:0], c.s[from:to]...)
+
+	return c.buf, nil
+}
+
+func (c *stringSource) cursor() source {
+	return &stringSource{s: c.s}
+}
+
+// pageCache is a source that reads the pages of a pageStore through its own buffer.
+type pageCache struct {
+	*pageStore
+	buf []byte
+}
+
+// pageStore loads pages of an io.ReaderAt on demand and evicts the oldest page when full.
+// It is shared by the cursors of a reader. Pages are never modified after loading, so a page
+// stays valid for its users even after it's evicted.
+type pageStore struct {
+	ra    io.ReaderAt
+	size  uint
+	mu    sync.Mutex
+	pages map[uint][]byte
+	order []uint
+}
+
+func (c *pageCache) cursor() source {
+	return &pageCache{pageStore: c.pageStore}
+}
+
+func (c *pageCache) bytes(from, to uint) ([]byte, error) {
+	if to > c.size || from > to {
+		return nil, io.ErrUnexpectedEOF
+	}
+
+	first, last := from/pageCacheSize, (to-1)/pageCacheSize
+	if from == to {
+		return nil, nil
+	}
+
+	if first == last {
+		p, err := c.page(first)
+		if err != nil {
+			return nil, err
+		}
+		return p[from%pageCacheSize : from%pageCacheSize+(to-from)], nil
+	}
+
+	c.buf = c.buf[:0]
+	for i := first; i <= last; i++ {
+		p, err := c.page(i)
+		if err != nil {
+			return nil, err
+		}
+
+		lo, hi := uint(0), uint(len(p))
+		if i == first {
+			lo = from % pageCacheSize
+		}
+		if i == last {
+			hi = (to-1)%pageCacheSize + 1
+		}
+		c.buf = append(c.buf, p[lo:hi]...)
+	}
+
+	return c.buf, nil
+}
+
+func (c *pageStore) page(i uint) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if p, ok := c.pages[i]; ok {
+		return p, nil
+	}
+
+	off := i * pageCacheSize
+	p := make([]byte, min(pageCacheSize, c.size-off))
+	if n, err := c.ra.ReadAt(p, int64(off)); n < len(p) || err != nil && err != io.EOF {
+		if err == nil || err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, fmt.Errorf("bitdata: reading page at %d: %w", off, err)
+	}
+
+	if len(c.order) == pageCacheLimit {
+		delete(c.pages, c.order[0])
+		c.order = c.order[1:]
+	}
+	c.pages[i] = p
+	c.order = append(c.order, i)
+
+	return p, nil
+}
+
+// MappedFile is a file mapped into memory with MapFile.
+type MappedFile struct {
+	data  BitData
+	unmap func() error
+}
+
+// MapFile maps the named file into memory read-only, so NewReader(m.BitData()) reads it
+// without loading it up front. On platforms without mmap support the whole file is read instead.
+// The data must not be used after Close.
+func MapFile(name string) (*MappedFile, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	if fi.Size() == 0 {
+		return &MappedFile{data: BitData{}}, nil
+	}
+
+	data, unmap, err := mapFile(f, int(fi.Size()))
+	if err != nil {
+		return nil, err
+	}
+
+	return &MappedFile{data: data, unmap: unmap}, nil
+}
+
+func (m *MappedFile) BitData() BitData {
+	return m.data
+}
+
+// Close unmaps the file.
+func (m *MappedFile) Close() error {
+	if m.unmap == nil {
+		return nil
+	}
+
+	err := m.unmap()
+	m.data, m.unmap = nil, nil
+
+	return err
+}
@@ -0,0 +1,18 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+//go:build !unix
+
+package bitdata
+
+import (
+	"io"
+	"os"
+)
+
+func mapFile(f *os.File, size int) (BitData, func() error, error) {
+	data := make(BitData, size)
+	if _, err := io.ReadFull(f, data); err != nil {
+		return nil, nil, err
+	}
+	return data, nil, nil
+}
@@ -0,0 +1,242 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReaderAt(t *testing.T) {
+	const count = 200_000 // 19 bits each, spans many pages
+
+	w := NewWriter()
+	rnd := rand.New(rand.NewSource(1))
+	values := make([]uint32, count)
+	for i := range values {
+		values[i] = rnd.Uint32() & mask[uint32](19)
+		w.Write32(values[i], 19)
+	}
+	w.Write16(0xABCD, 16)
+	data := w.BitData()
+
+	r := NewReaderAt(bytes.NewReader(data), int64(len(data)))
+	for i, want := range values {
+		got, err := r.Read32(19)
+		if err != nil || got != want {
+			t.Errorf("value %d mismatch: want=%d got=%d err=%v", i, want, got, err)
+			return
+		}
+	}
+
+	pos, ok := r.Find(0xABCD, 16)
+	if !ok || pos != count*19 {
+		t.Errorf("find mismatch: want=%d got=%d,%t", count*19, pos, ok)
+	}
+
+	_, _ = r.Seek(int64(pageCacheSize*8-4), io.SeekStart)
+	got := make([]byte, 100)
+	if n, err := r.Read(got); n != 100 || err != nil {
+		t.Errorf("read across pages: n=%d err=%v", n, err)
+	}
+	exp := make([]byte, 100)
+	mem := NewReader(data)
+	_, _ = mem.Seek(int64(pageCacheSize*8-4), io.SeekStart)
+	_, _ = mem.Read(exp)
+	if !bytes.Equal(exp, got) {
+		t.Errorf("read across pages mismatch")
+	}
+
+	r = NewReaderAt(bytes.NewReader(data), int64(len(data)))
+	_, _ = r.Seek(int64(len(data))*8-8, io.SeekStart)
+	if _, err := r.Read16(16); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("expected EOF, got %v", err)
+	}
+}
+
+func TestReaderAtCursors(t *testing.T) {
+	const count = 100_000
+
+	w := NewWriter()
+	for i := 0; i < count; i++ {
+		w.Write32(uint32(i), 21)
+	}
+	data := w.BitData()
+
+	r := NewReaderAt(bytes.NewReader(data), int64(len(data)))
+
+	const workers = 8
+	errs := make(chan error, workers)
+	for g := 0; g < workers; g++ {
+		c := r.Cursor()
+		c.Skip(uint(g*count/workers) * 21)
+
+		go func(first int) {
+			for i := first; i < first+count/workers; i++ {
+				v, err := c.Read32(21)
+				if err != nil || v != uint32(i) {
+					errs <- fmt.Errorf("value %d mismatch: got=%d err=%v", i, v, err)
+					return
+				}
+			}
+			errs <- nil
+		}(g * count / workers)
+	}
+
+	for g := 0; g < workers; g++ {
+		if err := <-errs; err != nil {
+			t.Error(err)
+		}
+	}
+
+	if r.BitsRead() != 0 {
+		t.Errorf("cursors moved the original reader")
+	}
+	if v, err := r.Read32(21); err != nil || v != 0 {
+		t.Errorf("original reader: got=%d err=%v", v, err)
+	}
+}
+
+type failingReaderAt struct{}
+
+var errFailingRead = errors.New("disk on fire")
+
+func (failingReaderAt) ReadAt([]byte, int64) (int, error) {
+	return 0, errFailingRead
+}
+
+func TestReaderAtError(t *testing.T) {
+	r := NewReaderAt(failingReaderAt{}, 100)
+	if _, err := r.Read8(8); !errors.Is(err, errFailingRead) {
+		t.Errorf("expected read error, got %v", err)
+	}
+	if r.BitsRead() != 0 {
+		t.Errorf("reader advanced on error: %d", r.BitsRead())
+	}
+
+	reads := []struct {
+		name string
+		read func(r *Reader) error
+	}{
+		{"Read", func(r *Reader) error { _, err := r.Read(make([]byte, 4)); return err }},
+		{"ReadBytesInto", func(r *Reader) error { return r.ReadBytesInto(make([]byte, 4)) }},
+		{"ReadBools", func(r *Reader) error { _, err := r.ReadBools(20); return err }},
+		{"ReadNibbles", func(r *Reader) error { return r.ReadNibbles(make([]byte, 4)) }},
+		{"ReadIP", func(r *Reader) error { _, err := r.ReadIP(); return err }},
+		{"ReadUvarint", func(r *Reader) error { _, err := r.ReadUvarint(); return err }},
+		{"ExpectZeros", func(r *Reader) error { return r.ExpectZeros(20) }},
+	}
+	for _, c := range reads {
+		// The data is shorter than the declared size.
+		r := NewReaderAt(bytes.NewReader([]byte{1, 2}), 100)
+		if err := c.read(r); !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Errorf("%s: expected io.ErrUnexpectedEOF for short data, got %v", c.name, err)
+		}
+
+		r = NewReaderAt(failingReaderAt{}, 100)
+		r.Skip(3)
+		if err := c.read(r); !errors.Is(err, errFailingRead) {
+			t.Errorf("%s: expected read error, got %v", c.name, err)
+		}
+	}
+}
+
+func TestFileReaders(t *testing.T) {
+	w := NewWriter()
+	for i := 0; i < 1000; i++ {
+		w.Write16(uint16(i), 13)
+	}
+
+	name := filepath.Join(t.TempDir(), "data.bin")
+	if err := os.WriteFile(name, w.BitData(), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := os.Open(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	fr, err := NewReaderFile(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := MapFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m.Close()
+
+	mr := NewReader(m.BitData())
+
+	for i := 0; i < 1000; i++ {
+		a, errA := fr.Read16(13)
+		b, errB := mr.Read16(13)
+		if errA != nil || errB != nil || a != uint16(i) || b != uint16(i) {
+			t.Errorf("value %d mismatch: file=%d,%v mapped=%d,%v", i, a, errA, b, errB)
+			return
+		}
+	}
+
+	if err := m.Close(); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
+
+func TestReaderString(t *testing.T) {
+	w := NewWriter()
+	for i := 0; i < 500; i++ {
+		w.Write16(uint16(i*7), 11)
+	}
+	w.WriteCRC(CRC16CCITT, 0)
+	s := string(w.BitData())
+
+	r := NewReaderString(s)
+	for i := 0; i < 500; i++ {
+		if v, err := r.Read16(11); err != nil || v != uint16(i*7)&0x7FF {
+			t.Errorf("value %d mismatch: got=%d err=%v", i, v, err)
+			return
+		}
+	}
+	if err := r.VerifyCRC(CRC16CCITT, 0); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	allocs := testing.AllocsPerRun(10, func() {
+		r := NewReaderString(s)
+		for i := 0; i < 500; i++ {
+			_, _ = r.Read16(11)
+		}
+	})
+	if allocs > 3 {
+		t.Errorf("too many allocations: %.0f", allocs)
+	}
+}
+
+func TestSourceReaderOptions(t *testing.T) {
+	w := NewWriter(WithBitOrder(MSBFirst))
+	w.Write16(0x1234, 13)
+	w.WriteUvarint(1000)
+	d := w.BitData()
+
+	readers := map[string]*Reader{
+		"NewReaderAt":     NewReaderAt(bytes.NewReader(d), int64(len(d)), WithBitOrder(MSBFirst), WithLimits(Limits{MaxLen: 10})),
+		"NewReaderString": NewReaderString(string(d), WithBitOrder(MSBFirst), WithLimits(Limits{MaxLen: 10})),
+	}
+	for name, r := range readers {
+		if v, err := r.Read16(13); err != nil || v != 0x1234 {
+			t.Errorf("%s: got %x, %v", name, v, err)
+		}
+		if _, err := ReadSlice(r, 0, func(r *Reader) (byte, error) { return r.Read8(8) }); !errors.Is(err, ErrLimitExceeded) {
+			t.Errorf("%s: expected ErrLimitExceeded, got %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,18 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+//go:build unix
+
+package bitdata
+
+import (
+	"os"
+	"syscall"
+)
+
+func mapFile(f *os.File, size int) (BitData, func() error, error) {
+	data, err := syscall.Mmap(int(f.Fd()), 0, size, syscall.PROT_READ, syscall.MAP_SHARED)
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, func() error { return syscall.Munmap(data) }, nil
+}
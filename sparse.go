@@ -0,0 +1,161 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"errors"
+	"math/bits"
+)
+
+var ErrInvalidSparse = errors.New("invalid sparse bit data")
+
+// sparseMaxK is the highest Exp-Golomb order WriteSparse chooses, the largest value of its 5 bit field.
+const sparseMaxK = 31
+
+// WriteSparse writes the first bitCount bits of d, taken in the bit order of the writer, coded for data
+// that is mostly zero bits: the bit count and the number of one bits as LEB128 varints, followed by the
+// length of the run of zero bits before every one bit as an Exp-Golomb code of the order k, if there
+// are any one bits. Such a code is the unsigned Exp-Golomb code of the run length shifted right by k,
+// followed by its low k bits; k, which is written in 5 bits before the codes, is chosen to make
+// the codes the shortest. The zero bits after the last one bit take no space.
+// It panics if d is shorter than bitCount bits.
+func (w *Writer) WriteSparse(d BitData, bitCount uint) {
+	if bitCount > uint(len(d))*8 {
+		panic("bitdata: bit range out of bounds")
+	}
+
+	runs := sparseRuns(d, bitCount, w.order)
+
+	w.WriteUvarint(uint64(bitCount))
+	w.WriteUvarint(uint64(len(runs)))
+	if len(runs) == 0 {
+		return
+	}
+
+	k := sparseOrder(runs)
+	w.Write8(k, 5)
+	for _, run := range runs {
+		w.WriteExpGolomb(run >> k)
+		w.Write64(run&mask[uint64](k), k)
+	}
+}
+
+// sparseRuns returns the lengths of the runs of zero bits before every one bit of the data.
+func sparseRuns(d BitData, bitCount uint, o BitOrder) []uint64 {
+	var runs []uint64
+
+	r := &Reader{data: d, end: bitCount, order: o}
+	next := uint(0)
+	for pos := uint(0); pos < bitCount; {
+		n := byte(min(bitCount-pos, 64))
+		v, _ := read[uint64](r, n)
+
+		if o == MSBFirst {
+			v <<= 64 - n
+			for v != 0 {
+				i := uint(bits.LeadingZeros64(v))
+				runs = append(runs, uint64(pos+i-next))
+				next = pos + i + 1
+				v &^= 1 << (63 - i)
+			}
+		} else {
+			for v != 0 {
+				i := uint(bits.TrailingZeros64(v))
+				runs = append(runs, uint64(pos+i-next))
+				next = pos + i + 1
+				v &= v - 1
+			}
+		}
+
+		pos += uint(n)
+	}
+
+	return runs
+}
+
+// sparseOrder returns the Exp-Golomb order that codes the run lengths in the fewest bits.
+func sparseOrder(runs []uint64) byte {
+	best, bestSize := byte(0), ^uint64(0)
+	for k := byte(0); k <= sparseMaxK; k++ {
+		var size uint64
+		for _, run := range runs {
+			size += uint64(2*bits.Len64(run>>k+1)-1) + uint64(k)
+		}
+		if size < bestSize {
+			best, bestSize = k, size
+		}
+	}
+	return best
+}
+
+// ReadSparse reads bit data written with WriteSparse and returns it expanded, in the bit order of the reader,
+// with its length in bits. It returns ErrInvalidSparse if the runs don't fit into the bit count.
+// On error the read position is left unchanged.
+func (r *Reader) ReadSparse() (BitData, uint, error) {
+	start := r.bitsRead
+
+	rr := ReaderError{reader: *r}
+	d, n, err := readSparse(&rr)
+	if rr.err != nil {
+		err = rr.err
+	}
+	if err != nil {
+		r.bitsRead = start
+		return nil, 0, err
+	}
+
+	*r = rr.reader
+
+	return d, n, nil
+}
+
+func readSparse(r *ReaderError) (BitData, uint, error) {
+	bitCount := r.ReadUvarint()
+	ones := r.ReadUvarint()
+	if r.err != nil {
+		return nil, 0, nil
+	}
+	if ones > bitCount || bitCount > uint64(^uint(0)>>1) {
+		return nil, 0, ErrInvalidSparse
+	}
+	if err := r.reader.charge((bitCount+7)/8, 1); err != nil {
+		return nil, 0, err
+	}
+
+	w := &Writer{order: r.reader.order}
+	if ones == 0 {
+		writeZeros(w, uint(bitCount))
+		return w.BitData(), uint(bitCount), nil
+	}
+
+	k := r.Read8(5)
+	for range ones {
+		run := r.ReadExpGolomb()
+		if r.err != nil {
+			return nil, 0, nil
+		}
+		if run > (bitCount-uint64(w.bitsWritten))>>k {
+			return nil, 0, ErrInvalidSparse
+		}
+		run = run<<k | r.Read64(k)
+		if r.err != nil {
+			return nil, 0, nil
+		}
+		if run >= bitCount-uint64(w.bitsWritten) {
+			return nil, 0, ErrInvalidSparse
+		}
+
+		writeZeros(w, uint(run))
+		w.WriteBool(true)
+	}
+	writeZeros(w, uint(bitCount)-w.bitsWritten)
+
+	return w.BitData(), uint(bitCount), nil
+}
+
+func (r *ReaderError) ReadSparse() (d BitData, n uint) {
+	if r.err == nil {
+		d, n, r.err = r.reader.ReadSparse()
+	}
+	return
+}
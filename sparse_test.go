@@ -0,0 +1,105 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"testing"
+)
+
+func TestSparse(t *testing.T) {
+	rnd := rand.New(rand.NewPCG(1, 2))
+
+	bitmap := func(n uint, density float64) BitData {
+		d := make(BitData, (n+7)/8)
+		for i := range n {
+			setBit(d, i, rnd.Float64() < density)
+		}
+		return d
+	}
+
+	tests := []struct {
+		name string
+		data BitData
+		bits uint
+	}{
+		{name: "empty", bits: 0},
+		{name: "zeros", data: make(BitData, 100), bits: 797},
+		{name: "ones", data: bytes.Repeat(BitData{0xFF}, 20), bits: 155},
+		{name: "sparse", data: bitmap(10000, 0.03), bits: 10000},
+		{name: "dense", data: bitmap(3001, 0.5), bits: 3001},
+		{name: "last", data: BitData{0, 0, 0x80}, bits: 24},
+	}
+
+	for _, test := range tests {
+		for _, order := range []BitOrder{LSBFirst, MSBFirst} {
+			w := NewWriter(WithBitOrder(order), WithStrict())
+			w.Write8(3, 3)
+			w.WriteSparse(test.data, test.bits)
+			w.Write8(5, 3)
+
+			r := NewReader(w.BitData(), WithBitOrder(order))
+			_, _ = r.Read8(3)
+			d, n, err := r.ReadSparse()
+			if err != nil {
+				t.Fatalf("%s %v: unexpected error: %v", test.name, order, err)
+			}
+			if v, _ := r.Read8(3); v != 5 {
+				t.Errorf("%s %v: unexpected trailing value %d", test.name, order, v)
+			}
+
+			if n != test.bits {
+				t.Errorf("%s %v: expected %d bits, got %d", test.name, order, test.bits, n)
+			}
+			want, got := NewReaderBits(test.data, test.bits, WithBitOrder(order)), NewReaderBits(d, n, WithBitOrder(order))
+			for i := uint(0); i < n; i++ {
+				a, _ := want.ReadBool()
+				b, _ := got.ReadBool()
+				if a != b {
+					t.Fatalf("%s %v: bit %d differs", test.name, order, i)
+				}
+			}
+		}
+	}
+
+	w := NewWriter()
+	w.WriteSparse(bitmap(10000, 0.03), 10000)
+	if w.BitsWritten() > 10000/4 {
+		t.Errorf("expected sparse data to shrink, got %d bits", w.BitsWritten())
+	}
+}
+
+func TestSparseErrors(t *testing.T) {
+	w := NewWriter()
+	w.WriteSparse(BitData{0x10, 0x01}, 16)
+	d := w.BitData()
+
+	for n := uint(0); n < w.BitsWritten(); n++ {
+		r := NewReaderBits(d, n)
+		if _, _, err := r.ReadSparse(); !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Errorf("%d bits: expected io.ErrUnexpectedEOF, got %v", n, err)
+		}
+		if r.BitsRead() != 0 {
+			t.Errorf("%d bits: read position moved to %d", n, r.BitsRead())
+		}
+	}
+
+	invalid := NewWriter()
+	invalid.WriteUvarint(8)
+	invalid.WriteUvarint(9)
+	if _, _, err := NewReader(invalid.BitData()).ReadSparse(); !errors.Is(err, ErrInvalidSparse) {
+		t.Errorf("expected ErrInvalidSparse for too many ones, got %v", err)
+	}
+
+	invalid = NewWriter()
+	invalid.WriteUvarint(8)
+	invalid.WriteUvarint(1)
+	invalid.Write8(0, 5)
+	invalid.WriteExpGolomb(8)
+	if _, _, err := NewReader(invalid.BitData()).ReadSparse(); !errors.Is(err, ErrInvalidSparse) {
+		t.Errorf("expected ErrInvalidSparse for a run past the end, got %v", err)
+	}
+}
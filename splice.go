@@ -0,0 +1,162 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+// Extract copies lengthBits bits starting at the bit offset offsetBits into a new BitData.
+// It panics if the range is out of bounds.
+func (d BitData) Extract(offsetBits, lengthBits uint) BitData {
+	w := NewWriter()
+	writeBits(w, d, offsetBits, lengthBits)
+	return w.BitData()
+}
+
+// Slice returns the lengthBits bits starting at the bit offset startBits as a BitData starting at bit 0.
+// If startBits is a multiple of 8, the result shares the storage with d and its last byte may hold bits
+// past the range; otherwise it's a copy like Extract returns. It panics if the range is out of bounds.
+func (d BitData) Slice(startBits, lengthBits uint) BitData {
+	if startBits%8 != 0 {
+		return d.Extract(startBits, lengthBits)
+	}
+	if startBits+lengthBits > uint(len(d))*8 {
+		panic("bitdata: bit range out of bounds")
+	}
+	return d[startBits/8 : (startBits+lengthBits+7)/8]
+}
+
+// Overwrite replaces the srcBits bits of the data starting at the bit offset offsetBits with the first
+// srcBits bits of src, in place. The bits around the range are preserved. It panics if the range is out of bounds.
+func (d BitData) Overwrite(offsetBits uint, src BitData, srcBits uint) {
+	if offsetBits+srcBits > uint(len(d))*8 {
+		panic("bitdata: bit range out of bounds")
+	}
+
+	r := NewReader(src)
+	for srcBits > 0 {
+		n := byte(min(srcBits, 64))
+
+		v, err := read[uint64](r, n)
+		if err != nil {
+			panic("bitdata: bit range out of bounds")
+		}
+		putBits(d, offsetBits, v, n)
+
+		offsetBits += uint(n)
+		srcBits -= uint(n)
+	}
+}
+
+// Insert returns a copy of the first bitCount bits of the data with the first srcBits bits of src
+// inserted at the bit offset offsetBits, and the new bit length. It panics if the range is out of bounds.
+func (d BitData) Insert(bitCount, offsetBits uint, src BitData, srcBits uint) (BitData, uint) {
+	if offsetBits > bitCount {
+		panic("bitdata: bit range out of bounds")
+	}
+
+	w := NewWriter()
+	writeBits(w, d, 0, offsetBits)
+	writeBits(w, src, 0, srcBits)
+	writeBits(w, d, offsetBits, bitCount-offsetBits)
+
+	return w.BitData(), w.bitsWritten
+}
+
+// WriteBitData appends the first bitCount bits of d, taken in the bit order of the writer. If the writer
+// is at a byte boundary, the whole bytes are copied directly. It panics if d is shorter than bitCount bits.
+func (w *Writer) WriteBitData(d BitData, bitCount uint) {
+	if bitCount > uint(len(d))*8 {
+		panic("bitdata: bit range out of bounds")
+	}
+
+	if w.recording || w.trace != nil || w.bitsWritten%8 != 0 {
+		r := NewReader(d)
+		r.order = w.order
+		for bitCount > 0 {
+			n := byte(min(bitCount, 64))
+			v, _ := read[uint64](r, n)
+			w.Write64(v, n)
+			bitCount -= uint(n)
+		}
+		return
+	}
+
+	whole := bitCount / 8
+	w.appendBytes(d[:whole])
+	w.bitsWritten += whole * 8
+	w.checkBudget()
+
+	if rem := byte(bitCount % 8); rem > 0 && w.order == MSBFirst {
+		write[byte](w, d[whole]>>(8-rem), rem)
+	} else if rem > 0 {
+		write[byte](w, d[whole], rem)
+	}
+}
+
+// WriteBitsFrom appends the next bitCount bits of the reader, which advances past them. The bits keep
+// their stream order even if the reader and the writer use different bit orders. If fewer than bitCount
+// bits remain, io.ErrUnexpectedEOF is returned and nothing is copied.
+func (w *Writer) WriteBitsFrom(r *Reader, bitCount uint) error {
+	if ok, err := r.ensure(bitCount); !ok {
+		if err == nil {
+			w.WriteZeros(bitCount)
+		}
+		return err
+	}
+	return copyBits(w, r, bitCount)
+}
+
+// ReadRemaining returns all unread bits of the reader, starting at bit 0 in the bit order of the reader,
+// and their count, and moves the read position to the end. For a reader whose data ends only when its source
+// does, the bits are read until the end of the source or an error; a reader of NewRandomReader never ends.
+func (r *Reader) ReadRemaining() (BitData, uint) {
+	w := &Writer{order: r.order}
+
+	if r.src == nil && r.bitsRead%8 == 0 && r.bitsRead <= r.end {
+		w.WriteBitData(r.data[r.bitsRead/8:], r.end-r.bitsRead)
+		r.bitsRead = r.end
+		return w.BitData(), w.bitsWritten
+	}
+
+	for r.bitsRead < r.end {
+		n := byte(min(r.end-r.bitsRead, 64))
+		v, err := read[uint64](r, n)
+		if err != nil && r.bitsRead+uint(n) <= r.end {
+			break
+		}
+		if err == nil {
+			write[uint64](w, v, n)
+		}
+	}
+
+	return w.BitData(), w.bitsWritten
+}
+
+func (r *ReaderError) ReadRemaining() (d BitData, n uint) {
+	if r.err == nil {
+		d, n = r.reader.ReadRemaining()
+	}
+	return
+}
+
+// Span is a bit range of a BitData, Length bits starting at the bit offset Offset.
+type Span struct {
+	Data   BitData
+	Offset uint
+	Length uint
+}
+
+// WriteSpans appends the bits of the spans in order, copying them without decoding, for example
+// to assemble a packet from a template and per-packet fields. The spans are taken in the bit order
+// of the writer. It panics if a span is out of bounds.
+func (w *Writer) WriteSpans(spans ...Span) {
+	for _, s := range spans {
+		writeBits(w, s.Data, s.Offset, s.Length)
+	}
+}
+
+// Gather returns the bits of the spans concatenated and the total length in bits.
+// It panics if a span is out of bounds.
+func Gather(spans ...Span) (BitData, uint) {
+	w := NewWriter()
+	w.WriteSpans(spans...)
+	return w.BitData(), w.bitsWritten
+}
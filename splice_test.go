@@ -0,0 +1,265 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestExtract(t *testing.T) {
+	d, _, _ := ParseBits("1011 0011 1000 1")
+
+	tests := []struct {
+		offset, length uint
+		exp            string
+	}{
+		{offset: 0, length: 0, exp: ""},
+		{offset: 0, length: 4, exp: "1011"},
+		{offset: 3, length: 7, exp: "1001 110"},
+		{offset: 8, length: 5, exp: "1000 1"},
+	}
+
+	for _, test := range tests {
+		exp, _, _ := ParseBits(test.exp)
+		if got := d.Extract(test.offset, test.length); !bytes.Equal(exp, got) {
+			t.Errorf("Extract(%d, %d): want=%s got=%s", test.offset, test.length, test.exp, got.FormatBinary(test.length, 4))
+		}
+	}
+}
+
+func TestBitDataSlice(t *testing.T) {
+	d, _, _ := ParseBits("1011 0011 1000 1101 0110 0000")
+
+	tests := []struct {
+		offset, length uint
+		exp            string
+	}{
+		{offset: 0, length: 0, exp: ""},
+		{offset: 3, length: 7, exp: "1001 110"},
+		{offset: 8, length: 8, exp: "1000 1101"},
+		{offset: 8, length: 16, exp: "1000 1101 0110 0000"},
+	}
+
+	for _, test := range tests {
+		got := d.Slice(test.offset, test.length)
+		if want, got := test.exp, got.FormatBinary(test.length, 4); want != got {
+			t.Errorf("Slice(%d, %d): want=%s got=%s", test.offset, test.length, want, got)
+		}
+	}
+
+	s := d.Slice(8, 5)
+	if len(s) != 1 {
+		t.Errorf("aligned slice length: %d", len(s))
+	}
+	s[0] = 0
+	if d[1] != 0 {
+		t.Error("aligned slice doesn't share the storage")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic")
+		}
+	}()
+	d.Slice(16, 9)
+}
+
+func TestOverwrite(t *testing.T) {
+	w := NewWriter()
+	w.Write8(0b101, 3)
+	w.Write16(0x123, 12) // field to rewrite
+	w.Write8(0b11, 2)
+	d := w.BitData()
+
+	patch := NewWriter()
+	patch.Write16(0xABC, 12)
+	d.Overwrite(3, patch.BitData(), 12)
+
+	r := NewReaderError(d)
+	if a, b, c := r.Read8(3), r.Read16(12), r.Read8(2); a != 0b101 || b != 0xABC || c != 0b11 {
+		t.Errorf("value mismatch: %b %x %b", a, b, c)
+	}
+
+	wide := make(BitData, 20)
+	src := BitData{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF}
+	wide.Overwrite(5, src, 75)
+	if got := wide.OnesCount(0, 160); got != 75 {
+		t.Errorf("ones count mismatch: want=75 got=%d", got)
+	}
+	if offset, length := wide.LongestRun(0, 160, true); offset != 5 || length != 75 {
+		t.Errorf("run mismatch: want=5,75 got=%d,%d", offset, length)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected panic")
+		}
+	}()
+	d.Overwrite(15, patch.BitData(), 12)
+}
+
+func TestInsert(t *testing.T) {
+	d, n, _ := ParseBits("1011 0011 1")
+	src, srcBits, _ := ParseBits("000")
+
+	tests := []struct {
+		offset  uint
+		exp     string
+		expBits uint
+	}{
+		{offset: 0, exp: "0001 0110 0111", expBits: 12},
+		{offset: 4, exp: "1011 0000 0111", expBits: 12},
+		{offset: 9, exp: "1011 0011 1000", expBits: 12},
+	}
+
+	for _, test := range tests {
+		got, bits := d.Insert(n, test.offset, src, srcBits)
+		exp, _, _ := ParseBits(test.exp)
+		if bits != test.expBits || !bytes.Equal(exp, got) {
+			t.Errorf("Insert at %d: want=%s got=%s", test.offset, test.exp, got.FormatBinary(bits, 4))
+		}
+	}
+}
+
+func TestWriteBitData(t *testing.T) {
+	src := NewWriter()
+	src.Write64(0xDEADBEEFCAFEF00D, 64)
+	src.Write16(0x1FF, 9)
+	d := src.BitData()
+
+	for _, prefix := range []byte{0, 3, 8} {
+		for _, record := range []bool{false, true} {
+			want := NewWriter()
+			want.Write8(0b101, prefix)
+			want.Write64(0xDEADBEEFCAFEF00D, 64)
+			want.Write16(0x1FF, 9)
+			want.WriteBool(true)
+
+			got := NewWriter()
+			got.SetRecording(record)
+			got.Write8(0b101, prefix)
+			got.WriteBitData(d, 73)
+			got.WriteBool(true)
+
+			if !bytes.Equal(want.BitData(), got.BitData()) || want.BitsWritten() != got.BitsWritten() {
+				t.Errorf("prefix %d, recording %t: want=%x got=%x", prefix, record, want.BitData(), got.BitData())
+			}
+		}
+	}
+
+	w := NewWriter()
+	w.WriteBitData(BitData{0xFF}, 3)
+	if w.BitData()[0] != 0b111 || w.BitsWritten() != 3 {
+		t.Errorf("padding bits copied: %b", w.BitData()[0])
+	}
+}
+
+func TestWriteBitsFrom(t *testing.T) {
+	src := NewWriter()
+	src.Write8(0b11, 2)
+	src.Write64(0xDEADBEEFCAFEF00D, 64)
+	src.Write16(0x1FF, 9)
+
+	for _, o := range []BitOrder{LSBFirst, MSBFirst} {
+		r := NewReader(src.BitData())
+		r.Skip(2)
+
+		w := NewWriter(WithBitOrder(o))
+		w.WriteBool(true)
+		if err := w.WriteBitsFrom(r, 73); err != nil {
+			t.Fatalf("order %d: unexpected error: %v", o, err)
+		}
+		if r.BitsRead() != 75 || w.BitsWritten() != 74 {
+			t.Errorf("order %d: positions: read=%d written=%d", o, r.BitsRead(), w.BitsWritten())
+		}
+
+		want, _, _ := ParseBits("1" + src.BitData().FormatBinary(75, 0)[2:])
+		got := w.BitData()
+		if o == MSBFirst {
+			got = got.ReverseByteBits()
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("order %d: want=%x got=%x", o, want, got)
+		}
+
+		if err := w.WriteBitsFrom(r, 6); !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Errorf("order %d: expected io.ErrUnexpectedEOF, got %v", o, err)
+		}
+		if r.BitsRead() != 75 || w.BitsWritten() != 74 {
+			t.Errorf("order %d: failed copy changed the positions", o)
+		}
+	}
+}
+
+func TestGather(t *testing.T) {
+	template, _, _ := ParseBits("1111 0000 1111 0000")
+	field, _, _ := ParseBits("101")
+
+	d, n := Gather(
+		Span{Data: template, Offset: 0, Length: 6},
+		Span{Data: field, Offset: 0, Length: 3},
+		Span{Data: template, Offset: 9, Length: 7},
+		Span{Data: field, Offset: 1, Length: 0},
+	)
+	if want, got := "111100101 1110000", d.FormatBinary(n, 9); want != got {
+		t.Errorf("want=%s got=%s", want, got)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic")
+		}
+	}()
+	Gather(Span{Data: field, Offset: 4, Length: 5})
+}
+
+func TestReadRemaining(t *testing.T) {
+	for _, order := range []BitOrder{LSBFirst, MSBFirst} {
+		for _, head := range []byte{0, 3, 8, 13} {
+			w := NewWriter(WithBitOrder(order))
+			w.Write16(0x1ABC, head)
+			tail := NewWriter(WithBitOrder(order))
+			for i := range 40 {
+				w.Write8(uint8(i), 7)
+				tail.Write8(uint8(i), 7)
+			}
+
+			data := w.BitData()
+			readers := map[string]*Reader{
+				"data":   NewReaderBits(data, w.BitsWritten(), WithBitOrder(order)),
+				"string": NewReaderString(string(data)),
+			}
+			readers["string"].order = order
+			readers["string"].end = w.BitsWritten()
+
+			for name, r := range readers {
+				_, _ = r.Read16(head)
+				d, n := r.ReadRemaining()
+				if n != tail.BitsWritten() || !bytes.Equal(d, tail.BitData()) {
+					t.Errorf("%v %s head=%d: got %d bits %x, want %d bits %x", order, name, head, n, d, tail.BitsWritten(), tail.BitData())
+				}
+				if r.BitsRead() != w.BitsWritten() {
+					t.Errorf("%v %s head=%d: expected the reader at the end, got %d", order, name, head, r.BitsRead())
+				}
+				if d, n := r.ReadRemaining(); n != 0 || len(d) != 0 {
+					t.Errorf("%v %s head=%d: expected nothing after the end, got %d bits", order, name, head, n)
+				}
+			}
+		}
+	}
+
+	r := NewReaderBits(BitData{0xFF, 0xFF}, 12)
+	_, _ = r.Read8(8)
+	if d, n := r.ReadRemaining(); n != 4 || !bytes.Equal(d, BitData{0x0F}) {
+		t.Errorf("expected the bits past the end cleared, got %d bits %x", n, d)
+	}
+
+	rr := NewReaderError(BitData{1})
+	rr.Read16(16)
+	if d, n := rr.ReadRemaining(); d != nil || n != 0 {
+		t.Errorf("expected nothing after an error, got %d bits", n)
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"math/bits"
+)
+
+// OnesCount returns the number of one bits in the bitCount bits starting at the bit offset fromBit.
+// Like FormatBinary, the range is clamped to the length of the data.
+func (d BitData) OnesCount(fromBit, bitCount uint) int {
+	fromBit, bitCount = d.clampRange(fromBit, bitCount)
+	return onesCount(d, fromBit, bitCount)
+}
+
+// LongestRun returns the offset and the length of the longest run of bits equal to bit
+// in the bitCount bits starting at the bit offset fromBit. If there are several runs of that length,
+// the first one is returned. The range is clamped to the length of the data.
+func (d BitData) LongestRun(fromBit, bitCount uint, bit bool) (offset, length uint) {
+	fromBit, bitCount = d.clampRange(fromBit, bitCount)
+
+	r := NewReader(d)
+	r.Skip(fromBit)
+
+	var run, runStart uint
+	for pos, end := fromBit, fromBit+bitCount; pos < end; {
+		n := uint(min(64, end-pos))
+
+		x, _ := read[uint64](r, byte(n))
+		if !bit {
+			x = ^x & mask[uint64](byte(n))
+		}
+
+		for i := uint(0); i < n; {
+			if ones := min(uint(bits.TrailingZeros64(^(x >> i))), n-i); ones > 0 {
+				if run == 0 {
+					runStart = pos + i
+				}
+				run += ones
+				i += ones
+
+				if run > length {
+					offset, length = runStart, run
+				}
+			}
+
+			if i < n {
+				run = 0
+				i += min(uint(bits.TrailingZeros64(x>>i)), n-i)
+			}
+		}
+
+		pos += n
+	}
+
+	return
+}
+
+func (d BitData) clampRange(fromBit, bitCount uint) (uint, uint) {
+	size := uint(len(d)) * 8
+	if fromBit > size {
+		fromBit = size
+	}
+	if bitCount > size-fromBit {
+		bitCount = size - fromBit
+	}
+	return fromBit, bitCount
+}
+
+func onesCount(d BitData, from, n uint) int {
+	count := 0
+
+	if ofs := from % 8; ofs != 0 && n > 0 {
+		take := min(8-ofs, n)
+		count += bits.OnesCount8(d[from/8] >> ofs & mask[byte](byte(take)))
+		from += take
+		n -= take
+	}
+
+	i := from / 8
+	for ; n >= 64; n -= 64 {
+		count += bits.OnesCount64(load64(d[i:]))
+		i += 8
+	}
+	for ; n >= 8; n -= 8 {
+		count += bits.OnesCount8(d[i])
+		i++
+	}
+	if n > 0 {
+		count += bits.OnesCount8(d[i] & mask[byte](byte(n)))
+	}
+
+	return count
+}
@@ -0,0 +1,70 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestOnesCount(t *testing.T) {
+	rnd := rand.New(rand.NewSource(42))
+	d := make(BitData, 40)
+	rnd.Read(d)
+
+	for i := 0; i < 500; i++ {
+		from := uint(rnd.Intn(330))
+		n := uint(rnd.Intn(330))
+
+		want := 0
+		for j := from; j < from+n && j < 320; j++ {
+			if getBit(d, j) {
+				want++
+			}
+		}
+
+		if got := d.OnesCount(from, n); want != got {
+			t.Errorf("OnesCount(%d, %d): want=%d got=%d", from, n, want, got)
+		}
+	}
+}
+
+func TestLongestRun(t *testing.T) {
+	d, _, _ := ParseBits("0110 1111 1000 0000 0001 1")
+
+	tests := []struct {
+		name      string
+		from, n   uint
+		bit       bool
+		expOffset uint
+		expLength uint
+	}{
+		{name: "ones", from: 0, n: 100, bit: true, expOffset: 4, expLength: 5},
+		{name: "zeros", from: 0, n: 100, bit: false, expOffset: 9, expLength: 10},
+		{name: "zeros-with-padding", from: 0, n: 24, bit: false, expOffset: 9, expLength: 10},
+		{name: "first-of-equal", from: 0, n: 4, bit: true, expOffset: 1, expLength: 2},
+		{name: "clipped", from: 6, n: 2, bit: true, expOffset: 6, expLength: 2},
+		{name: "none", from: 4, n: 5, bit: false, expOffset: 0, expLength: 0},
+		{name: "empty", from: 100, n: 5, bit: true, expOffset: 0, expLength: 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			offset, length := d.LongestRun(test.from, test.n, test.bit)
+			if offset != test.expOffset || length != test.expLength {
+				t.Errorf("want=%d,%d got=%d,%d", test.expOffset, test.expLength, offset, length)
+			}
+		})
+	}
+
+	long := make(BitData, 30)
+	for i := uint(70); i < 200; i++ {
+		setBit(long, i, true)
+	}
+	if offset, length := long.LongestRun(0, 240, true); offset != 70 || length != 130 {
+		t.Errorf("want=70,130 got=%d,%d", offset, length)
+	}
+	if offset, length := long.LongestRun(3, 240, false); offset != 3 || length != 67 {
+		t.Errorf("want=3,67 got=%d,%d", offset, length)
+	}
+}
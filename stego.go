@@ -0,0 +1,85 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrCarrierTooSmall = errors.New("carrier too small")
+
+// StegoFormat describes how data is hidden in a carrier: in the BitsPerByte lowest bits, 1 to 8,
+// of every Stride-th byte of the carrier, starting with the first.
+type StegoFormat struct {
+	BitsPerByte byte
+	Stride      int
+}
+
+// DefaultStego uses the lowest bit of every carrier byte.
+var DefaultStego = StegoFormat{BitsPerByte: 1, Stride: 1}
+
+func (f StegoFormat) check() {
+	if f.BitsPerByte < 1 || f.BitsPerByte > 8 || f.Stride < 1 {
+		panic("bitdata: invalid stego format")
+	}
+}
+
+// Capacity returns the number of bits that can be hidden in a carrier of the given length,
+// including the length header.
+func (f StegoFormat) Capacity(carrierLen int) uint {
+	f.check()
+	return uint((carrierLen+f.Stride-1)/f.Stride) * uint(f.BitsPerByte)
+}
+
+// Embed hides the first bitCount bits of the data, preceded by their count as an uvarint, in the low bits
+// of the carrier, in place. The other bits of the carrier are preserved. It returns ErrCarrierTooSmall,
+// leaving the carrier unchanged, if the carrier can't hold the data.
+func Embed(carrier []byte, d BitData, bitCount uint, f StegoFormat) error {
+	f.check()
+
+	w := NewWriter()
+	w.WriteUvarint(uint64(bitCount))
+	w.WriteBitData(d, bitCount)
+
+	if need, capacity := w.bitsWritten, f.Capacity(len(carrier)); need > capacity {
+		return fmt.Errorf("%w: %d bits needed, %d available", ErrCarrierTooSmall, need, capacity)
+	}
+
+	r := NewReader(w.BitData())
+	m := mask[byte](f.BitsPerByte)
+	for i, remain := 0, w.bitsWritten; remain > 0; i += f.Stride {
+		n := byte(min(remain, uint(f.BitsPerByte)))
+		v, _ := r.Read8(n)
+		carrier[i] = carrier[i]&^m | v
+		remain -= uint(n)
+	}
+
+	return nil
+}
+
+// Extract returns the data hidden in the carrier by Embed and its length in bits.
+// It returns ErrCarrierTooSmall if the carrier is shorter than the length header claims.
+func Extract(carrier []byte, f StegoFormat) (BitData, uint, error) {
+	f.check()
+
+	w := NewWriter()
+	for i := 0; i < len(carrier); i += f.Stride {
+		w.Write8(carrier[i], f.BitsPerByte)
+	}
+
+	r := NewReader(w.BitData())
+	r.end = w.bitsWritten
+
+	n, err := r.ReadUvarint()
+	if err != nil {
+		return nil, 0, fmt.Errorf("%w: %w", ErrCarrierTooSmall, err)
+	}
+	if n > uint64(r.end-r.bitsRead) {
+		return nil, 0, fmt.Errorf("%w: %d bits claimed, %d available", ErrCarrierTooSmall, n, r.end-r.bitsRead)
+	}
+
+	d := w.BitData().Extract(r.bitsRead, uint(n))
+
+	return d, uint(n), nil
+}
@@ -0,0 +1,54 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"bytes"
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+func TestStego(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	orig := make([]byte, 1000)
+	rnd.Read(orig)
+	secret := BitData("hidden message")
+
+	for _, f := range []StegoFormat{DefaultStego, {BitsPerByte: 2, Stride: 3}, {BitsPerByte: 8, Stride: 1}, {BitsPerByte: 3, Stride: 7}} {
+		carrier := bytes.Clone(orig)
+		if err := Embed(carrier, secret, 109, f); err != nil {
+			t.Fatalf("%+v: %v", f, err)
+		}
+
+		for i := range carrier {
+			keep := ^mask[byte](f.BitsPerByte)
+			if i%f.Stride != 0 {
+				keep = 0xFF
+			}
+			if carrier[i]&keep != orig[i]&keep {
+				t.Fatalf("%+v: byte %d changed outside the low bits", f, i)
+			}
+		}
+
+		d, n, err := Extract(carrier, f)
+		if err != nil {
+			t.Fatalf("%+v: %v", f, err)
+		}
+		if n != 109 || !bytes.Equal(d, secret.Extract(0, 109)) {
+			t.Errorf("%+v: got %q (%d bits)", f, d, n)
+		}
+	}
+
+	carrier := bytes.Clone(orig[:100])
+	if err := Embed(carrier, secret, 109, DefaultStego); !errors.Is(err, ErrCarrierTooSmall) {
+		t.Errorf("expected ErrCarrierTooSmall, got %v", err)
+	}
+	if !bytes.Equal(carrier, orig[:100]) {
+		t.Error("carrier changed by a failed Embed")
+	}
+
+	if _, _, err := Extract([]byte{0xFF, 0xFF, 0xFF}, StegoFormat{BitsPerByte: 8, Stride: 1}); !errors.Is(err, ErrCarrierTooSmall) {
+		t.Errorf("expected ErrCarrierTooSmall, got %v", err)
+	}
+}
@@ -0,0 +1,225 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+// writerChunkSize is the size of the chunks the Writer stores its data in. The first chunk grows
+// like a regular slice up to this size; after that, full chunks are kept and a new one is started,
+// so a growing writer never copies the data written so far.
+const writerChunkSize = 64 << 10
+
+// WriteTo writes the data to dst chunk by chunk, without assembling it first. It implements io.WriterTo.
+func (w *Writer) WriteTo(dst io.Writer) (int64, error) {
+	var total int64
+	for _, c := range w.chunks {
+		n, err := dst.Write(c)
+		total += int64(n)
+		if err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
+
+// assemble joins all chunks into one, which is then also used as the first chunk for further writes.
+func (w *Writer) assemble() BitData {
+	switch len(w.chunks) {
+	case 0:
+		return nil
+	case 1:
+		return w.chunks[0]
+	}
+
+	d := BitData(w.newChunk(int(w.size - w.base)))
+	for _, c := range w.chunks {
+		d = append(d, c...)
+	}
+	w.freeChunks(w.chunks)
+	w.chunks = append(w.chunks[:0], d)
+	w.shared = nil
+
+	return d
+}
+
+// appendByte adds a byte at the end of the data.
+func (w *Writer) appendByte(b byte) {
+	c := w.lastChunk()
+	w.reserve(c, 1)
+	*c = append(*c, b)
+	w.size++
+}
+
+// appendAligned adds a full 8, 16, 32 or 64 bits wide value at the end of the data.
+// It returns false for other bit counts or if the last chunk has no spare capacity for the value.
+func (w *Writer) appendAligned(v uint64, bitCount byte) bool {
+	c := w.lastChunk()
+	l, n := len(*c), int(bitCount/8)
+	if l+n > cap(*c) {
+		return false
+	}
+
+	b := (*c)[l : l+n]
+	switch bitCount {
+	case 8:
+		b[0] = byte(v)
+	case 16:
+		binary.LittleEndian.PutUint16(b, uint16(v))
+	case 32:
+		binary.LittleEndian.PutUint32(b, uint32(v))
+	case 64:
+		store64(b, v)
+	default:
+		return false
+	}
+
+	// Reslicing in place only updates the length, which avoids a write barrier.
+	*c = (*c)[:l+n]
+	w.size += uint(n)
+
+	return true
+}
+
+// appendWord adds the lowest n bytes of v, n at most 8, at the end of the data.
+func (w *Writer) appendWord(v uint64, n uint) {
+	if n == 0 {
+		return
+	}
+
+	c := w.lastChunk()
+	if len(*c)+8 <= writerChunkSize {
+		w.reserve(c, 8)
+	}
+	l := len(*c)
+
+	switch {
+	case l+8 <= cap(*c):
+		store64((*c)[l:l+8], v)
+		*c = (*c)[:l+int(n)]
+	case l+8 <= writerChunkSize:
+		*c = binary.LittleEndian.AppendUint64(*c, v)[:l+int(n)]
+	default:
+		for ; n > 0; n-- {
+			w.appendByte(byte(v))
+			v >>= 8
+		}
+		return
+	}
+
+	w.size += n
+}
+
+// appendBytes adds the bytes at the end of the data.
+func (w *Writer) appendBytes(p []byte) {
+	for len(p) > 0 {
+		c := w.lastChunk()
+
+		n := len(p)
+		if room := max(cap(*c), writerChunkSize) - len(*c); n > room {
+			n = room
+		}
+
+		w.reserve(c, n)
+		*c = append(*c, p[:n]...)
+		w.size += uint(n)
+		p = p[n:]
+	}
+}
+
+// lastChunk returns the chunk new bytes are appended to, starting a new one if the last one is full.
+func (w *Writer) lastChunk() *[]byte {
+	n := len(w.chunks)
+	if n == 0 {
+		w.chunks = append(w.chunks, nil)
+		return &w.chunks[0]
+	}
+
+	if c := &w.chunks[n-1]; len(*c) < cap(*c) || len(*c) < writerChunkSize {
+		return c
+	}
+
+	if w.discard {
+		// A size calculator keeps only the bytes from the one holding the write position.
+		if keep := w.bitsWritten / 8; keep > w.base && w.size-keep < writerChunkSize {
+			tail := append(w.newChunk(writerChunkSize), w.bytes(keep, w.size)...)
+			w.freeChunks(w.chunks)
+			w.chunks = append(w.chunks[:0], tail)
+			w.base = keep
+			return &w.chunks[0]
+		}
+	}
+
+	w.chunks = append(w.chunks, w.newChunk(writerChunkSize))
+
+	return &w.chunks[len(w.chunks)-1]
+}
+
+// byteAt returns a pointer to the byte at the index idx. Recently written bytes are found fastest.
+func (w *Writer) byteAt(idx uint) *byte {
+	end := w.size
+	for i := len(w.chunks) - 1; i >= 0; i-- {
+		c := w.chunks[i]
+		start := end - uint(len(c))
+		if idx >= start {
+			if i == 0 && w.isShared(c) {
+				w.unshare()
+				c = w.chunks[0]
+			}
+			return &c[idx-start]
+		}
+		end = start
+	}
+
+	if w.discard && idx < w.base {
+		// The byte was dropped by a size calculator, so the change doesn't matter.
+		return &w.dropped
+	}
+
+	panic("bitdata: byte index out of bounds")
+}
+
+// bytes returns the written bytes from the byte offset from up to the byte offset to.
+// The result is a copy if the range spans several chunks.
+func (w *Writer) bytes(from, to uint) BitData {
+	var d BitData
+	start := w.base
+
+	for _, c := range w.chunks {
+		end := start + uint(len(c))
+		if from < end && to > start {
+			lo, hi := max(from, start)-start, min(to, end)-start
+			if from >= start && to <= end {
+				return c[lo:hi]
+			}
+			d = append(d, c[lo:hi]...)
+		}
+		start = end
+	}
+
+	return d
+}
+
+// putBits overwrites bitCount already written bits at the bit offset offsetBits with the lowest bits of v.
+func (w *Writer) putBits(offsetBits uint, v uint64, bitCount byte) {
+	for bitCount > 0 {
+		ofs := offsetBits % 8
+		n := min(8-byte(ofs), bitCount)
+
+		b := w.byteAt(offsetBits / 8)
+		if w.order == MSBFirst {
+			shift := 8 - byte(ofs) - n
+			m := mask[byte](n) << shift
+			*b = *b&^m | byte(v>>(bitCount-n))<<shift&m
+		} else {
+			m := mask[byte](n) << ofs
+			*b = *b&^m | byte(v<<ofs)&m
+			v >>= n
+		}
+
+		offsetBits += uint(n)
+		bitCount -= n
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+)
+
+func TestWriterChunks(t *testing.T) {
+	rnd := rand.New(rand.NewSource(7))
+
+	type field struct {
+		v    uint64
+		bits byte
+	}
+
+	fields := make([]field, 100_000)
+	for i := range fields {
+		bits := byte(rnd.Intn(64) + 1)
+		fields[i] = field{v: rnd.Uint64() & mask[uint64](bits), bits: bits}
+	}
+
+	w := NewWriter()
+	for i, f := range fields {
+		w.Write64(f.v, f.bits)
+		if i == len(fields)/2 {
+			_ = w.BitData() // assembling in the middle must not disturb later writes
+		}
+	}
+
+	if len(w.chunks) < 3 {
+		t.Errorf("expected several chunks, got %d", len(w.chunks))
+	}
+
+	var buf bytes.Buffer
+	n, err := w.WriteTo(&buf)
+	if err != nil || n != int64((w.BitsWritten()+7)/8) {
+		t.Errorf("WriteTo: n=%d err=%v", n, err)
+	}
+
+	d := w.BitData()
+	if !bytes.Equal(d, buf.Bytes()) {
+		t.Errorf("WriteTo and BitData differ")
+	}
+
+	r := NewReader(d)
+	for i, f := range fields {
+		if v, err := r.Read64(f.bits); err != nil || v != f.v {
+			t.Errorf("field %d mismatch: want=%d got=%d err=%v", i, f.v, v, err)
+			return
+		}
+	}
+}
+
+func TestWriterChunkBoundary(t *testing.T) {
+	w := NewWriter()
+	w.Write8(0b1, 3)
+	_, _ = w.Write(make([]byte, writerChunkSize))
+	last := w.chunks[len(w.chunks)-1]
+	_, _ = w.Write(make([]byte, cap(last)-len(last)-4))
+	start := w.BitsWritten()
+
+	// the message prefix and the CRC input span the chunk boundary
+	w.BeginMessage(16)
+	_, _ = w.Write(bytes.Repeat([]byte{0xA5}, 100))
+	if err := w.EndMessage(); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	w.WriteCRC(CRC32, 0)
+	w.WriteParity(EvenParity, 200)
+
+	if len(w.chunks) < 2 {
+		t.Errorf("expected several chunks, got %d", len(w.chunks))
+	}
+
+	r := NewReader(w.BitData())
+	r.Skip(start)
+	msg, err := r.ReadMessage(16)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+		return
+	}
+	if msg.end-msg.bitsRead != 800 {
+		t.Errorf("message length mismatch: want=800 got=%d", msg.end-msg.bitsRead)
+	}
+	if err := r.VerifyCRC(CRC32, 0); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	if err := r.CheckParity(EvenParity, 200); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
+
+func TestWriterViewAndCopy(t *testing.T) {
+	w := NewWriter()
+	w.Write8(0b101, 3)
+
+	view := w.BitData()
+	cp := w.AppendBitData(nil)
+	prefixed := w.AppendBitData(BitData{0xFF})
+
+	w.Write8(0b11111, 5)
+	w.Write16(0xFFFF, 16)
+
+	if view[0] != 0b11111101 {
+		t.Errorf("view should see the completed byte: %08b", view[0])
+	}
+	if len(view) != 1 {
+		t.Errorf("view length changed: %d", len(view))
+	}
+	if !bytes.Equal(cp, BitData{0b101}) {
+		t.Errorf("copy changed: %08b", cp)
+	}
+	if !bytes.Equal(prefixed, BitData{0xFF, 0b101}) {
+		t.Errorf("appended copy mismatch: %x", prefixed)
+	}
+	if !bytes.Equal(w.AppendBitData(nil), w.BitData()) {
+		t.Errorf("copy and view differ")
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+)
+
+// StringDict compresses short strings against a static dictionary of frequent substrings, in the style
+// of smaz and FSST. Every substring found in the dictionary is replaced with its index, other bytes are
+// written as an escape code followed by the byte. The codes take the minimal number of bits
+// that can hold the escape code, which is the number of entries.
+type StringDict struct {
+	entries  []string
+	codeBits byte
+	byFirst  [256][]int // entry indices by their first byte, longest first
+}
+
+// NewStringDict returns a dictionary with the entries. Both sides of a stream must use the same entries
+// in the same order. It panics if an entry is empty.
+func NewStringDict(entries ...string) *StringDict {
+	d := &StringDict{
+		entries:  slices.Clone(entries),
+		codeBits: MinBits(uint64(len(entries))),
+	}
+
+	for i, e := range d.entries {
+		if e == "" {
+			panic("bitdata: empty string dictionary entry")
+		}
+		d.byFirst[e[0]] = append(d.byFirst[e[0]], i)
+	}
+	for _, list := range d.byFirst {
+		slices.SortStableFunc(list, func(a, b int) int {
+			return cmp.Compare(len(d.entries[b]), len(d.entries[a]))
+		})
+	}
+
+	return d
+}
+
+// WriteDictString writes the length of s in bytes as an LEB128 varint followed by the codes of s,
+// using the longest dictionary entry at every position.
+func (w *Writer) WriteDictString(d *StringDict, s string) {
+	w.WriteUvarint(uint64(len(s)))
+
+	escape := uint64(len(d.entries))
+	for i := 0; i < len(s); {
+		match := -1
+		for _, e := range d.byFirst[s[i]] {
+			if strings.HasPrefix(s[i:], d.entries[e]) {
+				match = e
+				break
+			}
+		}
+
+		if match < 0 {
+			w.Write64(escape, d.codeBits)
+			w.Write8(s[i], 8)
+			i++
+			continue
+		}
+
+		w.Write64(uint64(match), d.codeBits)
+		i += len(d.entries[match])
+	}
+}
+
+// ReadDictString reads a string written with WriteDictString using the same dictionary. It returns
+// ErrDictionaryIndex for an invalid code. On error the read position is left unchanged.
+func (r *Reader) ReadDictString(d *StringDict) (string, error) {
+	start := r.bitsRead
+
+	rr := ReaderError{reader: *r}
+	s, err := readDictString(&rr, d)
+	if rr.err != nil {
+		err = rr.err
+	}
+	if err != nil {
+		r.bitsRead = start
+		return "", err
+	}
+
+	*r = rr.reader
+
+	return s, nil
+}
+
+func readDictString(r *ReaderError, d *StringDict) (string, error) {
+	n := r.ReadUvarint()
+	if n > uint64(maxInt) {
+		return "", ErrLengthOverflow
+	}
+	if err := r.reader.checkLen(n); err != nil {
+		return "", err
+	}
+	if err := r.reader.charge(n, 1); err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	sb.Grow(preallocSize(int(n)))
+
+	escape := uint64(len(d.entries))
+	for uint64(sb.Len()) < n && r.err == nil {
+		code := r.Read64(d.codeBits)
+		switch {
+		case r.err != nil:
+		case code == escape:
+			sb.WriteByte(r.Read8(8))
+		case code < escape && uint64(sb.Len()+len(d.entries[code])) <= n:
+			sb.WriteString(d.entries[code])
+		default:
+			return "", ErrDictionaryIndex
+		}
+	}
+
+	return sb.String(), nil
+}
+
+func (r *ReaderError) ReadDictString(d *StringDict) (s string) {
+	if r.err == nil {
+		s, r.err = r.reader.ReadDictString(d)
+	}
+	return
+}
@@ -0,0 +1,60 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDictString(t *testing.T) {
+	d := NewStringDict("http://", "https://", "www.", ".com", ".org", "the", "e", " ")
+
+	strs := []string{
+		"",
+		"https://www.example.com",
+		"http://golang.org",
+		"the theme",
+		"ünïcödé",
+	}
+
+	w := NewWriter()
+	for _, s := range strs {
+		w.WriteDictString(d, s)
+	}
+
+	r := NewReader(w.BitData())
+	for _, want := range strs {
+		if got, err := r.ReadDictString(d); err != nil || got != want {
+			t.Errorf("want=%q got=%q err=%v", want, got, err)
+		}
+	}
+
+	w = NewWriter()
+	w.WriteDictString(d, "https://www.example.com")
+	if w.BitsWritten() >= 8*uint(len("https://www.example.com")) {
+		t.Errorf("string not compressed: %d bits", w.BitsWritten())
+	}
+}
+
+func TestDictStringErrors(t *testing.T) {
+	d := NewStringDict("abc", "d")
+
+	w := NewWriter()
+	w.WriteUvarint(2)
+	w.Write8(0, 2)
+
+	r := NewReader(w.BitData())
+	if _, err := r.ReadDictString(d); !errors.Is(err, ErrDictionaryIndex) || r.BitsRead() != 0 {
+		t.Errorf("expected ErrDictionaryIndex at 0, got %v at %d", err, r.BitsRead())
+	}
+
+	w = NewWriter()
+	w.WriteUvarint(1)
+	w.Write8(3, 2)
+
+	r = NewReader(w.BitData())
+	if _, err := r.ReadDictString(d); !errors.Is(err, ErrDictionaryIndex) {
+		t.Errorf("expected ErrDictionaryIndex, got %v", err)
+	}
+}
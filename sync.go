@@ -0,0 +1,52 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"sync"
+)
+
+// SyncWriter is a Writer that can be used from multiple goroutines. Records are written atomically,
+// either with Do or by appending a record encoded separately with WriteBitData, so fields of
+// different records are never interleaved. The order of records from different goroutines is
+// the order in which they acquired the writer.
+type SyncWriter struct {
+	mu sync.Mutex
+	w  *Writer
+}
+
+func NewSyncWriter() *SyncWriter {
+	return &SyncWriter{w: NewWriter()}
+}
+
+// Do calls fn with exclusive access to the underlying writer. The writer must not be retained after fn returns.
+func (s *SyncWriter) Do(fn func(w *Writer)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	fn(s.w)
+}
+
+// WriteBitData appends the first bitCount bits of d as one record. Encoding records into
+// a private Writer and appending them here keeps the time spent holding the lock short.
+func (s *SyncWriter) WriteBitData(d BitData, bitCount uint) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.w.WriteBitData(d, bitCount)
+}
+
+func (s *SyncWriter) BitsWritten() uint {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.w.bitsWritten
+}
+
+// BitData returns a copy of the data written so far.
+func (s *SyncWriter) BitData() BitData {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.w.AppendBitData(nil)
+}
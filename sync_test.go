@@ -0,0 +1,58 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestSyncWriter(t *testing.T) {
+	const (
+		producers = 8
+		records   = 500
+	)
+
+	s := NewSyncWriter()
+
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			for i := 0; i < records; i++ {
+				if i%2 == 0 {
+					s.Do(func(w *Writer) {
+						w.Write8(uint8(p), 3)
+						w.Write16(uint16(i), 10)
+					})
+					continue
+				}
+
+				rec := NewWriter()
+				rec.Write8(uint8(p), 3)
+				rec.Write16(uint16(i), 10)
+				s.WriteBitData(rec.BitData(), rec.BitsWritten())
+			}
+		}(p)
+	}
+	wg.Wait()
+
+	if want, got := uint(producers*records*13), s.BitsWritten(); want != got {
+		t.Errorf("bits written mismatch: want=%d got=%d", want, got)
+	}
+
+	next := make([]uint16, producers)
+	r := NewReaderError(s.BitData())
+	for n := 0; n < producers*records; n++ {
+		p, i := r.Read8(3), r.Read16(10)
+		if i != next[p] {
+			t.Errorf("record %d of producer %d out of order: got %d", next[p], p, i)
+			return
+		}
+		next[p]++
+	}
+	if err := r.Error(); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
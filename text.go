@@ -0,0 +1,118 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var ErrInvalidSyntax = errors.New("invalid syntax")
+
+const hexDigits = "0123456789abcdef"
+
+// ParseBits parses a string of '0' and '1' characters in stream order: the first character
+// is the first bit written. Spaces and underscores are ignored and can be used for grouping.
+// It returns the data and the number of bits in it.
+func ParseBits(s string) (BitData, uint, error) {
+	w := NewWriter()
+	for i, c := range s {
+		switch c {
+		case '0':
+			w.WriteBool(false)
+		case '1':
+			w.WriteBool(true)
+		case ' ', '_':
+		default:
+			return nil, 0, fmt.Errorf("%w: unexpected character %q at position %d", ErrInvalidSyntax, c, i)
+		}
+	}
+
+	return w.BitData(), w.bitsWritten, nil
+}
+
+// ParseHex parses a string of hexadecimal digits, two per byte. Spaces and underscores are ignored.
+func ParseHex(s string) (BitData, error) {
+	data := make(BitData, 0, len(s)/2)
+
+	var (
+		b    byte
+		half bool
+	)
+	for i, c := range s {
+		var v byte
+		switch {
+		case c >= '0' && c <= '9':
+			v = byte(c - '0')
+		case c >= 'a' && c <= 'f':
+			v = byte(c-'a') + 10
+		case c >= 'A' && c <= 'F':
+			v = byte(c-'A') + 10
+		case c == ' ' || c == '_':
+			continue
+		default:
+			return nil, fmt.Errorf("%w: unexpected character %q at position %d", ErrInvalidSyntax, c, i)
+		}
+
+		if half {
+			data = append(data, b<<4|v)
+		} else {
+			b = v
+		}
+		half = !half
+	}
+
+	if half {
+		return nil, fmt.Errorf("%w: odd number of hex digits", ErrInvalidSyntax)
+	}
+
+	return data, nil
+}
+
+// BinaryString returns all bits of the data in stream order, grouped by bytes.
+func (d BitData) BinaryString() string {
+	return d.FormatBinary(uint(len(d))*8, 8)
+}
+
+// FormatBinary returns the first bitCount bits of the data in stream order, the same order ParseBits
+// expects. If group is positive, a space is inserted after every group bits.
+func (d BitData) FormatBinary(bitCount uint, group int) string {
+	if maxBits := uint(len(d)) * 8; bitCount > maxBits {
+		bitCount = maxBits
+	}
+
+	var sb strings.Builder
+	sb.Grow(int(bitCount) * 2)
+
+	for i := uint(0); i < bitCount; i++ {
+		if group > 0 && i > 0 && i%uint(group) == 0 {
+			sb.WriteByte(' ')
+		}
+		sb.WriteByte('0' + (d[i/8]>>(i%8))&1)
+	}
+
+	return sb.String()
+}
+
+// HexString returns the data as hexadecimal digits, two per byte, without separators.
+func (d BitData) HexString() string {
+	return d.FormatHex(0)
+}
+
+// FormatHex returns the data as hexadecimal digits, two per byte.
+// If group is positive, a space is inserted after every group bytes.
+func (d BitData) FormatHex(group int) string {
+	var sb strings.Builder
+	sb.Grow(len(d) * 3)
+
+	for i, b := range d {
+		if group > 0 && i > 0 && i%group == 0 {
+			sb.WriteByte(' ')
+		}
+		sb.WriteByte(hexDigits[b>>4])
+		sb.WriteByte(hexDigits[b&0xF])
+	}
+
+	return sb.String()
+}
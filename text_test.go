@@ -0,0 +1,128 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestParseBits(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		expData BitData
+		expBits uint
+	}{
+		{
+			name:    "empty",
+			input:   "",
+			expData: BitData{},
+			expBits: 0,
+		},
+		{
+			name:    "single-bit",
+			input:   "1",
+			expData: BitData{0b1},
+			expBits: 1,
+		},
+		{
+			name:    "stream-order",
+			input:   "1011",
+			expData: BitData{0b1101},
+			expBits: 4,
+		},
+		{
+			name:    "grouped",
+			input:   "1011 0110_1",
+			expData: BitData{0b01101101, 0b1},
+			expBits: 9,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			d, n, err := ParseBits(test.input)
+			if err != nil {
+				t.Errorf("unexpected error: %s", err)
+				return
+			}
+			if n != test.expBits {
+				t.Errorf("bit count mismatch: want=%d got=%d", test.expBits, n)
+			}
+			if !bytes.Equal(d, test.expData) {
+				t.Errorf("data mismatch: want=%v got=%v", test.expData, d)
+			}
+		})
+	}
+
+	if _, _, err := ParseBits("10x1"); !errors.Is(err, ErrInvalidSyntax) {
+		t.Errorf("expected error, got %v", err)
+	}
+}
+
+func TestParseHex(t *testing.T) {
+	d, err := ParseHex("DEad be_EF")
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	if want := (BitData{0xDE, 0xAD, 0xBE, 0xEF}); !bytes.Equal(d, want) {
+		t.Errorf("data mismatch: want=%v got=%v", want, d)
+	}
+
+	if _, err := ParseHex("abc"); !errors.Is(err, ErrInvalidSyntax) {
+		t.Errorf("expected error, got %v", err)
+	}
+
+	if _, err := ParseHex("zz"); !errors.Is(err, ErrInvalidSyntax) {
+		t.Errorf("expected error, got %v", err)
+	}
+}
+
+func TestFormat(t *testing.T) {
+	w := NewWriter()
+	w.Write8(0b101, 3)
+	w.Write16(0xABC, 12)
+	d := w.BitData()
+
+	if want, got := "10100111 10101010", d.BinaryString(); want != got {
+		t.Errorf("want %q, got %q", want, got)
+	}
+	if want, got := "1010 0111 1010 101", d.FormatBinary(15, 4); want != got {
+		t.Errorf("want %q, got %q", want, got)
+	}
+	if want, got := "e555", d.HexString(); want != got {
+		t.Errorf("want %q, got %q", want, got)
+	}
+	if want, got := "e5 55", d.FormatHex(1); want != got {
+		t.Errorf("want %q, got %q", want, got)
+	}
+}
+
+func TestFormatRoundTrip(t *testing.T) {
+	w := NewWriter()
+	w.Write64(0xDEADBEEFDEAFFEED, 64)
+	w.Write8(0b10110, 5)
+	d := w.BitData()
+
+	s := d.FormatBinary(69, 7)
+	dd, n, err := ParseBits(s)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	if n != 69 {
+		t.Errorf("bit count mismatch: want=%d got=%d", 69, n)
+	}
+	if !bytes.Equal(d, dd) {
+		t.Errorf("data mismatch: want=%v got=%v", d, dd)
+	}
+
+	dd, err = ParseHex(d.FormatHex(3))
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	if !bytes.Equal(d, dd) {
+		t.Errorf("data mismatch: want=%v got=%v", d, dd)
+	}
+}
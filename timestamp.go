@@ -0,0 +1,151 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"iter"
+)
+
+// timestampBuckets are the widths of the delta-of-delta values after the prefixes 10, 110, 1110 and 1111.
+var timestampBuckets = [4]byte{7, 9, 12, 64}
+
+// TimestampEncoder compresses a series of timestamps, or any int64 values that grow steadily, with
+// the delta-of-delta scheme of time-series databases. The first value is written in 64 bits. For each of
+// the others, the difference of its delta from the previous delta is written as a single zero bit if it's
+// zero, and otherwise as a prefix of 2 to 4 bits followed by the value in 7, 9, 12 or 64 bits.
+// Regular timestamps take one bit each.
+type TimestampEncoder struct {
+	w     *Writer
+	prev  int64
+	delta int64
+	n     int
+}
+
+// NewTimestampEncoder returns an encoder appending the timestamps to the writer.
+func NewTimestampEncoder(w *Writer) *TimestampEncoder {
+	return &TimestampEncoder{w: w}
+}
+
+// Append writes the next timestamp.
+func (e *TimestampEncoder) Append(t int64) {
+	if e.n == 0 {
+		e.w.Write64(uint64(t), 64)
+		e.prev = t
+		e.n++
+		return
+	}
+
+	delta := t - e.prev
+	writeDeltaOfDelta(e.w, delta-e.delta)
+	e.prev, e.delta = t, delta
+	e.n++
+}
+
+// Len returns the number of timestamps written.
+func (e *TimestampEncoder) Len() int {
+	return e.n
+}
+
+func writeDeltaOfDelta(w *Writer, dod int64) {
+	if dod == 0 {
+		w.WriteBool(false)
+		return
+	}
+
+	for i, n := range timestampBuckets {
+		fits := n == 64 || dod >= -1<<(n-1) && dod < 1<<(n-1)
+		if !fits {
+			continue
+		}
+
+		for range i + 1 {
+			w.WriteBool(true)
+		}
+		if i < len(timestampBuckets)-1 {
+			w.WriteBool(false)
+		}
+		w.Write64(uint64(dod), n)
+		return
+	}
+}
+
+// TimestampDecoder reads timestamps written by a TimestampEncoder.
+type TimestampDecoder struct {
+	r     *Reader
+	prev  int64
+	delta int64
+	n     int
+}
+
+// NewTimestampDecoder returns a decoder reading timestamps from the reader.
+func NewTimestampDecoder(r *Reader) *TimestampDecoder {
+	return &TimestampDecoder{r: r}
+}
+
+// Next returns the next timestamp. The number of timestamps isn't stored, so the caller has to know it.
+// On error the read position and the state of the decoder are left unchanged.
+func (d *TimestampDecoder) Next() (int64, error) {
+	if d.n == 0 {
+		v, err := d.r.Read64(64)
+		if err != nil {
+			return 0, err
+		}
+		d.prev = int64(v)
+		d.n++
+		return d.prev, nil
+	}
+
+	dod, err := readDeltaOfDelta(d.r)
+	if err != nil {
+		return 0, err
+	}
+
+	d.delta += dod
+	d.prev += d.delta
+	d.n++
+
+	return d.prev, nil
+}
+
+func readDeltaOfDelta(r *Reader) (int64, error) {
+	start := r.bitsRead
+
+	var i int
+	for i < len(timestampBuckets) {
+		b, err := r.ReadBool()
+		if err != nil {
+			r.bitsRead = start
+			return 0, err
+		}
+		if !b {
+			break
+		}
+		i++
+	}
+	if i == 0 {
+		return 0, nil
+	}
+
+	n := timestampBuckets[i-1]
+	v, err := r.Read64(n)
+	if err != nil {
+		r.bitsRead = start
+		return 0, err
+	}
+
+	return signExtend(v, n), nil
+}
+
+// Timestamps returns an iterator over count timestamps written by a TimestampEncoder.
+// The iteration stops after the first error.
+func (r *Reader) Timestamps(count int) iter.Seq2[int64, error] {
+	return func(yield func(int64, error) bool) {
+		d := NewTimestampDecoder(r)
+		for range count {
+			t, err := d.Next()
+			if !yield(t, err) || err != nil {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"errors"
+	"io"
+	"math"
+	"testing"
+)
+
+func TestTimestamps(t *testing.T) {
+	ts := []int64{1700000000, 1700000060, 1700000120, 1700000180, 1700000241, 1700000299,
+		1700000400, 1700001000, 1700009000, 1700000000, math.MaxInt64, math.MinInt64, 0, 0}
+
+	w := NewWriter()
+	e := NewTimestampEncoder(w)
+	for _, v := range ts {
+		e.Append(v)
+	}
+	if e.Len() != len(ts) {
+		t.Errorf("got length %d", e.Len())
+	}
+
+	i := 0
+	for v, err := range NewReader(w.BitData()).Timestamps(len(ts)) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v != ts[i] {
+			t.Fatalf("timestamp %d: got %d, want %d", i, v, ts[i])
+		}
+		i++
+	}
+	if i != len(ts) {
+		t.Errorf("iterated %d timestamps", i)
+	}
+
+	w = NewWriter()
+	e = NewTimestampEncoder(w)
+	for i := range 1000 {
+		e.Append(1700000000 + int64(i)*10)
+	}
+	if w.bitsWritten != 64+9+998 {
+		t.Errorf("regular timestamps took %d bits", w.bitsWritten)
+	}
+
+	r := NewReader(w.BitData()[:9])
+	d := NewTimestampDecoder(r)
+	if _, err := d.Next(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := d.Next(); !errors.Is(err, io.ErrUnexpectedEOF) || r.bitsRead != 64 {
+		t.Errorf("expected io.ErrUnexpectedEOF at bit 64, got %v at %d", err, r.bitsRead)
+	}
+}
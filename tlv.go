@@ -0,0 +1,107 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"errors"
+)
+
+var ErrTagOverflow = errors.New("tag does not fit")
+
+// TLVFormat sets the widths of the tag and of the length of TLV (tag-length-value) records.
+// The length is the number of bits of the value.
+type TLVFormat struct {
+	TagBits byte
+	LenBits byte
+}
+
+func (f TLVFormat) headerBits() uint {
+	return uint(f.TagBits) + uint(f.LenBits)
+}
+
+// WriteTLV writes a TLV record with bitCount bits of the payload as the value.
+func (w *Writer) WriteTLV(f TLVFormat, tag uint64, payload BitData, bitCount uint) error {
+	if f.TagBits == 0 || f.TagBits > 64 || tag > mask[uint64](f.TagBits) {
+		return ErrTagOverflow
+	}
+	if f.LenBits == 0 || f.LenBits > 64 || uint64(bitCount) > mask[uint64](f.LenBits) {
+		return ErrLengthOverflow
+	}
+	if bitCount > uint(len(payload))*8 {
+		return ErrInvalidRange
+	}
+
+	w.Write64(tag, f.TagBits)
+	w.Write64(uint64(bitCount), f.LenBits)
+	writeBits(w, payload, 0, bitCount)
+
+	return nil
+}
+
+// WriteTLVValue writes v as a TLV record with the tag of its type in the registry.
+func (w *Writer) WriteTLVValue(f TLVFormat, reg *Registry, v any) error {
+	vw := &Writer{order: w.order, alloc: w.alloc}
+	defer vw.Release()
+
+	tag, err := reg.EncodeValue(vw, v)
+	if err != nil {
+		return err
+	}
+	return w.WriteTLV(f, tag, vw.BitData(), vw.bitsWritten)
+}
+
+// TLVIterator iterates over consecutive TLV records.
+//
+//	it := r.TLVs(format)
+//	for it.Next() {
+//		process(it.Tag(), it.Value())
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type TLVIterator struct {
+	r     *Reader
+	f     TLVFormat
+	tag   uint64
+	value *Reader
+	err   error
+}
+
+// TLVs returns an iterator over TLV records starting at the current position.
+// Iteration ends when fewer bits than a record header remain, which allows for padding at the end of data.
+func (r *Reader) TLVs(f TLVFormat) *TLVIterator {
+	return &TLVIterator{r: r, f: f}
+}
+
+func (it *TLVIterator) Next() bool {
+	if it.err != nil || it.r.bitsRead > it.r.end || it.r.end-it.r.bitsRead < it.f.headerBits() {
+		return false
+	}
+
+	it.tag, it.err = it.r.Read64(it.f.TagBits)
+	if it.err != nil {
+		return false
+	}
+
+	it.value, it.err = it.r.ReadMessage(it.f.LenBits)
+
+	return it.err == nil
+}
+
+func (it *TLVIterator) Tag() uint64 {
+	return it.tag
+}
+
+// Value returns a reader limited to the value of the current record.
+func (it *TLVIterator) Value() *Reader {
+	return it.value
+}
+
+// Decode decodes the value of the current record as the type registered with its tag.
+func (it *TLVIterator) Decode(reg *Registry) (any, error) {
+	return reg.DecodeValue(it.value, it.tag)
+}
+
+func (it *TLVIterator) Err() error {
+	return it.err
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestTLV(t *testing.T) {
+	f := TLVFormat{TagBits: 4, LenBits: 7}
+
+	p1, n1, _ := ParseBits("101")
+	p2, n2, _ := ParseBits("1111 0000 1010 0101 1")
+
+	w := NewWriter()
+	_ = w.WriteTLV(f, 1, p1, n1)
+	_ = w.WriteTLV(f, 15, p2, n2)
+	_ = w.WriteTLV(f, 3, nil, 0)
+
+	type record struct {
+		tag  uint64
+		bits string
+	}
+
+	var got []record
+	it := NewReader(w.BitData()).TLVs(f)
+	for it.Next() {
+		v := it.Value()
+		n := v.end - v.BitsRead()
+		d := NewWriter()
+		for i := uint(0); i < n; i++ {
+			b, _ := v.ReadBool()
+			d.WriteBool(b)
+		}
+		got = append(got, record{tag: it.Tag(), bits: d.BitData().FormatBinary(n, 0)})
+	}
+	if err := it.Err(); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	want := []record{
+		{tag: 1, bits: "101"},
+		{tag: 15, bits: "11110000101001011"},
+		{tag: 3, bits: ""},
+	}
+
+	if len(got) != len(want) {
+		t.Errorf("record count mismatch: want=%d got=%d", len(want), len(got))
+		return
+	}
+	for i := range want {
+		if want[i] != got[i] {
+			t.Errorf("record %d mismatch: want=%+v got=%+v", i, want[i], got[i])
+		}
+	}
+}
+
+func TestTLVErrors(t *testing.T) {
+	f := TLVFormat{TagBits: 2, LenBits: 3}
+
+	w := NewWriter()
+	if err := w.WriteTLV(f, 4, nil, 0); !errors.Is(err, ErrTagOverflow) {
+		t.Errorf("expected error, got %v", err)
+	}
+	if err := w.WriteTLV(f, 1, BitData{0}, 8); !errors.Is(err, ErrLengthOverflow) {
+		t.Errorf("expected error, got %v", err)
+	}
+	if err := w.WriteTLV(f, 1, BitData{}, 4); !errors.Is(err, ErrInvalidRange) {
+		t.Errorf("expected error, got %v", err)
+	}
+
+	// truncated value
+	w = NewWriter()
+	w.Write8(1, 2)
+	w.Write8(7, 3)
+	w.Write8(0, 3)
+
+	it := NewReader(w.BitData()).TLVs(f)
+	if it.Next() {
+		t.Error("unexpected record")
+	}
+	if err := it.Err(); err != io.ErrUnexpectedEOF {
+		t.Errorf("expected EOF, got %v", err)
+	}
+}
@@ -0,0 +1,37 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+// TraceFunc is called for every read or write operation with the name of the operation,
+// the bit offset at which it started, the number of bits and the value.
+type TraceFunc func(op string, offsetBits uint, bitCount byte, value uint64)
+
+// SetTrace sets the function called for every write. Use nil to disable tracing.
+func (w *Writer) SetTrace(fn TraceFunc) {
+	w.trace = fn
+}
+
+func (w *Writer) traceWrite(op string, value uint64, bitCount byte) {
+	w.trace(op, w.bitsWritten, bitCount, value&mask[uint64](bitCount))
+}
+
+// SetTrace sets the function called for every successful read. Use nil to disable tracing.
+func (r *Reader) SetTrace(fn TraceFunc) {
+	r.trace = fn
+}
+
+func (r *Reader) traceRead(op string, bitCount byte, value uint64) {
+	r.trace(op, r.bitsRead-uint(bitCount), bitCount, value)
+}
+
+// SetTrace sets the function called for every successful read. Use nil to disable tracing.
+func (r *ReaderError) SetTrace(fn TraceFunc) {
+	r.reader.SetTrace(fn)
+}
+
+func boolToUint64(v bool) uint64 {
+	if v {
+		return 1
+	}
+	return 0
+}
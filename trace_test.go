@@ -0,0 +1,75 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"testing"
+)
+
+type traceEntry struct {
+	op       string
+	offset   uint
+	bitCount byte
+	value    uint64
+}
+
+func TestTrace(t *testing.T) {
+	var entries []traceEntry
+	trace := func(op string, offsetBits uint, bitCount byte, value uint64) {
+		entries = append(entries, traceEntry{op: op, offset: offsetBits, bitCount: bitCount, value: value})
+	}
+
+	w := NewWriter()
+	w.SetTrace(trace)
+	w.WriteBool(true)
+	w.Write8(0xFF, 3)
+	w.Write16(0x1234, 16)
+	w.Write32(7, 5)
+	w.Write64(1, 40)
+	w.SetTrace(nil)
+	w.Write8(1, 1)
+
+	wantWrites := []traceEntry{
+		{op: "WriteBool", offset: 0, bitCount: 1, value: 1},
+		{op: "Write8", offset: 1, bitCount: 3, value: 7},
+		{op: "Write16", offset: 4, bitCount: 16, value: 0x1234},
+		{op: "Write32", offset: 20, bitCount: 5, value: 7},
+		{op: "Write64", offset: 25, bitCount: 40, value: 1},
+	}
+	compareTrace(t, wantWrites, entries)
+
+	entries = nil
+
+	r := NewReaderError(w.BitData())
+	r.SetTrace(trace)
+	r.ReadBool()
+	r.Read8(3)
+	r.Read16(16)
+	r.Read32(5)
+	r.Read64(40)
+	r.Read8(8) // fails, not traced
+
+	wantReads := []traceEntry{
+		{op: "ReadBool", offset: 0, bitCount: 1, value: 1},
+		{op: "Read8", offset: 1, bitCount: 3, value: 7},
+		{op: "Read16", offset: 4, bitCount: 16, value: 0x1234},
+		{op: "Read32", offset: 20, bitCount: 5, value: 7},
+		{op: "Read64", offset: 25, bitCount: 40, value: 1},
+	}
+	compareTrace(t, wantReads, entries)
+}
+
+func compareTrace(t *testing.T, want, got []traceEntry) {
+	t.Helper()
+
+	if len(want) != len(got) {
+		t.Errorf("trace length mismatch: want=%d got=%d", len(want), len(got))
+		return
+	}
+
+	for i := range want {
+		if want[i] != got[i] {
+			t.Errorf("trace entry %d mismatch: want=%+v got=%+v", i, want[i], got[i])
+		}
+	}
+}
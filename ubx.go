@@ -0,0 +1,102 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"errors"
+)
+
+var (
+	ErrUBXSync     = errors.New("missing UBX sync characters")
+	ErrUBXChecksum = errors.New("UBX checksum mismatch")
+)
+
+const (
+	ubxSync1 = 0xB5
+	ubxSync2 = 0x62
+)
+
+// UBXMessage is a message of the u-blox UBX protocol. UBX values are little-endian and its bitfields
+// (the X1, X2 and X4 types) number bits from the least significant one, so a payload is read field by field
+// with a reader in the LSBFirst order: NewReader(m.Payload).
+type UBXMessage struct {
+	Class   byte
+	ID      byte
+	Payload []byte
+}
+
+// UBXChecksum returns the 8-bit Fletcher checksum UBX computes over the class, the ID,
+// the length and the payload of a message.
+func UBXChecksum(data []byte) (a, b byte) {
+	for _, c := range data {
+		a += c
+		b += a
+	}
+	return
+}
+
+// WriteUBX writes a UBX frame: the sync characters, the class, the ID, the payload length,
+// the payload and the checksum. It returns ErrLengthOverflow for a payload longer than 65535 bytes.
+// The UBX frame functions expect a writer or reader in the LSBFirst order.
+func (w *Writer) WriteUBX(m UBXMessage) error {
+	if len(m.Payload) > 0xFFFF {
+		return ErrLengthOverflow
+	}
+
+	body := append([]byte{m.Class, m.ID, byte(len(m.Payload)), byte(len(m.Payload) >> 8)}, m.Payload...)
+	ckA, ckB := UBXChecksum(body)
+
+	w.Write8(ubxSync1, 8)
+	w.Write8(ubxSync2, 8)
+	writeAddrBytes(w, body)
+	w.Write8(ckA, 8)
+	w.Write8(ckB, 8)
+
+	return nil
+}
+
+// ReadUBX reads a UBX frame and checks its checksum. It returns ErrUBXSync if the frame doesn't start
+// with the sync characters and ErrUBXChecksum if the checksum doesn't match. On error the read position
+// is left unchanged.
+func (r *Reader) ReadUBX() (m UBXMessage, err error) {
+	start := r.bitsRead
+	defer func() {
+		if err != nil {
+			r.bitsRead = start
+			m = UBXMessage{}
+		}
+	}()
+
+	var head [6]byte
+	if err = readAddrBytes(r, head[:]); err != nil {
+		return
+	}
+	if head[0] != ubxSync1 || head[1] != ubxSync2 {
+		err = ErrUBXSync
+		return
+	}
+
+	n := int(head[4]) | int(head[5])<<8
+	body := make([]byte, 4+n+2)
+	copy(body, head[2:])
+	if err = readAddrBytes(r, body[4:]); err != nil {
+		return
+	}
+
+	ckA, ckB := UBXChecksum(body[:4+n])
+	if ckA != body[4+n] || ckB != body[5+n] {
+		err = ErrUBXChecksum
+		return
+	}
+
+	m = UBXMessage{Class: head[2], ID: head[3], Payload: body[4 : 4+n : 4+n]}
+
+	return
+}
+
+func (r *ReaderError) ReadUBX() (m UBXMessage) {
+	if r.err == nil {
+		m, r.err = r.reader.ReadUBX()
+	}
+	return
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+)
+
+func TestUBX(t *testing.T) {
+	// UBX-CFG-MSG poll of NAV-PVT
+	m := UBXMessage{Class: 0x06, ID: 0x01, Payload: []byte{0x01, 0x07}}
+
+	w := NewWriter()
+	w.Write8(1, 3)
+	if err := w.WriteUBX(m); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	r := NewReaderError(w.BitData())
+	r.Skip(3)
+	if got := r.ReadUBX(); !reflect.DeepEqual(m, got) {
+		t.Errorf("message mismatch: want=%+v got=%+v", m, got)
+	}
+	if err := r.Error(); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	w = NewWriter()
+	_ = w.WriteUBX(m)
+	if want := []byte{0xB5, 0x62, 0x06, 0x01, 0x02, 0x00, 0x01, 0x07, 0x11, 0x3A}; !bytes.Equal(want, w.BitData()) {
+		t.Errorf("encoding mismatch: want=%x got=%x", want, w.BitData())
+	}
+}
+
+func TestUBXBitfield(t *testing.T) {
+	// the valid (X1) and flags (X1) fields of UBX-NAV-PVT
+	payload := []byte{0b0000_0111, 0b1000_0011}
+
+	r := NewReaderError(payload)
+	validDate, validTime, fullyResolved := r.ReadBool(), r.ReadBool(), r.ReadBool()
+	r.Skip(5)
+	gnssFixOK, diffSoln := r.ReadBool(), r.ReadBool()
+	r.Skip(4) // psmState, headVehValid
+	carrSoln := r.Read8(2)
+
+	if !validDate || !validTime || !fullyResolved || !gnssFixOK || !diffSoln || carrSoln != 2 {
+		t.Errorf("unexpected bits: %t %t %t %t %t %d", validDate, validTime, fullyResolved, gnssFixOK, diffSoln, carrSoln)
+	}
+}
+
+func TestUBXErrors(t *testing.T) {
+	tests := []struct {
+		d   BitData
+		err error
+	}{
+		{d: BitData{0xB5, 0x63, 0x06, 0x01, 0x00, 0x00, 0x07, 0x1B}, err: ErrUBXSync},
+		{d: BitData{0xB5, 0x62, 0x06, 0x01, 0x00, 0x00, 0x07, 0x1C}, err: ErrUBXChecksum},
+		{d: BitData{0xB5, 0x62, 0x06, 0x01, 0x05, 0x00, 0x07, 0x16}, err: io.ErrUnexpectedEOF},
+	}
+
+	for _, test := range tests {
+		r := NewReader(test.d)
+		if _, err := r.ReadUBX(); !errors.Is(err, test.err) || r.BitsRead() != 0 {
+			t.Errorf("want=%v for %x got=%v at %d", test.err, test.d, err, r.BitsRead())
+		}
+	}
+
+	if _, err := NewReader(BitData{0xB5, 0x62, 0x06, 0x01, 0x00, 0x00, 0x07, 0x1B}).ReadUBX(); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
@@ -0,0 +1,34 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+// WriteUUID writes the 16 bytes of a UUID, in order, as 128 bits.
+func (w *Writer) WriteUUID(u [16]byte) {
+	e := byteOrder(w.order)
+	w.Write64(e.Uint64(u[:8]), 64)
+	w.Write64(e.Uint64(u[8:]), 64)
+}
+
+func (r *Reader) ReadUUID() (u [16]byte, err error) {
+	var lo, hi uint64
+	if lo, err = r.Read64(64); err != nil {
+		return
+	}
+	if hi, err = r.Read64(64); err != nil {
+		r.bitsRead -= 64
+		return
+	}
+
+	e := byteOrder(r.order)
+	e.PutUint64(u[:8], lo)
+	e.PutUint64(u[8:], hi)
+
+	return
+}
+
+func (r *ReaderError) ReadUUID() (u [16]byte) {
+	if r.err == nil {
+		u, r.err = r.reader.ReadUUID()
+	}
+	return
+}
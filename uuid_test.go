@@ -0,0 +1,47 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestUUID(t *testing.T) {
+	u := [16]byte{0x12, 0x3e, 0x45, 0x67, 0xe8, 0x9b, 0x12, 0xd3, 0xa4, 0x56, 0x42, 0x66, 0x14, 0x17, 0x40, 0x00}
+
+	w := NewWriter()
+	w.WriteUUID(u)
+	if !bytes.Equal(w.BitData(), u[:]) {
+		t.Errorf("aligned layout mismatch: want=%x got=%x", u, w.BitData())
+	}
+
+	for ofs := byte(1); ofs < 8; ofs++ {
+		w := NewWriter()
+		w.Write8(0xFF, ofs)
+		w.WriteUUID(u)
+		w.WriteBool(true)
+
+		r := NewReaderError(w.BitData())
+		r.Skip(uint(ofs))
+		if got := r.ReadUUID(); got != u {
+			t.Errorf("offset %d: want=%x got=%x", ofs, u, got)
+		}
+		if !r.ReadBool() {
+			t.Errorf("offset %d: trailing bit mismatch", ofs)
+		}
+		if err := r.Error(); err != nil {
+			t.Errorf("offset %d: unexpected error: %s", ofs, err)
+		}
+	}
+
+	r := NewReader(u[:15])
+	if _, err := r.ReadUUID(); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("expected EOF, got %v", err)
+	}
+	if r.BitsRead() != 0 {
+		t.Errorf("reader advanced on error: %d", r.BitsRead())
+	}
+}
@@ -0,0 +1,338 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"errors"
+)
+
+var (
+	ErrVarintOverflow = errors.New("varint overflows 64 bits")
+	ErrNonCanonical   = errors.New("non-canonical varint encoding")
+)
+
+const (
+	maxVarintBytes       = 10
+	maxSQLiteVarintBytes = 9
+)
+
+// WriteUvarint writes v in the LEB128 format: groups of 7 bits, least significant first, each group
+// followed by a continuation bit. On a byte boundary the result is the same as encoding/binary.AppendUvarint.
+func (w *Writer) WriteUvarint(v uint64) {
+	for v >= 0x80 {
+		w.Write8(byte(v)|0x80, 8)
+		v >>= 7
+	}
+	w.Write8(byte(v), 8)
+}
+
+// WriteVarint writes v as a zigzag encoded LEB128 varint, the same as encoding/binary.AppendVarint.
+func (w *Writer) WriteVarint(v int64) {
+	w.WriteUvarint(zigzag(v))
+}
+
+func (r *Reader) ReadUvarint() (uint64, error) {
+	var v uint64
+	for i := 0; i < maxVarintBytes; i++ {
+		b, err := r.Read8(8)
+		if err != nil {
+			return 0, err
+		}
+
+		if i == maxVarintBytes-1 && b > 1 {
+			return 0, ErrVarintOverflow
+		}
+
+		v |= uint64(b&0x7F) << (7 * i)
+		if b < 0x80 {
+			return v, nil
+		}
+	}
+
+	return 0, ErrVarintOverflow
+}
+
+func (r *Reader) ReadVarint() (int64, error) {
+	v, err := r.ReadUvarint()
+	return unzigzag(v), err
+}
+
+// WriteSQLiteVarint writes v in the SQLite varint format: 1 to 9 bytes, most significant group first.
+// The first 8 bytes hold 7 bits each with the high bit set if more bytes follow, the 9th byte holds 8 bits.
+func (w *Writer) WriteSQLiteVarint(v uint64) {
+	if v > 1<<56-1 {
+		for i := 7; i >= 0; i-- {
+			w.Write8(byte(v>>(8+7*i))|0x80, 8)
+		}
+		w.Write8(byte(v), 8)
+		return
+	}
+
+	n := 1
+	for v>>(7*n) != 0 {
+		n++
+	}
+	for i := n - 1; i > 0; i-- {
+		w.Write8(byte(v>>(7*i))|0x80, 8)
+	}
+	w.Write8(byte(v)&0x7F, 8)
+}
+
+// ReadSQLiteVarint reads a varint in the SQLite format. On error the read position is left unchanged.
+func (r *Reader) ReadSQLiteVarint() (uint64, error) {
+	start := r.bitsRead
+
+	var v uint64
+	for i := 0; i < maxSQLiteVarintBytes; i++ {
+		b, err := r.Read8(8)
+		if err != nil {
+			r.bitsRead = start
+			return 0, err
+		}
+
+		if i == maxSQLiteVarintBytes-1 {
+			return v<<8 | uint64(b), nil
+		}
+
+		v = v<<7 | uint64(b&0x7F)
+		if b < 0x80 {
+			break
+		}
+	}
+
+	return v, nil
+}
+
+// WriteGitVarint writes v in the offset varint format of Git packfiles: groups of 7 bits, most significant
+// first, each but the last with the high bit set. Every continuation also adds 2^7 to the value,
+// so each value has exactly one encoding.
+func (w *Writer) WriteGitVarint(v uint64) {
+	var buf [maxVarintBytes]byte
+	i := len(buf) - 1
+	buf[i] = byte(v) & 0x7F
+	for v >>= 7; v != 0; v >>= 7 {
+		v--
+		i--
+		buf[i] = byte(v) | 0x80
+	}
+	for _, b := range buf[i:] {
+		w.Write8(b, 8)
+	}
+}
+
+// ReadGitVarint reads a varint in the offset format of Git packfiles. It returns ErrVarintOverflow
+// if the value doesn't fit into 64 bits. On error the read position is left unchanged.
+func (r *Reader) ReadGitVarint() (uint64, error) {
+	start := r.bitsRead
+
+	var v uint64
+	for i := 0; ; i++ {
+		b, err := r.Read8(8)
+		if err != nil {
+			r.bitsRead = start
+			return 0, err
+		}
+
+		if i > 0 {
+			if v >= 1<<57-1 {
+				r.bitsRead = start
+				return 0, ErrVarintOverflow
+			}
+			v++
+		}
+
+		v = v<<7 | uint64(b&0x7F)
+		if b < 0x80 {
+			return v, nil
+		}
+	}
+}
+
+// WriteVLQ writes v as a variable-length quantity, the format of MIDI files: groups of 7 bits,
+// most significant first, each but the last with the high bit set. MIDI allows values of up to 28 bits.
+func (w *Writer) WriteVLQ(v uint64) {
+	n := 1
+	for n < maxVarintBytes && v>>(7*n) != 0 {
+		n++
+	}
+	for i := n - 1; i > 0; i-- {
+		w.Write8(byte(v>>(7*i))|0x80, 8)
+	}
+	w.Write8(byte(v)&0x7F, 8)
+}
+
+// ReadVLQ reads a variable-length quantity. It returns ErrVarintOverflow if the value
+// doesn't fit into 64 bits. On error the read position is left unchanged.
+func (r *Reader) ReadVLQ() (uint64, error) {
+	start := r.bitsRead
+
+	var v uint64
+	for {
+		b, err := r.Read8(8)
+		if err != nil {
+			r.bitsRead = start
+			return 0, err
+		}
+
+		if v>>57 != 0 {
+			r.bitsRead = start
+			return 0, ErrVarintOverflow
+		}
+
+		v = v<<7 | uint64(b&0x7F)
+		if b < 0x80 {
+			return v, nil
+		}
+	}
+}
+
+// WriteCompactSize writes v in the CompactSize format of Bitcoin: a single byte below 0xFD, otherwise
+// the marker 0xFD, 0xFE or 0xFF followed by 2, 4 or 8 bytes of the value, least significant first.
+func (w *Writer) WriteCompactSize(v uint64) {
+	switch {
+	case v < 0xFD:
+		w.Write8(byte(v), 8)
+	case v <= 0xFFFF:
+		w.Write8(0xFD, 8)
+		writeLittleEndian(w, v, 2)
+	case v <= 0xFFFFFFFF:
+		w.Write8(0xFE, 8)
+		writeLittleEndian(w, v, 4)
+	default:
+		w.Write8(0xFF, 8)
+		writeLittleEndian(w, v, 8)
+	}
+}
+
+// ReadCompactSize reads an integer in the CompactSize format. It returns ErrNonCanonical if the value
+// isn't written in its shortest form. On error the read position is left unchanged.
+func (r *Reader) ReadCompactSize() (uint64, error) {
+	start := r.bitsRead
+
+	marker, err := r.Read8(8)
+	if err != nil {
+		return 0, err
+	}
+	if marker < 0xFD {
+		return uint64(marker), nil
+	}
+
+	n := 2 << (marker - 0xFD)
+	v, err := readLittleEndian(r, n)
+	if err == nil && v < compactSizeMin[marker-0xFD] {
+		err = ErrNonCanonical
+	}
+	if err != nil {
+		r.bitsRead = start
+		return 0, err
+	}
+
+	return v, nil
+}
+
+// compactSizeMin holds the smallest values written with the markers 0xFD, 0xFE and 0xFF.
+var compactSizeMin = [3]uint64{0xFD, 0x10000, 0x100000000}
+
+// VarintFormat selects one of the supported variable-length integer formats.
+type VarintFormat byte
+
+const (
+	LEB128 VarintFormat = iota
+	SQLiteVarint
+	GitVarint
+	VLQ
+	CompactSize
+)
+
+// WriteUvarintFormat writes v in the varint format f.
+func (w *Writer) WriteUvarintFormat(f VarintFormat, v uint64) {
+	switch f {
+	case LEB128:
+		w.WriteUvarint(v)
+	case SQLiteVarint:
+		w.WriteSQLiteVarint(v)
+	case GitVarint:
+		w.WriteGitVarint(v)
+	case VLQ:
+		w.WriteVLQ(v)
+	case CompactSize:
+		w.WriteCompactSize(v)
+	default:
+		panic("bitdata: unknown varint format")
+	}
+}
+
+// ReadUvarintFormat reads a varint in the format f.
+func (r *Reader) ReadUvarintFormat(f VarintFormat) (uint64, error) {
+	switch f {
+	case LEB128:
+		return r.ReadUvarint()
+	case SQLiteVarint:
+		return r.ReadSQLiteVarint()
+	case GitVarint:
+		return r.ReadGitVarint()
+	case VLQ:
+		return r.ReadVLQ()
+	case CompactSize:
+		return r.ReadCompactSize()
+	default:
+		panic("bitdata: unknown varint format")
+	}
+}
+
+func (r *ReaderError) ReadUvarint() (v uint64) {
+	if r.err == nil {
+		v, r.err = r.reader.ReadUvarint()
+	}
+	return
+}
+
+func (r *ReaderError) ReadVarint() (v int64) {
+	if r.err == nil {
+		v, r.err = r.reader.ReadVarint()
+	}
+	return
+}
+
+func (r *ReaderError) ReadSQLiteVarint() (v uint64) {
+	if r.err == nil {
+		v, r.err = r.reader.ReadSQLiteVarint()
+	}
+	return
+}
+
+func (r *ReaderError) ReadGitVarint() (v uint64) {
+	if r.err == nil {
+		v, r.err = r.reader.ReadGitVarint()
+	}
+	return
+}
+
+func (r *ReaderError) ReadVLQ() (v uint64) {
+	if r.err == nil {
+		v, r.err = r.reader.ReadVLQ()
+	}
+	return
+}
+
+func (r *ReaderError) ReadCompactSize() (v uint64) {
+	if r.err == nil {
+		v, r.err = r.reader.ReadCompactSize()
+	}
+	return
+}
+
+func (r *ReaderError) ReadUvarintFormat(f VarintFormat) (v uint64) {
+	if r.err == nil {
+		v, r.err = r.reader.ReadUvarintFormat(f)
+	}
+	return
+}
+
+func zigzag(v int64) uint64 {
+	return uint64(v<<1) ^ uint64(v>>63)
+}
+
+func unzigzag(v uint64) int64 {
+	return int64(v>>1) ^ -int64(v&1)
+}
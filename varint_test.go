@@ -0,0 +1,221 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+	"testing"
+)
+
+func TestUvarint(t *testing.T) {
+	values := []uint64{0, 1, 127, 128, 300, 1 << 35, math.MaxUint64}
+
+	for _, v := range values {
+		w := NewWriter()
+		w.WriteUvarint(v)
+
+		if want := binary.AppendUvarint(nil, v); !bytes.Equal(want, w.BitData()) {
+			t.Errorf("encoding mismatch for %d: want=%x got=%x", v, want, w.BitData())
+		}
+
+		// unaligned
+		w = NewWriter()
+		w.Write8(0b101, 3)
+		w.WriteUvarint(v)
+
+		r := NewReader(w.BitData())
+		r.Skip(3)
+		got, err := r.ReadUvarint()
+		if err != nil {
+			t.Errorf("unexpected error for %d: %s", v, err)
+		}
+		if got != v {
+			t.Errorf("value mismatch: want=%d got=%d", v, got)
+		}
+	}
+}
+
+func TestVarint(t *testing.T) {
+	values := []int64{0, -1, 1, -64, 64, math.MinInt64, math.MaxInt64}
+
+	w := NewWriter()
+	for _, v := range values {
+		w.WriteVarint(v)
+	}
+
+	var want []byte
+	for _, v := range values {
+		want = binary.AppendVarint(want, v)
+	}
+	if !bytes.Equal(want, w.BitData()) {
+		t.Errorf("encoding mismatch: want=%x got=%x", want, w.BitData())
+	}
+
+	r := NewReaderError(w.BitData())
+	for _, v := range values {
+		if got := r.ReadVarint(); got != v {
+			t.Errorf("value mismatch: want=%d got=%d", v, got)
+		}
+	}
+	if err := r.Error(); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
+
+func TestUvarintOverflow(t *testing.T) {
+	d := BitData{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0x02}
+	if _, err := NewReader(d).ReadUvarint(); !errors.Is(err, ErrVarintOverflow) {
+		t.Errorf("expected overflow, got %v", err)
+	}
+}
+
+func TestSQLiteVarint(t *testing.T) {
+	tests := []struct {
+		v    uint64
+		want []byte
+	}{
+		{v: 0, want: []byte{0x00}},
+		{v: 0x7F, want: []byte{0x7F}},
+		{v: 240, want: []byte{0x81, 0x70}},
+		{v: 0x3FFF, want: []byte{0xFF, 0x7F}},
+		{v: 1<<56 - 1, want: []byte{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0x7F}},
+		{v: 1 << 56, want: []byte{0x80, 0xC0, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x00}},
+		{v: math.MaxUint64, want: []byte{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF}},
+	}
+
+	for _, test := range tests {
+		w := NewWriter()
+		w.WriteSQLiteVarint(test.v)
+		if !bytes.Equal(test.want, w.BitData()) {
+			t.Errorf("encoding mismatch for %#x: want=%x got=%x", test.v, test.want, w.BitData())
+		}
+
+		r := NewReaderError(append(BitData{0xAA}, test.want...))
+		r.Skip(8)
+		if got := r.ReadSQLiteVarint(); got != test.v {
+			t.Errorf("value mismatch: want=%#x got=%#x", test.v, got)
+		}
+		if err := r.Error(); err != nil {
+			t.Errorf("unexpected error: %s", err)
+		}
+	}
+
+	r := NewReader(BitData{0x81, 0x81})
+	if _, err := r.ReadSQLiteVarint(); !errors.Is(err, io.ErrUnexpectedEOF) || r.BitsRead() != 0 {
+		t.Errorf("want=%v got=%v at %d", io.ErrUnexpectedEOF, err, r.BitsRead())
+	}
+}
+
+func TestGitVarint(t *testing.T) {
+	tests := []struct {
+		v    uint64
+		want []byte
+	}{
+		{v: 0, want: []byte{0x00}},
+		{v: 0x7F, want: []byte{0x7F}},
+		{v: 0x80, want: []byte{0x80, 0x00}},
+		{v: 0x407F, want: []byte{0xFF, 0x7F}},
+		{v: 0x4080, want: []byte{0x80, 0x80, 0x00}},
+	}
+
+	for _, test := range tests {
+		w := NewWriter()
+		w.WriteGitVarint(test.v)
+		if !bytes.Equal(test.want, w.BitData()) {
+			t.Errorf("encoding mismatch for %#x: want=%x got=%x", test.v, test.want, w.BitData())
+		}
+	}
+
+	values := []uint64{0, 1, 0x80, 0x4080, 1<<56 + 3, math.MaxUint64}
+	for _, f := range []VarintFormat{LEB128, SQLiteVarint, GitVarint, VLQ, CompactSize} {
+		w := NewWriter()
+		w.WriteBool(true)
+		for _, v := range values {
+			w.WriteUvarintFormat(f, v)
+		}
+
+		r := NewReaderError(w.BitData())
+		r.Skip(1)
+		for _, v := range values {
+			if got := r.ReadUvarintFormat(f); got != v {
+				t.Errorf("format %d value mismatch: want=%#x got=%#x", f, v, got)
+			}
+		}
+		if err := r.Error(); err != nil {
+			t.Errorf("unexpected error: %s", err)
+		}
+	}
+
+	d := BitData{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0x7F}
+	r := NewReader(d)
+	if _, err := r.ReadGitVarint(); !errors.Is(err, ErrVarintOverflow) || r.BitsRead() != 0 {
+		t.Errorf("want=%v got=%v at %d", ErrVarintOverflow, err, r.BitsRead())
+	}
+}
+
+func TestVLQ(t *testing.T) {
+	tests := []struct {
+		v    uint64
+		want []byte
+	}{
+		{v: 0, want: []byte{0x00}},
+		{v: 0x40, want: []byte{0x40}},
+		{v: 0x80, want: []byte{0x81, 0x00}},
+		{v: 0x2000, want: []byte{0xC0, 0x00}},
+		{v: 0x1FFFFF, want: []byte{0xFF, 0xFF, 0x7F}},
+		{v: 0x0FFFFFFF, want: []byte{0xFF, 0xFF, 0xFF, 0x7F}},
+		{v: math.MaxUint64, want: []byte{0x81, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0x7F}},
+	}
+
+	for _, test := range tests {
+		w := NewWriter()
+		w.WriteVLQ(test.v)
+		if !bytes.Equal(test.want, w.BitData()) {
+			t.Errorf("encoding mismatch for %#x: want=%x got=%x", test.v, test.want, w.BitData())
+		}
+	}
+
+	d := BitData{0x82, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x00}
+	r := NewReader(d)
+	if _, err := r.ReadVLQ(); !errors.Is(err, ErrVarintOverflow) || r.BitsRead() != 0 {
+		t.Errorf("want=%v got=%v at %d", ErrVarintOverflow, err, r.BitsRead())
+	}
+}
+
+func TestCompactSize(t *testing.T) {
+	tests := []struct {
+		v    uint64
+		want []byte
+	}{
+		{v: 0, want: []byte{0x00}},
+		{v: 0xFC, want: []byte{0xFC}},
+		{v: 0xFD, want: []byte{0xFD, 0xFD, 0x00}},
+		{v: 0xFFFF, want: []byte{0xFD, 0xFF, 0xFF}},
+		{v: 0x10000, want: []byte{0xFE, 0x00, 0x00, 0x01, 0x00}},
+		{v: 0x100000000, want: []byte{0xFF, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00}},
+	}
+
+	for _, test := range tests {
+		w := NewWriter()
+		w.WriteCompactSize(test.v)
+		if !bytes.Equal(test.want, w.BitData()) {
+			t.Errorf("encoding mismatch for %#x: want=%x got=%x", test.v, test.want, w.BitData())
+		}
+	}
+
+	nonCanonical := []BitData{
+		{0xFD, 0xFC, 0x00},
+		{0xFE, 0xFF, 0xFF, 0x00, 0x00},
+		{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0x00, 0x00, 0x00, 0x00},
+	}
+	for _, d := range nonCanonical {
+		r := NewReader(d)
+		if _, err := r.ReadCompactSize(); !errors.Is(err, ErrNonCanonical) || r.BitsRead() != 0 {
+			t.Errorf("want=%v for %x got=%v at %d", ErrNonCanonical, d, err, r.BitsRead())
+		}
+	}
+}
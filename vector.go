@@ -0,0 +1,126 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"math"
+)
+
+// WriteUnitVector writes a 3D unit vector using the octahedral mapping, with bitCount bits
+// for each of the two mapped coordinates. The vector doesn't need to be normalized.
+func (w *Writer) WriteUnitVector(x, y, z float64, bitCount byte) {
+	l1 := math.Abs(x) + math.Abs(y) + math.Abs(z)
+	if l1 == 0 || math.IsNaN(l1) || math.IsInf(l1, 0) {
+		x, y, z, l1 = 0, 0, 1, 1
+	}
+
+	u, v := x/l1, y/l1
+	if z < 0 {
+		u, v = (1-math.Abs(v))*signNonZero(u), (1-math.Abs(u))*signNonZero(v)
+	}
+
+	w.WriteQuantized(u, -1, 1, bitCount)
+	w.WriteQuantized(v, -1, 1, bitCount)
+}
+
+// ReadUnitVector reads a vector written with WriteUnitVector. The returned vector is normalized.
+func (r *Reader) ReadUnitVector(bitCount byte) (x, y, z float64, err error) {
+	var u, v float64
+	if u, err = r.ReadQuantized(-1, 1, bitCount); err != nil {
+		return
+	}
+	if v, err = r.ReadQuantized(-1, 1, bitCount); err != nil {
+		return
+	}
+
+	x, y, z = u, v, 1-math.Abs(u)-math.Abs(v)
+	if z < 0 {
+		x, y = (1-math.Abs(v))*signNonZero(u), (1-math.Abs(u))*signNonZero(v)
+	}
+
+	l := math.Sqrt(x*x + y*y + z*z)
+	x, y, z = x/l, y/l, z/l
+
+	return
+}
+
+func (r *ReaderError) ReadUnitVector(bitCount byte) (x, y, z float64) {
+	if r.err == nil {
+		x, y, z, r.err = r.reader.ReadUnitVector(bitCount)
+	}
+	return
+}
+
+// WriteQuaternion writes a rotation quaternion using the smallest-three encoding: a 2-bit index of the
+// component with the largest magnitude followed by the other three components with bitCount bits each.
+// The quaternion doesn't need to be normalized.
+func (w *Writer) WriteQuaternion(x, y, z, qw float64, bitCount byte) {
+	q := [4]float64{x, y, z, qw}
+
+	l := math.Sqrt(x*x + y*y + z*z + qw*qw)
+	if l == 0 || math.IsNaN(l) || math.IsInf(l, 0) {
+		q, l = [4]float64{0, 0, 0, 1}, 1
+	}
+
+	largest := 0
+	for i := 1; i < 4; i++ {
+		if math.Abs(q[i]) > math.Abs(q[largest]) {
+			largest = i
+		}
+	}
+
+	// q and -q represent the same rotation, the largest component is made positive
+	if q[largest] < 0 {
+		l = -l
+	}
+
+	w.Write8(byte(largest), 2)
+	for i := 0; i < 4; i++ {
+		if i != largest {
+			w.WriteQuantized(q[i]/l, -math.Sqrt2/2, math.Sqrt2/2, bitCount)
+		}
+	}
+}
+
+// ReadQuaternion reads a quaternion written with WriteQuaternion. The returned quaternion is normalized.
+func (r *Reader) ReadQuaternion(bitCount byte) (x, y, z, qw float64, err error) {
+	var largest byte
+	if largest, err = r.Read8(2); err != nil {
+		return
+	}
+
+	var (
+		q   [4]float64
+		sum float64
+	)
+	for i := byte(0); i < 4; i++ {
+		if i == largest {
+			continue
+		}
+		if q[i], err = r.ReadQuantized(-math.Sqrt2/2, math.Sqrt2/2, bitCount); err != nil {
+			return
+		}
+		sum += q[i] * q[i]
+	}
+
+	q[largest] = math.Sqrt(math.Max(0, 1-sum))
+
+	l := math.Sqrt(sum + q[largest]*q[largest])
+	x, y, z, qw = q[0]/l, q[1]/l, q[2]/l, q[3]/l
+
+	return
+}
+
+func (r *ReaderError) ReadQuaternion(bitCount byte) (x, y, z, qw float64) {
+	if r.err == nil {
+		x, y, z, qw, r.err = r.reader.ReadQuaternion(bitCount)
+	}
+	return
+}
+
+func signNonZero(v float64) float64 {
+	if v < 0 {
+		return -1
+	}
+	return 1
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+)
+
+func TestUnitVector(t *testing.T) {
+	vectors := [][3]float64{
+		{1, 0, 0},
+		{0, -1, 0},
+		{0, 0, 1},
+		{0, 0, -1},
+		{1, 1, 1},
+		{-3, 2, -5},
+		{0, 0, 0},
+	}
+	for i := 0; i < 100; i++ {
+		vectors = append(vectors, [3]float64{rand.NormFloat64(), rand.NormFloat64(), rand.NormFloat64()})
+	}
+
+	const bits = 12
+
+	w := NewWriter()
+	for _, v := range vectors {
+		w.WriteUnitVector(v[0], v[1], v[2], bits)
+	}
+
+	if want, got := uint(len(vectors)*2*bits), w.BitsWritten(); want != got {
+		t.Errorf("size mismatch: want=%d got=%d", want, got)
+	}
+
+	r := NewReaderError(w.BitData())
+	for i, v := range vectors {
+		x, y, z := r.ReadUnitVector(bits)
+
+		l := math.Sqrt(v[0]*v[0] + v[1]*v[1] + v[2]*v[2])
+		if l == 0 {
+			v, l = [3]float64{0, 0, 1}, 1
+		}
+
+		if d := math.Sqrt(x*x + y*y + z*z); math.Abs(d-1) > 1e-9 {
+			t.Errorf("vector %d: not normalized, length=%g", i, d)
+		}
+
+		dot := (x*v[0] + y*v[1] + z*v[2]) / l
+		if angle := math.Acos(math.Min(1, dot)); angle > 0.002 {
+			t.Errorf("vector %d: angle error too big: %g", i, angle)
+		}
+	}
+
+	if err := r.Error(); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
+
+func TestQuaternion(t *testing.T) {
+	quaternions := [][4]float64{
+		{0, 0, 0, 1},
+		{0, 0, 0, -1},
+		{1, 0, 0, 0},
+		{0.5, -0.5, 0.5, -0.5},
+	}
+	for i := 0; i < 100; i++ {
+		quaternions = append(quaternions, [4]float64{rand.NormFloat64(), rand.NormFloat64(), rand.NormFloat64(), rand.NormFloat64()})
+	}
+
+	const bits = 10
+
+	w := NewWriter()
+	for _, q := range quaternions {
+		w.WriteQuaternion(q[0], q[1], q[2], q[3], bits)
+	}
+
+	if want, got := uint(len(quaternions)*(2+3*bits)), w.BitsWritten(); want != got {
+		t.Errorf("size mismatch: want=%d got=%d", want, got)
+	}
+
+	r := NewReaderError(w.BitData())
+	for i, q := range quaternions {
+		x, y, z, qw := r.ReadQuaternion(bits)
+
+		l := math.Sqrt(q[0]*q[0] + q[1]*q[1] + q[2]*q[2] + q[3]*q[3])
+
+		// q and -q are the same rotation
+		dot := math.Abs(x*q[0]+y*q[1]+z*q[2]+qw*q[3]) / l
+		if dot < 0.9999 {
+			t.Errorf("quaternion %d: mismatch, dot=%g", i, dot)
+		}
+	}
+
+	if err := r.Error(); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
@@ -0,0 +1,214 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+//go:build !bitdata_noreflect
+
+package bitdata
+
+import (
+	"encoding/binary"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// EncodeVersion writes a struct in the layout of the given version, as described in Marshal: the top-level
+// fields added in later versions are left out. Nested structs are always written in full.
+func (w *Writer) EncodeVersion(v any, version uint) error {
+	if m, ok := v.(BitMarshaler); ok {
+		return m.EncodeBits(w)
+	}
+
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	if !rv.IsValid() {
+		return fmt.Errorf("%w: nil pointer", ErrUnsupportedType)
+	}
+
+	c, err := structCodecOf(rv.Type())
+	if err != nil {
+		return err
+	}
+
+	for i := range c.fields {
+		f := &c.fields[i]
+		if f.since > version {
+			break
+		}
+		if err := f.encode(w, rv.Field(f.index)); err != nil {
+			return fmt.Errorf("field %s: %w", f.name, err)
+		}
+	}
+
+	return nil
+}
+
+// DecodeVersion reads a struct written in the layout of the given version into the struct pointed to by v.
+// The top-level fields added in later versions are set to their default values.
+func (r *Reader) DecodeVersion(v any, version uint) error {
+	if err := r.enter(); err != nil {
+		return err
+	}
+	defer r.leave()
+
+	if u, ok := v.(BitUnmarshaler); ok {
+		return u.DecodeBits(r)
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return fmt.Errorf("%w: decoding requires a non-nil pointer", ErrUnsupportedType)
+	}
+	rv = rv.Elem()
+
+	c, err := structCodecOf(rv.Type())
+	if err != nil {
+		return err
+	}
+
+	for i := range c.fields {
+		f := &c.fields[i]
+		fv := rv.Field(f.index)
+		if f.since <= version {
+			if err := f.decode(r, fv); err != nil {
+				return fmt.Errorf("field %s: %w", f.name, err)
+			}
+		} else if f.def.IsValid() {
+			fv.Set(f.def)
+		} else {
+			fv.SetZero()
+		}
+	}
+
+	return nil
+}
+
+// CheckCompatible checks that the struct type of newer, given as a value or a pointer, only appends fields
+// to the layout of the struct type of older: the fields of older must be encoded the same way in newer,
+// and every added field must have a since version above the versions of the fields of older.
+// It returns an error wrapping ErrIncompatible describing the first difference.
+func CheckCompatible(older, newer any) error {
+	oc, err := codecOfValue(older)
+	if err != nil {
+		return err
+	}
+	nc, err := codecOfValue(newer)
+	if err != nil {
+		return err
+	}
+
+	if len(nc.fields) < len(oc.fields) {
+		return fmt.Errorf("%w: field %s removed", ErrIncompatible, oc.fields[len(nc.fields)].name)
+	}
+
+	var latest uint
+	for i := range oc.fields {
+		of, nf := &oc.fields[i], &nc.fields[i]
+		if of.layout != nf.layout {
+			return fmt.Errorf("%w: field %s changed from %s to %s", ErrIncompatible, of.name, of.layout, nf.layout)
+		}
+		if of.since != nf.since {
+			return fmt.Errorf("%w: field %s moved from version %d to %d", ErrIncompatible, of.name, of.since, nf.since)
+		}
+		latest = of.since
+	}
+
+	for _, nf := range nc.fields[len(oc.fields):] {
+		if nf.since <= latest {
+			return fmt.Errorf("%w: added field %s needs a since version above %d", ErrIncompatible, nf.name, latest)
+		}
+	}
+
+	return nil
+}
+
+func codecOfValue(v any) (*structCodec, error) {
+	t := reflect.TypeOf(v)
+	if t == nil {
+		return nil, fmt.Errorf("%w: nil", ErrUnsupportedType)
+	}
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return structCodecOf(t)
+}
+
+// layoutOf describes how a value of the type t is encoded with the tag ft, so that the encodings
+// of two fields can be compared. The names of the fields and the types don't matter, only the bits do.
+func layoutOf(t reflect.Type, ft fieldTag) string {
+	var sb strings.Builder
+	if ft.setOrder && ft.order == MSBFirst {
+		sb.WriteString("msb ")
+	} else if ft.setOrder {
+		sb.WriteString("lsb ")
+	}
+
+	switch k := t.Kind(); {
+	case t.Implements(bitMarshalerType) || reflect.PointerTo(t).Implements(bitUnmarshalerType):
+		sb.WriteString(t.String())
+	case k == reflect.Array:
+		sb.WriteString("[" + strconv.Itoa(t.Len()) + "]" + layoutOf(t.Elem(), fieldTag{bits: ft.bits, byteOrder: ft.byteOrder}))
+	case k == reflect.Struct:
+		c, _ := structCodecOf(t)
+		sb.WriteByte('{')
+		for i, f := range c.fields {
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+			sb.WriteString(f.layout)
+		}
+		sb.WriteByte('}')
+	case k >= reflect.Int && k <= reflect.Int64, k >= reflect.Uint && k <= reflect.Uintptr:
+		n, _ := fieldBits(t, ft.bits, ft.byteOrder)
+		if k <= reflect.Int64 {
+			sb.WriteString("int" + strconv.Itoa(int(n)))
+		} else {
+			sb.WriteString("uint" + strconv.Itoa(int(n)))
+		}
+	default:
+		sb.WriteString(k.String())
+	}
+
+	if ft.byteOrder == binary.BigEndian && t.Kind() != reflect.Array {
+		sb.WriteString(" be")
+	} else if ft.byteOrder != nil && t.Kind() != reflect.Array {
+		sb.WriteString(" le")
+	}
+
+	return sb.String()
+}
+
+// parseDefault parses the default value of a field of the type t.
+func parseDefault(t reflect.Type, s string) (reflect.Value, error) {
+	v := reflect.New(t).Elem()
+
+	switch k := t.Kind(); {
+	case k == reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return v, err
+		}
+		v.SetBool(b)
+	case k >= reflect.Int && k <= reflect.Int64:
+		x, err := strconv.ParseInt(s, 0, t.Bits())
+		if err != nil {
+			return v, err
+		}
+		v.SetInt(x)
+	case k >= reflect.Uint && k <= reflect.Uintptr:
+		x, err := strconv.ParseUint(s, 0, t.Bits())
+		if err != nil {
+			return v, err
+		}
+		v.SetUint(x)
+	case k == reflect.Float32 || k == reflect.Float64:
+		x, err := strconv.ParseFloat(s, t.Bits())
+		if err != nil {
+			return v, err
+		}
+		v.SetFloat(x)
+	default:
+		return v, ErrUnsupportedType
+	}
+
+	return v, nil
+}
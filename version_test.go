@@ -0,0 +1,143 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+//go:build !bitdata_noreflect
+
+package bitdata
+
+import (
+	"errors"
+	"testing"
+)
+
+type sensorV1 struct {
+	ID    uint16 `bits:"12"`
+	Value int16  `bits:"10"`
+}
+
+type sensorV2 struct {
+	ID      uint16  `bits:"12"`
+	Value   int16   `bits:"10"`
+	Scale   uint8   `bits:"4,since=2,default=3"`
+	Enabled bool    `bits:",since=2,default=true"`
+	Offset  float32 `bits:",since=3"`
+}
+
+func TestVersion(t *testing.T) {
+	old, err := Marshal(sensorV1{ID: 0x123, Value: -7})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	v := sensorV2{Offset: 1.5}
+	if err := NewReader(old).DecodeVersion(&v, 1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := (sensorV2{ID: 0x123, Value: -7, Scale: 3, Enabled: true}); v != want {
+		t.Errorf("expected %+v, got %+v", want, v)
+	}
+
+	cur := sensorV2{ID: 5, Value: 100, Scale: 9, Enabled: false, Offset: 2.25}
+	for version, bits := range []uint{22, 22, 27, 59} {
+		w := NewWriter()
+		if err := w.EncodeVersion(cur, uint(version)); err != nil {
+			t.Fatalf("version %d: unexpected error: %v", version, err)
+		}
+		if w.BitsWritten() != bits {
+			t.Errorf("version %d: expected %d bits, got %d", version, bits, w.BitsWritten())
+		}
+
+		var got sensorV2
+		if err := NewReader(w.BitData()).DecodeVersion(&got, uint(version)); err != nil {
+			t.Fatalf("version %d: unexpected error: %v", version, err)
+		}
+		want := cur
+		if version < 3 {
+			want.Offset = 0
+		}
+		if version < 2 {
+			want.Scale, want.Enabled = 3, true
+		}
+		if got != want {
+			t.Errorf("version %d: expected %+v, got %+v", version, want, got)
+		}
+	}
+
+	full, _ := Marshal(cur)
+	latest := NewWriter()
+	_ = latest.EncodeVersion(&cur, 3)
+	if string(full) != string(latest.BitData()) {
+		t.Errorf("Marshal differs from the latest version")
+	}
+}
+
+func TestVersionInvalidTag(t *testing.T) {
+	tests := []any{
+		struct {
+			A uint8 `bits:"4,since=2"`
+			B uint8 `bits:"4"`
+		}{},
+		struct {
+			A uint8 `bits:"4,since=0"`
+		}{},
+		struct {
+			A uint8 `bits:"4,default=x"`
+		}{},
+		struct {
+			A uint8 `bits:"4,default=300"`
+		}{},
+		struct {
+			A [2]uint8 `bits:"4,since=1,default=1"`
+		}{},
+	}
+	for i, v := range tests {
+		if _, err := Marshal(v); !errors.Is(err, ErrInvalidTag) {
+			t.Errorf("%d: expected ErrInvalidTag, got %v", i, err)
+		}
+	}
+}
+
+func TestCheckCompatible(t *testing.T) {
+	if err := CheckCompatible(sensorV1{}, &sensorV2{}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := CheckCompatible(sensorV2{}, sensorV2{}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	type renamed struct {
+		Sensor uint16 `bits:"12"`
+		Level  int32  `bits:"10"`
+		Extra  uint8  `bits:",since=1"`
+	}
+	if err := CheckCompatible(sensorV1{}, renamed{}); err != nil {
+		t.Errorf("renamed: unexpected error: %v", err)
+	}
+
+	tests := map[string]any{
+		"removed": struct {
+			ID uint16 `bits:"12"`
+		}{},
+		"width": struct {
+			ID    uint16 `bits:"12"`
+			Value int16  `bits:"11"`
+		}{},
+		"signedness": struct {
+			ID    uint16 `bits:"12"`
+			Value uint16 `bits:"10"`
+		}{},
+		"no version": struct {
+			ID    uint16 `bits:"12"`
+			Value int16  `bits:"10"`
+			Extra bool
+		}{},
+	}
+	for name, v := range tests {
+		if err := CheckCompatible(sensorV1{}, v); !errors.Is(err, ErrIncompatible) {
+			t.Errorf("%s: expected ErrIncompatible, got %v", name, err)
+		}
+	}
+
+	if err := CheckCompatible(sensorV2{}, sensorV1{}); !errors.Is(err, ErrIncompatible) {
+		t.Errorf("downgrade: expected ErrIncompatible, got %v", err)
+	}
+}
@@ -0,0 +1,161 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"iter"
+	"math"
+	"math/bits"
+)
+
+// FloatEncoder compresses a series of float64 values as in the Gorilla time-series database: each value
+// is XORed with the previous one. A zero result is written as a zero bit. Otherwise a one bit is followed
+// by a zero bit and the meaningful bits of the result, if they fit in the window of the previous value,
+// or by a one bit, the number of leading zero bits in 5 bits, the number of meaningful bits in 6 bits,
+// with 0 meaning 64, and the meaningful bits. The first value is written in 64 bits.
+type FloatEncoder struct {
+	w        *Writer
+	prev     uint64
+	leading  byte
+	trailing byte
+	n        int
+}
+
+// NewFloatEncoder returns an encoder appending the values to the writer.
+func NewFloatEncoder(w *Writer) *FloatEncoder {
+	return &FloatEncoder{w: w}
+}
+
+// Append writes the next value.
+func (e *FloatEncoder) Append(v float64) {
+	b := math.Float64bits(v)
+	if e.n == 0 {
+		e.w.Write64(b, 64)
+		e.prev = b
+		e.leading = math.MaxUint8
+		e.n++
+		return
+	}
+
+	x := b ^ e.prev
+	e.prev = b
+	e.n++
+
+	if x == 0 {
+		e.w.WriteBool(false)
+		return
+	}
+	e.w.WriteBool(true)
+
+	leading := min(byte(bits.LeadingZeros64(x)), 31)
+	trailing := byte(bits.TrailingZeros64(x))
+
+	if e.leading != math.MaxUint8 && leading >= e.leading && trailing >= e.trailing {
+		e.w.WriteBool(false)
+		e.w.Write64(x>>e.trailing, 64-e.leading-e.trailing)
+		return
+	}
+
+	e.leading, e.trailing = leading, trailing
+	n := 64 - leading - trailing
+
+	e.w.WriteBool(true)
+	e.w.Write8(leading, 5)
+	e.w.Write8(n&63, 6)
+	e.w.Write64(x>>trailing, n)
+}
+
+// Len returns the number of values written.
+func (e *FloatEncoder) Len() int {
+	return e.n
+}
+
+// FloatDecoder reads values written by a FloatEncoder.
+type FloatDecoder struct {
+	r        *Reader
+	prev     uint64
+	leading  byte
+	trailing byte
+	n        int
+}
+
+// NewFloatDecoder returns a decoder reading values from the reader.
+func NewFloatDecoder(r *Reader) *FloatDecoder {
+	return &FloatDecoder{r: r}
+}
+
+// Next returns the next value. The number of values isn't stored, so the caller has to know it.
+// It returns ErrInvalidRange if a value refers to a window before one is set. On error the read position
+// and the state of the decoder are left unchanged.
+func (d *FloatDecoder) Next() (float64, error) {
+	if d.n == 0 {
+		v, err := d.r.Read64(64)
+		if err != nil {
+			return 0, err
+		}
+		d.prev = v
+		d.leading = math.MaxUint8
+		d.n++
+		return math.Float64frombits(v), nil
+	}
+
+	start := d.r.bitsRead
+
+	rr := ReaderError{reader: *d.r}
+	x, leading, trailing, err := d.readXOR(&rr)
+	if rr.err != nil {
+		err = rr.err
+	}
+	if err != nil {
+		d.r.bitsRead = start
+		return 0, err
+	}
+
+	*d.r = rr.reader
+
+	d.prev ^= x
+	d.leading, d.trailing = leading, trailing
+	d.n++
+
+	return math.Float64frombits(d.prev), nil
+}
+
+func (d *FloatDecoder) readXOR(r *ReaderError) (x uint64, leading, trailing byte, err error) {
+	leading, trailing = d.leading, d.trailing
+
+	if !r.ReadBool() {
+		return 0, leading, trailing, nil
+	}
+
+	if r.ReadBool() {
+		leading = r.Read8(5)
+		n := r.Read8(6)
+		if n == 0 {
+			n = 64
+		}
+		if leading+n > 64 {
+			return 0, 0, 0, ErrInvalidRange
+		}
+		trailing = 64 - leading - n
+	} else if leading == math.MaxUint8 {
+		return 0, 0, 0, ErrInvalidRange
+	}
+
+	x = r.Read64(64-leading-trailing) << trailing
+
+	return x, leading, trailing, nil
+}
+
+// Floats returns an iterator over count values written by a FloatEncoder.
+// The iteration stops after the first error.
+func (r *Reader) Floats(count int) iter.Seq2[float64, error] {
+	return func(yield func(float64, error) bool) {
+		d := NewFloatDecoder(r)
+		for range count {
+			v, err := d.Next()
+			if !yield(v, err) || err != nil {
+				return
+			}
+		}
+	}
+}
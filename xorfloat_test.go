@@ -0,0 +1,56 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"errors"
+	"io"
+	"math"
+	"testing"
+)
+
+func TestFloats(t *testing.T) {
+	values := []float64{12, 12, 24, 24.5, 15.25, -3, 0, math.Inf(1), math.SmallestNonzeroFloat64,
+		math.MaxFloat64, math.Copysign(0, -1), 1e-300, 12, 12}
+
+	w := NewWriter()
+	e := NewFloatEncoder(w)
+	for _, v := range values {
+		e.Append(v)
+	}
+	if e.Len() != len(values) {
+		t.Errorf("got length %d", e.Len())
+	}
+
+	i := 0
+	for v, err := range NewReader(w.BitData()).Floats(len(values)) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if math.Float64bits(v) != math.Float64bits(values[i]) {
+			t.Fatalf("value %d: got %g, want %g", i, v, values[i])
+		}
+		i++
+	}
+	if i != len(values) {
+		t.Errorf("iterated %d values", i)
+	}
+
+	w = NewWriter()
+	e = NewFloatEncoder(w)
+	for i := range 1000 {
+		e.Append(20 + float64(i%4)*0.5)
+	}
+	if bitsPerValue := float64(w.bitsWritten) / 1000; bitsPerValue > 16 {
+		t.Errorf("%.1f bits per value", bitsPerValue)
+	}
+
+	r := NewReader(w.BitData()[:9])
+	d := NewFloatDecoder(r)
+	if _, err := d.Next(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := d.Next(); !errors.Is(err, io.ErrUnexpectedEOF) || r.bitsRead != 64 {
+		t.Errorf("expected io.ErrUnexpectedEOF at bit 64, got %v at %d", err, r.bitsRead)
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import "errors"
+
+var ErrNotZero = errors.New("expected zero bits")
+
+// zeroBytes is the source of the whole zero bytes WriteZeros appends.
+var zeroBytes [256]byte
+
+// WriteZeros writes n zero bits, for example padding or reserved fields. The bits up to the next byte
+// boundary are already zero in the writer, so only the position moves and whole zero bytes are appended.
+func (w *Writer) WriteZeros(n uint) {
+	writeZeros(w, n)
+}
+
+func writeZeros(w *Writer, n uint) {
+	if w.recording || w.trace != nil {
+		for n > 0 {
+			c := min(n, 64)
+			w.Write64(0, byte(c))
+			n -= c
+		}
+		return
+	}
+
+	end := w.bitsWritten + n
+	for k := (end+7)/8 - w.size; k > 0; {
+		c := min(k, uint(len(zeroBytes)))
+		w.appendBytes(zeroBytes[:c])
+		k -= c
+	}
+	w.bitsWritten = end
+	w.checkBudget()
+}
+
+// ExpectZeros reads n bits and checks that all of them are zero, for example the padding or reserved
+// fields written by WriteZeros. The whole bytes are compared a word at a time. It returns ErrNotZero
+// if a bit is set. On error the read position is left unchanged.
+func (r *Reader) ExpectZeros(n uint) error {
+	if ok, err := r.ensure(n); !ok {
+		return err
+	}
+
+	start := r.bitsRead
+	err := expectZeros(r, n)
+	if err != nil {
+		r.bitsRead = start
+	}
+
+	return err
+}
+
+func expectZeros(r *Reader, n uint) error {
+	if head := min((8-r.bitsRead%8)%8, n); head > 0 {
+		if v, err := read[uint8](r, byte(head)); err != nil || v != 0 {
+			return zerosErr(err)
+		}
+		n -= head
+	}
+
+	if r.src == nil {
+		d := r.data[r.bitsRead/8 : r.bitsRead/8+n/8]
+		for len(d) >= 8 {
+			if load64(d) != 0 {
+				return ErrNotZero
+			}
+			d = d[8:]
+		}
+		for _, b := range d {
+			if b != 0 {
+				return ErrNotZero
+			}
+		}
+		r.bitsRead += n / 8 * 8
+		n %= 8
+	}
+
+	for n > 0 {
+		c := byte(min(n, 64))
+		if v, err := read[uint64](r, c); err != nil || v != 0 {
+			return zerosErr(err)
+		}
+		n -= uint(c)
+	}
+
+	return nil
+}
+
+func zerosErr(err error) error {
+	if err != nil {
+		return err
+	}
+	return ErrNotZero
+}
+
+func (r *ReaderError) ExpectZeros(n uint) {
+	if r.err == nil {
+		r.err = r.reader.ExpectZeros(n)
+	}
+}
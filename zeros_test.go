@@ -0,0 +1,95 @@
+// Copyright (c) 2025 by Marko Gaćeša
+
+package bitdata
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestWriteZeros(t *testing.T) {
+	for _, order := range []BitOrder{LSBFirst, MSBFirst} {
+		for _, head := range []byte{0, 1, 5, 8, 13} {
+			for _, n := range []uint{0, 1, 3, 7, 8, 9, 64, 65, 1000, 3000} {
+				want := NewWriter(WithBitOrder(order))
+				want.Write16(0x1FFF, head)
+				for k := n; k > 0; {
+					c := min(k, 64)
+					want.Write64(0, byte(c))
+					k -= c
+				}
+				want.Write8(0x5, 3)
+
+				got := NewWriter(WithBitOrder(order))
+				got.Write16(0x1FFF, head)
+				got.WriteZeros(n)
+				got.Write8(0x5, 3)
+
+				if got.BitsWritten() != want.BitsWritten() || !bytes.Equal(got.BitData(), want.BitData()) {
+					t.Errorf("%v head=%d n=%d: got %x, want %x", order, head, n, got.BitData(), want.BitData())
+				}
+			}
+		}
+	}
+
+	w := NewWriter()
+	w.SetRecording(true)
+	w.WriteZeros(100)
+	if len(w.Fields()) != 2 || w.BitsWritten() != 100 {
+		t.Errorf("expected the zeros to be recorded, got %v", w.Fields())
+	}
+
+	w = NewWriter()
+	w.SetBudget(50)
+	w.WriteZeros(60)
+	if !errors.Is(w.BudgetErr(), ErrBudgetExceeded) {
+		t.Errorf("expected ErrBudgetExceeded, got %v", w.BudgetErr())
+	}
+}
+
+func TestExpectZeros(t *testing.T) {
+	w := NewWriter()
+	w.Write8(1, 3)
+	w.WriteZeros(1000)
+	w.Write8(1, 1)
+	d := w.BitData()
+
+	for _, r := range []*Reader{NewReader(d), NewReaderString(string(d))} {
+		_, _ = r.Read8(3)
+		if err := r.ExpectZeros(1000); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := r.ExpectZeros(1); !errors.Is(err, ErrNotZero) || r.BitsRead() != 1003 {
+			t.Errorf("expected ErrNotZero at 1003, got %v at %d", err, r.BitsRead())
+		}
+		if err := r.ExpectZeros(10); !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Errorf("expected io.ErrUnexpectedEOF, got %v", err)
+		}
+	}
+
+	for i := uint(0); i < 300; i++ {
+		d := make(BitData, 40)
+		setBit(d, i, true)
+
+		r := NewReader(d)
+		_, _ = r.Read8(2)
+		err := r.ExpectZeros(300)
+		if i >= 2 && !errors.Is(err, ErrNotZero) {
+			t.Errorf("bit %d: expected ErrNotZero, got %v", i, err)
+		}
+		if i >= 2 && r.BitsRead() != 2 {
+			t.Errorf("bit %d: read position moved to %d", i, r.BitsRead())
+		}
+		if i < 2 && err != nil {
+			t.Errorf("bit %d: unexpected error: %v", i, err)
+		}
+	}
+
+	rr := NewReaderError(BitData{0, 0x08})
+	rr.ExpectZeros(12)
+	if !errors.Is(rr.Error(), ErrNotZero) {
+		t.Errorf("expected ErrNotZero, got %v", rr.Error())
+	}
+}